@@ -0,0 +1,41 @@
+package accountsrv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A status change an admin can make to a user or an org: the status it ends up
+// in and the ones it can be made from. Deleting isn't one of them, that goes
+// through DeleteUserAccount and DeleteOrg, which tidy up around it too.
+type statusChange struct {
+	to   string
+	from []string
+}
+
+var (
+	suspendAccount    = statusChange{to: UserStatusSuspended, from: []string{UserStatusActive}}
+	deactivateAccount = statusChange{to: UserStatusDeactivated, from: []string{UserStatusActive, UserStatusSuspended}}
+	reactivateAccount = statusChange{to: UserStatusActive, from: []string{UserStatusSuspended, UserStatusDeactivated}}
+	restoreAccount    = statusChange{to: UserStatusActive, from: []string{UserStatusDeleted}}
+)
+
+// Refuses the change for an account (kind says whose, "user" or "organization")
+// whose status it can't be made from
+func (c statusChange) check(kind string, status string) error {
+	for _, from := range c.from {
+		if status == from {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s account is %s, only %s accounts can be made %s", kind, status, strings.Join(c.from, " or "), c.to)
+}
+
+// Whether it's one of the statuses users and orgs can have
+func validAccountStatus(status string) bool {
+	switch status {
+	case UserStatusActive, UserStatusSuspended, UserStatusDeactivated, UserStatusDeleted:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,555 @@
+package accountsrv
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+// What got done, an action is <what it was done to>.<what was done>. Querying by
+// one also matches everything under it, e.g. user.login covers every way of
+// logging in.
+const (
+	AuditActionUserCreate        = "user.create"
+	AuditActionUserRead          = "user.read"
+	AuditActionUserUpdate        = "user.update"
+	AuditActionUserProfileUpdate = "user.profile.update"
+	AuditActionUserDelete        = "user.delete"
+	AuditActionUserSuspend       = "user.suspend"
+	AuditActionUserDeactivate    = "user.deactivate"
+	AuditActionUserReactivate    = "user.reactivate"
+	AuditActionUserRestore       = "user.restore"
+	AuditActionUserRoleChange    = "user.role.change"
+	AuditActionUserEmailVerify   = "user.email.verify"
+	AuditActionUserPhoneVerify   = "user.phone.verify"
+	AuditActionUserPasswordReset = "user.password.reset"
+	AuditActionUserMFAEnable     = "user.mfa.enable"
+	AuditActionUserMFADisable    = "user.mfa.disable"
+	AuditActionUserMFAReset      = "user.mfa.reset"
+	AuditActionUserPasskeyAdd    = "user.passkey.add"
+	AuditActionUserPasskeyDelete = "user.passkey.delete"
+	AuditActionUserSwitchOrg     = "user.switch_org"
+
+	AuditActionLoginPassword  = "user.login.password"
+	AuditActionLoginMFA       = "user.login.mfa"
+	AuditActionLoginChooseOrg = "user.login.choose_org"
+	AuditActionLoginPasskey   = "user.login.passkey"
+	AuditActionLoginMagicLink = "user.login.magic_link"
+	AuditActionLoginSMS       = "user.login.sms"
+
+	AuditActionOrgCreate             = "org.create"
+	AuditActionOrgUpdate             = "org.update"
+	AuditActionOrgProfileUpdate      = "org.profile.update"
+	AuditActionOrgPayorDetailsUpdate = "org.payor_details.update"
+	AuditActionOrgDelete             = "org.delete"
+	AuditActionOrgSuspend            = "org.suspend"
+	AuditActionOrgDeactivate         = "org.deactivate"
+	AuditActionOrgReactivate         = "org.reactivate"
+	AuditActionOrgRestore            = "org.restore"
+	AuditActionOrgUsersRead          = "org.users.read"
+	AuditActionOrgAuditRead          = "org.audit.read"
+
+	AuditActionInviteCreate = "invite.create"
+	AuditActionInviteRevoke = "invite.revoke"
+	AuditActionInviteResend = "invite.resend"
+	AuditActionInviteAccept = "invite.accept"
+
+	AuditActionNetworkRequest   = "network.request"
+	AuditActionNetworkAccept    = "network.accept"
+	AuditActionNetworkTerminate = "network.terminate"
+)
+
+// What an action was done to
+const (
+	AuditTargetUser    = "user"
+	AuditTargetOrg     = "org"
+	AuditTargetInvite  = "invite"
+	AuditTargetNetwork = "network_relationship"
+)
+
+// How it went
+const (
+	AuditOutcomeSuccess    = "success"
+	AuditOutcomeFailure    = "failure"
+	AuditOutcomeChallenged = "challenged" // A login got past the first factor and is waiting on MFA
+)
+
+// One entry in the audit trail. Seq, PrevHash and Hash are filled in by the sink
+// it's appended to: Hash covers everything else in the event including PrevHash,
+// the Hash of the event before it, so changing or dropping any event breaks every
+// hash after it.
+type AuditEvent struct {
+	Seq        int64         `json:"seq"`
+	ID         string        `json:"id"`
+	Time       time.Time     `json:"time"`
+	ActorID    string        `json:"actor_id,omitempty"` // The user who did it, empty when we can't tell (e.g. a failed login)
+	OrgID      string        `json:"org_id,omitempty"`   // The org it happened in
+	Action     string        `json:"action"`
+	TargetType string        `json:"target_type"`
+	TargetID   string        `json:"target_id,omitempty"`
+	Outcome    string        `json:"outcome"`
+	Error      string        `json:"error,omitempty"`   // Why it failed
+	Changes    []AuditChange `json:"changes,omitempty"` // What it changed, field by field
+	RequestID  string        `json:"request_id,omitempty"`
+	ClientIP   string        `json:"client_ip,omitempty"`
+	PrevHash   string        `json:"prev_hash"`
+	Hash       string        `json:"hash"`
+}
+
+// One field an action changed. Values are written out as text, anything that
+// isn't a plain value (a list, say) as JSON. A field that was created has no
+// Before and one that was deleted has no After.
+type AuditChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// What to look for in the audit trail. OrgID is required, the rest are optional.
+type AuditQuery struct {
+	OrgID    string
+	ActorID  string
+	TargetID string
+	Action   string    // Also matches the actions under it, see the AuditAction constants
+	Since    time.Time // Inclusive
+	Until    time.Time // Exclusive
+	Limit    int
+}
+
+// Where audit events end up. Appends have to be serialized so every event chains
+// onto the one before it, each sink takes care of that itself.
+type AuditSink interface {
+	// Chains the event onto the end of the trail
+	Append(ctx context.Context, event AuditEvent) error
+	// The events matching the query, newest first
+	Query(ctx context.Context, query AuditQuery) ([]AuditEvent, error)
+	// Walks the whole trail checking every hash, returning the last event. The
+	// chain can't tell when events are cut off the end, keep the last hash
+	// somewhere else and compare against it to catch that.
+	Verify(ctx context.Context) (AuditEvent, error)
+}
+
+// The hash of the event, covering all of it but the Hash itself
+func (e AuditEvent) computeHash() string {
+	e.Hash = ""
+	// Can't fail, there's nothing in an event that doesn't marshal
+	b, _ := json.Marshal(e)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// Puts the event at the end of a chain whose last event was seq/prevHash. The
+// time goes down to microseconds in UTC, as much as the DB keeps, so the hash
+// still checks out once the event has been through it.
+func (e AuditEvent) chain(seq int64, prevHash string) AuditEvent {
+	e.Seq = seq + 1
+	e.PrevHash = prevHash
+	e.Time = e.Time.UTC().Truncate(time.Microsecond)
+	e.Hash = e.computeHash()
+	return e
+}
+
+// Follows a chain along one event at a time, see AuditSink.Verify
+type auditChainCheck struct {
+	last AuditEvent
+}
+
+func (c *auditChainCheck) next(e AuditEvent) error {
+	if e.Seq != c.last.Seq+1 {
+		return fmt.Errorf("audit trail is broken: event %d follows event %d", e.Seq, c.last.Seq)
+	}
+	if e.PrevHash != c.last.Hash {
+		return fmt.Errorf("audit trail is broken: event %d doesn't chain onto event %d", e.Seq, c.last.Seq)
+	}
+	if e.computeHash() != e.Hash {
+		return fmt.Errorf("audit trail is broken: event %d has been altered", e.Seq)
+	}
+	c.last = e
+	return nil
+}
+
+var errAuditOrgRequired = errors.New("org_id is required")
+
+// Fills in the limit and checks the rest of the query makes sense
+func normalizeAuditQuery(query AuditQuery) (AuditQuery, error) {
+	if query.OrgID == "" {
+		return query, errAuditOrgRequired
+	}
+	if !query.Since.IsZero() && !query.Until.IsZero() && !query.Until.After(query.Since) {
+		return query, errors.New("until has to be after since")
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultPageLimit
+	}
+	if query.Limit > maxPageLimit {
+		query.Limit = maxPageLimit
+	}
+	return query, nil
+}
+
+// Whether the event matches the query, for sinks that can't filter any other way
+func (query AuditQuery) matches(e AuditEvent) bool {
+	switch {
+	case e.OrgID != query.OrgID:
+		return false
+	case query.ActorID != "" && e.ActorID != query.ActorID:
+		return false
+	case query.TargetID != "" && e.TargetID != query.TargetID:
+		return false
+	case query.Action != "" && e.Action != query.Action && !strings.HasPrefix(e.Action, query.Action+"."):
+		return false
+	case !query.Since.IsZero() && e.Time.Before(query.Since):
+		return false
+	case !query.Until.IsZero() && !e.Time.Before(query.Until):
+		return false
+	}
+	return true
+}
+
+// The field by field difference between two versions of something, either of
+// which can be nil (when it's being created or deleted). Nested objects are
+// flattened into dotted field names, e.g. profile.email.
+func auditDiff(before interface{}, after interface{}) []AuditChange {
+	beforeFields, afterFields := auditFields(before), auditFields(after)
+
+	names := make([]string, 0, len(beforeFields)+len(afterFields))
+	for name := range beforeFields {
+		names = append(names, name)
+	}
+	for name := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []AuditChange
+	for _, name := range names {
+		if beforeFields[name] != afterFields[name] {
+			changes = append(changes, AuditChange{Field: name, Before: beforeFields[name], After: afterFields[name]})
+		}
+	}
+	return changes
+}
+
+func auditFields(v interface{}) map[string]string {
+	fields := map[string]string{}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fields
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return fields
+	}
+	flattenAuditFields("", decoded, fields)
+	return fields
+}
+
+func flattenAuditFields(prefix string, v interface{}, fields map[string]string) {
+	switch v := v.(type) {
+	case nil:
+	case map[string]interface{}:
+		for key, value := range v {
+			name := key
+			if prefix != "" {
+				name = prefix + "." + key
+			}
+			flattenAuditFields(name, value, fields)
+		}
+	case string:
+		fields[prefix] = v
+	default:
+		b, _ := json.Marshal(v)
+		fields[prefix] = string(b)
+	}
+}
+
+// Keeps the audit trail in the audit_log table. Appends take a transaction level
+// advisory lock, so any number of instances can share the table and still
+// build a single chain.
+func NewDBAuditSink(db *sql.DB, logger log.Logger) AuditSink {
+	return &dbAuditSink{db: db, logger: log.With(logger, "audit", "db")}
+}
+
+type dbAuditSink struct {
+	db     *sql.DB
+	logger log.Logger
+}
+
+// The advisory lock key appends serialize on, any number will do as long as
+// nothing else uses it
+const auditLockKey = 0x61756469
+
+func (s *dbAuditSink) Append(ctx context.Context, event AuditEvent) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, s.logger)).Log("method", "Append", "err", err)
+		return errors.New("error writing audit event")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, auditLockKey); err != nil {
+		level.Error(loggerWithRequestID(ctx, s.logger)).Log("method", "Append", "err", err)
+		return errors.New("error writing audit event")
+	}
+
+	var seq int64
+	var prevHash string
+	err = tx.QueryRowContext(ctx, `SELECT seq, hash FROM audit_log ORDER BY seq DESC LIMIT 1`).Scan(&seq, &prevHash)
+	if err != nil && err != sql.ErrNoRows {
+		level.Error(loggerWithRequestID(ctx, s.logger)).Log("method", "Append", "err", err)
+		return errors.New("error writing audit event")
+	}
+	event = event.chain(seq, prevHash)
+
+	var changes interface{}
+	if len(event.Changes) > 0 {
+		b, _ := json.Marshal(event.Changes)
+		changes = string(b)
+	}
+
+	sqlCmd := `
+		INSERT INTO audit_log (seq, id, time, actor_id, org_id, action, target_type, target_id, outcome, error, changes, request_id, client_ip, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+	_, err = tx.ExecContext(ctx, sqlCmd, event.Seq, event.ID, event.Time, nullIfEmpty(event.ActorID), nullIfEmpty(event.OrgID),
+		event.Action, event.TargetType, nullIfEmpty(event.TargetID), event.Outcome, nullIfEmpty(event.Error), changes,
+		nullIfEmpty(event.RequestID), nullIfEmpty(event.ClientIP), event.PrevHash, event.Hash)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, s.logger)).Log("method", "Append", "err", err)
+		return errors.New("error writing audit event")
+	}
+
+	if err := tx.Commit(); err != nil {
+		level.Error(loggerWithRequestID(ctx, s.logger)).Log("method", "Append", "err", err)
+		return errors.New("error writing audit event")
+	}
+	return nil
+}
+
+const auditEventColumns = `seq, id, time, actor_id, org_id, action, target_type, target_id, outcome, error, changes, request_id, client_ip, prev_hash, hash`
+
+func scanAuditEvent(row interface{ Scan(...interface{}) error }) (AuditEvent, error) {
+	var event AuditEvent
+	var changes []byte
+	err := row.Scan(&event.Seq, &event.ID, &event.Time, nullableString(&event.ActorID), nullableString(&event.OrgID),
+		&event.Action, &event.TargetType, nullableString(&event.TargetID), &event.Outcome, nullableString(&event.Error), &changes,
+		nullableString(&event.RequestID), nullableString(&event.ClientIP), &event.PrevHash, &event.Hash)
+	if err != nil {
+		return event, err
+	}
+	event.Time = event.Time.UTC()
+	if changes != nil {
+		if err := json.Unmarshal(changes, &event.Changes); err != nil {
+			return event, err
+		}
+	}
+	return event, nil
+}
+
+func (s *dbAuditSink) Query(ctx context.Context, query AuditQuery) ([]AuditEvent, error) {
+	where := []string{"org_id = $1"}
+	args := []interface{}{query.OrgID}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.ActorID != "" {
+		where = append(where, "actor_id = "+arg(query.ActorID))
+	}
+	if query.TargetID != "" {
+		where = append(where, "target_id = "+arg(query.TargetID))
+	}
+	if query.Action != "" {
+		where = append(where, "(action = "+arg(query.Action)+" OR action LIKE "+arg(escapeLike(query.Action)+".%")+")")
+	}
+	if !query.Since.IsZero() {
+		where = append(where, "time >= "+arg(query.Since))
+	}
+	if !query.Until.IsZero() {
+		where = append(where, "time < "+arg(query.Until))
+	}
+
+	sqlCmd := `SELECT ` + auditEventColumns + ` FROM audit_log
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY seq DESC
+		LIMIT ` + arg(query.Limit)
+
+	rows, err := s.db.QueryContext(ctx, sqlCmd, args...)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, s.logger)).Log("method", "Query", "err", err)
+		return nil, errors.New("error querying audit log")
+	}
+	defer rows.Close()
+
+	events := []AuditEvent{}
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			level.Error(loggerWithRequestID(ctx, s.logger)).Log("method", "Query", "err", err)
+			return nil, errors.New("error querying audit log")
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("error querying audit log")
+	}
+
+	return events, nil
+}
+
+func (s *dbAuditSink) Verify(ctx context.Context) (AuditEvent, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+auditEventColumns+` FROM audit_log ORDER BY seq`)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, s.logger)).Log("method", "Verify", "err", err)
+		return AuditEvent{}, errors.New("error reading audit log")
+	}
+	defer rows.Close()
+
+	var check auditChainCheck
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			level.Error(loggerWithRequestID(ctx, s.logger)).Log("method", "Verify", "err", err)
+			return check.last, errors.New("error reading audit log")
+		}
+		if err := check.next(event); err != nil {
+			return check.last, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return check.last, errors.New("error reading audit log")
+	}
+
+	return check.last, nil
+}
+
+// Keeps the audit trail as a file with one JSON event per line. Only one process
+// can write to a given file, the chain is kept in memory between appends.
+func NewFileAuditSink(path string) AuditSink {
+	return &fileAuditSink{path: path}
+}
+
+type fileAuditSink struct {
+	path string
+
+	mu     sync.Mutex
+	loaded bool       // Whether last has been read from the file yet
+	last   AuditEvent // The event at the end of the file
+}
+
+func (s *fileAuditSink) Append(ctx context.Context, event AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Pick up where the file left off the first time round
+	if !s.loaded {
+		last, err := s.scan(func(AuditEvent) error { return nil })
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		s.last, s.loaded = last, true
+	}
+
+	event = event.chain(s.last.Seq, s.last.Hash)
+	line, _ := json.Marshal(event)
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("error writing audit event: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing audit event: %v", err)
+	}
+	// An audit event that's lost when the machine goes down might as well not
+	// have been written
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("error writing audit event: %v", err)
+	}
+
+	s.last = event
+	return nil
+}
+
+func (s *fileAuditSink) Query(ctx context.Context, query AuditQuery) ([]AuditEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := []AuditEvent{}
+	_, err := s.scan(func(event AuditEvent) error {
+		if query.matches(event) {
+			events = append(events, event)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	// The file is oldest first, and we want the newest
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	if len(events) > query.Limit {
+		events = events[:query.Limit]
+	}
+	return events, nil
+}
+
+func (s *fileAuditSink) Verify(ctx context.Context) (AuditEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var check auditChainCheck
+	_, err := s.scan(check.next)
+	if errors.Is(err, os.ErrNotExist) {
+		return AuditEvent{}, nil
+	}
+	return check.last, err
+}
+
+// Reads the file from the top, handing each event to fn, and returns the last one
+func (s *fileAuditSink) scan(fn func(AuditEvent) error) (AuditEvent, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return AuditEvent{}, err
+	}
+	defer f.Close()
+
+	var last AuditEvent
+	reader := bufio.NewReader(f)
+	for n := 1; ; n++ {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF && len(line) == 0 {
+			return last, nil
+		}
+		if err != nil && err != io.EOF {
+			return last, fmt.Errorf("error reading audit log: %v", err)
+		}
+
+		var event AuditEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return last, fmt.Errorf("audit trail is broken: line %d isn't an event", n)
+		}
+		if err := fn(event); err != nil {
+			return last, err
+		}
+		last = event
+	}
+}
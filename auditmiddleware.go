@@ -0,0 +1,809 @@
+package accountsrv
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/gofrs/uuid"
+)
+
+// Wraps a Service with another one that does something around each call, the
+// Service level counterpart of endpoint.Middleware
+type ServiceMiddleware func(Service) Service
+
+// Records every call that changes a user or an org (and the reads that hand out
+// anything PHI adjacent, like profiles) in the audit trail, whether it succeeded
+// or not. Updates and deletes record what they changed field by field, going by
+// what the repository has before and after the call. Calls that only hand out
+// short lived tokens (starting a passkey ceremony, sending a magic link and the
+// like) aren't recorded, what the token gets used for is.
+//
+// Events about a user go in the trail of every org the user belongs to, rather
+// than just the caller's, so an org's admins see everything that happens to
+// their members whoever does it.
+//
+// The audit trail failing doesn't fail the call, it's logged instead: by the time
+// we find out, whatever the call did is already done.
+func AuditMiddleware(sink AuditSink, repository Repository, logger log.Logger) ServiceMiddleware {
+	return func(next Service) Service {
+		return auditMiddleware{
+			next:       next,
+			sink:       sink,
+			repository: repository,
+			logger:     log.With(logger, "middleware", "audit"),
+		}
+	}
+}
+
+type auditMiddleware struct {
+	next       Service
+	sink       AuditSink
+	repository Repository
+	logger     log.Logger
+}
+
+// Fills in everything the event can get from the call's context and appends it.
+// The caller is the actor and their session's org the org, unless the event
+// already says otherwise. A failed call records why instead of any changes.
+func (mw auditMiddleware) record(ctx context.Context, event AuditEvent, err error) {
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		if event.ActorID == "" {
+			event.ActorID = principal.UserID
+		}
+		if event.OrgID == "" {
+			event.OrgID = principal.OrgID
+		}
+	}
+
+	id, _ := uuid.NewV4()
+	event.ID = id.String()
+	event.Time = time.Now()
+	event.RequestID = RequestIDFromContext(ctx)
+	event.ClientIP = ClientIPFromContext(ctx)
+	if event.Outcome == "" {
+		event.Outcome = AuditOutcomeSuccess
+	}
+	if err != nil {
+		event.Outcome = AuditOutcomeFailure
+		event.Error = err.Error()
+		event.Changes = nil
+	}
+
+	if err := mw.sink.Append(ctx, event); err != nil {
+		level.Error(loggerWithRequestID(ctx, mw.logger)).Log("action", event.Action, "target", event.TargetID, "err", err)
+	}
+}
+
+// Records an event about a user once in the trail of every org they belong to
+// in any of the snapshots, or going by the repository when there are none. An
+// event that already names its org, or is about a user in no org, goes through
+// record as is.
+func (mw auditMiddleware) recordUser(ctx context.Context, event AuditEvent, err error, snapshots ...*DetailedUser) {
+	if event.OrgID != "" || event.TargetID == "" {
+		mw.record(ctx, event, err)
+		return
+	}
+	if len(snapshots) == 0 {
+		orgs, _ := mw.repository.GetUserOrgs(ctx, event.TargetID)
+		snapshots = []*DetailedUser{{Orgs: orgs}}
+	}
+
+	recorded := map[string]bool{}
+	for _, user := range snapshots {
+		if user == nil {
+			continue
+		}
+		for _, membership := range user.Orgs {
+			if recorded[membership.OrgID] {
+				continue
+			}
+			recorded[membership.OrgID] = true
+			event.OrgID = membership.OrgID
+			mw.record(ctx, event, err)
+		}
+	}
+	if len(recorded) == 0 {
+		event.OrgID = ""
+		mw.record(ctx, event, err)
+	}
+}
+
+// The user as the repository has them right now, whatever their status, nil when
+// there's no such user
+func (mw auditMiddleware) snapshotUser(ctx context.Context, id string) *DetailedUser {
+	if id == "" {
+		return nil
+	}
+	account, err := mw.repository.GetUserAccountAnyStatus(ctx, id)
+	if err != nil {
+		return nil
+	}
+	profile, _ := mw.repository.GetUserProfile(ctx, id)
+	orgs, _ := mw.repository.GetUserOrgs(ctx, id)
+	return &DetailedUser{Account: account, Profile: profile, Orgs: orgs}
+}
+
+// Same as snapshotUser for orgs
+func (mw auditMiddleware) snapshotOrg(ctx context.Context, id string) *DetailedOrg {
+	if id == "" {
+		return nil
+	}
+	account, err := mw.repository.GetOrgAccountAnyStatus(ctx, id)
+	if err != nil {
+		return nil
+	}
+	org := DetailedOrg{Account: account}
+	org.Profile, _ = mw.repository.GetOrgProfile(ctx, id)
+	switch account.Type {
+	case OrgTypeProvider:
+		org.ProviderDetails, _ = mw.repository.GetProviderDetails(ctx, id)
+	case OrgTypePayor:
+		org.PayorDetails, _ = mw.repository.GetPayorDetails(ctx, id)
+	}
+	return &org
+}
+
+// The difference between two snapshots of a user. Their memberships are left
+// out, those show up as role changes instead (see recordRoleChanges).
+func auditUserDiff(before *DetailedUser, after *DetailedUser) []AuditChange {
+	strip := func(user *DetailedUser) *DetailedUser {
+		if user == nil {
+			return nil
+		}
+		stripped := *user
+		stripped.Orgs = nil
+		return &stripped
+	}
+	return auditDiff(strip(before), strip(after))
+}
+
+// Records an event for every org the user's role in changed between the two
+// snapshots, joining or leaving an org included
+func (mw auditMiddleware) recordRoleChanges(ctx context.Context, before *DetailedUser, after *DetailedUser) {
+	roles := func(user *DetailedUser) map[string]string {
+		roles := map[string]string{}
+		if user != nil {
+			for _, membership := range user.Orgs {
+				roles[membership.OrgID] = membership.Role
+			}
+		}
+		return roles
+	}
+	beforeRoles, afterRoles := roles(before), roles(after)
+
+	var userID string
+	if after != nil {
+		userID = after.Account.ID
+	} else if before != nil {
+		userID = before.Account.ID
+	}
+
+	for _, user := range []*DetailedUser{before, after} {
+		if user == nil {
+			continue
+		}
+		for _, membership := range user.Orgs {
+			orgID := membership.OrgID
+			if beforeRoles[orgID] == afterRoles[orgID] {
+				continue
+			}
+			mw.record(ctx, AuditEvent{
+				OrgID:      orgID,
+				Action:     AuditActionUserRoleChange,
+				TargetType: AuditTargetUser,
+				TargetID:   userID,
+				Changes:    []AuditChange{{Field: "role", Before: beforeRoles[orgID], After: afterRoles[orgID]}},
+			}, nil)
+			// Only the once, whichever snapshot we come across it in first
+			beforeRoles[orgID] = afterRoles[orgID]
+		}
+	}
+}
+
+// Runs update against the user and records the action with what it changed
+func (mw auditMiddleware) updateUser(ctx context.Context, action string, id string, update func() error) error {
+	before := mw.snapshotUser(ctx, id)
+	err := update()
+	after := mw.snapshotUser(ctx, id)
+
+	mw.recordUser(ctx, AuditEvent{
+		Action:     action,
+		TargetType: AuditTargetUser,
+		TargetID:   id,
+		Changes:    auditUserDiff(before, after),
+	}, err, before, after)
+	return err
+}
+
+// Same as updateUser for orgs, the event goes in the org's own trail
+func (mw auditMiddleware) updateOrg(ctx context.Context, action string, id string, update func() error) error {
+	before := mw.snapshotOrg(ctx, id)
+	err := update()
+	after := mw.snapshotOrg(ctx, id)
+
+	mw.record(ctx, AuditEvent{
+		OrgID:      id,
+		Action:     action,
+		TargetType: AuditTargetOrg,
+		TargetID:   id,
+		Changes:    auditDiff(before, after),
+	}, err)
+	return err
+}
+
+// Records a login (or a step of one). Who logged in comes from the result when
+// there is one, otherwise from the username if we were given it.
+func (mw auditMiddleware) recordLogin(ctx context.Context, action string, orgID string, username string, user LoginUser, challenge *MFAChallenge, err error) {
+	event := AuditEvent{
+		OrgID:      orgID,
+		Action:     action,
+		TargetType: AuditTargetUser,
+		TargetID:   user.User.Account.ID,
+	}
+	if user.Org.Account.ID != "" {
+		event.OrgID = user.Org.Account.ID
+	}
+	if event.TargetID == "" && username != "" {
+		if account, err := mw.repository.GetAccountByUsernameAnyStatus(ctx, username); err == nil {
+			event.TargetID = account.ID
+		}
+	}
+	// It's the user doing the logging in, whoever's session (if any) it came with
+	event.ActorID = event.TargetID
+	if err == nil && challenge != nil {
+		event.Outcome = AuditOutcomeChallenged
+	}
+
+	mw.recordUser(ctx, event, err)
+}
+
+func (mw auditMiddleware) CreateUser(ctx context.Context, orgID string, username string, password string, orgType string, firstName string, lastName string, email string, phone string) (string, error) {
+	id, err := mw.next.CreateUser(ctx, orgID, username, password, orgType, firstName, lastName, email, phone)
+
+	var after *DetailedUser
+	if err == nil {
+		after = mw.snapshotUser(ctx, id)
+	}
+	event := AuditEvent{
+		OrgID:      orgID,
+		Action:     AuditActionUserCreate,
+		TargetType: AuditTargetUser,
+		TargetID:   id,
+		Changes:    auditUserDiff(nil, after),
+	}
+	// Someone signing themselves up is their own actor
+	if _, ok := PrincipalFromContext(ctx); !ok {
+		event.ActorID = id
+	}
+	mw.record(ctx, event, err)
+	if err == nil {
+		mw.recordRoleChanges(ctx, nil, after)
+	}
+	return id, err
+}
+
+// Deleted users keep their memberships, so there are no role changes to record
+func (mw auditMiddleware) DeleteUserAccount(ctx context.Context, id string) error {
+	return mw.updateUser(ctx, AuditActionUserDelete, id, func() error {
+		return mw.next.DeleteUserAccount(ctx, id)
+	})
+}
+
+func (mw auditMiddleware) UpdateUserProfile(ctx context.Context, accountID string, updates map[string]interface{}) error {
+	return mw.updateUser(ctx, AuditActionUserProfileUpdate, accountID, func() error {
+		return mw.next.UpdateUserProfile(ctx, accountID, updates)
+	})
+}
+
+func (mw auditMiddleware) GetUserAccount(ctx context.Context, id string) (UserAccount, error) {
+	return mw.next.GetUserAccount(ctx, id)
+}
+
+// Comes with the user's profile, so it's recorded
+func (mw auditMiddleware) GetDetailedUser(ctx context.Context, id string, includeOrgs bool) (DetailedUser, error) {
+	user, err := mw.next.GetDetailedUser(ctx, id, includeOrgs)
+
+	mw.recordUser(ctx, AuditEvent{
+		Action:     AuditActionUserRead,
+		TargetType: AuditTargetUser,
+		TargetID:   id,
+	}, err)
+	return user, err
+}
+
+func (mw auditMiddleware) UpdateUserAccount(ctx context.Context, id string, updates map[string]interface{}) error {
+	return mw.updateUser(ctx, AuditActionUserUpdate, id, func() error {
+		return mw.next.UpdateUserAccount(ctx, id, updates)
+	})
+}
+
+func (mw auditMiddleware) SuspendUser(ctx context.Context, userID string) (account UserAccount, err error) {
+	err = mw.updateUser(ctx, AuditActionUserSuspend, userID, func() error {
+		account, err = mw.next.SuspendUser(ctx, userID)
+		return err
+	})
+	return account, err
+}
+
+func (mw auditMiddleware) DeactivateUser(ctx context.Context, userID string) (account UserAccount, err error) {
+	err = mw.updateUser(ctx, AuditActionUserDeactivate, userID, func() error {
+		account, err = mw.next.DeactivateUser(ctx, userID)
+		return err
+	})
+	return account, err
+}
+
+func (mw auditMiddleware) ReactivateUser(ctx context.Context, userID string) (account UserAccount, err error) {
+	err = mw.updateUser(ctx, AuditActionUserReactivate, userID, func() error {
+		account, err = mw.next.ReactivateUser(ctx, userID)
+		return err
+	})
+	return account, err
+}
+
+func (mw auditMiddleware) RestoreUser(ctx context.Context, userID string) (account UserAccount, err error) {
+	err = mw.updateUser(ctx, AuditActionUserRestore, userID, func() error {
+		account, err = mw.next.RestoreUser(ctx, userID)
+		return err
+	})
+	return account, err
+}
+
+func (mw auditMiddleware) Login(ctx context.Context, orgID string, username string, password string) (LoginUser, *MFAChallenge, error) {
+	user, challenge, err := mw.next.Login(ctx, orgID, username, password)
+	mw.recordLogin(ctx, AuditActionLoginPassword, orgID, username, user, challenge, err)
+	return user, challenge, err
+}
+
+func (mw auditMiddleware) CompleteMFALogin(ctx context.Context, challengeToken string, code string) (LoginUser, error) {
+	user, err := mw.next.CompleteMFALogin(ctx, challengeToken, code)
+	mw.recordLogin(ctx, AuditActionLoginMFA, "", "", user, nil, err)
+	return user, err
+}
+
+func (mw auditMiddleware) Authenticate(ctx context.Context, token string) (Principal, error) {
+	return mw.next.Authenticate(ctx, token)
+}
+
+// Only checks the password, the session comes with ChooseLoginOrg
+func (mw auditMiddleware) LoginWithoutOrg(ctx context.Context, username string, password string) (OrgSelection, error) {
+	selection, err := mw.next.LoginWithoutOrg(ctx, username, password)
+	mw.recordLogin(ctx, AuditActionLoginPassword, "", username, LoginUser{}, nil, err)
+	return selection, err
+}
+
+func (mw auditMiddleware) ChooseLoginOrg(ctx context.Context, token string, orgID string) (LoginUser, *MFAChallenge, error) {
+	user, challenge, err := mw.next.ChooseLoginOrg(ctx, token, orgID)
+	mw.recordLogin(ctx, AuditActionLoginChooseOrg, orgID, "", user, challenge, err)
+	return user, challenge, err
+}
+
+func (mw auditMiddleware) SwitchOrg(ctx context.Context, orgID string) (LoginUser, *MFAChallenge, error) {
+	user, challenge, err := mw.next.SwitchOrg(ctx, orgID)
+
+	event := AuditEvent{
+		OrgID:      orgID,
+		Action:     AuditActionUserSwitchOrg,
+		TargetType: AuditTargetUser,
+	}
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		event.TargetID = principal.UserID
+		event.Changes = []AuditChange{{Field: "org_id", Before: principal.OrgID, After: orgID}}
+	}
+	if err == nil && challenge != nil {
+		event.Outcome = AuditOutcomeChallenged
+	}
+	mw.record(ctx, event, err)
+	return user, challenge, err
+}
+
+func (mw auditMiddleware) ListMyOrgs(ctx context.Context) ([]OrgMembership, error) {
+	return mw.next.ListMyOrgs(ctx)
+}
+
+func (mw auditMiddleware) CreateOrg(ctx context.Context, name string, orgType string, parentID string, phone string, address string, timezone string, website string, providerDetails *ProviderDetails, payorDetails *PayorDetails) (string, error) {
+	id, err := mw.next.CreateOrg(ctx, name, orgType, parentID, phone, address, timezone, website, providerDetails, payorDetails)
+
+	var after *DetailedOrg
+	if err == nil {
+		after = mw.snapshotOrg(ctx, id)
+	}
+	mw.record(ctx, AuditEvent{
+		OrgID:      id,
+		Action:     AuditActionOrgCreate,
+		TargetType: AuditTargetOrg,
+		TargetID:   id,
+		Changes:    auditDiff(nil, after),
+	}, err)
+	return id, err
+}
+
+func (mw auditMiddleware) GetOrg(ctx context.Context, id string) (DetailedOrg, error) {
+	return mw.next.GetOrg(ctx, id)
+}
+
+func (mw auditMiddleware) UpdateOrgAccount(ctx context.Context, id string, updates map[string]interface{}) error {
+	return mw.updateOrg(ctx, AuditActionOrgUpdate, id, func() error {
+		return mw.next.UpdateOrgAccount(ctx, id, updates)
+	})
+}
+
+func (mw auditMiddleware) UpdateOrgProfile(ctx context.Context, id string, updates map[string]interface{}) error {
+	return mw.updateOrg(ctx, AuditActionOrgProfileUpdate, id, func() error {
+		return mw.next.UpdateOrgProfile(ctx, id, updates)
+	})
+}
+
+func (mw auditMiddleware) DeleteOrg(ctx context.Context, id string, force bool) error {
+	return mw.updateOrg(ctx, AuditActionOrgDelete, id, func() error {
+		return mw.next.DeleteOrg(ctx, id, force)
+	})
+}
+
+func (mw auditMiddleware) SuspendOrg(ctx context.Context, orgID string) (account OrgAccount, err error) {
+	err = mw.updateOrg(ctx, AuditActionOrgSuspend, orgID, func() error {
+		account, err = mw.next.SuspendOrg(ctx, orgID)
+		return err
+	})
+	return account, err
+}
+
+func (mw auditMiddleware) DeactivateOrg(ctx context.Context, orgID string) (account OrgAccount, err error) {
+	err = mw.updateOrg(ctx, AuditActionOrgDeactivate, orgID, func() error {
+		account, err = mw.next.DeactivateOrg(ctx, orgID)
+		return err
+	})
+	return account, err
+}
+
+func (mw auditMiddleware) ReactivateOrg(ctx context.Context, orgID string) (account OrgAccount, err error) {
+	err = mw.updateOrg(ctx, AuditActionOrgReactivate, orgID, func() error {
+		account, err = mw.next.ReactivateOrg(ctx, orgID)
+		return err
+	})
+	return account, err
+}
+
+func (mw auditMiddleware) RestoreOrg(ctx context.Context, orgID string) (account OrgAccount, err error) {
+	err = mw.updateOrg(ctx, AuditActionOrgRestore, orgID, func() error {
+		account, err = mw.next.RestoreOrg(ctx, orgID)
+		return err
+	})
+	return account, err
+}
+
+// Comes with the users' profiles, so it's recorded
+func (mw auditMiddleware) ListOrgUsers(ctx context.Context, orgID string, query OrgUserQuery) (OrgUserPage, error) {
+	page, err := mw.next.ListOrgUsers(ctx, orgID, query)
+
+	mw.record(ctx, AuditEvent{
+		OrgID:      orgID,
+		Action:     AuditActionOrgUsersRead,
+		TargetType: AuditTargetOrg,
+		TargetID:   orgID,
+	}, err)
+	return page, err
+}
+
+func (mw auditMiddleware) ListChildOrgs(ctx context.Context, orgID string) ([]OrgAccount, error) {
+	return mw.next.ListChildOrgs(ctx, orgID)
+}
+
+func (mw auditMiddleware) GetOrgTree(ctx context.Context, orgID string) (OrgHierarchy, error) {
+	return mw.next.GetOrgTree(ctx, orgID)
+}
+
+func (mw auditMiddleware) UpdatePayorDetails(ctx context.Context, orgID string, details PayorDetails) error {
+	return mw.updateOrg(ctx, AuditActionOrgPayorDetailsUpdate, orgID, func() error {
+		return mw.next.UpdatePayorDetails(ctx, orgID, details)
+	})
+}
+
+func (mw auditMiddleware) FindPayor(ctx context.Context, payerID string) (DetailedOrg, error) {
+	return mw.next.FindPayor(ctx, payerID)
+}
+
+func (mw auditMiddleware) CreateInvite(ctx context.Context, orgID string, email string, role string) (IssuedInvite, error) {
+	issued, err := mw.next.CreateInvite(ctx, orgID, email, role)
+
+	event := AuditEvent{
+		OrgID:      orgID,
+		Action:     AuditActionInviteCreate,
+		TargetType: AuditTargetInvite,
+		TargetID:   issued.Invite.ID,
+	}
+	if err == nil {
+		event.Changes = auditDiff(nil, issued.Invite)
+	}
+	mw.record(ctx, event, err)
+	return issued, err
+}
+
+func (mw auditMiddleware) ListInvites(ctx context.Context, orgID string) ([]Invite, error) {
+	return mw.next.ListInvites(ctx, orgID)
+}
+
+func (mw auditMiddleware) RevokeInvite(ctx context.Context, orgID string, inviteID string) error {
+	err := mw.next.RevokeInvite(ctx, orgID, inviteID)
+
+	mw.record(ctx, AuditEvent{
+		OrgID:      orgID,
+		Action:     AuditActionInviteRevoke,
+		TargetType: AuditTargetInvite,
+		TargetID:   inviteID,
+	}, err)
+	return err
+}
+
+func (mw auditMiddleware) ResendInvite(ctx context.Context, orgID string, inviteID string) (IssuedInvite, error) {
+	issued, err := mw.next.ResendInvite(ctx, orgID, inviteID)
+
+	mw.record(ctx, AuditEvent{
+		OrgID:      orgID,
+		Action:     AuditActionInviteResend,
+		TargetType: AuditTargetInvite,
+		TargetID:   inviteID,
+	}, err)
+	return issued, err
+}
+
+// Accepting an invite without a session creates the account too, so that's
+// recorded as well as the role it comes with
+func (mw auditMiddleware) AcceptInvite(ctx context.Context, token string, username string, password string, firstName string, lastName string, phone string) (string, error) {
+	principal, loggedIn := PrincipalFromContext(ctx)
+	var before *DetailedUser
+	if loggedIn {
+		before = mw.snapshotUser(ctx, principal.UserID)
+	}
+
+	userID, err := mw.next.AcceptInvite(ctx, token, username, password, firstName, lastName, phone)
+	if err != nil {
+		mw.recordUser(ctx, AuditEvent{Action: AuditActionInviteAccept, TargetType: AuditTargetUser, TargetID: principal.UserID}, err)
+		return userID, err
+	}
+
+	after := mw.snapshotUser(ctx, userID)
+	if !loggedIn {
+		mw.recordUser(ctx, AuditEvent{
+			ActorID:    userID,
+			Action:     AuditActionUserCreate,
+			TargetType: AuditTargetUser,
+			TargetID:   userID,
+			Changes:    auditUserDiff(nil, after),
+		}, nil, after)
+	}
+	// The invite's org is whichever one they've just joined
+	for _, org := range orgsJoined(before, after) {
+		mw.record(ctx, AuditEvent{
+			ActorID:    userID,
+			OrgID:      org.OrgID,
+			Action:     AuditActionInviteAccept,
+			TargetType: AuditTargetUser,
+			TargetID:   userID,
+		}, nil)
+	}
+	mw.recordRoleChanges(ctx, before, after)
+	return userID, err
+}
+
+// The orgs the user belongs to after that they didn't before
+func orgsJoined(before *DetailedUser, after *DetailedUser) []OrgMembership {
+	if after == nil {
+		return nil
+	}
+	had := map[string]bool{}
+	if before != nil {
+		for _, membership := range before.Orgs {
+			had[membership.OrgID] = true
+		}
+	}
+	var joined []OrgMembership
+	for _, membership := range after.Orgs {
+		if !had[membership.OrgID] {
+			joined = append(joined, membership)
+		}
+	}
+	return joined
+}
+
+// The relationship as the repository has it right now, nil when there's no such relationship
+func (mw auditMiddleware) snapshotNetwork(ctx context.Context, id string) *NetworkRelationship {
+	relationship, err := mw.repository.GetNetworkRelationship(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return &relationship
+}
+
+// Records a change to a network relationship, in the trail of the org that made it
+func (mw auditMiddleware) recordNetwork(ctx context.Context, action string, orgID string, relationshipID string, before *NetworkRelationship, after NetworkRelationship, err error) {
+	event := AuditEvent{
+		OrgID:      orgID,
+		Action:     action,
+		TargetType: AuditTargetNetwork,
+		TargetID:   relationshipID,
+	}
+	if err == nil {
+		event.TargetID = after.ID
+		event.Changes = auditDiff(before, after)
+	}
+	mw.record(ctx, event, err)
+}
+
+func (mw auditMiddleware) RequestNetworkRelationship(ctx context.Context, orgID string, partnerID string, effectiveDate string, terminationDate string) (NetworkRelationship, error) {
+	relationship, err := mw.next.RequestNetworkRelationship(ctx, orgID, partnerID, effectiveDate, terminationDate)
+	mw.recordNetwork(ctx, AuditActionNetworkRequest, orgID, "", nil, relationship, err)
+	return relationship, err
+}
+
+func (mw auditMiddleware) AcceptNetworkRelationship(ctx context.Context, orgID string, relationshipID string) (NetworkRelationship, error) {
+	before := mw.snapshotNetwork(ctx, relationshipID)
+	relationship, err := mw.next.AcceptNetworkRelationship(ctx, orgID, relationshipID)
+	mw.recordNetwork(ctx, AuditActionNetworkAccept, orgID, relationshipID, before, relationship, err)
+	return relationship, err
+}
+
+func (mw auditMiddleware) TerminateNetworkRelationship(ctx context.Context, orgID string, relationshipID string) (NetworkRelationship, error) {
+	before := mw.snapshotNetwork(ctx, relationshipID)
+	relationship, err := mw.next.TerminateNetworkRelationship(ctx, orgID, relationshipID)
+	mw.recordNetwork(ctx, AuditActionNetworkTerminate, orgID, relationshipID, before, relationship, err)
+	return relationship, err
+}
+
+func (mw auditMiddleware) ListPayors(ctx context.Context, providerID string, query NetworkQuery) ([]NetworkRelationship, error) {
+	return mw.next.ListPayors(ctx, providerID, query)
+}
+
+func (mw auditMiddleware) ListProviders(ctx context.Context, payorID string, query NetworkQuery) ([]NetworkRelationship, error) {
+	return mw.next.ListProviders(ctx, payorID, query)
+}
+
+// Looking at the audit trail is itself recorded in it
+func (mw auditMiddleware) QueryAuditLog(ctx context.Context, query AuditQuery) ([]AuditEvent, error) {
+	events, err := mw.next.QueryAuditLog(ctx, query)
+
+	mw.record(ctx, AuditEvent{
+		OrgID:      query.OrgID,
+		Action:     AuditActionOrgAuditRead,
+		TargetType: AuditTargetOrg,
+		TargetID:   query.OrgID,
+	}, err)
+	return events, err
+}
+
+func (mw auditMiddleware) SendEmailVerification(ctx context.Context, userID string) error {
+	return mw.next.SendEmailVerification(ctx, userID)
+}
+
+func (mw auditMiddleware) VerifyEmail(ctx context.Context, userID string, token string) error {
+	return mw.updateUser(ctx, AuditActionUserEmailVerify, userID, func() error {
+		return mw.next.VerifyEmail(ctx, userID, token)
+	})
+}
+
+func (mw auditMiddleware) SendPhoneVerification(ctx context.Context, userID string) error {
+	return mw.next.SendPhoneVerification(ctx, userID)
+}
+
+func (mw auditMiddleware) VerifyPhone(ctx context.Context, userID string, code string) error {
+	return mw.updateUser(ctx, AuditActionUserPhoneVerify, userID, func() error {
+		return mw.next.VerifyPhone(ctx, userID, code)
+	})
+}
+
+func (mw auditMiddleware) RequestPasswordReset(ctx context.Context, username string, email string) error {
+	return mw.next.RequestPasswordReset(ctx, username, email)
+}
+
+// Whose password it was is in the token, which only the service can read, so
+// the event goes without a target
+func (mw auditMiddleware) ResetPassword(ctx context.Context, token string, password string) error {
+	err := mw.next.ResetPassword(ctx, token, password)
+
+	mw.record(ctx, AuditEvent{
+		Action:     AuditActionUserPasswordReset,
+		TargetType: AuditTargetUser,
+	}, err)
+	return err
+}
+
+func (mw auditMiddleware) EnrollTOTP(ctx context.Context, userID string) (TOTPEnrollment, error) {
+	return mw.next.EnrollTOTP(ctx, userID)
+}
+
+func (mw auditMiddleware) ConfirmTOTP(ctx context.Context, userID string, code string) ([]string, error) {
+	codes, err := mw.next.ConfirmTOTP(ctx, userID, code)
+
+	mw.recordUser(ctx, AuditEvent{
+		Action:     AuditActionUserMFAEnable,
+		TargetType: AuditTargetUser,
+		TargetID:   userID,
+	}, err)
+	return codes, err
+}
+
+func (mw auditMiddleware) DisableMFA(ctx context.Context, userID string, code string) error {
+	err := mw.next.DisableMFA(ctx, userID, code)
+
+	mw.recordUser(ctx, AuditEvent{
+		Action:     AuditActionUserMFADisable,
+		TargetType: AuditTargetUser,
+		TargetID:   userID,
+	}, err)
+	return err
+}
+
+func (mw auditMiddleware) ResetMFA(ctx context.Context, userID string) error {
+	err := mw.next.ResetMFA(ctx, userID)
+
+	mw.recordUser(ctx, AuditEvent{
+		Action:     AuditActionUserMFAReset,
+		TargetType: AuditTargetUser,
+		TargetID:   userID,
+	}, err)
+	return err
+}
+
+func (mw auditMiddleware) BeginPasskeyRegistration(ctx context.Context, userID string) (PasskeyCeremony, error) {
+	return mw.next.BeginPasskeyRegistration(ctx, userID)
+}
+
+func (mw auditMiddleware) FinishPasskeyRegistration(ctx context.Context, userID string, token string, name string, credential []byte) (Passkey, error) {
+	passkey, err := mw.next.FinishPasskeyRegistration(ctx, userID, token, name, credential)
+
+	mw.recordUser(ctx, AuditEvent{
+		Action:     AuditActionUserPasskeyAdd,
+		TargetType: AuditTargetUser,
+		TargetID:   userID,
+		Changes:    []AuditChange{{Field: "passkey", After: passkey.ID}},
+	}, err)
+	return passkey, err
+}
+
+func (mw auditMiddleware) ListPasskeys(ctx context.Context, userID string) ([]Passkey, error) {
+	return mw.next.ListPasskeys(ctx, userID)
+}
+
+func (mw auditMiddleware) DeletePasskey(ctx context.Context, userID string, passkeyID string) error {
+	err := mw.next.DeletePasskey(ctx, userID, passkeyID)
+
+	mw.recordUser(ctx, AuditEvent{
+		Action:     AuditActionUserPasskeyDelete,
+		TargetType: AuditTargetUser,
+		TargetID:   userID,
+		Changes:    []AuditChange{{Field: "passkey", Before: passkeyID}},
+	}, err)
+	return err
+}
+
+func (mw auditMiddleware) BeginPasskeyLogin(ctx context.Context, orgID string) (PasskeyCeremony, error) {
+	return mw.next.BeginPasskeyLogin(ctx, orgID)
+}
+
+func (mw auditMiddleware) PasskeyLogin(ctx context.Context, orgID string, token string, credential []byte) (LoginUser, error) {
+	user, err := mw.next.PasskeyLogin(ctx, orgID, token, credential)
+	mw.recordLogin(ctx, AuditActionLoginPasskey, orgID, "", user, nil, err)
+	return user, err
+}
+
+func (mw auditMiddleware) RequestMagicLink(ctx context.Context, orgID string, username string, email string) error {
+	return mw.next.RequestMagicLink(ctx, orgID, username, email)
+}
+
+func (mw auditMiddleware) MagicLinkLogin(ctx context.Context, orgID string, token string) (LoginUser, *MFAChallenge, error) {
+	user, challenge, err := mw.next.MagicLinkLogin(ctx, orgID, token)
+	mw.recordLogin(ctx, AuditActionLoginMagicLink, orgID, "", user, challenge, err)
+	return user, challenge, err
+}
+
+func (mw auditMiddleware) RequestSMSCode(ctx context.Context, orgID string, username string) (SMSCodeChallenge, error) {
+	return mw.next.RequestSMSCode(ctx, orgID, username)
+}
+
+func (mw auditMiddleware) SMSCodeLogin(ctx context.Context, orgID string, token string, code string) (LoginUser, *MFAChallenge, error) {
+	user, challenge, err := mw.next.SMSCodeLogin(ctx, orgID, token, code)
+	mw.recordLogin(ctx, AuditActionLoginSMS, orgID, "", user, challenge, err)
+	return user, challenge, err
+}
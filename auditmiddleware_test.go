@@ -0,0 +1,57 @@
+package accountsrv
+
+import (
+	"context"
+	"sort"
+	"testing"
+)
+
+type fakeAuditSink struct {
+	AuditSink
+	events []AuditEvent
+}
+
+func (f *fakeAuditSink) Append(_ context.Context, event AuditEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+// A change to a user goes in the trail of each of their orgs, not the caller's
+// (or nowhere, for a caller without a session)
+func TestAuditUserEventsGoToTheUsersOrgs(t *testing.T) {
+	repo := newFakeRepo()
+	repo.addMember("org", "admin", RoleAdmin)
+	repo.addMember("org", "user", RoleMember)
+	repo.addMember("other", "user", RoleMember)
+	repo.addMember("elsewhere", "outsider", RoleAdmin)
+	repo.profiles["user"] = UserProfile{Phone: "+15555550199"}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"anonymous", context.Background()},
+		{"from another org", as("outsider", "elsewhere")},
+		{"the user", as("user", "org")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &fakeAuditSink{}
+			svc := AuditMiddleware(sink, repo, nopLogger)(newTestService(repo))
+
+			svc.UpdateUserProfile(tt.ctx, "user", map[string]interface{}{"first_name": "New"})
+
+			var orgs []string
+			for _, event := range sink.events {
+				if event.Action != AuditActionUserProfileUpdate || event.TargetID != "user" {
+					t.Fatalf("unexpected event %+v", event)
+				}
+				orgs = append(orgs, event.OrgID)
+			}
+			sort.Strings(orgs)
+			if len(orgs) != 2 || orgs[0] != "org" || orgs[1] != "other" {
+				t.Fatalf("recorded under %v, want [org other]", orgs)
+			}
+		})
+	}
+}
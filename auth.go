@@ -0,0 +1,165 @@
+package accountsrv
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"google.golang.org/grpc/metadata"
+)
+
+// How long a session handed out on login is good for
+const sessionTTL = 12 * time.Hour
+
+// How the user proved who they were when their session started
+const (
+	AuthMethodPassword = "password"
+	AuthMethodPasskey  = "passkey"
+	AuthMethodEmail    = PasswordlessMethodEmail // A magic link
+	AuthMethodSMS      = PasswordlessMethodSMS
+)
+
+// How a login got this far, which decides what else an org can still ask for.
+// A passkey counts as MFA on its own, anything else only once a TOTP code
+// checked out too.
+type sessionAuth struct {
+	Method string
+	MFA    bool
+}
+
+// A logged in session as we keep it in the DB. Only the hash of the token is
+// ever stored, the token itself goes back to the caller once and that's it.
+type Session struct {
+	ID         string    `db:"id" json:"id"`
+	TokenHash  string    `db:"token_hash" json:"-"`
+	UserID     string    `db:"user_id" json:"user_id"`
+	OrgID      string    `db:"org_id" json:"org_id"`
+	AuthMethod string    `db:"auth_method" json:"auth_method"`
+	MFA        bool      `db:"mfa" json:"mfa"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	ExpiresAt  time.Time `db:"expires_at" json:"expires_at"`
+}
+
+func (s Session) auth() sessionAuth {
+	return sessionAuth{Method: s.AuthMethod, MFA: s.MFA}
+}
+
+// What a successful login hands back for the caller to authenticate with from
+// then on (as a bearer token).
+type SessionToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Who is making the request, resolved from their bearer token
+type Principal struct {
+	UserID    string `json:"user_id"`
+	OrgID     string `json:"org_id"`
+	SessionID string `json:"session_id"`
+
+	// How the session was logged in, to hold it to the rules of the orgs it acts on
+	auth sessionAuth
+}
+
+type principalKey struct{}
+
+// Returns a copy of the context carrying the authenticated caller
+func ContextWithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// Pulls the authenticated caller out of the context, ok is false for anonymous requests
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// Endpoint middleware that resolves the bearer token (if the caller sent one)
+// into a Principal for the service to check against. Requests without a token
+// go through anonymously, it's up to the service to decide what needs one. A
+// token that doesn't check out is refused outright though.
+func AuthMiddleware(s Service) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			token := AuthTokenFromContext(ctx)
+			if token == "" {
+				return next(ctx, request)
+			}
+
+			principal, err := s.Authenticate(ctx, token)
+			if err != nil {
+				return nil, err
+			}
+
+			return next(ContextWithPrincipal(ctx, principal), request)
+		}
+	}
+}
+
+// Generates a new random session token
+func newSessionToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// What we store (and look sessions up by) instead of the token itself. The token
+// is 256 random bits so a plain SHA-256 is all it takes.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Both transports carry the caller's credentials the same way (a bearer token), so
+// they both drop it into the context under the same key. Anything below the
+// transport layer only ever looks at the context and never cares which one it
+// came in through.
+
+type authTokenKey struct{}
+
+// Returns a copy of the context carrying the caller's bearer token
+func ContextWithAuthToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, authTokenKey{}, token)
+}
+
+// Pulls the caller's bearer token out of the context, or an empty string if there is none
+func AuthTokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(authTokenKey{}).(string)
+	return token
+}
+
+// Strips the "Bearer " scheme off an Authorization value, returning an empty
+// string for anything else.
+func parseBearerToken(value string) string {
+	const prefix = "bearer "
+	if len(value) <= len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(value[len(prefix):])
+}
+
+// httptransport.ServerBefore func that reads the Authorization header
+func httpAuthTokenToContext(ctx context.Context, req *http.Request) context.Context {
+	if token := parseBearerToken(req.Header.Get("Authorization")); token != "" {
+		return ContextWithAuthToken(ctx, token)
+	}
+	return ctx
+}
+
+// grpctransport.ServerBefore func that reads the authorization metadata
+func grpcAuthTokenToContext(ctx context.Context, md metadata.MD) context.Context {
+	for _, value := range md.Get("authorization") {
+		if token := parseBearerToken(value); token != "" {
+			return ContextWithAuthToken(ctx, token)
+		}
+	}
+	return ctx
+}
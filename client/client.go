@@ -0,0 +1,859 @@
+// Package client lets other services talk to accountsrv through the very same
+// Service interface the server implements. Under the hood every method goes
+// through a go-kit HTTP client endpoint, so the only thing a caller needs is
+// the base URL of an accountsrv instance.
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/sd"
+	"github.com/go-kit/kit/sd/lb"
+	httptransport "github.com/go-kit/kit/transport/http"
+
+	"github.com/rjjp5294/accountsrv"
+)
+
+// The knobs a caller can turn, set with the Option funcs below
+type config struct {
+	httpClient *http.Client
+	authToken  string
+	timeout    time.Duration
+	attempts   int
+	backoff    time.Duration
+}
+
+// Functional option for New and NewEndpoints
+type Option func(*config)
+
+// Use this http.Client instead of http.DefaultClient
+func WithHTTPClient(c *http.Client) Option {
+	return func(cfg *config) { cfg.httpClient = c }
+}
+
+// Sends this bearer token on every call. A token put in the context with
+// accountsrv.ContextWithAuthToken takes precedence for that one call.
+func WithAuthToken(token string) Option {
+	return func(cfg *config) { cfg.authToken = token }
+}
+
+// How long a call (retries included) gets before giving up. Defaults to 10s.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *config) { cfg.timeout = timeout }
+}
+
+// How many times a call is attempted in total when it fails in a way worth
+// retrying, see retryable. Defaults to 3, 1 turns retries off.
+func WithAttempts(attempts int) Option {
+	return func(cfg *config) { cfg.attempts = attempts }
+}
+
+// How long to wait before the first retry, doubling for every one after that.
+// Defaults to 100ms.
+func WithBackoff(backoff time.Duration) Option {
+	return func(cfg *config) { cfg.backoff = backoff }
+}
+
+// Factory func for a Service that calls the accountsrv instance at the given base
+// URL (e.g. "http://accountsrv:8080").
+func New(instance string, options ...Option) (accountsrv.Service, error) {
+	endpoints, err := NewEndpoints(instance, options...)
+	if err != nil {
+		return nil, err
+	}
+	return service{endpoints: endpoints}, nil
+}
+
+// Factory func for the client side Endpoints, for anyone that would rather wrap
+// them in their own middleware before using them.
+func NewEndpoints(instance string, options ...Option) (accountsrv.Endpoints, error) {
+	cfg := config{
+		httpClient: http.DefaultClient,
+		timeout:    10 * time.Second,
+		attempts:   3,
+		backoff:    100 * time.Millisecond,
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	if !strings.HasPrefix(instance, "http") {
+		instance = "http://" + instance
+	}
+	base, err := url.Parse(instance)
+	if err != nil {
+		return accountsrv.Endpoints{}, err
+	}
+
+	clientOptions := []httptransport.ClientOption{
+		httptransport.SetClient(cfg.httpClient),
+		httptransport.ClientBefore(
+			authTokenToHTTP(cfg.authToken),
+			requestIDToHTTP,
+		),
+	}
+
+	// Each endpoint gets wrapped in the retry logic the same way, what's worth
+	// retrying depends on the method
+	newEndpoint := func(method string, enc httptransport.EncodeRequestFunc, dec httptransport.DecodeResponseFunc) endpoint.Endpoint {
+		e := httptransport.NewClient(method, base, enc, dec, clientOptions...).Endpoint()
+		return retry(e, idempotent(method), cfg.attempts, cfg.timeout, cfg.backoff)
+	}
+
+	return accountsrv.Endpoints{
+		CreateUser:        newEndpoint("POST", encodeCreateUserReq, decodeCreateUserResp),
+		GetUser:           newEndpoint("GET", encodeGetUserReq, decodeGetUserResp),
+		DeleteUser:        newEndpoint("DELETE", encodeDeleteUserReq, decodeDeleteUserResp),
+		SuspendUser:       newEndpoint("POST", encodeUserStatusReq("suspend"), decodeUserStatusResp),
+		DeactivateUser:    newEndpoint("POST", encodeUserStatusReq("deactivate"), decodeUserStatusResp),
+		ReactivateUser:    newEndpoint("POST", encodeUserStatusReq("reactivate"), decodeUserStatusResp),
+		RestoreUser:       newEndpoint("POST", encodeUserStatusReq("restore"), decodeUserStatusResp),
+		UpdateUserAccount: newEndpoint("PATCH", encodeUpdateUserAccountReq, decodeUpdateUserAccountResp),
+		LoginUser:         newEndpoint("POST", encodeLoginReq, decodeLoginResp),
+		UpdateUserProfile: newEndpoint("PUT", encodeUpdateUserProfileReq, decodeUpdateUserProfileResp),
+		GetSession:        newEndpoint("GET", encodeGetSessionReq, decodeGetSessionResp),
+
+		SendEmailVerification: newEndpoint("POST", encodeSendEmailVerificationReq, decodeSendEmailVerificationResp),
+		VerifyEmail:           newEndpoint("POST", encodeVerifyEmailReq, decodeVerifyEmailResp),
+		SendPhoneVerification: newEndpoint("POST", encodeSendPhoneVerificationReq, decodeSendPhoneVerificationResp),
+		VerifyPhone:           newEndpoint("POST", encodeVerifyPhoneReq, decodeVerifyPhoneResp),
+
+		RequestPasswordReset: newEndpoint("POST", encodeRequestPasswordResetReq, decodeRequestPasswordResetResp),
+		ResetPassword:        newEndpoint("POST", encodeResetPasswordReq, decodeResetPasswordResp),
+
+		CompleteMFALogin: newEndpoint("POST", encodeCompleteMFALoginReq, decodeCompleteMFALoginResp),
+		EnrollTOTP:       newEndpoint("POST", encodeEnrollTOTPReq, decodeEnrollTOTPResp),
+		ConfirmTOTP:      newEndpoint("POST", encodeConfirmTOTPReq, decodeConfirmTOTPResp),
+		DisableMFA:       newEndpoint("POST", encodeDisableMFAReq, decodeDisableMFAResp),
+		ResetMFA:         newEndpoint("POST", encodeResetMFAReq, decodeResetMFAResp),
+
+		BeginPasskeyRegistration:  newEndpoint("POST", encodeBeginPasskeyRegistrationReq, decodeBeginPasskeyRegistrationResp),
+		FinishPasskeyRegistration: newEndpoint("POST", encodeFinishPasskeyRegistrationReq, decodeFinishPasskeyRegistrationResp),
+		ListPasskeys:              newEndpoint("GET", encodeListPasskeysReq, decodeListPasskeysResp),
+		DeletePasskey:             newEndpoint("DELETE", encodeDeletePasskeyReq, decodeDeletePasskeyResp),
+		BeginPasskeyLogin:         newEndpoint("POST", encodeBeginPasskeyLoginReq, decodeBeginPasskeyLoginResp),
+		PasskeyLogin:              newEndpoint("POST", encodePasskeyLoginReq, decodePasskeyLoginResp),
+
+		RequestMagicLink: newEndpoint("POST", encodeRequestMagicLinkReq, decodeRequestMagicLinkResp),
+		MagicLinkLogin:   newEndpoint("POST", encodeMagicLinkLoginReq, decodeLoginResp),
+		RequestSMSCode:   newEndpoint("POST", encodeRequestSMSCodeReq, decodeRequestSMSCodeResp),
+		SMSCodeLogin:     newEndpoint("POST", encodeSMSCodeLoginReq, decodeLoginResp),
+
+		LoginWithoutOrg: newEndpoint("POST", encodeLoginWithoutOrgReq, decodeLoginWithoutOrgResp),
+		ChooseLoginOrg:  newEndpoint("POST", encodeChooseLoginOrgReq, decodeLoginResp),
+		SwitchOrg:       newEndpoint("POST", encodeSwitchOrgReq, decodeLoginResp),
+		ListMyOrgs:      newEndpoint("GET", encodeListMyOrgsReq, decodeListMyOrgsResp),
+
+		CreateOrg:        newEndpoint("POST", encodeCreateOrgReq, decodeCreateOrgResp),
+		GetOrg:           newEndpoint("GET", encodeGetOrgReq, decodeGetOrgResp),
+		UpdateOrgAccount: newEndpoint("PATCH", encodeUpdateOrgAccountReq, decodeUpdateOrgAccountResp),
+		UpdateOrgProfile: newEndpoint("PATCH", encodeUpdateOrgProfileReq, decodeUpdateOrgProfileResp),
+		DeleteOrg:        newEndpoint("DELETE", encodeDeleteOrgReq, decodeDeleteOrgResp),
+		SuspendOrg:       newEndpoint("POST", encodeOrgStatusReq("suspend"), decodeOrgStatusResp),
+		DeactivateOrg:    newEndpoint("POST", encodeOrgStatusReq("deactivate"), decodeOrgStatusResp),
+		ReactivateOrg:    newEndpoint("POST", encodeOrgStatusReq("reactivate"), decodeOrgStatusResp),
+		RestoreOrg:       newEndpoint("POST", encodeOrgStatusReq("restore"), decodeOrgStatusResp),
+		ListOrgUsers:     newEndpoint("GET", encodeListOrgUsersReq, decodeListOrgUsersResp),
+		ListChildOrgs:    newEndpoint("GET", encodeListChildOrgsReq, decodeListChildOrgsResp),
+		GetOrgTree:       newEndpoint("GET", encodeGetOrgTreeReq, decodeGetOrgTreeResp),
+
+		UpdatePayorDetails: newEndpoint("PUT", encodeUpdatePayorDetailsReq, decodeUpdatePayorDetailsResp),
+		FindPayor:          newEndpoint("GET", encodeFindPayorReq, decodeFindPayorResp),
+
+		CreateInvite: newEndpoint("POST", encodeCreateInviteReq, decodeCreateInviteResp),
+		ListInvites:  newEndpoint("GET", encodeListInvitesReq, decodeListInvitesResp),
+		RevokeInvite: newEndpoint("DELETE", encodeRevokeInviteReq, decodeRevokeInviteResp),
+		ResendInvite: newEndpoint("POST", encodeResendInviteReq, decodeResendInviteResp),
+		AcceptInvite: newEndpoint("POST", encodeAcceptInviteReq, decodeAcceptInviteResp),
+
+		RequestNetworkRelationship:   newEndpoint("POST", encodeRequestNetworkRelationshipReq, decodeNetworkRelationshipResp),
+		AcceptNetworkRelationship:    newEndpoint("POST", encodeAcceptNetworkRelationshipReq, decodeNetworkRelationshipResp),
+		TerminateNetworkRelationship: newEndpoint("POST", encodeTerminateNetworkRelationshipReq, decodeNetworkRelationshipResp),
+		ListPayors:                   newEndpoint("GET", encodeListPayorsReq, decodeListNetworkResp),
+		ListProviders:                newEndpoint("GET", encodeListProvidersReq, decodeListNetworkResp),
+
+		QueryAuditLog: newEndpoint("GET", encodeQueryAuditLogReq, decodeQueryAuditLogResp),
+	}, nil
+}
+
+// Wraps the endpoint in go-kit's retrying load balancer (with just the one
+// endpoint to balance over) and hands back the last error rather than the
+// lb.RetryError so callers can still errors.As it into the typed errors. The
+// balancer would go again straight away, so the callback waits out the backoff
+// first, which gives an instance that's restarting a moment to come back.
+func retry(e endpoint.Endpoint, idempotent bool, attempts int, timeout time.Duration, backoff time.Duration) endpoint.Endpoint {
+	balancer := lb.NewRoundRobin(sd.FixedEndpointer{e})
+
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		deadline := time.Now().Add(timeout)
+		retrying := lb.RetryWithCallback(timeout, balancer, func(n int, err error) (bool, error) {
+			if n >= attempts || !retryable(err, idempotent) {
+				return false, nil
+			}
+			// Better the error we got than a timeout, if the call would run out of
+			// time waiting. Same for a caller that's given up in the meantime.
+			wait := backoff << (n - 1)
+			if time.Now().Add(wait).After(deadline) {
+				return false, nil
+			}
+			select {
+			case <-time.After(wait):
+				return true, nil
+			case <-ctx.Done():
+				return false, nil
+			}
+		})
+
+		response, err := retrying(ctx, request)
+		var retryErr lb.RetryError
+		if errors.As(err, &retryErr) {
+			return nil, retryErr.Final
+		}
+		return response, err
+	}
+}
+
+// Whether sending the same request twice leaves things the same as sending it
+// once. Our PUTs and PATCHes only ever set fields. DELETEs would be too, except
+// that a retry of one that went through ends in a 404 for a delete that worked.
+// POSTs create things or use tokens up, so they're never safe to repeat.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodPatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// Not being able to connect at all means the request never left, so that's
+// worth another try whatever the call. Anything else (a timeout, a gateway in
+// front of accountsrv giving up) could have come after accountsrv did the work,
+// so only an idempotent call is tried again. Anything accountsrv actually
+// answered is final.
+func retryable(err error, idempotent bool) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	if !idempotent {
+		return false
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	switch apiErr.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// httptransport.ClientBefore func that sends the caller's bearer token
+func authTokenToHTTP(defaultToken string) httptransport.RequestFunc {
+	return func(ctx context.Context, req *http.Request) context.Context {
+		token := accountsrv.AuthTokenFromContext(ctx)
+		if token == "" {
+			token = defaultToken
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return ctx
+	}
+}
+
+// httptransport.ClientBefore func that passes along the request ID we're working
+// under (if any) so both services' logs line up.
+func requestIDToHTTP(ctx context.Context, req *http.Request) context.Context {
+	if id := accountsrv.RequestIDFromContext(ctx); id != "" {
+		req.Header.Set(accountsrv.RequestIDHeader, id)
+	}
+	return ctx
+}
+
+// Implements accountsrv.Service by calling through the client side endpoints
+type service struct {
+	endpoints accountsrv.Endpoints
+}
+
+func (s service) CreateUser(ctx context.Context, orgID string, username string, password string, orgType string, firstName string, lastName string, email string, phone string) (string, error) {
+	resp, err := s.endpoints.CreateUser(ctx, accountsrv.CreateUserRequest{
+		OrgID:     orgID,
+		Username:  username,
+		Password:  password,
+		OrgType:   orgType,
+		FirstName: firstName,
+		LastName:  lastName,
+		Email:     email,
+		Phone:     phone,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.(accountsrv.CreateUserResponse).ID, nil
+}
+
+func (s service) DeleteUserAccount(ctx context.Context, id string) error {
+	_, err := s.endpoints.DeleteUser(ctx, accountsrv.DeleteUserRequest{ID: id})
+	return err
+}
+
+func (s service) SuspendUser(ctx context.Context, userID string) (accountsrv.UserAccount, error) {
+	resp, err := s.endpoints.SuspendUser(ctx, accountsrv.UserStatusRequest{UserID: userID})
+	if err != nil {
+		return accountsrv.UserAccount{}, err
+	}
+	return resp.(accountsrv.UserStatusResponse).Account, nil
+}
+
+func (s service) DeactivateUser(ctx context.Context, userID string) (accountsrv.UserAccount, error) {
+	resp, err := s.endpoints.DeactivateUser(ctx, accountsrv.UserStatusRequest{UserID: userID})
+	if err != nil {
+		return accountsrv.UserAccount{}, err
+	}
+	return resp.(accountsrv.UserStatusResponse).Account, nil
+}
+
+func (s service) ReactivateUser(ctx context.Context, userID string) (accountsrv.UserAccount, error) {
+	resp, err := s.endpoints.ReactivateUser(ctx, accountsrv.UserStatusRequest{UserID: userID})
+	if err != nil {
+		return accountsrv.UserAccount{}, err
+	}
+	return resp.(accountsrv.UserStatusResponse).Account, nil
+}
+
+func (s service) RestoreUser(ctx context.Context, userID string) (accountsrv.UserAccount, error) {
+	resp, err := s.endpoints.RestoreUser(ctx, accountsrv.UserStatusRequest{UserID: userID})
+	if err != nil {
+		return accountsrv.UserAccount{}, err
+	}
+	return resp.(accountsrv.UserStatusResponse).Account, nil
+}
+
+func (s service) UpdateUserProfile(ctx context.Context, accountID string, updates map[string]interface{}) error {
+	// The wire format is the ProfileUpdates struct, so only its fields can be sent
+	var profileUpdates accountsrv.ProfileUpdates
+	for k, v := range updates {
+		value, ok := v.(string)
+		if !ok {
+			return errors.New("profile updates must be strings")
+		}
+		switch k {
+		case "first_name":
+			profileUpdates.FirstName = value
+		case "last_name":
+			profileUpdates.LastName = value
+		case "email":
+			profileUpdates.Email = value
+		case "phone":
+			profileUpdates.Phone = value
+		default:
+			return errors.New("unknown profile field " + k)
+		}
+	}
+
+	_, err := s.endpoints.UpdateUserProfile(ctx, accountsrv.UpdateProfileRequest{
+		AccountID: accountID,
+		Updates:   profileUpdates,
+	})
+	return err
+}
+
+func (s service) GetUserAccount(ctx context.Context, id string) (accountsrv.UserAccount, error) {
+	resp, err := s.endpoints.GetUser(ctx, accountsrv.GetUserRequest{ID: id})
+	if err != nil {
+		return accountsrv.UserAccount{}, err
+	}
+	return resp.(accountsrv.GetUserAccountResponse).UserAccount, nil
+}
+
+func (s service) GetDetailedUser(ctx context.Context, id string, includeOrgs bool) (accountsrv.DetailedUser, error) {
+	include := []string{"profile"}
+	if includeOrgs {
+		include = append(include, "orgs")
+	}
+
+	resp, err := s.endpoints.GetUser(ctx, accountsrv.GetUserRequest{ID: id, Include: include})
+	if err != nil {
+		return accountsrv.DetailedUser{}, err
+	}
+	return resp.(accountsrv.GetDetailedUserResponse).User, nil
+}
+
+func (s service) UpdateUserAccount(ctx context.Context, id string, updates map[string]interface{}) error {
+	// Same deal as the profile, the wire format is the AccountUpdates struct
+	var accountUpdates accountsrv.AccountUpdates
+	for k, v := range updates {
+		value, ok := v.(string)
+		if !ok {
+			return errors.New("account updates must be strings")
+		}
+		switch k {
+		case "username":
+			accountUpdates.Username = value
+		default:
+			return errors.New("unknown account field " + k)
+		}
+	}
+
+	_, err := s.endpoints.UpdateUserAccount(ctx, accountsrv.UpdateAccountRequest{
+		ID:      id,
+		Updates: accountUpdates,
+	})
+	return err
+}
+
+func (s service) Login(ctx context.Context, orgID string, username string, password string) (accountsrv.LoginUser, *accountsrv.MFAChallenge, error) {
+	resp, err := s.endpoints.LoginUser(ctx, accountsrv.LoginRequest{
+		OrgID:    orgID,
+		Username: username,
+		Password: password,
+	})
+	return loginResult(resp, err)
+}
+
+// Unpacks a LoginResponse, which every way of logging in that can end in an MFA
+// challenge answers with
+func loginResult(resp interface{}, err error) (accountsrv.LoginUser, *accountsrv.MFAChallenge, error) {
+	if err != nil {
+		return accountsrv.LoginUser{}, nil, err
+	}
+	loginResp := resp.(accountsrv.LoginResponse)
+	if loginResp.MFA != nil || loginResp.LoginDetails == nil {
+		return accountsrv.LoginUser{}, loginResp.MFA, nil
+	}
+	return *loginResp.LoginDetails, nil, nil
+}
+
+func (s service) CompleteMFALogin(ctx context.Context, challengeToken string, code string) (accountsrv.LoginUser, error) {
+	resp, err := s.endpoints.CompleteMFALogin(ctx, accountsrv.CompleteMFALoginRequest{Token: challengeToken, Code: code})
+	if err != nil {
+		return accountsrv.LoginUser{}, err
+	}
+	return resp.(accountsrv.CompleteMFALoginResponse).LoginDetails, nil
+}
+
+// Asks accountsrv who the token belongs to, i.e. calls GET /session with it
+func (s service) Authenticate(ctx context.Context, token string) (accountsrv.Principal, error) {
+	resp, err := s.endpoints.GetSession(accountsrv.ContextWithAuthToken(ctx, token), accountsrv.GetSessionRequest{})
+	if err != nil {
+		return accountsrv.Principal{}, err
+	}
+	return resp.(accountsrv.GetSessionResponse).Principal, nil
+}
+
+func (s service) CreateOrg(ctx context.Context, name string, orgType string, parentID string, phone string, address string, timezone string, website string, providerDetails *accountsrv.ProviderDetails, payorDetails *accountsrv.PayorDetails) (string, error) {
+	resp, err := s.endpoints.CreateOrg(ctx, accountsrv.CreateOrgRequest{
+		Name:            name,
+		Type:            orgType,
+		ParentID:        parentID,
+		Phone:           phone,
+		Address:         address,
+		Timezone:        timezone,
+		Website:         website,
+		ProviderDetails: providerDetails,
+		PayorDetails:    payorDetails,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.(accountsrv.CreateOrgResponse).ID, nil
+}
+
+func (s service) GetOrg(ctx context.Context, id string) (accountsrv.DetailedOrg, error) {
+	resp, err := s.endpoints.GetOrg(ctx, accountsrv.GetOrgRequest{ID: id})
+	if err != nil {
+		return accountsrv.DetailedOrg{}, err
+	}
+	return resp.(accountsrv.GetOrgResponse).Org, nil
+}
+
+func (s service) UpdateOrgAccount(ctx context.Context, id string, updates map[string]interface{}) error {
+	var accountUpdates accountsrv.OrgAccountUpdates
+	for k, v := range updates {
+		value, ok := v.(string)
+		if !ok {
+			return errors.New("org account updates must be strings")
+		}
+		switch k {
+		case "name":
+			accountUpdates.Name = value
+		default:
+			return errors.New("unknown org account field " + k)
+		}
+	}
+
+	_, err := s.endpoints.UpdateOrgAccount(ctx, accountsrv.UpdateOrgAccountRequest{
+		ID:      id,
+		Updates: accountUpdates,
+	})
+	return err
+}
+
+func (s service) UpdateOrgProfile(ctx context.Context, id string, updates map[string]interface{}) error {
+	var profileUpdates accountsrv.OrgProfileUpdates
+	for k, v := range updates {
+		value, ok := v.(string)
+		if !ok {
+			return errors.New("org profile updates must be strings")
+		}
+		switch k {
+		case "phone":
+			profileUpdates.Phone = value
+		case "address":
+			profileUpdates.Address = value
+		case "timezone":
+			profileUpdates.Timezone = value
+		case "website":
+			profileUpdates.Website = value
+		default:
+			return errors.New("unknown org profile field " + k)
+		}
+	}
+
+	_, err := s.endpoints.UpdateOrgProfile(ctx, accountsrv.UpdateOrgProfileRequest{
+		ID:      id,
+		Updates: profileUpdates,
+	})
+	return err
+}
+
+func (s service) DeleteOrg(ctx context.Context, id string, force bool) error {
+	_, err := s.endpoints.DeleteOrg(ctx, accountsrv.DeleteOrgRequest{ID: id, Force: force})
+	return err
+}
+
+func (s service) SuspendOrg(ctx context.Context, orgID string) (accountsrv.OrgAccount, error) {
+	resp, err := s.endpoints.SuspendOrg(ctx, accountsrv.OrgStatusRequest{OrgID: orgID})
+	if err != nil {
+		return accountsrv.OrgAccount{}, err
+	}
+	return resp.(accountsrv.OrgStatusResponse).Account, nil
+}
+
+func (s service) DeactivateOrg(ctx context.Context, orgID string) (accountsrv.OrgAccount, error) {
+	resp, err := s.endpoints.DeactivateOrg(ctx, accountsrv.OrgStatusRequest{OrgID: orgID})
+	if err != nil {
+		return accountsrv.OrgAccount{}, err
+	}
+	return resp.(accountsrv.OrgStatusResponse).Account, nil
+}
+
+func (s service) ReactivateOrg(ctx context.Context, orgID string) (accountsrv.OrgAccount, error) {
+	resp, err := s.endpoints.ReactivateOrg(ctx, accountsrv.OrgStatusRequest{OrgID: orgID})
+	if err != nil {
+		return accountsrv.OrgAccount{}, err
+	}
+	return resp.(accountsrv.OrgStatusResponse).Account, nil
+}
+
+func (s service) RestoreOrg(ctx context.Context, orgID string) (accountsrv.OrgAccount, error) {
+	resp, err := s.endpoints.RestoreOrg(ctx, accountsrv.OrgStatusRequest{OrgID: orgID})
+	if err != nil {
+		return accountsrv.OrgAccount{}, err
+	}
+	return resp.(accountsrv.OrgStatusResponse).Account, nil
+}
+
+func (s service) ListOrgUsers(ctx context.Context, orgID string, query accountsrv.OrgUserQuery) (accountsrv.OrgUserPage, error) {
+	resp, err := s.endpoints.ListOrgUsers(ctx, accountsrv.ListOrgUsersRequest{OrgID: orgID, Query: query})
+	if err != nil {
+		return accountsrv.OrgUserPage{}, err
+	}
+	return resp.(accountsrv.ListOrgUsersResponse).OrgUserPage, nil
+}
+
+func (s service) ListChildOrgs(ctx context.Context, orgID string) ([]accountsrv.OrgAccount, error) {
+	resp, err := s.endpoints.ListChildOrgs(ctx, accountsrv.ListChildOrgsRequest{OrgID: orgID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(accountsrv.ListChildOrgsResponse).Orgs, nil
+}
+
+func (s service) GetOrgTree(ctx context.Context, orgID string) (accountsrv.OrgHierarchy, error) {
+	resp, err := s.endpoints.GetOrgTree(ctx, accountsrv.GetOrgTreeRequest{OrgID: orgID})
+	if err != nil {
+		return accountsrv.OrgHierarchy{}, err
+	}
+	return resp.(accountsrv.GetOrgTreeResponse).OrgHierarchy, nil
+}
+
+func (s service) UpdatePayorDetails(ctx context.Context, orgID string, details accountsrv.PayorDetails) error {
+	_, err := s.endpoints.UpdatePayorDetails(ctx, accountsrv.UpdatePayorDetailsRequest{ID: orgID, Details: details})
+	return err
+}
+
+func (s service) FindPayor(ctx context.Context, payerID string) (accountsrv.DetailedOrg, error) {
+	resp, err := s.endpoints.FindPayor(ctx, accountsrv.FindPayorRequest{PayerID: payerID})
+	if err != nil {
+		return accountsrv.DetailedOrg{}, err
+	}
+	return resp.(accountsrv.FindPayorResponse).Org, nil
+}
+
+func (s service) CreateInvite(ctx context.Context, orgID string, email string, role string) (accountsrv.IssuedInvite, error) {
+	resp, err := s.endpoints.CreateInvite(ctx, accountsrv.CreateInviteRequest{OrgID: orgID, Email: email, Role: role})
+	if err != nil {
+		return accountsrv.IssuedInvite{}, err
+	}
+	return resp.(accountsrv.CreateInviteResponse).IssuedInvite, nil
+}
+
+func (s service) ListInvites(ctx context.Context, orgID string) ([]accountsrv.Invite, error) {
+	resp, err := s.endpoints.ListInvites(ctx, accountsrv.ListInvitesRequest{OrgID: orgID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(accountsrv.ListInvitesResponse).Invites, nil
+}
+
+func (s service) RevokeInvite(ctx context.Context, orgID string, inviteID string) error {
+	_, err := s.endpoints.RevokeInvite(ctx, accountsrv.RevokeInviteRequest{OrgID: orgID, InviteID: inviteID})
+	return err
+}
+
+func (s service) ResendInvite(ctx context.Context, orgID string, inviteID string) (accountsrv.IssuedInvite, error) {
+	resp, err := s.endpoints.ResendInvite(ctx, accountsrv.ResendInviteRequest{OrgID: orgID, InviteID: inviteID})
+	if err != nil {
+		return accountsrv.IssuedInvite{}, err
+	}
+	return resp.(accountsrv.ResendInviteResponse).IssuedInvite, nil
+}
+
+func (s service) AcceptInvite(ctx context.Context, token string, username string, password string, firstName string, lastName string, phone string) (string, error) {
+	resp, err := s.endpoints.AcceptInvite(ctx, accountsrv.AcceptInviteRequest{
+		Token:     token,
+		Username:  username,
+		Password:  password,
+		FirstName: firstName,
+		LastName:  lastName,
+		Phone:     phone,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.(accountsrv.AcceptInviteResponse).UserID, nil
+}
+
+func (s service) RequestNetworkRelationship(ctx context.Context, orgID string, partnerID string, effectiveDate string, terminationDate string) (accountsrv.NetworkRelationship, error) {
+	resp, err := s.endpoints.RequestNetworkRelationship(ctx, accountsrv.RequestNetworkRelationshipRequest{
+		OrgID:           orgID,
+		PartnerID:       partnerID,
+		EffectiveDate:   effectiveDate,
+		TerminationDate: terminationDate,
+	})
+	if err != nil {
+		return accountsrv.NetworkRelationship{}, err
+	}
+	return resp.(accountsrv.NetworkRelationshipResponse).Relationship, nil
+}
+
+func (s service) AcceptNetworkRelationship(ctx context.Context, orgID string, relationshipID string) (accountsrv.NetworkRelationship, error) {
+	resp, err := s.endpoints.AcceptNetworkRelationship(ctx, accountsrv.NetworkRelationshipActionRequest{OrgID: orgID, RelationshipID: relationshipID})
+	if err != nil {
+		return accountsrv.NetworkRelationship{}, err
+	}
+	return resp.(accountsrv.NetworkRelationshipResponse).Relationship, nil
+}
+
+func (s service) TerminateNetworkRelationship(ctx context.Context, orgID string, relationshipID string) (accountsrv.NetworkRelationship, error) {
+	resp, err := s.endpoints.TerminateNetworkRelationship(ctx, accountsrv.NetworkRelationshipActionRequest{OrgID: orgID, RelationshipID: relationshipID})
+	if err != nil {
+		return accountsrv.NetworkRelationship{}, err
+	}
+	return resp.(accountsrv.NetworkRelationshipResponse).Relationship, nil
+}
+
+func (s service) ListPayors(ctx context.Context, providerID string, query accountsrv.NetworkQuery) ([]accountsrv.NetworkRelationship, error) {
+	resp, err := s.endpoints.ListPayors(ctx, accountsrv.ListNetworkRequest{OrgID: providerID, Query: query})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(accountsrv.ListNetworkResponse).Relationships, nil
+}
+
+func (s service) ListProviders(ctx context.Context, payorID string, query accountsrv.NetworkQuery) ([]accountsrv.NetworkRelationship, error) {
+	resp, err := s.endpoints.ListProviders(ctx, accountsrv.ListNetworkRequest{OrgID: payorID, Query: query})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(accountsrv.ListNetworkResponse).Relationships, nil
+}
+
+func (s service) QueryAuditLog(ctx context.Context, query accountsrv.AuditQuery) ([]accountsrv.AuditEvent, error) {
+	resp, err := s.endpoints.QueryAuditLog(ctx, accountsrv.QueryAuditLogRequest{Query: query})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(accountsrv.QueryAuditLogResponse).Events, nil
+}
+
+func (s service) SendEmailVerification(ctx context.Context, userID string) error {
+	_, err := s.endpoints.SendEmailVerification(ctx, accountsrv.SendEmailVerificationRequest{UserID: userID})
+	return err
+}
+
+func (s service) VerifyEmail(ctx context.Context, userID string, token string) error {
+	_, err := s.endpoints.VerifyEmail(ctx, accountsrv.VerifyEmailRequest{UserID: userID, Token: token})
+	return err
+}
+
+func (s service) SendPhoneVerification(ctx context.Context, userID string) error {
+	_, err := s.endpoints.SendPhoneVerification(ctx, accountsrv.SendPhoneVerificationRequest{UserID: userID})
+	return err
+}
+
+func (s service) VerifyPhone(ctx context.Context, userID string, code string) error {
+	_, err := s.endpoints.VerifyPhone(ctx, accountsrv.VerifyPhoneRequest{UserID: userID, Code: code})
+	return err
+}
+
+func (s service) RequestPasswordReset(ctx context.Context, username string, email string) error {
+	_, err := s.endpoints.RequestPasswordReset(ctx, accountsrv.RequestPasswordResetRequest{Username: username, Email: email})
+	return err
+}
+
+func (s service) ResetPassword(ctx context.Context, token string, password string) error {
+	_, err := s.endpoints.ResetPassword(ctx, accountsrv.ResetPasswordRequest{Token: token, Password: password})
+	return err
+}
+
+func (s service) EnrollTOTP(ctx context.Context, userID string) (accountsrv.TOTPEnrollment, error) {
+	resp, err := s.endpoints.EnrollTOTP(ctx, accountsrv.EnrollTOTPRequest{UserID: userID})
+	if err != nil {
+		return accountsrv.TOTPEnrollment{}, err
+	}
+	return resp.(accountsrv.EnrollTOTPResponse).Enrollment, nil
+}
+
+func (s service) ConfirmTOTP(ctx context.Context, userID string, code string) ([]string, error) {
+	resp, err := s.endpoints.ConfirmTOTP(ctx, accountsrv.ConfirmTOTPRequest{UserID: userID, Code: code})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(accountsrv.ConfirmTOTPResponse).RecoveryCodes, nil
+}
+
+func (s service) DisableMFA(ctx context.Context, userID string, code string) error {
+	_, err := s.endpoints.DisableMFA(ctx, accountsrv.DisableMFARequest{UserID: userID, Code: code})
+	return err
+}
+
+func (s service) ResetMFA(ctx context.Context, userID string) error {
+	_, err := s.endpoints.ResetMFA(ctx, accountsrv.ResetMFARequest{UserID: userID})
+	return err
+}
+
+func (s service) BeginPasskeyRegistration(ctx context.Context, userID string) (accountsrv.PasskeyCeremony, error) {
+	resp, err := s.endpoints.BeginPasskeyRegistration(ctx, accountsrv.BeginPasskeyRegistrationRequest{UserID: userID})
+	if err != nil {
+		return accountsrv.PasskeyCeremony{}, err
+	}
+	return resp.(accountsrv.BeginPasskeyRegistrationResponse).Ceremony, nil
+}
+
+func (s service) FinishPasskeyRegistration(ctx context.Context, userID string, token string, name string, credential []byte) (accountsrv.Passkey, error) {
+	resp, err := s.endpoints.FinishPasskeyRegistration(ctx, accountsrv.FinishPasskeyRegistrationRequest{
+		UserID:     userID,
+		Token:      token,
+		Name:       name,
+		Credential: credential,
+	})
+	if err != nil {
+		return accountsrv.Passkey{}, err
+	}
+	return resp.(accountsrv.FinishPasskeyRegistrationResponse).Passkey, nil
+}
+
+func (s service) ListPasskeys(ctx context.Context, userID string) ([]accountsrv.Passkey, error) {
+	resp, err := s.endpoints.ListPasskeys(ctx, accountsrv.ListPasskeysRequest{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(accountsrv.ListPasskeysResponse).Passkeys, nil
+}
+
+func (s service) DeletePasskey(ctx context.Context, userID string, passkeyID string) error {
+	_, err := s.endpoints.DeletePasskey(ctx, accountsrv.DeletePasskeyRequest{UserID: userID, PasskeyID: passkeyID})
+	return err
+}
+
+func (s service) BeginPasskeyLogin(ctx context.Context, orgID string) (accountsrv.PasskeyCeremony, error) {
+	resp, err := s.endpoints.BeginPasskeyLogin(ctx, accountsrv.BeginPasskeyLoginRequest{OrgID: orgID})
+	if err != nil {
+		return accountsrv.PasskeyCeremony{}, err
+	}
+	return resp.(accountsrv.BeginPasskeyLoginResponse).Ceremony, nil
+}
+
+func (s service) PasskeyLogin(ctx context.Context, orgID string, token string, credential []byte) (accountsrv.LoginUser, error) {
+	resp, err := s.endpoints.PasskeyLogin(ctx, accountsrv.PasskeyLoginRequest{OrgID: orgID, Token: token, Credential: credential})
+	if err != nil {
+		return accountsrv.LoginUser{}, err
+	}
+	return resp.(accountsrv.PasskeyLoginResponse).LoginDetails, nil
+}
+
+func (s service) RequestMagicLink(ctx context.Context, orgID string, username string, email string) error {
+	_, err := s.endpoints.RequestMagicLink(ctx, accountsrv.RequestMagicLinkRequest{OrgID: orgID, Username: username, Email: email})
+	return err
+}
+
+func (s service) MagicLinkLogin(ctx context.Context, orgID string, token string) (accountsrv.LoginUser, *accountsrv.MFAChallenge, error) {
+	resp, err := s.endpoints.MagicLinkLogin(ctx, accountsrv.MagicLinkLoginRequest{OrgID: orgID, Token: token})
+	return loginResult(resp, err)
+}
+
+func (s service) RequestSMSCode(ctx context.Context, orgID string, username string) (accountsrv.SMSCodeChallenge, error) {
+	resp, err := s.endpoints.RequestSMSCode(ctx, accountsrv.RequestSMSCodeRequest{OrgID: orgID, Username: username})
+	if err != nil {
+		return accountsrv.SMSCodeChallenge{}, err
+	}
+	return resp.(accountsrv.RequestSMSCodeResponse).Challenge, nil
+}
+
+func (s service) SMSCodeLogin(ctx context.Context, orgID string, token string, code string) (accountsrv.LoginUser, *accountsrv.MFAChallenge, error) {
+	resp, err := s.endpoints.SMSCodeLogin(ctx, accountsrv.SMSCodeLoginRequest{OrgID: orgID, Token: token, Code: code})
+	return loginResult(resp, err)
+}
+
+func (s service) LoginWithoutOrg(ctx context.Context, username string, password string) (accountsrv.OrgSelection, error) {
+	resp, err := s.endpoints.LoginWithoutOrg(ctx, accountsrv.LoginWithoutOrgRequest{Username: username, Password: password})
+	if err != nil {
+		return accountsrv.OrgSelection{}, err
+	}
+	return resp.(accountsrv.LoginWithoutOrgResponse).Selection, nil
+}
+
+func (s service) ChooseLoginOrg(ctx context.Context, token string, orgID string) (accountsrv.LoginUser, *accountsrv.MFAChallenge, error) {
+	resp, err := s.endpoints.ChooseLoginOrg(ctx, accountsrv.ChooseLoginOrgRequest{Token: token, OrgID: orgID})
+	return loginResult(resp, err)
+}
+
+func (s service) SwitchOrg(ctx context.Context, orgID string) (accountsrv.LoginUser, *accountsrv.MFAChallenge, error) {
+	resp, err := s.endpoints.SwitchOrg(ctx, accountsrv.SwitchOrgRequest{OrgID: orgID})
+	return loginResult(resp, err)
+}
+
+func (s service) ListMyOrgs(ctx context.Context) ([]accountsrv.OrgMembership, error) {
+	resp, err := s.endpoints.ListMyOrgs(ctx, accountsrv.ListMyOrgsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(accountsrv.ListMyOrgsResponse).Orgs, nil
+}
@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rjjp5294/accountsrv"
+)
+
+// Counts the requests that go out, whether or not they get anywhere
+type countingTransport struct {
+	calls int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.calls, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTestEndpoints(t *testing.T, instance string, options ...Option) (accountsrv.Endpoints, *countingTransport) {
+	transport := &countingTransport{}
+	options = append([]Option{WithHTTPClient(&http.Client{Transport: transport}), WithBackoff(time.Millisecond)}, options...)
+	endpoints, err := NewEndpoints(instance, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return endpoints, transport
+}
+
+// Answers every request with the status and body
+func respondWith(status int, header http.Header, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for name, values := range header {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}
+}
+
+// Whatever accountsrv answers with comes back as an *Error wrapping the typed
+// error for its status, so callers can errors.Is / errors.As it
+func TestErrorsDecodeToTypedErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		body   string
+		want   error
+	}{
+		{"unauthenticated", http.StatusUnauthorized, nil, `{"error":"unauthenticated"}`, accountsrv.ErrUnauthenticated},
+		{"forbidden", http.StatusForbidden, nil, `{"error":"forbidden"}`, accountsrv.ErrForbidden},
+		{"suspended", http.StatusForbidden, nil, `{"error":"forbidden: account is suspended","reason":"account_suspended"}`, accountsrv.ErrAccountSuspended},
+		{"not found", http.StatusNotFound, nil, `{"error":"not found"}`, accountsrv.ErrNotFound},
+		{"org has members", http.StatusConflict, nil, `{"error":"organization still has members"}`, accountsrv.ErrOrgHasMembers},
+		{"org has children", http.StatusConflict, nil, `{"error":"` + accountsrv.ErrOrgHasChildren.Error() + `"}`, accountsrv.ErrOrgHasChildren},
+		{"rate limited", http.StatusTooManyRequests, http.Header{"Retry-After": {"7"}}, `{"error":"rate limit exceeded"}`, accountsrv.ErrRateLimited},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(respondWith(tt.status, tt.header, tt.body))
+			defer server.Close()
+			endpoints, _ := newTestEndpoints(t, server.URL)
+
+			_, err := endpoints.GetUser(context.Background(), accountsrv.GetUserRequest{ID: "user"})
+
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			var apiErr *Error
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Fatalf("got %#v, want an *Error with status %d", err, tt.status)
+			}
+		})
+	}
+
+	t.Run("retry after", func(t *testing.T) {
+		server := httptest.NewServer(respondWith(http.StatusTooManyRequests, http.Header{"Retry-After": {"7"}}, `{"error":"rate limit exceeded"}`))
+		defer server.Close()
+		endpoints, _ := newTestEndpoints(t, server.URL)
+
+		_, err := endpoints.GetUser(context.Background(), accountsrv.GetUserRequest{ID: "user"})
+
+		var rateLimitErr *accountsrv.RateLimitError
+		if !errors.As(err, &rateLimitErr) || rateLimitErr.RetryAfter != 7*time.Second {
+			t.Fatalf("got %v, want a RateLimitError to retry after 7s", err)
+		}
+	})
+
+	t.Run("not from accountsrv", func(t *testing.T) {
+		server := httptest.NewServer(respondWith(http.StatusBadGateway, http.Header{accountsrv.RequestIDHeader: {"req-1"}}, `<html>Bad Gateway</html>`))
+		defer server.Close()
+		endpoints, _ := newTestEndpoints(t, server.URL, WithAttempts(1))
+
+		_, err := endpoints.GetUser(context.Background(), accountsrv.GetUserRequest{ID: "user"})
+
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.Message != http.StatusText(http.StatusBadGateway) || apiErr.RequestID != "req-1" {
+			t.Fatalf("got %#v, want the status text and the request ID from the header", err)
+		}
+	})
+}
+
+// Only calls that are safe to repeat are retried, unless the connection never
+// opened, and nothing accountsrv itself answered is
+func TestOnlySafeCallsAreRetried(t *testing.T) {
+	call := map[string]func(accountsrv.Endpoints) error{
+		"GET": func(e accountsrv.Endpoints) error {
+			_, err := e.GetUser(context.Background(), accountsrv.GetUserRequest{ID: "user"})
+			return err
+		},
+		"POST": func(e accountsrv.Endpoints) error {
+			_, err := e.CreateUser(context.Background(), accountsrv.CreateUserRequest{OrgID: "org", Username: "user"})
+			return err
+		},
+		"DELETE": func(e accountsrv.Endpoints) error {
+			_, err := e.DeleteUser(context.Background(), accountsrv.DeleteUserRequest{ID: "user"})
+			return err
+		},
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		status int
+		calls  int32
+	}{
+		{"GET through a failing gateway", "GET", http.StatusServiceUnavailable, 3},
+		{"POST through a failing gateway", "POST", http.StatusServiceUnavailable, 1},
+		{"DELETE through a failing gateway", "DELETE", http.StatusServiceUnavailable, 1},
+		{"GET answered by accountsrv", "GET", http.StatusBadRequest, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(respondWith(tt.status, nil, `{"error":"nope"}`))
+			defer server.Close()
+			endpoints, transport := newTestEndpoints(t, server.URL)
+
+			if err := call[tt.method](endpoints); err == nil {
+				t.Fatal("got no error")
+			}
+			if got := atomic.LoadInt32(&transport.calls); got != tt.calls {
+				t.Fatalf("got %d calls, want %d", got, tt.calls)
+			}
+		})
+	}
+
+	t.Run("POST that never connected", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		endpoints, transport := newTestEndpoints(t, server.URL)
+
+		if err := call["POST"](endpoints); err == nil {
+			t.Fatal("got no error")
+		}
+		if got := atomic.LoadInt32(&transport.calls); got != 3 {
+			t.Fatalf("got %d calls, want 3", got)
+		}
+	})
+}
+
+// The bearer token (the context's over the default) and the request ID go out
+// with every call
+func TestAuthTokenAndRequestIDAreSent(t *testing.T) {
+	var auth, requestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, requestID = r.Header.Get("Authorization"), r.Header.Get(accountsrv.RequestIDHeader)
+		w.Write([]byte(`{"user":{"id":"user"}}`))
+	}))
+	defer server.Close()
+	endpoints, _ := newTestEndpoints(t, server.URL, WithAuthToken("default-token"))
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		wantAuth      string
+		wantRequestID string
+	}{
+		{"defaults", context.Background(), "Bearer default-token", ""},
+		{"from the context", accountsrv.ContextWithRequestID(accountsrv.ContextWithAuthToken(context.Background(), "call-token"), "req-1"), "Bearer call-token", "req-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := endpoints.GetUser(tt.ctx, accountsrv.GetUserRequest{ID: "user"}); err != nil {
+				t.Fatal(err)
+			}
+			if auth != tt.wantAuth || requestID != tt.wantRequestID {
+				t.Fatalf("got Authorization %q and request ID %q, want %q and %q", auth, requestID, tt.wantAuth, tt.wantRequestID)
+			}
+		})
+	}
+}
+
+// Retries wait a little longer each time rather than going again straight away
+func TestRetriesBackOff(t *testing.T) {
+	server := httptest.NewServer(respondWith(http.StatusServiceUnavailable, nil, `{"error":"unavailable"}`))
+	defer server.Close()
+	endpoints, _ := newTestEndpoints(t, server.URL, WithBackoff(20*time.Millisecond))
+
+	start := time.Now()
+	if _, err := endpoints.GetUser(context.Background(), accountsrv.GetUserRequest{ID: "user"}); err == nil {
+		t.Fatal("got no error")
+	}
+	// 20ms before the second attempt, 40ms before the third
+	if took := time.Since(start); took < 60*time.Millisecond {
+		t.Fatalf("took %s, want at least 60ms of backoff", took)
+	}
+}
@@ -0,0 +1,958 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/rjjp5294/accountsrv"
+)
+
+/*
+The client side mirror image of accountsrv's http.go: the encoders turn a request
+struct into an HTTP request against the right route, and the decoders turn the HTTP
+response back into the response struct (or an error).
+*/
+
+// Error is what any non-2xx response from accountsrv comes back as. When the
+// status maps onto one of accountsrv's typed errors that error is wrapped, so
+// errors.Is / errors.As against e.g. accountsrv.ErrRateLimited work as expected.
+type Error struct {
+	StatusCode int
+	Message    string
+	RequestID  string
+	Reason     string // e.g. accountsrv.ReasonAccountSuspended, for the errors that come with one
+
+	err error
+}
+
+func (e *Error) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("accountsrv: %s (status %d, request %s)", e.Message, e.StatusCode, e.RequestID)
+	}
+	return fmt.Sprintf("accountsrv: %s (status %d)", e.Message, e.StatusCode)
+}
+
+func (e *Error) Unwrap() error { return e.err }
+
+// Reads the error body EncodeError wrote, returning nil for a successful response
+func errorFromResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	var body struct {
+		Error     string `json:"error"`
+		RequestID string `json:"request_id"`
+		Reason    string `json:"reason"`
+	}
+	// Whatever's in front of accountsrv (e.g. a load balancer) might not answer in
+	// JSON, in which case we fall back to the status text.
+	raw, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err := json.Unmarshal(raw, &body); err != nil || body.Error == "" {
+		body.Error = http.StatusText(resp.StatusCode)
+	}
+	if body.RequestID == "" {
+		body.RequestID = resp.Header.Get(accountsrv.RequestIDHeader)
+	}
+
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Message:    body.Error,
+		RequestID:  body.RequestID,
+		Reason:     body.Reason,
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		apiErr.err = &accountsrv.RateLimitError{RetryAfter: time.Duration(retryAfter) * time.Second}
+	case http.StatusUnauthorized:
+		apiErr.err = accountsrv.ErrUnauthenticated
+	case http.StatusForbidden:
+		apiErr.err = accountsrv.ErrForbidden
+		if body.Reason == accountsrv.ReasonAccountSuspended {
+			apiErr.err = accountsrv.ErrAccountSuspended
+		}
+	case http.StatusConflict:
+		apiErr.err = accountsrv.ErrOrgHasMembers
+		if body.Error == accountsrv.ErrOrgHasChildren.Error() {
+			apiErr.err = accountsrv.ErrOrgHasChildren
+		}
+	case http.StatusNotFound:
+		apiErr.err = accountsrv.ErrNotFound
+	}
+
+	return apiErr
+}
+
+// Points the request at the route made up of the given path segments (escaped),
+// tacked on to whatever path the base URL already had.
+func setPath(req *http.Request, segments ...string) {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+
+	rawBase := strings.TrimSuffix(req.URL.EscapedPath(), "/")
+	base := strings.TrimSuffix(req.URL.Path, "/")
+
+	req.URL.Path = base + "/" + strings.Join(segments, "/")
+	req.URL.RawPath = rawBase + "/" + strings.Join(escaped, "/")
+}
+
+// Sets the request body to the JSON encoding of v
+func setJSONBody(req *http.Request, v interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.ContentLength = int64(buf.Len())
+	req.Body = ioutil.NopCloser(&buf)
+	return nil
+}
+
+func encodeCreateUserReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.CreateUserRequest)
+	setPath(req, "orgs", r.OrgID, "users")
+	return setJSONBody(req, r)
+}
+
+func decodeCreateUserResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.CreateUserResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeGetUserReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.GetUserRequest)
+	setPath(req, "users", r.ID)
+	if len(r.Include) > 0 {
+		req.URL.RawQuery = url.Values{"include": {strings.Join(r.Include, ",")}}.Encode()
+	}
+	return nil
+}
+
+// The route answers with just the account or with the whole user depending on
+// whether anything was included, so look at which one came back.
+func decodeGetUserResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var body struct {
+		UserAccount accountsrv.UserAccount   `json:"user_account"`
+		User        *accountsrv.DetailedUser `json:"user"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	if body.User != nil {
+		return accountsrv.GetDetailedUserResponse{User: *body.User}, nil
+	}
+	return accountsrv.GetUserAccountResponse{UserAccount: body.UserAccount}, nil
+}
+
+func encodeDeleteUserReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.DeleteUserRequest)
+	setPath(req, "users", r.ID)
+	return nil
+}
+
+func decodeDeleteUserResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.DeleteUserResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+// The status changes are all POST /users/{id}/<action>
+func encodeUserStatusReq(action string) httptransport.EncodeRequestFunc {
+	return func(_ context.Context, req *http.Request, request interface{}) error {
+		r := request.(accountsrv.UserStatusRequest)
+		setPath(req, "users", r.UserID, action)
+		return nil
+	}
+}
+
+func decodeUserStatusResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.UserStatusResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeUpdateUserAccountReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.UpdateAccountRequest)
+	setPath(req, "users", r.ID)
+	return setJSONBody(req, r.Updates)
+}
+
+func decodeUpdateUserAccountResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.UpdateAccountResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeLoginReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.LoginRequest)
+	setPath(req, "orgs", r.OrgID, "login")
+	return setJSONBody(req, r)
+}
+
+func decodeLoginResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.LoginResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeUpdateUserProfileReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.UpdateProfileRequest)
+	setPath(req, "users", r.AccountID, "profile")
+	// The route takes the updates themselves as the body
+	return setJSONBody(req, r.Updates)
+}
+
+func decodeUpdateUserProfileResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.UpdateProfileResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeCreateOrgReq(_ context.Context, req *http.Request, request interface{}) error {
+	setPath(req, "orgs")
+	return setJSONBody(req, request.(accountsrv.CreateOrgRequest))
+}
+
+func decodeCreateOrgResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.CreateOrgResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeGetSessionReq(_ context.Context, req *http.Request, request interface{}) error {
+	setPath(req, "session")
+	return nil
+}
+
+func decodeGetSessionResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.GetSessionResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeGetOrgReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.GetOrgRequest)
+	setPath(req, "orgs", r.ID)
+	return nil
+}
+
+func decodeGetOrgResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.GetOrgResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeUpdateOrgAccountReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.UpdateOrgAccountRequest)
+	setPath(req, "orgs", r.ID)
+	return setJSONBody(req, r.Updates)
+}
+
+func decodeUpdateOrgAccountResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.UpdateOrgAccountResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeUpdateOrgProfileReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.UpdateOrgProfileRequest)
+	setPath(req, "orgs", r.ID, "profile")
+	return setJSONBody(req, r.Updates)
+}
+
+func decodeUpdateOrgProfileResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.UpdateOrgProfileResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeDeleteOrgReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.DeleteOrgRequest)
+	setPath(req, "orgs", r.ID)
+	if r.Force {
+		req.URL.RawQuery = url.Values{"force": {"true"}}.Encode()
+	}
+	return nil
+}
+
+func decodeDeleteOrgResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.DeleteOrgResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+// Same as encodeUserStatusReq, POST /orgs/{org_id}/<action>
+func encodeOrgStatusReq(action string) httptransport.EncodeRequestFunc {
+	return func(_ context.Context, req *http.Request, request interface{}) error {
+		r := request.(accountsrv.OrgStatusRequest)
+		setPath(req, "orgs", r.OrgID, action)
+		return nil
+	}
+}
+
+func decodeOrgStatusResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.OrgStatusResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeListOrgUsersReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.ListOrgUsersRequest)
+	setPath(req, "orgs", r.OrgID, "users")
+
+	params := url.Values{}
+	for key, value := range map[string]string{
+		"role":   r.Query.Role,
+		"status": r.Query.Status,
+		"q":      r.Query.Text,
+		"sort":   r.Query.Sort,
+		"cursor": r.Query.Cursor,
+	} {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	if r.Query.Desc {
+		params.Set("order", "desc")
+	}
+	if r.Query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(r.Query.Limit))
+	}
+	req.URL.RawQuery = params.Encode()
+	return nil
+}
+
+func decodeListOrgUsersResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.ListOrgUsersResponse
+	err := json.NewDecoder(resp.Body).Decode(&response.OrgUserPage)
+	return response, err
+}
+
+func encodeListChildOrgsReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.ListChildOrgsRequest)
+	setPath(req, "orgs", r.OrgID, "children")
+	return nil
+}
+
+func decodeListChildOrgsResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.ListChildOrgsResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeGetOrgTreeReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.GetOrgTreeRequest)
+	setPath(req, "orgs", r.OrgID, "tree")
+	return nil
+}
+
+func decodeGetOrgTreeResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.GetOrgTreeResponse
+	err := json.NewDecoder(resp.Body).Decode(&response.OrgHierarchy)
+	return response, err
+}
+
+func encodeUpdatePayorDetailsReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.UpdatePayorDetailsRequest)
+	setPath(req, "orgs", r.ID, "payor-details")
+	return setJSONBody(req, r.Details)
+}
+
+func decodeUpdatePayorDetailsResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.UpdatePayorDetailsResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeFindPayorReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.FindPayorRequest)
+	setPath(req, "payors")
+	req.URL.RawQuery = url.Values{"payer_id": {r.PayerID}}.Encode()
+	return nil
+}
+
+func decodeFindPayorResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.FindPayorResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeCreateInviteReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.CreateInviteRequest)
+	setPath(req, "orgs", r.OrgID, "invites")
+	return setJSONBody(req, r)
+}
+
+func decodeCreateInviteResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.CreateInviteResponse
+	err := json.NewDecoder(resp.Body).Decode(&response.IssuedInvite)
+	return response, err
+}
+
+func encodeListInvitesReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.ListInvitesRequest)
+	setPath(req, "orgs", r.OrgID, "invites")
+	return nil
+}
+
+func decodeListInvitesResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.ListInvitesResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeRevokeInviteReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.RevokeInviteRequest)
+	setPath(req, "orgs", r.OrgID, "invites", r.InviteID)
+	return nil
+}
+
+func decodeRevokeInviteResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.RevokeInviteResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeResendInviteReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.ResendInviteRequest)
+	setPath(req, "orgs", r.OrgID, "invites", r.InviteID, "resend")
+	return nil
+}
+
+func decodeResendInviteResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.ResendInviteResponse
+	err := json.NewDecoder(resp.Body).Decode(&response.IssuedInvite)
+	return response, err
+}
+
+func encodeAcceptInviteReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.AcceptInviteRequest)
+	setPath(req, "invites", "accept")
+	return setJSONBody(req, r)
+}
+
+func decodeAcceptInviteResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.AcceptInviteResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeRequestNetworkRelationshipReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.RequestNetworkRelationshipRequest)
+	setPath(req, "orgs", r.OrgID, "network")
+	return setJSONBody(req, r)
+}
+
+func encodeAcceptNetworkRelationshipReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.NetworkRelationshipActionRequest)
+	setPath(req, "orgs", r.OrgID, "network", r.RelationshipID, "accept")
+	return nil
+}
+
+func encodeTerminateNetworkRelationshipReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.NetworkRelationshipActionRequest)
+	setPath(req, "orgs", r.OrgID, "network", r.RelationshipID, "terminate")
+	return nil
+}
+
+func decodeNetworkRelationshipResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.NetworkRelationshipResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeListPayorsReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.ListNetworkRequest)
+	setPath(req, "orgs", r.OrgID, "payors")
+	req.URL.RawQuery = networkQueryParams(r.Query).Encode()
+	return nil
+}
+
+func encodeListProvidersReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.ListNetworkRequest)
+	setPath(req, "orgs", r.OrgID, "providers")
+	req.URL.RawQuery = networkQueryParams(r.Query).Encode()
+	return nil
+}
+
+func networkQueryParams(query accountsrv.NetworkQuery) url.Values {
+	params := url.Values{}
+	if query.Status != "" {
+		params.Set("status", query.Status)
+	}
+	if query.EffectiveOn != "" {
+		params.Set("effective_on", query.EffectiveOn)
+	}
+	return params
+}
+
+func decodeListNetworkResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.ListNetworkResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeQueryAuditLogReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.QueryAuditLogRequest)
+	setPath(req, "orgs", r.Query.OrgID, "audit")
+
+	params := url.Values{}
+	for key, value := range map[string]string{
+		"actor_id":  r.Query.ActorID,
+		"target_id": r.Query.TargetID,
+		"action":    r.Query.Action,
+	} {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	if !r.Query.Since.IsZero() {
+		params.Set("since", r.Query.Since.Format(time.RFC3339Nano))
+	}
+	if !r.Query.Until.IsZero() {
+		params.Set("until", r.Query.Until.Format(time.RFC3339Nano))
+	}
+	if r.Query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(r.Query.Limit))
+	}
+	req.URL.RawQuery = params.Encode()
+	return nil
+}
+
+func decodeQueryAuditLogResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.QueryAuditLogResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeSendEmailVerificationReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.SendEmailVerificationRequest)
+	setPath(req, "users", r.UserID, "email", "verification")
+	return nil
+}
+
+func decodeSendEmailVerificationResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.SendEmailVerificationResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeVerifyEmailReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.VerifyEmailRequest)
+	setPath(req, "users", r.UserID, "email", "verify")
+	return setJSONBody(req, r)
+}
+
+func decodeVerifyEmailResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.VerifyEmailResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeSendPhoneVerificationReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.SendPhoneVerificationRequest)
+	setPath(req, "users", r.UserID, "phone", "verification")
+	return nil
+}
+
+func decodeSendPhoneVerificationResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.SendPhoneVerificationResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeVerifyPhoneReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.VerifyPhoneRequest)
+	setPath(req, "users", r.UserID, "phone", "verify")
+	return setJSONBody(req, r)
+}
+
+func decodeVerifyPhoneResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.VerifyPhoneResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeRequestPasswordResetReq(_ context.Context, req *http.Request, request interface{}) error {
+	setPath(req, "password-resets")
+	return setJSONBody(req, request.(accountsrv.RequestPasswordResetRequest))
+}
+
+func decodeRequestPasswordResetResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.RequestPasswordResetResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeResetPasswordReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.ResetPasswordRequest)
+	setPath(req, "password-resets", r.Token)
+	return setJSONBody(req, r)
+}
+
+func decodeResetPasswordResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.ResetPasswordResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeCompleteMFALoginReq(_ context.Context, req *http.Request, request interface{}) error {
+	setPath(req, "login", "mfa")
+	return setJSONBody(req, request.(accountsrv.CompleteMFALoginRequest))
+}
+
+func decodeCompleteMFALoginResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.CompleteMFALoginResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeEnrollTOTPReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.EnrollTOTPRequest)
+	setPath(req, "users", r.UserID, "mfa", "totp")
+	return nil
+}
+
+func decodeEnrollTOTPResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.EnrollTOTPResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeConfirmTOTPReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.ConfirmTOTPRequest)
+	setPath(req, "users", r.UserID, "mfa", "totp", "confirm")
+	return setJSONBody(req, r)
+}
+
+func decodeConfirmTOTPResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.ConfirmTOTPResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeDisableMFAReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.DisableMFARequest)
+	setPath(req, "users", r.UserID, "mfa", "disable")
+	return setJSONBody(req, r)
+}
+
+func decodeDisableMFAResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.DisableMFAResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeResetMFAReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.ResetMFARequest)
+	setPath(req, "users", r.UserID, "mfa", "reset")
+	return nil
+}
+
+func decodeResetMFAResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.ResetMFAResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeBeginPasskeyRegistrationReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.BeginPasskeyRegistrationRequest)
+	setPath(req, "users", r.UserID, "passkeys", "registration")
+	return nil
+}
+
+func decodeBeginPasskeyRegistrationResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.BeginPasskeyRegistrationResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeFinishPasskeyRegistrationReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.FinishPasskeyRegistrationRequest)
+	setPath(req, "users", r.UserID, "passkeys")
+	return setJSONBody(req, r)
+}
+
+func decodeFinishPasskeyRegistrationResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.FinishPasskeyRegistrationResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeListPasskeysReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.ListPasskeysRequest)
+	setPath(req, "users", r.UserID, "passkeys")
+	return nil
+}
+
+func decodeListPasskeysResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.ListPasskeysResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeDeletePasskeyReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.DeletePasskeyRequest)
+	setPath(req, "users", r.UserID, "passkeys", r.PasskeyID)
+	return nil
+}
+
+func decodeDeletePasskeyResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.DeletePasskeyResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeBeginPasskeyLoginReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.BeginPasskeyLoginRequest)
+	setPath(req, "orgs", r.OrgID, "login", "passkey", "begin")
+	return nil
+}
+
+func decodeBeginPasskeyLoginResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.BeginPasskeyLoginResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodePasskeyLoginReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.PasskeyLoginRequest)
+	setPath(req, "orgs", r.OrgID, "login", "passkey")
+	return setJSONBody(req, r)
+}
+
+func decodePasskeyLoginResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.PasskeyLoginResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func encodeRequestMagicLinkReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.RequestMagicLinkRequest)
+	setPath(req, "orgs", r.OrgID, "login", "magic-links")
+	return setJSONBody(req, r)
+}
+
+func decodeRequestMagicLinkResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.RequestMagicLinkResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+// Answered with a LoginResponse, so decodeLoginResp takes care of it
+func encodeMagicLinkLoginReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.MagicLinkLoginRequest)
+	setPath(req, "orgs", r.OrgID, "login", "magic-links", r.Token)
+	return nil
+}
+
+func encodeRequestSMSCodeReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.RequestSMSCodeRequest)
+	setPath(req, "orgs", r.OrgID, "login", "sms")
+	return setJSONBody(req, r)
+}
+
+func decodeRequestSMSCodeResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.RequestSMSCodeResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+// Same as encodeMagicLinkLoginReq, decodeLoginResp takes care of the answer
+func encodeSMSCodeLoginReq(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(accountsrv.SMSCodeLoginRequest)
+	setPath(req, "orgs", r.OrgID, "login", "sms", "verify")
+	return setJSONBody(req, r)
+}
+
+func encodeLoginWithoutOrgReq(_ context.Context, req *http.Request, request interface{}) error {
+	setPath(req, "login")
+	return setJSONBody(req, request)
+}
+
+func decodeLoginWithoutOrgResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.LoginWithoutOrgResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+// decodeLoginResp takes care of the answer to this one and to switching orgs
+func encodeChooseLoginOrgReq(_ context.Context, req *http.Request, request interface{}) error {
+	setPath(req, "login", "org")
+	return setJSONBody(req, request)
+}
+
+func encodeSwitchOrgReq(_ context.Context, req *http.Request, request interface{}) error {
+	setPath(req, "session", "org")
+	return setJSONBody(req, request)
+}
+
+func encodeListMyOrgsReq(_ context.Context, req *http.Request, request interface{}) error {
+	setPath(req, "session", "orgs")
+	return nil
+}
+
+func decodeListMyOrgsResp(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return nil, err
+	}
+	var response accountsrv.ListMyOrgsResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
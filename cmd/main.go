@@ -2,18 +2,28 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
 	"flag"
 	"fmt"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/go-webauthn/webauthn/webauthn"
 	_ "github.com/lib/pq"
+	"google.golang.org/grpc"
 
+	"net"
 	"net/http"
+	"net/smtp"
+	"net/url"
 	"os"
+	"strings"
+	_ "time/tzdata" // Org timezones have to resolve even where the system has no tz database
 
 	"github.com/rjjp5294/accountsrv"
+	"github.com/rjjp5294/accountsrv/pb"
 )
 
 /*According to Go-Kit docs, the main func should be a bit hefty since it is meant
@@ -45,6 +55,45 @@ func main() {
 	// Define the command line flag for the port we want to run the http service on. This returns a pointer to the
 	// string when flag.Parse() is called.
 	var httpAddr = flag.String("http", ":8080", "http listen address")
+	// Same deal for the gRPC server which runs right alongside the HTTP one
+	var grpcAddr = flag.String("grpc", ":8081", "gRPC listen address")
+	// Whether to serve a Swagger UI for the OpenAPI document at /docs
+	var docs = flag.Bool("docs", false, "serve Swagger UI at /docs")
+	// Token bucket limits for the login and account creation routes, in the form <count>/<duration>
+	// (e.g. 10/1m), or "off" to turn that particular limit off.
+	var (
+		rateLimitIP       = flag.String("ratelimit-ip", "30/1m", "rate limit per client IP on login and account creation")
+		rateLimitUsername = flag.String("ratelimit-username", "10/1m", "rate limit per username on login and account creation")
+		rateLimitOrg      = flag.String("ratelimit-org", "300/1m", "rate limit per organization on login and account creation")
+	)
+	// The key the tokens we hand out (e.g. org invites) are signed with. Defaults to the
+	// environment so it doesn't have to show up in the process list.
+	var signingKey = flag.String("signing-key", os.Getenv("ACCOUNTSRV_SIGNING_KEY"), "key to sign invite tokens with (default $ACCOUNTSRV_SIGNING_KEY)")
+	// Where emails go out through. With neither an SMTP server nor a directory they're
+	// only logged, which is fine locally and nowhere else.
+	var (
+		smtpAddr = flag.String("smtp", "", "SMTP server to send email through, host:port (password from $ACCOUNTSRV_SMTP_PASSWORD)")
+		smtpUser = flag.String("smtp-user", "", "username to authenticate to the SMTP server with")
+		mailFrom = flag.String("mail-from", "accounts@localhost", "address emails are sent from")
+		mailDir  = flag.String("mail-dir", "", "write emails as .eml files to this directory instead of sending them")
+		appURL   = flag.String("app-url", "http://localhost:3000", "base URL the links in emails point to")
+	)
+	// There's no real SMS provider yet, text messages (login codes) are written to a
+	// directory or logged.
+	var smsDir = flag.String("sms-dir", "", "write text messages as .txt files to this directory instead of logging them")
+	var mfaIssuer = flag.String("mfa-issuer", "accountsrv", "name authenticator apps list TOTP codes under")
+	// Passkeys are bound to the relying party ID, a domain the app is served from
+	// (or a parent of it). Changing it later orphans every passkey registered so far.
+	var (
+		webAuthnRPID   = flag.String("webauthn-rp-id", "", "WebAuthn relying party ID passkeys are bound to (default the host of -app-url)")
+		webAuthnRPName = flag.String("webauthn-rp-name", "accountsrv", "name shown to users when they register a passkey")
+	)
+
+	// Where the audit trail goes, the audit_log table unless a file is given
+	var (
+		auditFile   = flag.String("audit-file", "", "write the audit trail to this JSON lines file instead of the database")
+		auditVerify = flag.Bool("audit-verify", false, "check the audit trail's hash chain and exit")
+	)
 
 	var logger log.Logger
 	{
@@ -92,9 +141,90 @@ func main() {
 		// Initialize Repository interface && underlying struct using the NewRepo factory func
 		repository := accountsrv.NewRepo(db, logger)
 
+		// Without a key configured we make one up, which works fine until the process
+		// restarts and every token signed so far stops checking out.
+		key := []byte(*signingKey)
+		if len(key) == 0 {
+			key = make([]byte, 32)
+			if _, err := rand.Read(key); err != nil {
+				level.Error(logger).Log("exit", err)
+				os.Exit(-1)
+			}
+			level.Warn(logger).Log("msg", "no signing key set, using a random one, invites won't survive a restart")
+		}
+
 		// Initialize the account service using the factory func, passing in the repository
 		// instance we just created along with the logger we defined above.
-		accountService = accountsrv.NewService(repository, logger)
+		var mailer accountsrv.Mailer
+		switch {
+		case *smtpAddr != "":
+			var auth smtp.Auth
+			if *smtpUser != "" {
+				host, _, _ := net.SplitHostPort(*smtpAddr)
+				auth = smtp.PlainAuth("", *smtpUser, os.Getenv("ACCOUNTSRV_SMTP_PASSWORD"), host)
+			}
+			mailer = accountsrv.NewSMTPMailer(*smtpAddr, *mailFrom, auth)
+		case *mailDir != "":
+			mailer = accountsrv.NewFileMailer(*mailDir, *mailFrom)
+		default:
+			mailer = accountsrv.NewLogMailer(logger)
+		}
+
+		var smsSender accountsrv.SMSSender
+		if *smsDir != "" {
+			smsSender = accountsrv.NewFileSMSSender(*smsDir)
+		} else {
+			smsSender = accountsrv.NewLogSMSSender(logger)
+		}
+
+		rpID := *webAuthnRPID
+		if rpID == "" {
+			u, err := url.Parse(*appURL)
+			if err != nil {
+				level.Error(logger).Log("exit", err)
+				os.Exit(-1)
+			}
+			rpID = u.Hostname()
+		}
+		webAuthn, err := webauthn.New(&webauthn.Config{
+			RPID:          rpID,
+			RPDisplayName: *webAuthnRPName,
+			RPOrigins:     []string{strings.TrimSuffix(*appURL, "/")},
+		})
+		if err != nil {
+			level.Error(logger).Log("exit", err)
+			os.Exit(-1)
+		}
+
+		var auditSink accountsrv.AuditSink
+		if *auditFile != "" {
+			auditSink = accountsrv.NewFileAuditSink(*auditFile)
+		} else {
+			auditSink = accountsrv.NewDBAuditSink(db, logger)
+		}
+		if *auditVerify {
+			last, err := auditSink.Verify(ctx)
+			if err != nil {
+				level.Error(logger).Log("exit", err)
+				os.Exit(-1)
+			}
+			// Worth keeping somewhere else, the chain alone can't tell when events are
+			// cut off the end
+			level.Info(logger).Log("msg", "audit trail checks out", "seq", last.Seq, "hash", last.Hash)
+			os.Exit(0)
+		}
+
+		accountService = accountsrv.NewService(repository, logger, accountsrv.ServiceConfig{
+			SigningKey: key,
+			Mailer:     mailer,
+			SMSSender:  smsSender,
+			AppURL:     *appURL,
+			MFAIssuer:  *mfaIssuer,
+			WebAuthn:   webAuthn,
+			AuditSink:  auditSink,
+		})
+		// Everything that goes through the service from here on ends up in the audit trail
+		accountService = accountsrv.AuditMiddleware(auditSink, repository, logger)(accountService)
 	}
 
 	// Create a channel for errors
@@ -104,16 +234,79 @@ func main() {
 	// service we initialized about as a dependency and central scope control
 	endpoints := accountsrv.MakeEndpoints(accountService)
 
+	// Wrap the endpoints that can be brute forced or spammed with the rate limiter.
+	// The store is in memory for now, swap it out for a shared one once we run more
+	// than one instance.
+	{
+		var rateLimits accountsrv.RateLimitConfig
+		for _, l := range []struct {
+			spec  string
+			limit *accountsrv.RateLimit
+		}{
+			{*rateLimitIP, &rateLimits.PerIP},
+			{*rateLimitUsername, &rateLimits.PerUsername},
+			{*rateLimitOrg, &rateLimits.PerOrg},
+		} {
+			var err error
+			if *l.limit, err = accountsrv.ParseRateLimit(l.spec); err != nil {
+				level.Error(logger).Log("exit", err)
+				os.Exit(-1)
+			}
+		}
+
+		rateLimitStore := accountsrv.NewMemoryRateLimitStore()
+		endpoints.LoginUser = accountsrv.RateLimitMiddleware("login", rateLimitStore, rateLimits)(endpoints.LoginUser)
+		endpoints.CreateUser = accountsrv.RateLimitMiddleware("create_user", rateLimitStore, rateLimits)(endpoints.CreateUser)
+		endpoints.CreateOrg = accountsrv.RateLimitMiddleware("create_org", rateLimitStore, rateLimits)(endpoints.CreateOrg)
+		endpoints.AcceptInvite = accountsrv.RateLimitMiddleware("accept_invite", rateLimitStore, rateLimits)(endpoints.AcceptInvite)
+		endpoints.SendEmailVerification = accountsrv.RateLimitMiddleware("send_email_verification", rateLimitStore, rateLimits)(endpoints.SendEmailVerification)
+		endpoints.SendPhoneVerification = accountsrv.RateLimitMiddleware("send_phone_verification", rateLimitStore, rateLimits)(endpoints.SendPhoneVerification)
+		endpoints.VerifyPhone = accountsrv.RateLimitMiddleware("verify_phone", rateLimitStore, rateLimits)(endpoints.VerifyPhone)
+		endpoints.RequestPasswordReset = accountsrv.RateLimitMiddleware("request_password_reset", rateLimitStore, rateLimits)(endpoints.RequestPasswordReset)
+		endpoints.ResetPassword = accountsrv.RateLimitMiddleware("reset_password", rateLimitStore, rateLimits)(endpoints.ResetPassword)
+		endpoints.CompleteMFALogin = accountsrv.RateLimitMiddleware("complete_mfa_login", rateLimitStore, rateLimits)(endpoints.CompleteMFALogin)
+		endpoints.ConfirmTOTP = accountsrv.RateLimitMiddleware("confirm_totp", rateLimitStore, rateLimits)(endpoints.ConfirmTOTP)
+		endpoints.DisableMFA = accountsrv.RateLimitMiddleware("disable_mfa", rateLimitStore, rateLimits)(endpoints.DisableMFA)
+		endpoints.FinishPasskeyRegistration = accountsrv.RateLimitMiddleware("finish_passkey_registration", rateLimitStore, rateLimits)(endpoints.FinishPasskeyRegistration)
+		endpoints.BeginPasskeyLogin = accountsrv.RateLimitMiddleware("begin_passkey_login", rateLimitStore, rateLimits)(endpoints.BeginPasskeyLogin)
+		endpoints.PasskeyLogin = accountsrv.RateLimitMiddleware("passkey_login", rateLimitStore, rateLimits)(endpoints.PasskeyLogin)
+		endpoints.RequestMagicLink = accountsrv.RateLimitMiddleware("request_magic_link", rateLimitStore, rateLimits)(endpoints.RequestMagicLink)
+		endpoints.MagicLinkLogin = accountsrv.RateLimitMiddleware("magic_link_login", rateLimitStore, rateLimits)(endpoints.MagicLinkLogin)
+		endpoints.RequestSMSCode = accountsrv.RateLimitMiddleware("request_sms_code", rateLimitStore, rateLimits)(endpoints.RequestSMSCode)
+		endpoints.SMSCodeLogin = accountsrv.RateLimitMiddleware("sms_code_login", rateLimitStore, rateLimits)(endpoints.SMSCodeLogin)
+		// Shares its buckets with logging in to an org, it's the same password to guess
+		endpoints.LoginWithoutOrg = accountsrv.RateLimitMiddleware("login", rateLimitStore, rateLimits)(endpoints.LoginWithoutOrg)
+		endpoints.ChooseLoginOrg = accountsrv.RateLimitMiddleware("choose_login_org", rateLimitStore, rateLimits)(endpoints.ChooseLoginOrg)
+	}
+
 	// Spin up the server in a goroutine
 	go func() {
 		fmt.Println("listening on port", *httpAddr)
 		// Initialize a server instance using the Background Context and Endpoints we
 		// defined above, injecting the dependencies directly
 		handler := accountsrv.NewHTTPServer(ctx, endpoints)
+		if *docs {
+			handler = accountsrv.WithSwaggerUI(handler)
+		}
 		// Start the server, pipe errors (if any) resulting from this into the errs channel
 		errs <- http.ListenAndServe(*httpAddr, handler)
 	}()
 
+	// Spin up the gRPC server in its own goroutine, it shares the very same endpoints
+	// (and therefore the very same service) as the HTTP server.
+	go func() {
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			errs <- err
+			return
+		}
+		fmt.Println("gRPC listening on port", *grpcAddr)
+
+		grpcServer := grpc.NewServer(grpc.UnaryInterceptor(kitgrpc.Interceptor))
+		pb.RegisterAccountServer(grpcServer, accountsrv.NewGRPCServer(ctx, endpoints))
+		errs <- grpcServer.Serve(listener)
+	}()
+
 	// Log errors from the errs channel
 	level.Error(logger).Log("exit", <-errs)
 }
@@ -0,0 +1,142 @@
+package accountsrv
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// How passwords are hashed. Anything stored with another algorithm gets rehashed
+// with this one the next time its user logs in.
+const (
+	PasswordAlgorithmBcrypt    = "bcrypt"
+	passwordAlgorithmPlaintext = "plaintext" // Only what was carried over from before credentials had their own table
+)
+
+// A user's password as we keep it. It lives in its own table and type so it can
+// never end up in a UserAccount, or anything else that gets sent back to a
+// caller, and none of it is ever serialized.
+type Credential struct {
+	UserID         string    `db:"user_id" json:"-"`
+	Hash           string    `db:"hash" json:"-"`
+	Algorithm      string    `db:"algorithm" json:"-"`
+	ChangedAt      time.Time `db:"changed_at" json:"-"`
+	FailedAttempts int       `db:"failed_attempts" json:"-"`
+}
+
+// The password policy, checked whenever a password is set
+const (
+	minPasswordLength = 10 // In characters
+	maxPasswordBytes  = 72 // bcrypt only looks at the first 72 bytes
+	// How many of the user's previous passwords a new one can't be
+	passwordHistoryDepth = 5
+)
+
+// What every failed login comes back as, whatever the reason was. Telling the
+// caller would tell them which usernames exist and who's in which org.
+var errInvalidLogin = fmt.Errorf("%w: incorrect username or password", ErrUnauthenticated)
+
+// Checks the password against the policy. personal is whatever else we know about
+// the user (username, email) that the password shouldn't just be a copy of.
+func checkPasswordPolicy(password string, personal ...string) error {
+	if utf8.RuneCountInString(password) < minPasswordLength {
+		return fmt.Errorf("password has to be at least %d characters", minPasswordLength)
+	}
+	// Refuse rather than silently ignore the rest
+	if len(password) > maxPasswordBytes {
+		return fmt.Errorf("password can't be longer than %d bytes", maxPasswordBytes)
+	}
+
+	lower := strings.ToLower(password)
+	for _, p := range personal {
+		// Just the mailbox part of an email, the domain is often the org's name
+		if at := strings.LastIndex(p, "@"); at > 0 {
+			p = p[:at]
+		}
+		if p = strings.ToLower(strings.TrimSpace(p)); len(p) >= 3 && strings.Contains(lower, p) {
+			return errors.New("password can't contain your username or email")
+		}
+	}
+
+	return nil
+}
+
+// Whether the password is one of the given credentials (the current one and the
+// history), i.e. a password the user has had before.
+func reusedPassword(password string, credentials []Credential) bool {
+	for _, credential := range credentials {
+		if ok, _ := credential.verify(password); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Hashes the password into a new credential for the user
+func newCredential(userID string, password string) (Credential, error) {
+	if password == "" {
+		return Credential{}, errors.New("password is required")
+	}
+	// bcrypt only looks at the first 72 bytes, refuse rather than silently ignore the rest
+	if len(password) > maxPasswordBytes {
+		return Credential{}, fmt.Errorf("password can't be longer than %d bytes", maxPasswordBytes)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return Credential{}, err
+	}
+
+	return Credential{
+		UserID:    userID,
+		Hash:      string(hash),
+		Algorithm: PasswordAlgorithmBcrypt,
+	}, nil
+}
+
+// Whether the password matches the credential, and if it does whether the
+// credential should be rehashed because it isn't stored the way we'd store it today.
+func (c Credential) verify(password string) (ok bool, rehash bool) {
+	switch c.Algorithm {
+	case PasswordAlgorithmBcrypt:
+		if bcrypt.CompareHashAndPassword([]byte(c.Hash), []byte(password)) != nil {
+			return false, false
+		}
+		cost, err := bcrypt.Cost([]byte(c.Hash))
+		return true, err != nil || cost < bcrypt.DefaultCost
+	case passwordAlgorithmPlaintext:
+		return subtle.ConstantTimeCompare([]byte(c.Hash), []byte(password)) == 1, true
+	default:
+		return false, false
+	}
+}
+
+var (
+	dummyCredentialOnce  sync.Once
+	dummyCredentialValue Credential
+)
+
+// A credential for a password nobody knows, hashed the way real ones are. Logins
+// with nothing real to check the password against check it against this instead,
+// so they take just as long and the response time doesn't give away which
+// accounts exist. NewService hashes it in the background so the first login
+// that needs it doesn't take longer than the rest.
+func dummyCredential() Credential {
+	dummyCredentialOnce.Do(func() {
+		password := make([]byte, 32)
+		rand.Read(password)
+		hash, err := bcrypt.GenerateFromPassword(password, bcrypt.DefaultCost)
+		if err != nil {
+			panic(err)
+		}
+		dummyCredentialValue = Credential{Hash: string(hash), Algorithm: PasswordAlgorithmBcrypt}
+	})
+	return dummyCredentialValue
+}
@@ -0,0 +1,21 @@
+package accountsrv
+
+import (
+	"errors"
+	"time"
+)
+
+// How long the link in a verification email is good for
+const emailVerificationTTL = 24 * time.Hour
+
+// A verification email that went out. Like sessions only the hash of the token is
+// kept, and the address it went to is kept along with it so a link sent to an
+// address the user has since changed away from can't verify the new one.
+type EmailVerification struct {
+	TokenHash string    `db:"token_hash" json:"-"`
+	UserID    string    `db:"user_id" json:"user_id"`
+	Email     string    `db:"email" json:"email"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+}
+
+var errInvalidVerification = errors.New("verification link is invalid, has expired or was already used")
@@ -2,6 +2,7 @@ package accountsrv
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-kit/kit/endpoint"
 )
@@ -15,21 +16,157 @@ import (
 type Endpoints struct {
 	CreateUser        endpoint.Endpoint
 	GetUser           endpoint.Endpoint
+	DeleteUser        endpoint.Endpoint
+	SuspendUser       endpoint.Endpoint
+	DeactivateUser    endpoint.Endpoint
+	ReactivateUser    endpoint.Endpoint
+	RestoreUser       endpoint.Endpoint
+	UpdateUserAccount endpoint.Endpoint
 	LoginUser         endpoint.Endpoint
 	UpdateUserProfile endpoint.Endpoint
+	GetSession        endpoint.Endpoint
 
-	CreateOrg endpoint.Endpoint
+	CreateOrg        endpoint.Endpoint
+	GetOrg           endpoint.Endpoint
+	UpdateOrgAccount endpoint.Endpoint
+	UpdateOrgProfile endpoint.Endpoint
+	DeleteOrg        endpoint.Endpoint
+	SuspendOrg       endpoint.Endpoint
+	DeactivateOrg    endpoint.Endpoint
+	ReactivateOrg    endpoint.Endpoint
+	RestoreOrg       endpoint.Endpoint
+	ListOrgUsers     endpoint.Endpoint
+	ListChildOrgs    endpoint.Endpoint
+	GetOrgTree       endpoint.Endpoint
+
+	UpdatePayorDetails endpoint.Endpoint
+	FindPayor          endpoint.Endpoint
+
+	CreateInvite endpoint.Endpoint
+	ListInvites  endpoint.Endpoint
+	RevokeInvite endpoint.Endpoint
+	ResendInvite endpoint.Endpoint
+	AcceptInvite endpoint.Endpoint
+
+	RequestNetworkRelationship   endpoint.Endpoint
+	AcceptNetworkRelationship    endpoint.Endpoint
+	TerminateNetworkRelationship endpoint.Endpoint
+	ListPayors                   endpoint.Endpoint
+	ListProviders                endpoint.Endpoint
+
+	QueryAuditLog endpoint.Endpoint
+
+	SendEmailVerification endpoint.Endpoint
+	VerifyEmail           endpoint.Endpoint
+	SendPhoneVerification endpoint.Endpoint
+	VerifyPhone           endpoint.Endpoint
+
+	RequestPasswordReset endpoint.Endpoint
+	ResetPassword        endpoint.Endpoint
+
+	CompleteMFALogin endpoint.Endpoint
+	EnrollTOTP       endpoint.Endpoint
+	ConfirmTOTP      endpoint.Endpoint
+	DisableMFA       endpoint.Endpoint
+	ResetMFA         endpoint.Endpoint
+
+	BeginPasskeyRegistration  endpoint.Endpoint
+	FinishPasskeyRegistration endpoint.Endpoint
+	ListPasskeys              endpoint.Endpoint
+	DeletePasskey             endpoint.Endpoint
+	BeginPasskeyLogin         endpoint.Endpoint
+	PasskeyLogin              endpoint.Endpoint
+
+	RequestMagicLink endpoint.Endpoint
+	MagicLinkLogin   endpoint.Endpoint
+	RequestSMSCode   endpoint.Endpoint
+	SMSCodeLogin     endpoint.Endpoint
+
+	LoginWithoutOrg endpoint.Endpoint
+	ChooseLoginOrg  endpoint.Endpoint
+	SwitchOrg       endpoint.Endpoint
+	ListMyOrgs      endpoint.Endpoint
 }
 
 // Factory function that exposes this service-specific functionalities
 func MakeEndpoints(s Service) Endpoints {
+	// Every endpoint resolves the caller's bearer token (if they sent one) before
+	// anything else, so the Service can tell who it's dealing with.
+	authenticate := AuthMiddleware(s)
+
 	return Endpoints{
-		CreateUser:        makeCreateUserEndpoint(s),
-		GetUser:           makeGetUserAccountEndpoint(s),
-		LoginUser:         makeLoginUserEndpoint(s),
-		UpdateUserProfile: makeUpdateUserProfileEndpoint(s),
+		CreateUser:        authenticate(makeCreateUserEndpoint(s)),
+		GetUser:           authenticate(makeGetUserAccountEndpoint(s)),
+		DeleteUser:        authenticate(makeDeleteUserEndpoint(s)),
+		SuspendUser:       authenticate(makeSuspendUserEndpoint(s)),
+		DeactivateUser:    authenticate(makeDeactivateUserEndpoint(s)),
+		ReactivateUser:    authenticate(makeReactivateUserEndpoint(s)),
+		RestoreUser:       authenticate(makeRestoreUserEndpoint(s)),
+		UpdateUserAccount: authenticate(makeUpdateUserAccountEndpoint(s)),
+		LoginUser:         authenticate(makeLoginUserEndpoint(s)),
+		UpdateUserProfile: authenticate(makeUpdateUserProfileEndpoint(s)),
+		GetSession:        authenticate(makeGetSessionEndpoint()),
 
-		CreateOrg: makeCreateOrgEndpoint(s),
+		CreateOrg:        authenticate(makeCreateOrgEndpoint(s)),
+		GetOrg:           authenticate(makeGetOrgEndpoint(s)),
+		UpdateOrgAccount: authenticate(makeUpdateOrgAccountEndpoint(s)),
+		UpdateOrgProfile: authenticate(makeUpdateOrgProfileEndpoint(s)),
+		DeleteOrg:        authenticate(makeDeleteOrgEndpoint(s)),
+		SuspendOrg:       authenticate(makeSuspendOrgEndpoint(s)),
+		DeactivateOrg:    authenticate(makeDeactivateOrgEndpoint(s)),
+		ReactivateOrg:    authenticate(makeReactivateOrgEndpoint(s)),
+		RestoreOrg:       authenticate(makeRestoreOrgEndpoint(s)),
+		ListOrgUsers:     authenticate(makeListOrgUsersEndpoint(s)),
+		ListChildOrgs:    authenticate(makeListChildOrgsEndpoint(s)),
+		GetOrgTree:       authenticate(makeGetOrgTreeEndpoint(s)),
+
+		UpdatePayorDetails: authenticate(makeUpdatePayorDetailsEndpoint(s)),
+		FindPayor:          authenticate(makeFindPayorEndpoint(s)),
+
+		CreateInvite: authenticate(makeCreateInviteEndpoint(s)),
+		ListInvites:  authenticate(makeListInvitesEndpoint(s)),
+		RevokeInvite: authenticate(makeRevokeInviteEndpoint(s)),
+		ResendInvite: authenticate(makeResendInviteEndpoint(s)),
+		AcceptInvite: authenticate(makeAcceptInviteEndpoint(s)),
+
+		RequestNetworkRelationship:   authenticate(makeRequestNetworkRelationshipEndpoint(s)),
+		AcceptNetworkRelationship:    authenticate(makeAcceptNetworkRelationshipEndpoint(s)),
+		TerminateNetworkRelationship: authenticate(makeTerminateNetworkRelationshipEndpoint(s)),
+		ListPayors:                   authenticate(makeListPayorsEndpoint(s)),
+		ListProviders:                authenticate(makeListProvidersEndpoint(s)),
+
+		QueryAuditLog: authenticate(makeQueryAuditLogEndpoint(s)),
+
+		SendEmailVerification: authenticate(makeSendEmailVerificationEndpoint(s)),
+		VerifyEmail:           authenticate(makeVerifyEmailEndpoint(s)),
+		SendPhoneVerification: authenticate(makeSendPhoneVerificationEndpoint(s)),
+		VerifyPhone:           authenticate(makeVerifyPhoneEndpoint(s)),
+
+		RequestPasswordReset: authenticate(makeRequestPasswordResetEndpoint(s)),
+		ResetPassword:        authenticate(makeResetPasswordEndpoint(s)),
+
+		CompleteMFALogin: authenticate(makeCompleteMFALoginEndpoint(s)),
+		EnrollTOTP:       authenticate(makeEnrollTOTPEndpoint(s)),
+		ConfirmTOTP:      authenticate(makeConfirmTOTPEndpoint(s)),
+		DisableMFA:       authenticate(makeDisableMFAEndpoint(s)),
+		ResetMFA:         authenticate(makeResetMFAEndpoint(s)),
+
+		BeginPasskeyRegistration:  authenticate(makeBeginPasskeyRegistrationEndpoint(s)),
+		FinishPasskeyRegistration: authenticate(makeFinishPasskeyRegistrationEndpoint(s)),
+		ListPasskeys:              authenticate(makeListPasskeysEndpoint(s)),
+		DeletePasskey:             authenticate(makeDeletePasskeyEndpoint(s)),
+		BeginPasskeyLogin:         authenticate(makeBeginPasskeyLoginEndpoint(s)),
+		PasskeyLogin:              authenticate(makePasskeyLoginEndpoint(s)),
+
+		RequestMagicLink: authenticate(makeRequestMagicLinkEndpoint(s)),
+		MagicLinkLogin:   authenticate(makeMagicLinkLoginEndpoint(s)),
+		RequestSMSCode:   authenticate(makeRequestSMSCodeEndpoint(s)),
+		SMSCodeLogin:     authenticate(makeSMSCodeLoginEndpoint(s)),
+
+		LoginWithoutOrg: authenticate(makeLoginWithoutOrgEndpoint(s)),
+		ChooseLoginOrg:  authenticate(makeChooseLoginOrgEndpoint(s)),
+		SwitchOrg:       authenticate(makeSwitchOrgEndpoint(s)),
+		ListMyOrgs:      authenticate(makeListMyOrgsEndpoint(s)),
 	}
 }
 
@@ -46,11 +183,85 @@ func makeCreateUserEndpoint(s Service) endpoint.Endpoint {
 	}
 }
 
+// Plain GET just brings back the account, asking to include anything else (profile,
+// orgs) brings back the whole DetailedUser instead.
 func makeGetUserAccountEndpoint(s Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(GetUserRequest)
-		account, err := s.GetUserAccount(ctx, req.ID)
-		return GetUserAccountResponse{UserAccount: account, Err: err}, nil
+
+		if len(req.Include) == 0 {
+			account, err := s.GetUserAccount(ctx, req.ID)
+			return GetUserAccountResponse{UserAccount: account, Err: err}, nil
+		}
+
+		// The profile always comes along with a DetailedUser, so it only matters
+		// whether the orgs were asked for too.
+		var includeOrgs bool
+		for _, include := range req.Include {
+			switch include {
+			case "profile":
+			case "orgs":
+				includeOrgs = true
+			default:
+				return GetDetailedUserResponse{Err: fmt.Errorf("cannot include %q", include)}, nil
+			}
+		}
+
+		user, err := s.GetDetailedUser(ctx, req.ID, includeOrgs)
+		return GetDetailedUserResponse{User: user, Err: err}, nil
+	}
+}
+
+func makeDeleteUserEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeleteUserRequest)
+		err := s.DeleteUserAccount(ctx, req.ID)
+		return DeleteUserResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeSuspendUserEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UserStatusRequest)
+		account, err := s.SuspendUser(ctx, req.UserID)
+		return UserStatusResponse{Account: account, Err: err}, nil
+	}
+}
+
+func makeDeactivateUserEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UserStatusRequest)
+		account, err := s.DeactivateUser(ctx, req.UserID)
+		return UserStatusResponse{Account: account, Err: err}, nil
+	}
+}
+
+func makeReactivateUserEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UserStatusRequest)
+		account, err := s.ReactivateUser(ctx, req.UserID)
+		return UserStatusResponse{Account: account, Err: err}, nil
+	}
+}
+
+func makeRestoreUserEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UserStatusRequest)
+		account, err := s.RestoreUser(ctx, req.UserID)
+		return UserStatusResponse{Account: account, Err: err}, nil
+	}
+}
+
+func makeUpdateUserAccountEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UpdateAccountRequest)
+
+		// Same trick as the profile updates, only the fields that were set make it into the map
+		updatesMap := structToMapByTag(req.Updates, "json")
+
+		err := s.UpdateUserAccount(ctx, req.ID, updatesMap)
+
+		return UpdateAccountResponse{OK: "ok", Err: err}, nil
 	}
 }
 
@@ -58,16 +269,30 @@ func makeLoginUserEndpoint(s Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(LoginRequest)
 
-		loginDetails, err := s.Login(ctx, req.OrgID, req.Username, req.Password)
+		loginDetails, challenge, err := s.Login(ctx, req.OrgID, req.Username, req.Password)
+		if err != nil || challenge != nil {
+			return LoginResponse{MFA: challenge, Err: err}, nil
+		}
 
 		return LoginResponse{
-			LoginDetails: loginDetails,
-			Err:          err,
+			LoginDetails: &loginDetails,
 		}, nil
 
 	}
 }
 
+// Tells the caller who their bearer token belongs to. The auth middleware already
+// did the work, so there's nothing to ask the Service.
+func makeGetSessionEndpoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		principal, ok := PrincipalFromContext(ctx)
+		if !ok {
+			return GetSessionResponse{Err: ErrUnauthenticated}, nil
+		}
+		return GetSessionResponse{Principal: principal}, nil
+	}
+}
+
 func makeUpdateUserProfileEndpoint(s Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(UpdateProfileRequest)
@@ -85,8 +310,504 @@ func makeCreateOrgEndpoint(s Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(CreateOrgRequest)
 
-		id, err := s.CreateOrg(ctx, req.Name, req.Type, req.Phone, req.Address, req.Timezone, req.Website)
+		id, err := s.CreateOrg(ctx, req.Name, req.Type, req.ParentID, req.Phone, req.Address, req.Timezone, req.Website, req.ProviderDetails, req.PayorDetails)
 
 		return CreateOrgResponse{ID: id, Err: err}, nil
 	}
 }
+
+func makeGetOrgEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetOrgRequest)
+
+		org, err := s.GetOrg(ctx, req.ID)
+
+		return GetOrgResponse{Org: org, Err: err}, nil
+	}
+}
+
+func makeUpdateOrgAccountEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UpdateOrgAccountRequest)
+
+		updatesMap := structToMapByTag(req.Updates, "json")
+
+		err := s.UpdateOrgAccount(ctx, req.ID, updatesMap)
+
+		return UpdateOrgAccountResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeUpdateOrgProfileEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UpdateOrgProfileRequest)
+
+		updatesMap := structToMapByTag(req.Updates, "json")
+
+		err := s.UpdateOrgProfile(ctx, req.ID, updatesMap)
+
+		return UpdateOrgProfileResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeDeleteOrgEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeleteOrgRequest)
+
+		err := s.DeleteOrg(ctx, req.ID, req.Force)
+
+		return DeleteOrgResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeSuspendOrgEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(OrgStatusRequest)
+		account, err := s.SuspendOrg(ctx, req.OrgID)
+		return OrgStatusResponse{Account: account, Err: err}, nil
+	}
+}
+
+func makeDeactivateOrgEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(OrgStatusRequest)
+		account, err := s.DeactivateOrg(ctx, req.OrgID)
+		return OrgStatusResponse{Account: account, Err: err}, nil
+	}
+}
+
+func makeReactivateOrgEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(OrgStatusRequest)
+		account, err := s.ReactivateOrg(ctx, req.OrgID)
+		return OrgStatusResponse{Account: account, Err: err}, nil
+	}
+}
+
+func makeRestoreOrgEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(OrgStatusRequest)
+		account, err := s.RestoreOrg(ctx, req.OrgID)
+		return OrgStatusResponse{Account: account, Err: err}, nil
+	}
+}
+
+func makeListOrgUsersEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListOrgUsersRequest)
+
+		page, err := s.ListOrgUsers(ctx, req.OrgID, req.Query)
+
+		return ListOrgUsersResponse{OrgUserPage: page, Err: err}, nil
+	}
+}
+
+func makeListChildOrgsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListChildOrgsRequest)
+
+		orgs, err := s.ListChildOrgs(ctx, req.OrgID)
+
+		return ListChildOrgsResponse{Orgs: orgs, Err: err}, nil
+	}
+}
+
+func makeGetOrgTreeEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetOrgTreeRequest)
+
+		hierarchy, err := s.GetOrgTree(ctx, req.OrgID)
+
+		return GetOrgTreeResponse{OrgHierarchy: hierarchy, Err: err}, nil
+	}
+}
+
+func makeUpdatePayorDetailsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UpdatePayorDetailsRequest)
+
+		err := s.UpdatePayorDetails(ctx, req.ID, req.Details)
+
+		return UpdatePayorDetailsResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeFindPayorEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(FindPayorRequest)
+
+		org, err := s.FindPayor(ctx, req.PayerID)
+
+		return FindPayorResponse{Org: org, Err: err}, nil
+	}
+}
+
+func makeCreateInviteEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CreateInviteRequest)
+
+		issued, err := s.CreateInvite(ctx, req.OrgID, req.Email, req.Role)
+
+		return CreateInviteResponse{IssuedInvite: issued, Err: err}, nil
+	}
+}
+
+func makeListInvitesEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListInvitesRequest)
+
+		invites, err := s.ListInvites(ctx, req.OrgID)
+
+		return ListInvitesResponse{Invites: invites, Err: err}, nil
+	}
+}
+
+func makeRevokeInviteEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(RevokeInviteRequest)
+
+		err := s.RevokeInvite(ctx, req.OrgID, req.InviteID)
+
+		return RevokeInviteResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeResendInviteEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ResendInviteRequest)
+
+		issued, err := s.ResendInvite(ctx, req.OrgID, req.InviteID)
+
+		return ResendInviteResponse{IssuedInvite: issued, Err: err}, nil
+	}
+}
+
+func makeAcceptInviteEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(AcceptInviteRequest)
+
+		userID, err := s.AcceptInvite(ctx, req.Token, req.Username, req.Password, req.FirstName, req.LastName, req.Phone)
+
+		return AcceptInviteResponse{UserID: userID, Err: err}, nil
+	}
+}
+
+func makeRequestNetworkRelationshipEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(RequestNetworkRelationshipRequest)
+
+		relationship, err := s.RequestNetworkRelationship(ctx, req.OrgID, req.PartnerID, req.EffectiveDate, req.TerminationDate)
+
+		return NetworkRelationshipResponse{Relationship: relationship, Err: err}, nil
+	}
+}
+
+func makeAcceptNetworkRelationshipEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(NetworkRelationshipActionRequest)
+
+		relationship, err := s.AcceptNetworkRelationship(ctx, req.OrgID, req.RelationshipID)
+
+		return NetworkRelationshipResponse{Relationship: relationship, Err: err}, nil
+	}
+}
+
+func makeTerminateNetworkRelationshipEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(NetworkRelationshipActionRequest)
+
+		relationship, err := s.TerminateNetworkRelationship(ctx, req.OrgID, req.RelationshipID)
+
+		return NetworkRelationshipResponse{Relationship: relationship, Err: err}, nil
+	}
+}
+
+func makeListPayorsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListNetworkRequest)
+
+		payors, err := s.ListPayors(ctx, req.OrgID, req.Query)
+
+		return ListNetworkResponse{Relationships: payors, Err: err}, nil
+	}
+}
+
+func makeListProvidersEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListNetworkRequest)
+
+		providers, err := s.ListProviders(ctx, req.OrgID, req.Query)
+
+		return ListNetworkResponse{Relationships: providers, Err: err}, nil
+	}
+}
+
+func makeQueryAuditLogEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(QueryAuditLogRequest)
+
+		events, err := s.QueryAuditLog(ctx, req.Query)
+
+		return QueryAuditLogResponse{Events: events, Err: err}, nil
+	}
+}
+
+func makeSendEmailVerificationEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(SendEmailVerificationRequest)
+
+		err := s.SendEmailVerification(ctx, req.UserID)
+
+		return SendEmailVerificationResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeVerifyEmailEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(VerifyEmailRequest)
+
+		err := s.VerifyEmail(ctx, req.UserID, req.Token)
+
+		return VerifyEmailResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeSendPhoneVerificationEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(SendPhoneVerificationRequest)
+
+		err := s.SendPhoneVerification(ctx, req.UserID)
+
+		return SendPhoneVerificationResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeVerifyPhoneEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(VerifyPhoneRequest)
+
+		err := s.VerifyPhone(ctx, req.UserID, req.Code)
+
+		return VerifyPhoneResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeRequestPasswordResetEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(RequestPasswordResetRequest)
+
+		err := s.RequestPasswordReset(ctx, req.Username, req.Email)
+
+		return RequestPasswordResetResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeResetPasswordEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ResetPasswordRequest)
+
+		err := s.ResetPassword(ctx, req.Token, req.Password)
+
+		return ResetPasswordResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeCompleteMFALoginEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CompleteMFALoginRequest)
+
+		loginDetails, err := s.CompleteMFALogin(ctx, req.Token, req.Code)
+
+		return CompleteMFALoginResponse{LoginDetails: loginDetails, Err: err}, nil
+	}
+}
+
+func makeEnrollTOTPEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(EnrollTOTPRequest)
+
+		enrollment, err := s.EnrollTOTP(ctx, req.UserID)
+
+		return EnrollTOTPResponse{Enrollment: enrollment, Err: err}, nil
+	}
+}
+
+func makeConfirmTOTPEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ConfirmTOTPRequest)
+
+		recoveryCodes, err := s.ConfirmTOTP(ctx, req.UserID, req.Code)
+
+		return ConfirmTOTPResponse{RecoveryCodes: recoveryCodes, Err: err}, nil
+	}
+}
+
+func makeDisableMFAEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DisableMFARequest)
+
+		err := s.DisableMFA(ctx, req.UserID, req.Code)
+
+		return DisableMFAResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeResetMFAEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ResetMFARequest)
+
+		err := s.ResetMFA(ctx, req.UserID)
+
+		return ResetMFAResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeBeginPasskeyRegistrationEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(BeginPasskeyRegistrationRequest)
+
+		ceremony, err := s.BeginPasskeyRegistration(ctx, req.UserID)
+
+		return BeginPasskeyRegistrationResponse{Ceremony: ceremony, Err: err}, nil
+	}
+}
+
+func makeFinishPasskeyRegistrationEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(FinishPasskeyRegistrationRequest)
+
+		passkey, err := s.FinishPasskeyRegistration(ctx, req.UserID, req.Token, req.Name, req.Credential)
+
+		return FinishPasskeyRegistrationResponse{Passkey: passkey, Err: err}, nil
+	}
+}
+
+func makeListPasskeysEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListPasskeysRequest)
+
+		passkeys, err := s.ListPasskeys(ctx, req.UserID)
+
+		return ListPasskeysResponse{Passkeys: passkeys, Err: err}, nil
+	}
+}
+
+func makeDeletePasskeyEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeletePasskeyRequest)
+
+		err := s.DeletePasskey(ctx, req.UserID, req.PasskeyID)
+
+		return DeletePasskeyResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeBeginPasskeyLoginEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(BeginPasskeyLoginRequest)
+
+		ceremony, err := s.BeginPasskeyLogin(ctx, req.OrgID)
+
+		return BeginPasskeyLoginResponse{Ceremony: ceremony, Err: err}, nil
+	}
+}
+
+func makePasskeyLoginEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(PasskeyLoginRequest)
+
+		loginDetails, err := s.PasskeyLogin(ctx, req.OrgID, req.Token, req.Credential)
+
+		return PasskeyLoginResponse{LoginDetails: loginDetails, Err: err}, nil
+	}
+}
+
+func makeRequestMagicLinkEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(RequestMagicLinkRequest)
+
+		err := s.RequestMagicLink(ctx, req.OrgID, req.Username, req.Email)
+
+		return RequestMagicLinkResponse{OK: "ok", Err: err}, nil
+	}
+}
+
+func makeMagicLinkLoginEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(MagicLinkLoginRequest)
+
+		loginDetails, challenge, err := s.MagicLinkLogin(ctx, req.OrgID, req.Token)
+		if err != nil || challenge != nil {
+			return LoginResponse{MFA: challenge, Err: err}, nil
+		}
+
+		return LoginResponse{LoginDetails: &loginDetails}, nil
+	}
+}
+
+func makeRequestSMSCodeEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(RequestSMSCodeRequest)
+
+		challenge, err := s.RequestSMSCode(ctx, req.OrgID, req.Username)
+
+		return RequestSMSCodeResponse{Challenge: challenge, Err: err}, nil
+	}
+}
+
+func makeSMSCodeLoginEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(SMSCodeLoginRequest)
+
+		loginDetails, challenge, err := s.SMSCodeLogin(ctx, req.OrgID, req.Token, req.Code)
+		if err != nil || challenge != nil {
+			return LoginResponse{MFA: challenge, Err: err}, nil
+		}
+
+		return LoginResponse{LoginDetails: &loginDetails}, nil
+	}
+}
+
+func makeLoginWithoutOrgEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(LoginWithoutOrgRequest)
+
+		selection, err := s.LoginWithoutOrg(ctx, req.Username, req.Password)
+
+		return LoginWithoutOrgResponse{Selection: selection, Err: err}, nil
+	}
+}
+
+func makeChooseLoginOrgEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ChooseLoginOrgRequest)
+
+		loginDetails, challenge, err := s.ChooseLoginOrg(ctx, req.Token, req.OrgID)
+		if err != nil || challenge != nil {
+			return LoginResponse{MFA: challenge, Err: err}, nil
+		}
+
+		return LoginResponse{LoginDetails: &loginDetails}, nil
+	}
+}
+
+func makeSwitchOrgEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(SwitchOrgRequest)
+
+		loginDetails, challenge, err := s.SwitchOrg(ctx, req.OrgID)
+		if err != nil || challenge != nil {
+			return LoginResponse{MFA: challenge, Err: err}, nil
+		}
+
+		return LoginResponse{LoginDetails: &loginDetails}, nil
+	}
+}
+
+func makeListMyOrgsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		orgs, err := s.ListMyOrgs(ctx)
+
+		return ListMyOrgsResponse{Orgs: orgs, Err: err}, nil
+	}
+}
@@ -0,0 +1,35 @@
+package accountsrv
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The errors the service hands back that callers are expected to tell apart.
+// The transports map each of them onto a status code (see CodeFrom and
+// GRPCCodeFrom), everything else is treated as a bad request.
+var (
+	ErrUnauthenticated = errors.New("missing or invalid credentials")
+	ErrForbidden       = errors.New("not allowed to do that")
+	ErrOrgHasMembers   = errors.New("organization still has members")
+	ErrOrgHasChildren  = errors.New("organization still has child organizations")
+	ErrNotFound        = errors.New("not found")
+
+	// Only once the password checks out, before that a suspended user fails to
+	// log in like anyone else would
+	ErrAccountSuspended = fmt.Errorf("%w: account is suspended", ErrForbidden)
+)
+
+// Reason codes go out alongside the errors a caller can't tell apart by status
+// code alone, e.g. a suspended account from any other 403
+const ReasonAccountSuspended = "account_suspended"
+
+// The reason code for the error, "" when it doesn't have one
+func ReasonFrom(err error) string {
+	switch {
+	case errors.Is(err, ErrAccountSuspended):
+		return ReasonAccountSuspended
+	default:
+		return ""
+	}
+}
@@ -1,10 +1,29 @@
 module github.com/rjjp5294/accountsrv
 
-go 1.16
+go 1.23.0
 
 require (
+	github.com/fxamacker/cbor/v2 v2.5.0
 	github.com/go-kit/kit v0.10.0
+	github.com/go-webauthn/webauthn v0.9.4
 	github.com/gofrs/uuid v4.0.0+incompatible
 	github.com/gorilla/mux v1.8.0
 	github.com/lib/pq v1.10.0
+	golang.org/x/crypto v0.36.0
+	google.golang.org/grpc v1.73.0
+	google.golang.org/protobuf v1.36.6
+)
+
+require (
+	github.com/go-logfmt/logfmt v0.5.0 // indirect
+	github.com/go-webauthn/x v0.1.5 // indirect
+	github.com/golang-jwt/jwt/v5 v5.2.0 // indirect
+	github.com/google/go-tpm v0.9.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/mitchellh/mapstructure v1.5.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	golang.org/x/net v0.38.0 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+	golang.org/x/text v0.23.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250324211829-b45e905df463 // indirect
 )
@@ -0,0 +1,2151 @@
+package accountsrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rjjp5294/accountsrv/pb"
+)
+
+/*
+The gRPC flavor of the Transport Layer. It is the exact same idea as http.go: map the
+gRPC-world stuff (protobuf messages, metadata) onto the very same Endpoints, so the
+Endpoints and Service have no idea whether a request came in over HTTP or gRPC.
+*/
+
+// Holds a go-kit gRPC handler per rpc, the methods below just hand the protobuf
+// request to the right one and unwrap what comes back.
+type grpcServer struct {
+	pb.UnimplementedAccountServer
+
+	createUser        grpctransport.Handler
+	getUser           grpctransport.Handler
+	deleteUser        grpctransport.Handler
+	suspendUser       grpctransport.Handler
+	deactivateUser    grpctransport.Handler
+	reactivateUser    grpctransport.Handler
+	restoreUser       grpctransport.Handler
+	updateUserAccount grpctransport.Handler
+	loginUser         grpctransport.Handler
+	updateUserProfile grpctransport.Handler
+	getSession        grpctransport.Handler
+
+	sendEmailVerification grpctransport.Handler
+	verifyEmail           grpctransport.Handler
+	sendPhoneVerification grpctransport.Handler
+	verifyPhone           grpctransport.Handler
+
+	requestPasswordReset grpctransport.Handler
+	resetPassword        grpctransport.Handler
+
+	completeMFALogin          grpctransport.Handler
+	enrollTOTP                grpctransport.Handler
+	confirmTOTP               grpctransport.Handler
+	disableMFA                grpctransport.Handler
+	resetMFA                  grpctransport.Handler
+	beginPasskeyRegistration  grpctransport.Handler
+	finishPasskeyRegistration grpctransport.Handler
+	listPasskeys              grpctransport.Handler
+	deletePasskey             grpctransport.Handler
+	beginPasskeyLogin         grpctransport.Handler
+	passkeyLogin              grpctransport.Handler
+	requestMagicLink          grpctransport.Handler
+	magicLinkLogin            grpctransport.Handler
+	requestSMSCode            grpctransport.Handler
+	sMSCodeLogin              grpctransport.Handler
+
+	loginWithoutOrg grpctransport.Handler
+	chooseLoginOrg  grpctransport.Handler
+	switchOrg       grpctransport.Handler
+	listMyOrgs      grpctransport.Handler
+
+	createOrg        grpctransport.Handler
+	getOrg           grpctransport.Handler
+	updateOrgAccount grpctransport.Handler
+	updateOrgProfile grpctransport.Handler
+	deleteOrg        grpctransport.Handler
+	suspendOrg       grpctransport.Handler
+	deactivateOrg    grpctransport.Handler
+	reactivateOrg    grpctransport.Handler
+	restoreOrg       grpctransport.Handler
+	listOrgUsers     grpctransport.Handler
+	listChildOrgs    grpctransport.Handler
+	getOrgTree       grpctransport.Handler
+
+	updatePayorDetails grpctransport.Handler
+	findPayor          grpctransport.Handler
+
+	createInvite grpctransport.Handler
+	listInvites  grpctransport.Handler
+	revokeInvite grpctransport.Handler
+	resendInvite grpctransport.Handler
+	acceptInvite grpctransport.Handler
+
+	requestNetworkRelationship   grpctransport.Handler
+	acceptNetworkRelationship    grpctransport.Handler
+	terminateNetworkRelationship grpctransport.Handler
+	listPayors                   grpctransport.Handler
+	listProviders                grpctransport.Handler
+
+	queryAuditLog grpctransport.Handler
+}
+
+// Factory function for the gRPC server, the counterpart of NewHTTPServer. Register
+// the result on a grpc.Server with pb.RegisterAccountServer.
+func NewGRPCServer(ctx context.Context, endpoints Endpoints) pb.AccountServer {
+	// Same idea as the HTTP options, everything that gets pulled out of the transport
+	// ends up in the context under the same keys so the layers below can't tell the difference.
+	options := []grpctransport.ServerOption{
+		grpctransport.ServerBefore(
+			grpcRequestIDToContext,
+			grpcAuthTokenToContext,
+		),
+	}
+
+	return &grpcServer{
+		createUser: grpctransport.NewServer(
+			endpoints.CreateUser,
+			decodeGRPCCreateUserReq,
+			encodeGRPCCreateUserResp,
+			options...,
+		),
+		getUser: grpctransport.NewServer(
+			endpoints.GetUser,
+			decodeGRPCGetUserReq,
+			encodeGRPCGetUserResp,
+			options...,
+		),
+		deleteUser: grpctransport.NewServer(
+			endpoints.DeleteUser,
+			decodeGRPCDeleteUserReq,
+			encodeGRPCDeleteUserResp,
+			options...,
+		),
+		suspendUser: grpctransport.NewServer(
+			endpoints.SuspendUser,
+			decodeGRPCUserStatusReq,
+			encodeGRPCUserStatusResp,
+			options...,
+		),
+		deactivateUser: grpctransport.NewServer(
+			endpoints.DeactivateUser,
+			decodeGRPCUserStatusReq,
+			encodeGRPCUserStatusResp,
+			options...,
+		),
+		reactivateUser: grpctransport.NewServer(
+			endpoints.ReactivateUser,
+			decodeGRPCUserStatusReq,
+			encodeGRPCUserStatusResp,
+			options...,
+		),
+		restoreUser: grpctransport.NewServer(
+			endpoints.RestoreUser,
+			decodeGRPCUserStatusReq,
+			encodeGRPCUserStatusResp,
+			options...,
+		),
+		updateUserAccount: grpctransport.NewServer(
+			endpoints.UpdateUserAccount,
+			decodeGRPCUpdateUserAccountReq,
+			encodeGRPCUpdateUserAccountResp,
+			options...,
+		),
+		loginUser: grpctransport.NewServer(
+			endpoints.LoginUser,
+			decodeGRPCLoginReq,
+			encodeGRPCLoginResp,
+			options...,
+		),
+		updateUserProfile: grpctransport.NewServer(
+			endpoints.UpdateUserProfile,
+			decodeGRPCUpdateUserProfileReq,
+			encodeGRPCUpdateUserProfileResp,
+			options...,
+		),
+		getSession: grpctransport.NewServer(
+			endpoints.GetSession,
+			decodeGRPCGetSessionReq,
+			encodeGRPCGetSessionResp,
+			options...,
+		),
+		sendEmailVerification: grpctransport.NewServer(
+			endpoints.SendEmailVerification,
+			decodeGRPCSendEmailVerificationReq,
+			encodeGRPCSendEmailVerificationResp,
+			options...,
+		),
+		verifyEmail: grpctransport.NewServer(
+			endpoints.VerifyEmail,
+			decodeGRPCVerifyEmailReq,
+			encodeGRPCVerifyEmailResp,
+			options...,
+		),
+		sendPhoneVerification: grpctransport.NewServer(
+			endpoints.SendPhoneVerification,
+			decodeGRPCSendPhoneVerificationReq,
+			encodeGRPCSendPhoneVerificationResp,
+			options...,
+		),
+		verifyPhone: grpctransport.NewServer(
+			endpoints.VerifyPhone,
+			decodeGRPCVerifyPhoneReq,
+			encodeGRPCVerifyPhoneResp,
+			options...,
+		),
+		requestPasswordReset: grpctransport.NewServer(
+			endpoints.RequestPasswordReset,
+			decodeGRPCRequestPasswordResetReq,
+			encodeGRPCRequestPasswordResetResp,
+			options...,
+		),
+		resetPassword: grpctransport.NewServer(
+			endpoints.ResetPassword,
+			decodeGRPCResetPasswordReq,
+			encodeGRPCResetPasswordResp,
+			options...,
+		),
+		completeMFALogin: grpctransport.NewServer(
+			endpoints.CompleteMFALogin,
+			decodeGRPCCompleteMFALoginReq,
+			encodeGRPCCompleteMFALoginResp,
+			options...,
+		),
+		enrollTOTP: grpctransport.NewServer(
+			endpoints.EnrollTOTP,
+			decodeGRPCEnrollTOTPReq,
+			encodeGRPCEnrollTOTPResp,
+			options...,
+		),
+		confirmTOTP: grpctransport.NewServer(
+			endpoints.ConfirmTOTP,
+			decodeGRPCConfirmTOTPReq,
+			encodeGRPCConfirmTOTPResp,
+			options...,
+		),
+		disableMFA: grpctransport.NewServer(
+			endpoints.DisableMFA,
+			decodeGRPCDisableMFAReq,
+			encodeGRPCDisableMFAResp,
+			options...,
+		),
+		resetMFA: grpctransport.NewServer(
+			endpoints.ResetMFA,
+			decodeGRPCResetMFAReq,
+			encodeGRPCResetMFAResp,
+			options...,
+		),
+		beginPasskeyRegistration: grpctransport.NewServer(
+			endpoints.BeginPasskeyRegistration,
+			decodeGRPCBeginPasskeyRegistrationReq,
+			encodeGRPCBeginPasskeyRegistrationResp,
+			options...,
+		),
+		finishPasskeyRegistration: grpctransport.NewServer(
+			endpoints.FinishPasskeyRegistration,
+			decodeGRPCFinishPasskeyRegistrationReq,
+			encodeGRPCFinishPasskeyRegistrationResp,
+			options...,
+		),
+		listPasskeys: grpctransport.NewServer(
+			endpoints.ListPasskeys,
+			decodeGRPCListPasskeysReq,
+			encodeGRPCListPasskeysResp,
+			options...,
+		),
+		deletePasskey: grpctransport.NewServer(
+			endpoints.DeletePasskey,
+			decodeGRPCDeletePasskeyReq,
+			encodeGRPCDeletePasskeyResp,
+			options...,
+		),
+		beginPasskeyLogin: grpctransport.NewServer(
+			endpoints.BeginPasskeyLogin,
+			decodeGRPCBeginPasskeyLoginReq,
+			encodeGRPCBeginPasskeyLoginResp,
+			options...,
+		),
+		passkeyLogin: grpctransport.NewServer(
+			endpoints.PasskeyLogin,
+			decodeGRPCPasskeyLoginReq,
+			encodeGRPCPasskeyLoginResp,
+			options...,
+		),
+		requestMagicLink: grpctransport.NewServer(
+			endpoints.RequestMagicLink,
+			decodeGRPCRequestMagicLinkReq,
+			encodeGRPCRequestMagicLinkResp,
+			options...,
+		),
+		magicLinkLogin: grpctransport.NewServer(
+			endpoints.MagicLinkLogin,
+			decodeGRPCMagicLinkLoginReq,
+			encodeGRPCLoginResp,
+			options...,
+		),
+		requestSMSCode: grpctransport.NewServer(
+			endpoints.RequestSMSCode,
+			decodeGRPCRequestSMSCodeReq,
+			encodeGRPCRequestSMSCodeResp,
+			options...,
+		),
+		sMSCodeLogin: grpctransport.NewServer(
+			endpoints.SMSCodeLogin,
+			decodeGRPCSMSCodeLoginReq,
+			encodeGRPCLoginResp,
+			options...,
+		),
+		loginWithoutOrg: grpctransport.NewServer(
+			endpoints.LoginWithoutOrg,
+			decodeGRPCLoginWithoutOrgReq,
+			encodeGRPCLoginWithoutOrgResp,
+			options...,
+		),
+		chooseLoginOrg: grpctransport.NewServer(
+			endpoints.ChooseLoginOrg,
+			decodeGRPCChooseLoginOrgReq,
+			encodeGRPCLoginResp,
+			options...,
+		),
+		switchOrg: grpctransport.NewServer(
+			endpoints.SwitchOrg,
+			decodeGRPCSwitchOrgReq,
+			encodeGRPCLoginResp,
+			options...,
+		),
+		listMyOrgs: grpctransport.NewServer(
+			endpoints.ListMyOrgs,
+			decodeGRPCListMyOrgsReq,
+			encodeGRPCListMyOrgsResp,
+			options...,
+		),
+		createOrg: grpctransport.NewServer(
+			endpoints.CreateOrg,
+			decodeGRPCCreateOrgReq,
+			encodeGRPCCreateOrgResp,
+			options...,
+		),
+		getOrg: grpctransport.NewServer(
+			endpoints.GetOrg,
+			decodeGRPCGetOrgReq,
+			encodeGRPCGetOrgResp,
+			options...,
+		),
+		updateOrgAccount: grpctransport.NewServer(
+			endpoints.UpdateOrgAccount,
+			decodeGRPCUpdateOrgAccountReq,
+			encodeGRPCUpdateOrgAccountResp,
+			options...,
+		),
+		updateOrgProfile: grpctransport.NewServer(
+			endpoints.UpdateOrgProfile,
+			decodeGRPCUpdateOrgProfileReq,
+			encodeGRPCUpdateOrgProfileResp,
+			options...,
+		),
+		deleteOrg: grpctransport.NewServer(
+			endpoints.DeleteOrg,
+			decodeGRPCDeleteOrgReq,
+			encodeGRPCDeleteOrgResp,
+			options...,
+		),
+		suspendOrg: grpctransport.NewServer(
+			endpoints.SuspendOrg,
+			decodeGRPCOrgStatusReq,
+			encodeGRPCOrgStatusResp,
+			options...,
+		),
+		deactivateOrg: grpctransport.NewServer(
+			endpoints.DeactivateOrg,
+			decodeGRPCOrgStatusReq,
+			encodeGRPCOrgStatusResp,
+			options...,
+		),
+		reactivateOrg: grpctransport.NewServer(
+			endpoints.ReactivateOrg,
+			decodeGRPCOrgStatusReq,
+			encodeGRPCOrgStatusResp,
+			options...,
+		),
+		restoreOrg: grpctransport.NewServer(
+			endpoints.RestoreOrg,
+			decodeGRPCOrgStatusReq,
+			encodeGRPCOrgStatusResp,
+			options...,
+		),
+		listOrgUsers: grpctransport.NewServer(
+			endpoints.ListOrgUsers,
+			decodeGRPCListOrgUsersReq,
+			encodeGRPCListOrgUsersResp,
+			options...,
+		),
+		listChildOrgs: grpctransport.NewServer(
+			endpoints.ListChildOrgs,
+			decodeGRPCListChildOrgsReq,
+			encodeGRPCListChildOrgsResp,
+			options...,
+		),
+		getOrgTree: grpctransport.NewServer(
+			endpoints.GetOrgTree,
+			decodeGRPCGetOrgTreeReq,
+			encodeGRPCGetOrgTreeResp,
+			options...,
+		),
+		updatePayorDetails: grpctransport.NewServer(
+			endpoints.UpdatePayorDetails,
+			decodeGRPCUpdatePayorDetailsReq,
+			encodeGRPCUpdatePayorDetailsResp,
+			options...,
+		),
+		findPayor: grpctransport.NewServer(
+			endpoints.FindPayor,
+			decodeGRPCFindPayorReq,
+			encodeGRPCFindPayorResp,
+			options...,
+		),
+		createInvite: grpctransport.NewServer(
+			endpoints.CreateInvite,
+			decodeGRPCCreateInviteReq,
+			encodeGRPCCreateInviteResp,
+			options...,
+		),
+		listInvites: grpctransport.NewServer(
+			endpoints.ListInvites,
+			decodeGRPCListInvitesReq,
+			encodeGRPCListInvitesResp,
+			options...,
+		),
+		revokeInvite: grpctransport.NewServer(
+			endpoints.RevokeInvite,
+			decodeGRPCRevokeInviteReq,
+			encodeGRPCRevokeInviteResp,
+			options...,
+		),
+		resendInvite: grpctransport.NewServer(
+			endpoints.ResendInvite,
+			decodeGRPCResendInviteReq,
+			encodeGRPCResendInviteResp,
+			options...,
+		),
+		acceptInvite: grpctransport.NewServer(
+			endpoints.AcceptInvite,
+			decodeGRPCAcceptInviteReq,
+			encodeGRPCAcceptInviteResp,
+			options...,
+		),
+		requestNetworkRelationship: grpctransport.NewServer(
+			endpoints.RequestNetworkRelationship,
+			decodeGRPCRequestNetworkRelationshipReq,
+			encodeGRPCNetworkRelationshipResp,
+			options...,
+		),
+		acceptNetworkRelationship: grpctransport.NewServer(
+			endpoints.AcceptNetworkRelationship,
+			decodeGRPCNetworkRelationshipActionReq,
+			encodeGRPCNetworkRelationshipResp,
+			options...,
+		),
+		terminateNetworkRelationship: grpctransport.NewServer(
+			endpoints.TerminateNetworkRelationship,
+			decodeGRPCNetworkRelationshipActionReq,
+			encodeGRPCNetworkRelationshipResp,
+			options...,
+		),
+		listPayors: grpctransport.NewServer(
+			endpoints.ListPayors,
+			decodeGRPCListNetworkReq,
+			encodeGRPCListNetworkResp,
+			options...,
+		),
+		listProviders: grpctransport.NewServer(
+			endpoints.ListProviders,
+			decodeGRPCListNetworkReq,
+			encodeGRPCListNetworkResp,
+			options...,
+		),
+		queryAuditLog: grpctransport.NewServer(
+			endpoints.QueryAuditLog,
+			decodeGRPCQueryAuditLogReq,
+			encodeGRPCQueryAuditLogResp,
+			options...,
+		),
+	}
+}
+
+func (s *grpcServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserReply, error) {
+	_, resp, err := s.createUser.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.CreateUserReply), nil
+}
+
+func (s *grpcServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserReply, error) {
+	_, resp, err := s.getUser.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.GetUserReply), nil
+}
+
+func (s *grpcServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserReply, error) {
+	_, resp, err := s.deleteUser.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.DeleteUserReply), nil
+}
+
+func (s *grpcServer) SuspendUser(ctx context.Context, req *pb.UserStatusRequest) (*pb.UserStatusReply, error) {
+	_, resp, err := s.suspendUser.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.UserStatusReply), nil
+}
+
+func (s *grpcServer) DeactivateUser(ctx context.Context, req *pb.UserStatusRequest) (*pb.UserStatusReply, error) {
+	_, resp, err := s.deactivateUser.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.UserStatusReply), nil
+}
+
+func (s *grpcServer) ReactivateUser(ctx context.Context, req *pb.UserStatusRequest) (*pb.UserStatusReply, error) {
+	_, resp, err := s.reactivateUser.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.UserStatusReply), nil
+}
+
+func (s *grpcServer) RestoreUser(ctx context.Context, req *pb.UserStatusRequest) (*pb.UserStatusReply, error) {
+	_, resp, err := s.restoreUser.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.UserStatusReply), nil
+}
+
+func (s *grpcServer) UpdateUserAccount(ctx context.Context, req *pb.UpdateAccountRequest) (*pb.UpdateAccountReply, error) {
+	_, resp, err := s.updateUserAccount.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.UpdateAccountReply), nil
+}
+
+func (s *grpcServer) LoginUser(ctx context.Context, req *pb.LoginRequest) (*pb.LoginReply, error) {
+	_, resp, err := s.loginUser.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.LoginReply), nil
+}
+
+func (s *grpcServer) UpdateUserProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UpdateProfileReply, error) {
+	_, resp, err := s.updateUserProfile.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.UpdateProfileReply), nil
+}
+
+func (s *grpcServer) GetSession(ctx context.Context, req *pb.GetSessionRequest) (*pb.GetSessionReply, error) {
+	_, resp, err := s.getSession.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.GetSessionReply), nil
+}
+
+func (s *grpcServer) SendEmailVerification(ctx context.Context, req *pb.SendEmailVerificationRequest) (*pb.SendEmailVerificationReply, error) {
+	_, resp, err := s.sendEmailVerification.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.SendEmailVerificationReply), nil
+}
+
+func (s *grpcServer) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailReply, error) {
+	_, resp, err := s.verifyEmail.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.VerifyEmailReply), nil
+}
+
+func (s *grpcServer) SendPhoneVerification(ctx context.Context, req *pb.SendPhoneVerificationRequest) (*pb.SendPhoneVerificationReply, error) {
+	_, resp, err := s.sendPhoneVerification.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.SendPhoneVerificationReply), nil
+}
+
+func (s *grpcServer) VerifyPhone(ctx context.Context, req *pb.VerifyPhoneRequest) (*pb.VerifyPhoneReply, error) {
+	_, resp, err := s.verifyPhone.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.VerifyPhoneReply), nil
+}
+
+func (s *grpcServer) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetReply, error) {
+	_, resp, err := s.requestPasswordReset.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.RequestPasswordResetReply), nil
+}
+
+func (s *grpcServer) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordReply, error) {
+	_, resp, err := s.resetPassword.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.ResetPasswordReply), nil
+}
+
+func (s *grpcServer) CompleteMFALogin(ctx context.Context, req *pb.CompleteMFALoginRequest) (*pb.CompleteMFALoginReply, error) {
+	_, resp, err := s.completeMFALogin.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.CompleteMFALoginReply), nil
+}
+
+func (s *grpcServer) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPReply, error) {
+	_, resp, err := s.enrollTOTP.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.EnrollTOTPReply), nil
+}
+
+func (s *grpcServer) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPReply, error) {
+	_, resp, err := s.confirmTOTP.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.ConfirmTOTPReply), nil
+}
+
+func (s *grpcServer) DisableMFA(ctx context.Context, req *pb.DisableMFARequest) (*pb.DisableMFAReply, error) {
+	_, resp, err := s.disableMFA.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.DisableMFAReply), nil
+}
+
+func (s *grpcServer) ResetMFA(ctx context.Context, req *pb.ResetMFARequest) (*pb.ResetMFAReply, error) {
+	_, resp, err := s.resetMFA.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.ResetMFAReply), nil
+}
+
+func (s *grpcServer) BeginPasskeyRegistration(ctx context.Context, req *pb.BeginPasskeyRegistrationRequest) (*pb.BeginPasskeyRegistrationReply, error) {
+	_, resp, err := s.beginPasskeyRegistration.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.BeginPasskeyRegistrationReply), nil
+}
+
+func (s *grpcServer) FinishPasskeyRegistration(ctx context.Context, req *pb.FinishPasskeyRegistrationRequest) (*pb.FinishPasskeyRegistrationReply, error) {
+	_, resp, err := s.finishPasskeyRegistration.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.FinishPasskeyRegistrationReply), nil
+}
+
+func (s *grpcServer) ListPasskeys(ctx context.Context, req *pb.ListPasskeysRequest) (*pb.ListPasskeysReply, error) {
+	_, resp, err := s.listPasskeys.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.ListPasskeysReply), nil
+}
+
+func (s *grpcServer) DeletePasskey(ctx context.Context, req *pb.DeletePasskeyRequest) (*pb.DeletePasskeyReply, error) {
+	_, resp, err := s.deletePasskey.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.DeletePasskeyReply), nil
+}
+
+func (s *grpcServer) BeginPasskeyLogin(ctx context.Context, req *pb.BeginPasskeyLoginRequest) (*pb.BeginPasskeyLoginReply, error) {
+	_, resp, err := s.beginPasskeyLogin.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.BeginPasskeyLoginReply), nil
+}
+
+func (s *grpcServer) PasskeyLogin(ctx context.Context, req *pb.PasskeyLoginRequest) (*pb.PasskeyLoginReply, error) {
+	_, resp, err := s.passkeyLogin.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.PasskeyLoginReply), nil
+}
+
+func (s *grpcServer) RequestMagicLink(ctx context.Context, req *pb.RequestMagicLinkRequest) (*pb.RequestMagicLinkReply, error) {
+	_, resp, err := s.requestMagicLink.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.RequestMagicLinkReply), nil
+}
+
+func (s *grpcServer) MagicLinkLogin(ctx context.Context, req *pb.MagicLinkLoginRequest) (*pb.LoginReply, error) {
+	_, resp, err := s.magicLinkLogin.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.LoginReply), nil
+}
+
+func (s *grpcServer) RequestSMSCode(ctx context.Context, req *pb.RequestSMSCodeRequest) (*pb.RequestSMSCodeReply, error) {
+	_, resp, err := s.requestSMSCode.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.RequestSMSCodeReply), nil
+}
+
+func (s *grpcServer) SMSCodeLogin(ctx context.Context, req *pb.SMSCodeLoginRequest) (*pb.LoginReply, error) {
+	_, resp, err := s.sMSCodeLogin.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.LoginReply), nil
+}
+
+func (s *grpcServer) LoginWithoutOrg(ctx context.Context, req *pb.LoginWithoutOrgRequest) (*pb.LoginWithoutOrgReply, error) {
+	_, resp, err := s.loginWithoutOrg.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.LoginWithoutOrgReply), nil
+}
+
+func (s *grpcServer) ChooseLoginOrg(ctx context.Context, req *pb.ChooseLoginOrgRequest) (*pb.LoginReply, error) {
+	_, resp, err := s.chooseLoginOrg.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.LoginReply), nil
+}
+
+func (s *grpcServer) SwitchOrg(ctx context.Context, req *pb.SwitchOrgRequest) (*pb.LoginReply, error) {
+	_, resp, err := s.switchOrg.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.LoginReply), nil
+}
+
+func (s *grpcServer) ListMyOrgs(ctx context.Context, req *pb.ListMyOrgsRequest) (*pb.ListMyOrgsReply, error) {
+	_, resp, err := s.listMyOrgs.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.ListMyOrgsReply), nil
+}
+
+func (s *grpcServer) CreateOrg(ctx context.Context, req *pb.CreateOrgRequest) (*pb.CreateOrgReply, error) {
+	_, resp, err := s.createOrg.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.CreateOrgReply), nil
+}
+
+func (s *grpcServer) GetOrg(ctx context.Context, req *pb.GetOrgRequest) (*pb.GetOrgReply, error) {
+	_, resp, err := s.getOrg.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.GetOrgReply), nil
+}
+
+func (s *grpcServer) UpdateOrgAccount(ctx context.Context, req *pb.UpdateOrgAccountRequest) (*pb.UpdateOrgAccountReply, error) {
+	_, resp, err := s.updateOrgAccount.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.UpdateOrgAccountReply), nil
+}
+
+func (s *grpcServer) UpdateOrgProfile(ctx context.Context, req *pb.UpdateOrgProfileRequest) (*pb.UpdateOrgProfileReply, error) {
+	_, resp, err := s.updateOrgProfile.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.UpdateOrgProfileReply), nil
+}
+
+func (s *grpcServer) DeleteOrg(ctx context.Context, req *pb.DeleteOrgRequest) (*pb.DeleteOrgReply, error) {
+	_, resp, err := s.deleteOrg.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.DeleteOrgReply), nil
+}
+
+func (s *grpcServer) SuspendOrg(ctx context.Context, req *pb.OrgStatusRequest) (*pb.OrgStatusReply, error) {
+	_, resp, err := s.suspendOrg.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.OrgStatusReply), nil
+}
+
+func (s *grpcServer) DeactivateOrg(ctx context.Context, req *pb.OrgStatusRequest) (*pb.OrgStatusReply, error) {
+	_, resp, err := s.deactivateOrg.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.OrgStatusReply), nil
+}
+
+func (s *grpcServer) ReactivateOrg(ctx context.Context, req *pb.OrgStatusRequest) (*pb.OrgStatusReply, error) {
+	_, resp, err := s.reactivateOrg.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.OrgStatusReply), nil
+}
+
+func (s *grpcServer) RestoreOrg(ctx context.Context, req *pb.OrgStatusRequest) (*pb.OrgStatusReply, error) {
+	_, resp, err := s.restoreOrg.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.OrgStatusReply), nil
+}
+
+func (s *grpcServer) ListOrgUsers(ctx context.Context, req *pb.ListOrgUsersRequest) (*pb.ListOrgUsersReply, error) {
+	_, resp, err := s.listOrgUsers.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.ListOrgUsersReply), nil
+}
+
+func (s *grpcServer) ListChildOrgs(ctx context.Context, req *pb.ListChildOrgsRequest) (*pb.ListChildOrgsReply, error) {
+	_, resp, err := s.listChildOrgs.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.ListChildOrgsReply), nil
+}
+
+func (s *grpcServer) GetOrgTree(ctx context.Context, req *pb.GetOrgTreeRequest) (*pb.GetOrgTreeReply, error) {
+	_, resp, err := s.getOrgTree.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.GetOrgTreeReply), nil
+}
+
+func (s *grpcServer) UpdatePayorDetails(ctx context.Context, req *pb.UpdatePayorDetailsRequest) (*pb.UpdatePayorDetailsReply, error) {
+	_, resp, err := s.updatePayorDetails.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.UpdatePayorDetailsReply), nil
+}
+
+func (s *grpcServer) FindPayor(ctx context.Context, req *pb.FindPayorRequest) (*pb.FindPayorReply, error) {
+	_, resp, err := s.findPayor.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.FindPayorReply), nil
+}
+
+func (s *grpcServer) CreateInvite(ctx context.Context, req *pb.CreateInviteRequest) (*pb.CreateInviteReply, error) {
+	_, resp, err := s.createInvite.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.CreateInviteReply), nil
+}
+
+func (s *grpcServer) ListInvites(ctx context.Context, req *pb.ListInvitesRequest) (*pb.ListInvitesReply, error) {
+	_, resp, err := s.listInvites.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.ListInvitesReply), nil
+}
+
+func (s *grpcServer) RevokeInvite(ctx context.Context, req *pb.RevokeInviteRequest) (*pb.RevokeInviteReply, error) {
+	_, resp, err := s.revokeInvite.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.RevokeInviteReply), nil
+}
+
+func (s *grpcServer) ResendInvite(ctx context.Context, req *pb.ResendInviteRequest) (*pb.ResendInviteReply, error) {
+	_, resp, err := s.resendInvite.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.ResendInviteReply), nil
+}
+
+func (s *grpcServer) AcceptInvite(ctx context.Context, req *pb.AcceptInviteRequest) (*pb.AcceptInviteReply, error) {
+	_, resp, err := s.acceptInvite.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.AcceptInviteReply), nil
+}
+
+func (s *grpcServer) RequestNetworkRelationship(ctx context.Context, req *pb.RequestNetworkRelationshipRequest) (*pb.NetworkRelationshipReply, error) {
+	_, resp, err := s.requestNetworkRelationship.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.NetworkRelationshipReply), nil
+}
+
+func (s *grpcServer) AcceptNetworkRelationship(ctx context.Context, req *pb.NetworkRelationshipActionRequest) (*pb.NetworkRelationshipReply, error) {
+	_, resp, err := s.acceptNetworkRelationship.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.NetworkRelationshipReply), nil
+}
+
+func (s *grpcServer) TerminateNetworkRelationship(ctx context.Context, req *pb.NetworkRelationshipActionRequest) (*pb.NetworkRelationshipReply, error) {
+	_, resp, err := s.terminateNetworkRelationship.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.NetworkRelationshipReply), nil
+}
+
+func (s *grpcServer) ListPayors(ctx context.Context, req *pb.ListNetworkRequest) (*pb.ListNetworkReply, error) {
+	_, resp, err := s.listPayors.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.ListNetworkReply), nil
+}
+
+func (s *grpcServer) ListProviders(ctx context.Context, req *pb.ListNetworkRequest) (*pb.ListNetworkReply, error) {
+	_, resp, err := s.listProviders.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.ListNetworkReply), nil
+}
+
+func (s *grpcServer) QueryAuditLog(ctx context.Context, req *pb.QueryAuditLogRequest) (*pb.QueryAuditLogReply, error) {
+	_, resp, err := s.queryAuditLog.ServeGRPC(grpcClientIPToContext(ctx), req)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return resp.(*pb.QueryAuditLogReply), nil
+}
+
+// grpctransport.ServerBefore func, the gRPC version of requestIDMiddleware. The
+// request ID is read from (and echoed back in) the x-request-id metadata.
+func grpcRequestIDToContext(ctx context.Context, md metadata.MD) context.Context {
+	var id string
+	if ids := md.Get(RequestIDHeader); len(ids) > 0 {
+		id = ids[0]
+	}
+	if !validRequestID(id) {
+		id = newRequestID()
+	}
+
+	// Only fails if we aren't inside an rpc, in which case there's nobody to echo it to
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id))
+
+	return ContextWithRequestID(ctx, id)
+}
+
+// The gRPC version of clientIPToContext. ServerBefore funcs only get handed the
+// metadata, so this is called on the way in to each rpc instead.
+func grpcClientIPToContext(ctx context.Context) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ctx
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	return ContextWithClientIP(ctx, host)
+}
+
+// Turns whatever error came back from the endpoint into a gRPC status, the same
+// way EncodeError does for HTTP.
+func grpcError(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		grpc.SetTrailer(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(rateLimitErr.RetryAfter.Seconds())))))
+	}
+	if reason := ReasonFrom(err); reason != "" {
+		grpc.SetTrailer(ctx, metadata.Pairs("reason", reason))
+	}
+
+	return status.Error(GRPCCodeFrom(err), err.Error())
+}
+
+// The gRPC counterpart of CodeFrom
+func GRPCCodeFrom(err error) codes.Code {
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		return codes.ResourceExhausted
+	case errors.Is(err, ErrUnauthenticated):
+		return codes.Unauthenticated
+	case errors.Is(err, ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, ErrOrgHasMembers), errors.Is(err, ErrOrgHasChildren):
+		return codes.FailedPrecondition
+	case errors.Is(err, ErrNotFound):
+		return codes.NotFound
+	default:
+		return codes.InvalidArgument
+	}
+}
+
+// Business-logic errors ride along in the response struct (see Errorer), pull them
+// out so they can be returned as a gRPC status instead of a reply.
+func responseError(response interface{}) error {
+	if e, ok := response.(Errorer); ok {
+		return e.error()
+	}
+	return nil
+}
+
+func decodeGRPCCreateUserReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.CreateUserRequest)
+	return CreateUserRequest{
+		OrgID:     req.OrgId,
+		Username:  req.Username,
+		Password:  req.Password,
+		OrgType:   req.OrgType,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Email:     req.Email,
+		Phone:     req.Phone,
+	}, nil
+}
+
+func encodeGRPCCreateUserResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(CreateUserResponse)
+	return &pb.CreateUserReply{Id: resp.ID}, nil
+}
+
+func decodeGRPCGetUserReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GetUserRequest)
+	return GetUserRequest{ID: req.Id, Include: req.Include}, nil
+}
+
+func encodeGRPCGetUserResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	// Which one we get back depends on whether anything was included
+	switch resp := response.(type) {
+	case GetDetailedUserResponse:
+		return &pb.GetUserReply{
+			UserAccount: toPBUserAccount(resp.User.Account),
+			User:        toPBDetailedUser(resp.User),
+		}, nil
+	default:
+		return &pb.GetUserReply{UserAccount: toPBUserAccount(resp.(GetUserAccountResponse).UserAccount)}, nil
+	}
+}
+
+func decodeGRPCDeleteUserReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.DeleteUserRequest)
+	return DeleteUserRequest{ID: req.Id}, nil
+}
+
+func encodeGRPCDeleteUserResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(DeleteUserResponse)
+	return &pb.DeleteUserReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCUserStatusReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UserStatusRequest)
+	return UserStatusRequest{UserID: req.UserId}, nil
+}
+
+func encodeGRPCUserStatusResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(UserStatusResponse)
+	return &pb.UserStatusReply{Account: toPBUserAccount(resp.Account)}, nil
+}
+
+func decodeGRPCUpdateUserAccountReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UpdateAccountRequest)
+	return UpdateAccountRequest{
+		ID: req.Id,
+		Updates: AccountUpdates{
+			Username: req.GetAccountUpdates().GetUsername(),
+		},
+	}, nil
+}
+
+func encodeGRPCUpdateUserAccountResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(UpdateAccountResponse)
+	return &pb.UpdateAccountReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCLoginReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.LoginRequest)
+	return LoginRequest{
+		OrgID:    req.OrgId,
+		Username: req.Username,
+		Password: req.Password,
+	}, nil
+}
+
+func encodeGRPCLoginResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(LoginResponse)
+	if resp.MFA != nil {
+		return &pb.LoginReply{Mfa: toPBMFAChallenge(*resp.MFA)}, nil
+	}
+	return &pb.LoginReply{LoginDetails: toPBLoginUser(*resp.LoginDetails)}, nil
+}
+
+func decodeGRPCUpdateUserProfileReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UpdateProfileRequest)
+	updates := req.GetProfileUpdates()
+	return UpdateProfileRequest{
+		AccountID: req.AccountId,
+		Updates: ProfileUpdates{
+			FirstName: updates.GetFirstName(),
+			LastName:  updates.GetLastName(),
+			Email:     updates.GetEmail(),
+			Phone:     updates.GetPhone(),
+		},
+	}, nil
+}
+
+func encodeGRPCUpdateUserProfileResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(UpdateProfileResponse)
+	return &pb.UpdateProfileReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCGetSessionReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return GetSessionRequest{}, nil
+}
+
+func encodeGRPCGetSessionResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(GetSessionResponse)
+	return &pb.GetSessionReply{
+		Principal: &pb.Principal{
+			UserId:    resp.Principal.UserID,
+			OrgId:     resp.Principal.OrgID,
+			SessionId: resp.Principal.SessionID,
+		},
+	}, nil
+}
+
+func decodeGRPCSendEmailVerificationReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SendEmailVerificationRequest)
+	return SendEmailVerificationRequest{UserID: req.UserId}, nil
+}
+
+func encodeGRPCSendEmailVerificationResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(SendEmailVerificationResponse)
+	return &pb.SendEmailVerificationReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCVerifyEmailReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.VerifyEmailRequest)
+	if req.Token == "" {
+		return nil, errors.New("token is required")
+	}
+	return VerifyEmailRequest{UserID: req.UserId, Token: req.Token}, nil
+}
+
+func encodeGRPCVerifyEmailResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(VerifyEmailResponse)
+	return &pb.VerifyEmailReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCSendPhoneVerificationReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SendPhoneVerificationRequest)
+	return SendPhoneVerificationRequest{UserID: req.UserId}, nil
+}
+
+func encodeGRPCSendPhoneVerificationResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(SendPhoneVerificationResponse)
+	return &pb.SendPhoneVerificationReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCVerifyPhoneReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.VerifyPhoneRequest)
+	if req.Code == "" {
+		return nil, errors.New("code is required")
+	}
+	return VerifyPhoneRequest{UserID: req.UserId, Code: req.Code}, nil
+}
+
+func encodeGRPCVerifyPhoneResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(VerifyPhoneResponse)
+	return &pb.VerifyPhoneReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCRequestPasswordResetReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.RequestPasswordResetRequest)
+	return RequestPasswordResetRequest{Username: req.Username, Email: req.Email}, nil
+}
+
+func encodeGRPCRequestPasswordResetResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(RequestPasswordResetResponse)
+	return &pb.RequestPasswordResetReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCResetPasswordReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ResetPasswordRequest)
+	return ResetPasswordRequest{Token: req.Token, Password: req.Password}, nil
+}
+
+func encodeGRPCResetPasswordResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(ResetPasswordResponse)
+	return &pb.ResetPasswordReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCCompleteMFALoginReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.CompleteMFALoginRequest)
+	if req.Token == "" || req.Code == "" {
+		return nil, errors.New("token and code are required")
+	}
+	return CompleteMFALoginRequest{Token: req.Token, Code: req.Code}, nil
+}
+
+func encodeGRPCCompleteMFALoginResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(CompleteMFALoginResponse)
+	return &pb.CompleteMFALoginReply{LoginDetails: toPBLoginUser(resp.LoginDetails)}, nil
+}
+
+func decodeGRPCEnrollTOTPReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.EnrollTOTPRequest)
+	return EnrollTOTPRequest{UserID: req.UserId}, nil
+}
+
+func encodeGRPCEnrollTOTPResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(EnrollTOTPResponse)
+	return &pb.EnrollTOTPReply{Enrollment: toPBTOTPEnrollment(resp.Enrollment)}, nil
+}
+
+func decodeGRPCConfirmTOTPReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ConfirmTOTPRequest)
+	if req.Code == "" {
+		return nil, errors.New("code is required")
+	}
+	return ConfirmTOTPRequest{UserID: req.UserId, Code: req.Code}, nil
+}
+
+func encodeGRPCConfirmTOTPResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(ConfirmTOTPResponse)
+	return &pb.ConfirmTOTPReply{RecoveryCodes: resp.RecoveryCodes}, nil
+}
+
+func decodeGRPCDisableMFAReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.DisableMFARequest)
+	if req.Code == "" {
+		return nil, errors.New("code is required")
+	}
+	return DisableMFARequest{UserID: req.UserId, Code: req.Code}, nil
+}
+
+func encodeGRPCDisableMFAResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(DisableMFAResponse)
+	return &pb.DisableMFAReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCResetMFAReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ResetMFARequest)
+	return ResetMFARequest{UserID: req.UserId}, nil
+}
+
+func encodeGRPCResetMFAResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(ResetMFAResponse)
+	return &pb.ResetMFAReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCBeginPasskeyRegistrationReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.BeginPasskeyRegistrationRequest)
+	return BeginPasskeyRegistrationRequest{UserID: req.UserId}, nil
+}
+
+func encodeGRPCBeginPasskeyRegistrationResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(BeginPasskeyRegistrationResponse)
+	return &pb.BeginPasskeyRegistrationReply{Ceremony: toPBPasskeyCeremony(resp.Ceremony)}, nil
+}
+
+func decodeGRPCFinishPasskeyRegistrationReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.FinishPasskeyRegistrationRequest)
+	if req.Token == "" || req.Credential == "" {
+		return nil, errors.New("token and credential are required")
+	}
+	return FinishPasskeyRegistrationRequest{
+		UserID:     req.UserId,
+		Token:      req.Token,
+		Name:       req.Name,
+		Credential: json.RawMessage(req.Credential),
+	}, nil
+}
+
+func encodeGRPCFinishPasskeyRegistrationResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(FinishPasskeyRegistrationResponse)
+	return &pb.FinishPasskeyRegistrationReply{Passkey: toPBPasskey(resp.Passkey)}, nil
+}
+
+func decodeGRPCListPasskeysReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListPasskeysRequest)
+	return ListPasskeysRequest{UserID: req.UserId}, nil
+}
+
+func encodeGRPCListPasskeysResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(ListPasskeysResponse)
+	passkeys := make([]*pb.Passkey, len(resp.Passkeys))
+	for i, p := range resp.Passkeys {
+		passkeys[i] = toPBPasskey(p)
+	}
+	return &pb.ListPasskeysReply{Passkeys: passkeys}, nil
+}
+
+func decodeGRPCDeletePasskeyReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.DeletePasskeyRequest)
+	return DeletePasskeyRequest{UserID: req.UserId, PasskeyID: req.PasskeyId}, nil
+}
+
+func encodeGRPCDeletePasskeyResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(DeletePasskeyResponse)
+	return &pb.DeletePasskeyReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCBeginPasskeyLoginReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.BeginPasskeyLoginRequest)
+	return BeginPasskeyLoginRequest{OrgID: req.OrgId}, nil
+}
+
+func encodeGRPCBeginPasskeyLoginResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(BeginPasskeyLoginResponse)
+	return &pb.BeginPasskeyLoginReply{Ceremony: toPBPasskeyCeremony(resp.Ceremony)}, nil
+}
+
+func decodeGRPCPasskeyLoginReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.PasskeyLoginRequest)
+	if req.Token == "" || req.Credential == "" {
+		return nil, errors.New("token and credential are required")
+	}
+	return PasskeyLoginRequest{OrgID: req.OrgId, Token: req.Token, Credential: json.RawMessage(req.Credential)}, nil
+}
+
+func encodeGRPCPasskeyLoginResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(PasskeyLoginResponse)
+	return &pb.PasskeyLoginReply{LoginDetails: toPBLoginUser(resp.LoginDetails)}, nil
+}
+
+func decodeGRPCRequestMagicLinkReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.RequestMagicLinkRequest)
+	return RequestMagicLinkRequest{OrgID: req.OrgId, Username: req.Username, Email: req.Email}, nil
+}
+
+func encodeGRPCRequestMagicLinkResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(RequestMagicLinkResponse)
+	return &pb.RequestMagicLinkReply{Ok: resp.OK}, nil
+}
+
+// Answered by encodeGRPCLoginResp, same as logging in with a password
+func decodeGRPCMagicLinkLoginReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.MagicLinkLoginRequest)
+	if req.Token == "" {
+		return nil, errors.New("token is required")
+	}
+	return MagicLinkLoginRequest{OrgID: req.OrgId, Token: req.Token}, nil
+}
+
+func decodeGRPCRequestSMSCodeReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.RequestSMSCodeRequest)
+	return RequestSMSCodeRequest{OrgID: req.OrgId, Username: req.Username}, nil
+}
+
+func encodeGRPCRequestSMSCodeResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(RequestSMSCodeResponse)
+	return &pb.RequestSMSCodeReply{Challenge: &pb.SMSCodeChallenge{
+		Token:     resp.Challenge.Token,
+		ExpiresAt: formatTimestamp(resp.Challenge.ExpiresAt),
+	}}, nil
+}
+
+// Answered by encodeGRPCLoginResp too
+func decodeGRPCSMSCodeLoginReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SMSCodeLoginRequest)
+	if req.Token == "" || req.Code == "" {
+		return nil, errors.New("token and code are required")
+	}
+	return SMSCodeLoginRequest{OrgID: req.OrgId, Token: req.Token, Code: req.Code}, nil
+}
+
+func decodeGRPCLoginWithoutOrgReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.LoginWithoutOrgRequest)
+	return LoginWithoutOrgRequest{Username: req.Username, Password: req.Password}, nil
+}
+
+func encodeGRPCLoginWithoutOrgResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(LoginWithoutOrgResponse)
+	return &pb.LoginWithoutOrgReply{Selection: &pb.OrgSelection{
+		Token:     resp.Selection.Token,
+		ExpiresAt: formatTimestamp(resp.Selection.ExpiresAt),
+		Orgs:      toPBOrgMemberships(resp.Selection.Orgs),
+	}}, nil
+}
+
+// Answered by encodeGRPCLoginResp too
+func decodeGRPCChooseLoginOrgReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ChooseLoginOrgRequest)
+	if req.Token == "" || req.OrgId == "" {
+		return nil, errors.New("token and org_id are required")
+	}
+	return ChooseLoginOrgRequest{Token: req.Token, OrgID: req.OrgId}, nil
+}
+
+// Answered by encodeGRPCLoginResp too
+func decodeGRPCSwitchOrgReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SwitchOrgRequest)
+	if req.OrgId == "" {
+		return nil, errors.New("org_id is required")
+	}
+	return SwitchOrgRequest{OrgID: req.OrgId}, nil
+}
+
+func decodeGRPCListMyOrgsReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return ListMyOrgsRequest{}, nil
+}
+
+func encodeGRPCListMyOrgsResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(ListMyOrgsResponse)
+	return &pb.ListMyOrgsReply{Orgs: toPBOrgMemberships(resp.Orgs)}, nil
+}
+
+func decodeGRPCCreateOrgReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.CreateOrgRequest)
+	createReq := CreateOrgRequest{
+		Name:     req.Name,
+		Type:     req.Type,
+		ParentID: req.ParentId,
+		Phone:    req.Phone,
+		Address:  req.Address,
+		Timezone: req.Timezone,
+		Website:  req.Website,
+	}
+	if req.ProviderDetails != nil {
+		createReq.ProviderDetails = &ProviderDetails{
+			NPI:   req.ProviderDetails.Npi,
+			TaxID: req.ProviderDetails.TaxId,
+		}
+	}
+	if req.PayorDetails != nil {
+		payorDetails := fromPBPayorDetails(req.PayorDetails)
+		createReq.PayorDetails = &payorDetails
+	}
+	return createReq, nil
+}
+
+func encodeGRPCCreateOrgResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(CreateOrgResponse)
+	return &pb.CreateOrgReply{Id: resp.ID}, nil
+}
+
+func decodeGRPCUpdatePayorDetailsReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UpdatePayorDetailsRequest)
+	updateReq := UpdatePayorDetailsRequest{ID: req.Id}
+	if req.PayorDetails != nil {
+		updateReq.Details = fromPBPayorDetails(req.PayorDetails)
+	}
+	return updateReq, nil
+}
+
+func encodeGRPCUpdatePayorDetailsResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(UpdatePayorDetailsResponse)
+	return &pb.UpdatePayorDetailsReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCFindPayorReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.FindPayorRequest)
+	return FindPayorRequest{PayerID: req.PayerId}, nil
+}
+
+func encodeGRPCFindPayorResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(FindPayorResponse)
+	return &pb.FindPayorReply{Org: toPBDetailedOrg(resp.Org)}, nil
+}
+
+func decodeGRPCGetOrgReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GetOrgRequest)
+	return GetOrgRequest{ID: req.Id}, nil
+}
+
+func encodeGRPCGetOrgResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(GetOrgResponse)
+	return &pb.GetOrgReply{Org: toPBDetailedOrg(resp.Org)}, nil
+}
+
+func decodeGRPCUpdateOrgAccountReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UpdateOrgAccountRequest)
+	return UpdateOrgAccountRequest{
+		ID: req.Id,
+		Updates: OrgAccountUpdates{
+			Name:            req.GetAccountUpdates().GetName(),
+			MFARequired:     req.GetAccountUpdates().MfaRequired,
+			PasskeyRequired: req.GetAccountUpdates().PasskeyRequired,
+			ParentID:        req.GetAccountUpdates().ParentId,
+		},
+	}, nil
+}
+
+func encodeGRPCUpdateOrgAccountResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(UpdateOrgAccountResponse)
+	return &pb.UpdateOrgAccountReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCUpdateOrgProfileReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UpdateOrgProfileRequest)
+	updates := req.GetProfileUpdates()
+	return UpdateOrgProfileRequest{
+		ID: req.Id,
+		Updates: OrgProfileUpdates{
+			Phone:    updates.GetPhone(),
+			Address:  updates.GetAddress(),
+			Timezone: updates.GetTimezone(),
+			Website:  updates.GetWebsite(),
+		},
+	}, nil
+}
+
+func encodeGRPCUpdateOrgProfileResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(UpdateOrgProfileResponse)
+	return &pb.UpdateOrgProfileReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCDeleteOrgReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.DeleteOrgRequest)
+	return DeleteOrgRequest{ID: req.Id, Force: req.Force}, nil
+}
+
+func encodeGRPCDeleteOrgResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(DeleteOrgResponse)
+	return &pb.DeleteOrgReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCOrgStatusReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.OrgStatusRequest)
+	return OrgStatusRequest{OrgID: req.OrgId}, nil
+}
+
+func encodeGRPCOrgStatusResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(OrgStatusResponse)
+	return &pb.OrgStatusReply{Account: toPBOrgAccount(resp.Account)}, nil
+}
+
+func decodeGRPCListOrgUsersReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListOrgUsersRequest)
+	return ListOrgUsersRequest{
+		OrgID: req.OrgId,
+		Query: OrgUserQuery{
+			Role:   req.Role,
+			Status: req.Status,
+			Text:   req.Q,
+			Sort:   req.Sort,
+			Desc:   req.Desc,
+			Limit:  int(req.Limit),
+			Cursor: req.Cursor,
+		},
+	}, nil
+}
+
+func encodeGRPCListOrgUsersResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(ListOrgUsersResponse)
+	users := make([]*pb.OrgMember, len(resp.Users))
+	for i, member := range resp.Users {
+		users[i] = &pb.OrgMember{
+			Account: toPBUserAccount(member.Account),
+			Profile: toPBUserProfile(member.Profile),
+			Role:    member.Role,
+		}
+	}
+	return &pb.ListOrgUsersReply{
+		Users:      users,
+		Total:      int32(resp.Total),
+		NextCursor: resp.NextCursor,
+		PrevCursor: resp.PrevCursor,
+	}, nil
+}
+
+func decodeGRPCListChildOrgsReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListChildOrgsRequest)
+	return ListChildOrgsRequest{OrgID: req.OrgId}, nil
+}
+
+func encodeGRPCListChildOrgsResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(ListChildOrgsResponse)
+	return &pb.ListChildOrgsReply{Orgs: toPBOrgAccounts(resp.Orgs)}, nil
+}
+
+func decodeGRPCGetOrgTreeReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GetOrgTreeRequest)
+	return GetOrgTreeRequest{OrgID: req.OrgId}, nil
+}
+
+func encodeGRPCGetOrgTreeResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(GetOrgTreeResponse)
+	return &pb.GetOrgTreeReply{
+		Ancestors: toPBOrgAccounts(resp.Ancestors),
+		Tree:      toPBOrgTree(resp.Tree),
+	}, nil
+}
+
+func decodeGRPCCreateInviteReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.CreateInviteRequest)
+	return CreateInviteRequest{OrgID: req.OrgId, Email: req.Email, Role: req.Role}, nil
+}
+
+func encodeGRPCCreateInviteResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(CreateInviteResponse)
+	return &pb.CreateInviteReply{Invite: toPBInvite(resp.Invite), Token: resp.Token}, nil
+}
+
+func decodeGRPCListInvitesReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListInvitesRequest)
+	return ListInvitesRequest{OrgID: req.OrgId}, nil
+}
+
+func encodeGRPCListInvitesResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(ListInvitesResponse)
+	invites := make([]*pb.Invite, len(resp.Invites))
+	for i, invite := range resp.Invites {
+		invites[i] = toPBInvite(invite)
+	}
+	return &pb.ListInvitesReply{Invites: invites}, nil
+}
+
+func decodeGRPCRevokeInviteReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.RevokeInviteRequest)
+	return RevokeInviteRequest{OrgID: req.OrgId, InviteID: req.InviteId}, nil
+}
+
+func encodeGRPCRevokeInviteResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(RevokeInviteResponse)
+	return &pb.RevokeInviteReply{Ok: resp.OK}, nil
+}
+
+func decodeGRPCResendInviteReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ResendInviteRequest)
+	return ResendInviteRequest{OrgID: req.OrgId, InviteID: req.InviteId}, nil
+}
+
+func encodeGRPCResendInviteResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(ResendInviteResponse)
+	return &pb.ResendInviteReply{Invite: toPBInvite(resp.Invite), Token: resp.Token}, nil
+}
+
+func decodeGRPCAcceptInviteReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.AcceptInviteRequest)
+	if req.Token == "" {
+		return nil, errors.New("token is required")
+	}
+	return AcceptInviteRequest{
+		Token:     req.Token,
+		Username:  req.Username,
+		Password:  req.Password,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Phone:     req.Phone,
+	}, nil
+}
+
+func encodeGRPCAcceptInviteResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(AcceptInviteResponse)
+	return &pb.AcceptInviteReply{UserId: resp.UserID}, nil
+}
+
+func decodeGRPCRequestNetworkRelationshipReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.RequestNetworkRelationshipRequest)
+	return RequestNetworkRelationshipRequest{
+		OrgID:           req.OrgId,
+		PartnerID:       req.PartnerId,
+		EffectiveDate:   req.EffectiveDate,
+		TerminationDate: req.TerminationDate,
+	}, nil
+}
+
+func decodeGRPCNetworkRelationshipActionReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.NetworkRelationshipActionRequest)
+	return NetworkRelationshipActionRequest{OrgID: req.OrgId, RelationshipID: req.RelationshipId}, nil
+}
+
+func encodeGRPCNetworkRelationshipResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(NetworkRelationshipResponse)
+	return &pb.NetworkRelationshipReply{Relationship: toPBNetworkRelationship(resp.Relationship)}, nil
+}
+
+func decodeGRPCListNetworkReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListNetworkRequest)
+	return ListNetworkRequest{
+		OrgID: req.OrgId,
+		Query: NetworkQuery{Status: req.Status, EffectiveOn: req.EffectiveOn},
+	}, nil
+}
+
+func encodeGRPCListNetworkResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(ListNetworkResponse)
+	relationships := make([]*pb.NetworkRelationship, len(resp.Relationships))
+	for i, relationship := range resp.Relationships {
+		relationships[i] = toPBNetworkRelationship(relationship)
+	}
+	return &pb.ListNetworkReply{Relationships: relationships}, nil
+}
+
+func decodeGRPCQueryAuditLogReq(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.QueryAuditLogRequest)
+	query := AuditQuery{
+		OrgID:    req.OrgId,
+		ActorID:  req.ActorId,
+		TargetID: req.TargetId,
+		Action:   req.Action,
+		Limit:    int(req.Limit),
+	}
+	var err error
+	if query.Since, err = parseTimestamp("since", req.Since); err != nil {
+		return nil, err
+	}
+	if query.Until, err = parseTimestamp("until", req.Until); err != nil {
+		return nil, err
+	}
+	return QueryAuditLogRequest{Query: query}, nil
+}
+
+func encodeGRPCQueryAuditLogResp(_ context.Context, response interface{}) (interface{}, error) {
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+	resp := response.(QueryAuditLogResponse)
+	events := make([]*pb.AuditEvent, len(resp.Events))
+	for i, event := range resp.Events {
+		events[i] = toPBAuditEvent(event)
+	}
+	return &pb.QueryAuditLogReply{Events: events}, nil
+}
+
+// Helpers for going from our types to their protobuf twins
+
+// Timestamps go out as RFC 3339 strings rather than google.protobuf.Timestamp, so
+// they keep the org's timezone the same as they do over HTTP. A zero time is "".
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func formatTimestampPtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTimestamp(*t)
+}
+
+// The other way round from formatTimestamp, an empty string is the zero time
+func parseTimestamp(name string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q, expected an RFC 3339 time", name, value)
+	}
+	return t, nil
+}
+
+func toPBUserAccount(a UserAccount) *pb.UserAccount {
+	return &pb.UserAccount{
+		Id:        a.ID,
+		Username:  a.Username,
+		OrgType:   a.OrgType,
+		JoinedOn:  formatTimestamp(a.JoinedOn),
+		Status:    a.Status,
+		DeletedAt: formatTimestampPtr(a.DeletedAt),
+	}
+}
+
+func toPBUserProfile(p UserProfile) *pb.UserProfile {
+	return &pb.UserProfile{
+		AccountId:       p.AccountID,
+		FirstName:       p.FirstName,
+		LastName:        p.LastName,
+		Email:           p.Email,
+		Phone:           p.Phone,
+		LastLogin:       formatTimestampPtr(p.LastLogin),
+		EmailVerifiedAt: formatTimestampPtr(p.EmailVerifiedAt),
+		PhoneVerifiedAt: formatTimestampPtr(p.PhoneVerifiedAt),
+	}
+}
+
+func toPBDetailedUser(u DetailedUser) *pb.DetailedUser {
+	return &pb.DetailedUser{
+		Account: toPBUserAccount(u.Account),
+		Profile: toPBUserProfile(u.Profile),
+		Orgs:    toPBOrgMemberships(u.Orgs),
+	}
+}
+
+func toPBOrgMemberships(memberships []OrgMembership) []*pb.OrgMembership {
+	orgs := make([]*pb.OrgMembership, len(memberships))
+	for i, org := range memberships {
+		orgs[i] = &pb.OrgMembership{
+			OrgId:   org.OrgID,
+			OrgName: org.OrgName,
+			OrgType: org.OrgType,
+			Role:    org.Role,
+		}
+	}
+	return orgs
+}
+
+func toPBOrgAccount(a OrgAccount) *pb.OrgAccount {
+	return &pb.OrgAccount{
+		Id:              a.ID,
+		Name:            a.Name,
+		Type:            a.Type,
+		JoinedOn:        formatTimestamp(a.JoinedOn),
+		MfaRequired:     a.MFARequired,
+		PasskeyRequired: a.PasskeyRequired,
+		ParentId:        a.ParentID,
+		Status:          a.Status,
+		DeletedAt:       formatTimestampPtr(a.DeletedAt),
+	}
+}
+
+func toPBOrgAccounts(accounts []OrgAccount) []*pb.OrgAccount {
+	orgs := make([]*pb.OrgAccount, len(accounts))
+	for i, account := range accounts {
+		orgs[i] = toPBOrgAccount(account)
+	}
+	return orgs
+}
+
+func toPBOrgTree(t OrgTree) *pb.OrgTree {
+	children := make([]*pb.OrgTree, len(t.Children))
+	for i, child := range t.Children {
+		children[i] = toPBOrgTree(child)
+	}
+	return &pb.OrgTree{Org: toPBOrgAccount(t.Org), Children: children}
+}
+
+func toPBDetailedOrg(o DetailedOrg) *pb.DetailedOrg {
+	org := &pb.DetailedOrg{
+		Account: toPBOrgAccount(o.Account),
+		Profile: &pb.OrgProfile{
+			AccountId: o.Profile.AccountID,
+			Phone:     o.Profile.Phone,
+			Address:   o.Profile.Address,
+			Timezone:  o.Profile.Timezone,
+			Website:   o.Profile.Website,
+		},
+	}
+	if o.ProviderDetails != nil {
+		org.ProviderDetails = &pb.ProviderDetails{
+			AccountId: o.ProviderDetails.AccountID,
+			Npi:       o.ProviderDetails.NPI,
+			TaxId:     o.ProviderDetails.TaxID,
+		}
+	}
+	if o.PayorDetails != nil {
+		org.PayorDetails = toPBPayorDetails(*o.PayorDetails)
+	}
+	return org
+}
+
+func toPBPayorDetails(d PayorDetails) *pb.PayorDetails {
+	payerIDs := make([]*pb.PayerID, len(d.PayerIDs))
+	for i, payerID := range d.PayerIDs {
+		payerIDs[i] = &pb.PayerID{PayerId: payerID.PayerID, Label: payerID.Label}
+	}
+	return &pb.PayorDetails{AccountId: d.AccountID, PayerIds: payerIDs}
+}
+
+func fromPBPayorDetails(d *pb.PayorDetails) PayorDetails {
+	details := PayorDetails{AccountID: d.AccountId}
+	for _, payerID := range d.PayerIds {
+		details.PayerIDs = append(details.PayerIDs, PayerID{PayerID: payerID.PayerId, Label: payerID.Label})
+	}
+	return details
+}
+
+func toPBLoginUser(l LoginUser) *pb.LoginUser {
+	return &pb.LoginUser{
+		User: toPBDetailedUser(l.User),
+		Org:  toPBDetailedOrg(l.Org),
+		Session: &pb.SessionToken{
+			Token:     l.Session.Token,
+			ExpiresAt: timestamppb.New(l.Session.ExpiresAt),
+		},
+		RecoveryCodes: l.RecoveryCodes,
+	}
+}
+
+func toPBTOTPEnrollment(e TOTPEnrollment) *pb.TOTPEnrollment {
+	return &pb.TOTPEnrollment{Secret: e.Secret, OtpauthUri: e.URI}
+}
+
+func toPBMFAChallenge(c MFAChallenge) *pb.MFAChallenge {
+	challenge := &pb.MFAChallenge{
+		Token:     c.Token,
+		ExpiresAt: timestamppb.New(c.ExpiresAt),
+	}
+	if c.Enrollment != nil {
+		challenge.Enrollment = toPBTOTPEnrollment(*c.Enrollment)
+	}
+	return challenge
+}
+
+func toPBInvite(i Invite) *pb.Invite {
+	return &pb.Invite{
+		Id:         i.ID,
+		OrgId:      i.OrgID,
+		Email:      i.Email,
+		Role:       i.Role,
+		InvitedBy:  i.InvitedBy,
+		CreatedAt:  formatTimestamp(i.CreatedAt),
+		ExpiresAt:  formatTimestamp(i.ExpiresAt),
+		AcceptedAt: formatTimestampPtr(i.AcceptedAt),
+		AcceptedBy: i.AcceptedBy,
+		RevokedAt:  formatTimestampPtr(i.RevokedAt),
+		Status:     i.Status,
+	}
+}
+
+func toPBNetworkRelationship(r NetworkRelationship) *pb.NetworkRelationship {
+	return &pb.NetworkRelationship{
+		Id:              r.ID,
+		ProviderId:      r.ProviderID,
+		ProviderName:    r.ProviderName,
+		PayorId:         r.PayorID,
+		PayorName:       r.PayorName,
+		Status:          r.Status,
+		EffectiveDate:   r.EffectiveDate,
+		TerminationDate: r.TerminationDate,
+		RequestedBy:     r.RequestedBy,
+		RequestedAt:     formatTimestamp(r.RequestedAt),
+		AcceptedAt:      formatTimestampPtr(r.AcceptedAt),
+		TerminatedAt:    formatTimestampPtr(r.TerminatedAt),
+	}
+}
+
+func toPBAuditEvent(e AuditEvent) *pb.AuditEvent {
+	changes := make([]*pb.AuditChange, len(e.Changes))
+	for i, change := range e.Changes {
+		changes[i] = &pb.AuditChange{Field: change.Field, Before: change.Before, After: change.After}
+	}
+	return &pb.AuditEvent{
+		Seq:        e.Seq,
+		Id:         e.ID,
+		Time:       formatTimestamp(e.Time),
+		ActorId:    e.ActorID,
+		OrgId:      e.OrgID,
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetId:   e.TargetID,
+		Outcome:    e.Outcome,
+		Error:      e.Error,
+		Changes:    changes,
+		RequestId:  e.RequestID,
+		ClientIp:   e.ClientIP,
+		PrevHash:   e.PrevHash,
+		Hash:       e.Hash,
+	}
+}
+
+func toPBPasskey(p Passkey) *pb.Passkey {
+	return &pb.Passkey{
+		Id:             p.ID,
+		Name:           p.Name,
+		Transports:     p.Transports,
+		BackupEligible: p.BackupEligible,
+		CreatedAt:      formatTimestamp(p.CreatedAt),
+		LastUsedAt:     formatTimestampPtr(p.LastUsedAt),
+	}
+}
+
+func toPBPasskeyCeremony(c PasskeyCeremony) *pb.PasskeyCeremony {
+	return &pb.PasskeyCeremony{Token: c.Token, Options: string(c.Options)}
+}
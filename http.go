@@ -3,7 +3,13 @@ package accountsrv
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
@@ -25,17 +31,79 @@ the Transport request into a Service-friendly format to then safely invoke the S
 func NewHTTPServer(ctx context.Context, endpoints Endpoints) http.Handler {
 	// Init the router
 	router := mux.NewRouter()
+	// Tag every request with a request ID first so everything downstream (including
+	// errors) can be correlated back to it.
+	router.Use(requestIDMiddleware)
 	// Have the router use the middleware we defined, in this case it simply
 	// adds the content-type:application/json header to each of our responses.
 	router.Use(commonMiddleware)
 
+	// Options shared by every handler, so transport-level failures (e.g. a body that
+	// won't decode) come back in the same JSON error shape as business-logic errors.
+	options := []httptransport.ServerOption{
+		httptransport.ServerErrorEncoder(EncodeError),
+		httptransport.ServerBefore(clientIPToContext, httpAuthTokenToContext),
+	}
+
 	// TODO: Subrouting
 
+	// The OpenAPI document describing everything below
+	router.Methods("GET").Path("/openapi.json").HandlerFunc(openAPIHandler)
+
 	router.Methods("GET").Path("/users/{id}").Handler(
 		httptransport.NewServer(
 			endpoints.GetUser,
 			DecodeGetUserReq,
 			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("DELETE").Path("/users/{id}").Handler(
+		httptransport.NewServer(
+			endpoints.DeleteUser,
+			DecodeDeleteUserReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/suspend").Handler(
+		httptransport.NewServer(
+			endpoints.SuspendUser,
+			DecodeUserStatusReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/deactivate").Handler(
+		httptransport.NewServer(
+			endpoints.DeactivateUser,
+			DecodeUserStatusReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/reactivate").Handler(
+		httptransport.NewServer(
+			endpoints.ReactivateUser,
+			DecodeUserStatusReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/restore").Handler(
+		httptransport.NewServer(
+			endpoints.RestoreUser,
+			DecodeUserStatusReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("PATCH").Path("/users/{id}").Handler(
+		httptransport.NewServer(
+			endpoints.UpdateUserAccount,
+			DecodeUpdateUserAccountReq,
+			EncodeResponse,
+			options...,
 		))
 
 	router.Methods("PUT").Path("/users/{id}/profile").Handler(
@@ -43,6 +111,167 @@ func NewHTTPServer(ctx context.Context, endpoints Endpoints) http.Handler {
 			endpoints.UpdateUserProfile,
 			DecodeUpdateUserProfileReq,
 			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/email/verification").Handler(
+		httptransport.NewServer(
+			endpoints.SendEmailVerification,
+			DecodeSendEmailVerificationReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/email/verify").Handler(
+		httptransport.NewServer(
+			endpoints.VerifyEmail,
+			DecodeVerifyEmailReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/phone/verification").Handler(
+		httptransport.NewServer(
+			endpoints.SendPhoneVerification,
+			DecodeSendPhoneVerificationReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/phone/verify").Handler(
+		httptransport.NewServer(
+			endpoints.VerifyPhone,
+			DecodeVerifyPhoneReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/login").Handler(
+		httptransport.NewServer(
+			endpoints.LoginWithoutOrg,
+			DecodeLoginWithoutOrgReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/login/org").Handler(
+		httptransport.NewServer(
+			endpoints.ChooseLoginOrg,
+			DecodeChooseLoginOrgReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/login/mfa").Handler(
+		httptransport.NewServer(
+			endpoints.CompleteMFALogin,
+			DecodeCompleteMFALoginReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/mfa/totp").Handler(
+		httptransport.NewServer(
+			endpoints.EnrollTOTP,
+			DecodeEnrollTOTPReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/mfa/totp/confirm").Handler(
+		httptransport.NewServer(
+			endpoints.ConfirmTOTP,
+			DecodeConfirmTOTPReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/mfa/disable").Handler(
+		httptransport.NewServer(
+			endpoints.DisableMFA,
+			DecodeDisableMFAReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/mfa/reset").Handler(
+		httptransport.NewServer(
+			endpoints.ResetMFA,
+			DecodeResetMFAReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/passkeys/registration").Handler(
+		httptransport.NewServer(
+			endpoints.BeginPasskeyRegistration,
+			DecodeBeginPasskeyRegistrationReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/users/{id}/passkeys").Handler(
+		httptransport.NewServer(
+			endpoints.FinishPasskeyRegistration,
+			DecodeFinishPasskeyRegistrationReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("GET").Path("/users/{id}/passkeys").Handler(
+		httptransport.NewServer(
+			endpoints.ListPasskeys,
+			DecodeListPasskeysReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("DELETE").Path("/users/{id}/passkeys/{passkey_id}").Handler(
+		httptransport.NewServer(
+			endpoints.DeletePasskey,
+			DecodeDeletePasskeyReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/password-resets").Handler(
+		httptransport.NewServer(
+			endpoints.RequestPasswordReset,
+			DecodeRequestPasswordResetReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/password-resets/{token}").Handler(
+		httptransport.NewServer(
+			endpoints.ResetPassword,
+			DecodeResetPasswordReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("GET").Path("/session").Handler(
+		httptransport.NewServer(
+			endpoints.GetSession,
+			DecodeGetSessionReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/session/org").Handler(
+		httptransport.NewServer(
+			endpoints.SwitchOrg,
+			DecodeSwitchOrgReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("GET").Path("/session/orgs").Handler(
+		httptransport.NewServer(
+			endpoints.ListMyOrgs,
+			DecodeListMyOrgsReq,
+			EncodeResponse,
+			options...,
 		))
 
 	router.Methods("POST").Path("/orgs").Handler(
@@ -50,6 +279,71 @@ func NewHTTPServer(ctx context.Context, endpoints Endpoints) http.Handler {
 			endpoints.CreateOrg,
 			DecodeCreateOrgReq,
 			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("GET").Path("/orgs/{org_id}").Handler(
+		httptransport.NewServer(
+			endpoints.GetOrg,
+			DecodeGetOrgReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("PATCH").Path("/orgs/{org_id}").Handler(
+		httptransport.NewServer(
+			endpoints.UpdateOrgAccount,
+			DecodeUpdateOrgAccountReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("PATCH").Path("/orgs/{org_id}/profile").Handler(
+		httptransport.NewServer(
+			endpoints.UpdateOrgProfile,
+			DecodeUpdateOrgProfileReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("DELETE").Path("/orgs/{org_id}").Handler(
+		httptransport.NewServer(
+			endpoints.DeleteOrg,
+			DecodeDeleteOrgReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/suspend").Handler(
+		httptransport.NewServer(
+			endpoints.SuspendOrg,
+			DecodeOrgStatusReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/deactivate").Handler(
+		httptransport.NewServer(
+			endpoints.DeactivateOrg,
+			DecodeOrgStatusReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/reactivate").Handler(
+		httptransport.NewServer(
+			endpoints.ReactivateOrg,
+			DecodeOrgStatusReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/restore").Handler(
+		httptransport.NewServer(
+			endpoints.RestoreOrg,
+			DecodeOrgStatusReq,
+			EncodeResponse,
+			options...,
 		))
 
 	// Instead of passing in the Endpoint directly, we instead
@@ -60,6 +354,55 @@ func NewHTTPServer(ctx context.Context, endpoints Endpoints) http.Handler {
 			endpoints.LoginUser,
 			DecodeLoginReq,
 			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/login/passkey/begin").Handler(
+		httptransport.NewServer(
+			endpoints.BeginPasskeyLogin,
+			DecodeBeginPasskeyLoginReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/login/passkey").Handler(
+		httptransport.NewServer(
+			endpoints.PasskeyLogin,
+			DecodePasskeyLoginReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/login/magic-links").Handler(
+		httptransport.NewServer(
+			endpoints.RequestMagicLink,
+			DecodeRequestMagicLinkReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/login/magic-links/{token}").Handler(
+		httptransport.NewServer(
+			endpoints.MagicLinkLogin,
+			DecodeMagicLinkLoginReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/login/sms").Handler(
+		httptransport.NewServer(
+			endpoints.RequestSMSCode,
+			DecodeRequestSMSCodeReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/login/sms/verify").Handler(
+		httptransport.NewServer(
+			endpoints.SMSCodeLogin,
+			DecodeSMSCodeLoginReq,
+			EncodeResponse,
+			options...,
 		))
 
 	router.Methods("POST").Path("/orgs/{org_id}/users").Handler(
@@ -67,6 +410,135 @@ func NewHTTPServer(ctx context.Context, endpoints Endpoints) http.Handler {
 			endpoints.CreateUser, // The endpoint itself
 			DecodeCreateUserReq,  // How we want to "decode" the request, i.e. take the HTTP request and cast it into something the Endpoint/service can use
 			EncodeResponse,       // How we want to "encode" the resulting response our Endpoint returns (this case as JSON)
+			options...,
+		))
+
+	router.Methods("PUT").Path("/orgs/{org_id}/payor-details").Handler(
+		httptransport.NewServer(
+			endpoints.UpdatePayorDetails,
+			DecodeUpdatePayorDetailsReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("GET").Path("/payors").Handler(
+		httptransport.NewServer(
+			endpoints.FindPayor,
+			DecodeFindPayorReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("GET").Path("/orgs/{org_id}/users").Handler(
+		httptransport.NewServer(
+			endpoints.ListOrgUsers,
+			DecodeListOrgUsersReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("GET").Path("/orgs/{org_id}/children").Handler(
+		httptransport.NewServer(
+			endpoints.ListChildOrgs,
+			DecodeListChildOrgsReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("GET").Path("/orgs/{org_id}/tree").Handler(
+		httptransport.NewServer(
+			endpoints.GetOrgTree,
+			DecodeGetOrgTreeReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/invites").Handler(
+		httptransport.NewServer(
+			endpoints.CreateInvite,
+			DecodeCreateInviteReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("GET").Path("/orgs/{org_id}/invites").Handler(
+		httptransport.NewServer(
+			endpoints.ListInvites,
+			DecodeListInvitesReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("DELETE").Path("/orgs/{org_id}/invites/{invite_id}").Handler(
+		httptransport.NewServer(
+			endpoints.RevokeInvite,
+			DecodeRevokeInviteReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/invites/{invite_id}/resend").Handler(
+		httptransport.NewServer(
+			endpoints.ResendInvite,
+			DecodeResendInviteReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/invites/accept").Handler(
+		httptransport.NewServer(
+			endpoints.AcceptInvite,
+			DecodeAcceptInviteReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/network").Handler(
+		httptransport.NewServer(
+			endpoints.RequestNetworkRelationship,
+			DecodeRequestNetworkRelationshipReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/network/{relationship_id}/accept").Handler(
+		httptransport.NewServer(
+			endpoints.AcceptNetworkRelationship,
+			DecodeAcceptNetworkRelationshipReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("POST").Path("/orgs/{org_id}/network/{relationship_id}/terminate").Handler(
+		httptransport.NewServer(
+			endpoints.TerminateNetworkRelationship,
+			DecodeTerminateNetworkRelationshipReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("GET").Path("/orgs/{org_id}/payors").Handler(
+		httptransport.NewServer(
+			endpoints.ListPayors,
+			DecodeListPayorsReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("GET").Path("/orgs/{org_id}/providers").Handler(
+		httptransport.NewServer(
+			endpoints.ListProviders,
+			DecodeListProvidersReq,
+			EncodeResponse,
+			options...,
+		))
+
+	router.Methods("GET").Path("/orgs/{org_id}/audit").Handler(
+		httptransport.NewServer(
+			endpoints.QueryAuditLog,
+			DecodeQueryAuditLogReq,
+			EncodeResponse,
+			options...,
 		))
 
 	return router
@@ -99,6 +571,10 @@ func EncodeResponse(ctx context.Context, respWriter http.ResponseWriter, respons
 		return nil
 	}
 	respWriter.Header().Set("Content-Type", "application/json; charset=utf-8")
+	// Most responses are a plain 200, the few that aren't say so
+	if sc, ok := response.(httptransport.StatusCoder); ok {
+		respWriter.WriteHeader(sc.StatusCode())
+	}
 	return json.NewEncoder(respWriter).Encode(response)
 }
 
@@ -139,10 +615,46 @@ func DecodeGetUserReq(ctx context.Context, req *http.Request) (interface{}, erro
 		ID: pathVars["id"],
 	}
 
+	// ?include=profile,orgs (or repeated ?include=profile&include=orgs)
+	for _, include := range req.URL.Query()["include"] {
+		for _, part := range strings.Split(include, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				userReq.Include = append(userReq.Include, part)
+			}
+		}
+	}
+
 	return userReq, nil
 
 }
 
+func DecodeDeleteUserReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return DeleteUserRequest{ID: pathVars["id"]}, nil
+}
+
+// For all of suspending, deactivating, reactivating and restoring, which only
+// differ in the path
+func DecodeUserStatusReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return UserStatusRequest{UserID: pathVars["id"]}, nil
+}
+
+func DecodeUpdateUserAccountReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var updatesReq UpdateAccountRequest
+
+	err := json.NewDecoder(req.Body).Decode(&updatesReq.Updates)
+
+	if err != nil {
+		return updatesReq, err
+	}
+
+	updatesReq.ID = pathVars["id"]
+
+	return updatesReq, nil
+}
+
 func DecodeLoginReq(ctx context.Context, req *http.Request) (interface{}, error) {
 	var loginReq LoginRequest
 
@@ -172,10 +684,266 @@ func DecodeUpdateUserProfileReq(ctx context.Context, req *http.Request) (interfa
 	return updatesReq, nil
 }
 
-func DecodeCreateOrgReq(ctx context.Context, req *http.Request) (interface{}, error) {
-	var orgReq CreateOrgRequest
+func DecodeSendEmailVerificationReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return SendEmailVerificationRequest{UserID: pathVars["id"]}, nil
+}
 
-	err := json.NewDecoder(req.Body).Decode(&orgReq)
+func DecodeVerifyEmailReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var verifyReq VerifyEmailRequest
+
+	err := json.NewDecoder(req.Body).Decode(&verifyReq)
+	if err != nil {
+		return nil, err
+	}
+	if verifyReq.Token == "" {
+		return nil, errors.New("token is required")
+	}
+
+	verifyReq.UserID = pathVars["id"]
+
+	return verifyReq, nil
+}
+
+func DecodeSendPhoneVerificationReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return SendPhoneVerificationRequest{UserID: pathVars["id"]}, nil
+}
+
+func DecodeVerifyPhoneReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var verifyReq VerifyPhoneRequest
+
+	err := json.NewDecoder(req.Body).Decode(&verifyReq)
+	if err != nil {
+		return nil, err
+	}
+	if verifyReq.Code == "" {
+		return nil, errors.New("code is required")
+	}
+
+	verifyReq.UserID = pathVars["id"]
+
+	return verifyReq, nil
+}
+
+func DecodeRequestPasswordResetReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	var resetReq RequestPasswordResetRequest
+
+	err := json.NewDecoder(req.Body).Decode(&resetReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return resetReq, nil
+}
+
+func DecodeResetPasswordReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var resetReq ResetPasswordRequest
+
+	err := json.NewDecoder(req.Body).Decode(&resetReq)
+	if err != nil {
+		return nil, err
+	}
+
+	resetReq.Token = pathVars["token"]
+
+	return resetReq, nil
+}
+
+func DecodeLoginWithoutOrgReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	var loginReq LoginWithoutOrgRequest
+
+	err := json.NewDecoder(req.Body).Decode(&loginReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return loginReq, nil
+}
+
+func DecodeChooseLoginOrgReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	var chooseReq ChooseLoginOrgRequest
+
+	err := json.NewDecoder(req.Body).Decode(&chooseReq)
+	if err != nil {
+		return nil, err
+	}
+	if chooseReq.Token == "" || chooseReq.OrgID == "" {
+		return nil, errors.New("token and org_id are required")
+	}
+
+	return chooseReq, nil
+}
+
+func DecodeCompleteMFALoginReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	var mfaReq CompleteMFALoginRequest
+
+	err := json.NewDecoder(req.Body).Decode(&mfaReq)
+	if err != nil {
+		return nil, err
+	}
+	if mfaReq.Token == "" || mfaReq.Code == "" {
+		return nil, errors.New("token and code are required")
+	}
+
+	return mfaReq, nil
+}
+
+func DecodeEnrollTOTPReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return EnrollTOTPRequest{UserID: pathVars["id"]}, nil
+}
+
+func DecodeConfirmTOTPReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var confirmReq ConfirmTOTPRequest
+
+	err := json.NewDecoder(req.Body).Decode(&confirmReq)
+	if err != nil {
+		return nil, err
+	}
+	if confirmReq.Code == "" {
+		return nil, errors.New("code is required")
+	}
+
+	confirmReq.UserID = pathVars["id"]
+
+	return confirmReq, nil
+}
+
+func DecodeDisableMFAReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var disableReq DisableMFARequest
+
+	err := json.NewDecoder(req.Body).Decode(&disableReq)
+	if err != nil {
+		return nil, err
+	}
+	if disableReq.Code == "" {
+		return nil, errors.New("code is required")
+	}
+
+	disableReq.UserID = pathVars["id"]
+
+	return disableReq, nil
+}
+
+func DecodeResetMFAReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return ResetMFARequest{UserID: pathVars["id"]}, nil
+}
+
+func DecodeBeginPasskeyRegistrationReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return BeginPasskeyRegistrationRequest{UserID: pathVars["id"]}, nil
+}
+
+func DecodeFinishPasskeyRegistrationReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var finishReq FinishPasskeyRegistrationRequest
+
+	err := json.NewDecoder(req.Body).Decode(&finishReq)
+	if err != nil {
+		return nil, err
+	}
+	if finishReq.Token == "" || len(finishReq.Credential) == 0 {
+		return nil, errors.New("token and credential are required")
+	}
+
+	finishReq.UserID = pathVars["id"]
+
+	return finishReq, nil
+}
+
+func DecodeListPasskeysReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return ListPasskeysRequest{UserID: pathVars["id"]}, nil
+}
+
+func DecodeDeletePasskeyReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return DeletePasskeyRequest{UserID: pathVars["id"], PasskeyID: pathVars["passkey_id"]}, nil
+}
+
+func DecodeBeginPasskeyLoginReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return BeginPasskeyLoginRequest{OrgID: pathVars["org_id"]}, nil
+}
+
+func DecodePasskeyLoginReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var loginReq PasskeyLoginRequest
+
+	err := json.NewDecoder(req.Body).Decode(&loginReq)
+	if err != nil {
+		return nil, err
+	}
+	if loginReq.Token == "" || len(loginReq.Credential) == 0 {
+		return nil, errors.New("token and credential are required")
+	}
+
+	loginReq.OrgID = pathVars["org_id"]
+
+	return loginReq, nil
+}
+
+func DecodeRequestMagicLinkReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var linkReq RequestMagicLinkRequest
+
+	err := json.NewDecoder(req.Body).Decode(&linkReq)
+	if err != nil {
+		return nil, err
+	}
+
+	linkReq.OrgID = pathVars["org_id"]
+
+	return linkReq, nil
+}
+
+func DecodeMagicLinkLoginReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return MagicLinkLoginRequest{OrgID: pathVars["org_id"], Token: pathVars["token"]}, nil
+}
+
+func DecodeRequestSMSCodeReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var codeReq RequestSMSCodeRequest
+
+	err := json.NewDecoder(req.Body).Decode(&codeReq)
+	if err != nil {
+		return nil, err
+	}
+
+	codeReq.OrgID = pathVars["org_id"]
+
+	return codeReq, nil
+}
+
+func DecodeSMSCodeLoginReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var loginReq SMSCodeLoginRequest
+
+	err := json.NewDecoder(req.Body).Decode(&loginReq)
+	if err != nil {
+		return nil, err
+	}
+	if loginReq.Token == "" || loginReq.Code == "" {
+		return nil, errors.New("token and code are required")
+	}
+
+	loginReq.OrgID = pathVars["org_id"]
+
+	return loginReq, nil
+}
+
+func DecodeCreateOrgReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	var orgReq CreateOrgRequest
+
+	err := json.NewDecoder(req.Body).Decode(&orgReq)
 	if err != nil {
 		return nil, err
 	}
@@ -183,22 +951,321 @@ func DecodeCreateOrgReq(ctx context.Context, req *http.Request) (interface{}, er
 	return orgReq, nil
 }
 
-func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
+func DecodeGetSessionReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	return GetSessionRequest{}, nil
+}
+
+func DecodeSwitchOrgReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	var switchReq SwitchOrgRequest
+
+	err := json.NewDecoder(req.Body).Decode(&switchReq)
+	if err != nil {
+		return nil, err
+	}
+	if switchReq.OrgID == "" {
+		return nil, errors.New("org_id is required")
+	}
+
+	return switchReq, nil
+}
+
+func DecodeListMyOrgsReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	return ListMyOrgsRequest{}, nil
+}
+
+func DecodeGetOrgReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return GetOrgRequest{ID: pathVars["org_id"]}, nil
+}
+
+func DecodeUpdateOrgAccountReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var updatesReq UpdateOrgAccountRequest
+
+	err := json.NewDecoder(req.Body).Decode(&updatesReq.Updates)
+
+	if err != nil {
+		return updatesReq, err
+	}
+
+	updatesReq.ID = pathVars["org_id"]
+
+	return updatesReq, nil
+}
+
+func DecodeUpdateOrgProfileReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var updatesReq UpdateOrgProfileRequest
+
+	err := json.NewDecoder(req.Body).Decode(&updatesReq.Updates)
+
+	if err != nil {
+		return updatesReq, err
+	}
+
+	updatesReq.ID = pathVars["org_id"]
+
+	return updatesReq, nil
+}
+
+// DELETE /orgs/{org_id}?force=true
+func DecodeDeleteOrgReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	deleteReq := DeleteOrgRequest{ID: pathVars["org_id"]}
+
+	if force := req.URL.Query().Get("force"); force != "" {
+		var err error
+		if deleteReq.Force, err = strconv.ParseBool(force); err != nil {
+			return nil, fmt.Errorf("invalid force value %q", force)
+		}
+	}
+
+	return deleteReq, nil
+}
+
+// Same as DecodeUserStatusReq for orgs
+func DecodeOrgStatusReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return OrgStatusRequest{OrgID: pathVars["org_id"]}, nil
+}
+
+// GET /orgs/{org_id}/users?role=&status=&q=&sort=&order=&limit=&cursor=
+func DecodeListChildOrgsReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return ListChildOrgsRequest{OrgID: pathVars["org_id"]}, nil
+}
+
+func DecodeGetOrgTreeReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return GetOrgTreeRequest{OrgID: pathVars["org_id"]}, nil
+}
+
+func DecodeListOrgUsersReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	params := req.URL.Query()
+
+	listReq := ListOrgUsersRequest{
+		OrgID: pathVars["org_id"],
+		Query: OrgUserQuery{
+			Role:   params.Get("role"),
+			Status: params.Get("status"),
+			Text:   params.Get("q"),
+			Sort:   params.Get("sort"),
+			Cursor: params.Get("cursor"),
+		},
+	}
+
+	switch order := params.Get("order"); order {
+	case "", "asc":
+	case "desc":
+		listReq.Query.Desc = true
+	default:
+		return nil, fmt.Errorf("invalid order %q", order)
+	}
+
+	if limit := params.Get("limit"); limit != "" {
+		var err error
+		if listReq.Query.Limit, err = strconv.Atoi(limit); err != nil || listReq.Query.Limit < 1 {
+			return nil, fmt.Errorf("invalid limit %q", limit)
+		}
+	}
+
+	return listReq, nil
+}
+
+func DecodeUpdatePayorDetailsReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var updateReq UpdatePayorDetailsRequest
+
+	err := json.NewDecoder(req.Body).Decode(&updateReq.Details)
+
+	if err != nil {
+		return updateReq, err
+	}
+
+	updateReq.ID = pathVars["org_id"]
+
+	return updateReq, nil
+}
+
+// GET /payors?payer_id=...
+func DecodeFindPayorReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	payerID := req.URL.Query().Get("payer_id")
+	if payerID == "" {
+		return nil, errors.New("payer_id is required")
+	}
+	return FindPayorRequest{PayerID: payerID}, nil
+}
+
+func DecodeCreateInviteReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var inviteReq CreateInviteRequest
+
+	err := json.NewDecoder(req.Body).Decode(&inviteReq)
+	if err != nil {
+		return nil, err
+	}
+
+	inviteReq.OrgID = pathVars["org_id"]
+
+	return inviteReq, nil
+}
+
+func DecodeListInvitesReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return ListInvitesRequest{OrgID: pathVars["org_id"]}, nil
+}
+
+func DecodeRevokeInviteReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return RevokeInviteRequest{OrgID: pathVars["org_id"], InviteID: pathVars["invite_id"]}, nil
+}
+
+func DecodeResendInviteReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return ResendInviteRequest{OrgID: pathVars["org_id"], InviteID: pathVars["invite_id"]}, nil
+}
+
+func DecodeAcceptInviteReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	var acceptReq AcceptInviteRequest
+
+	err := json.NewDecoder(req.Body).Decode(&acceptReq)
+	if err != nil {
+		return nil, err
+	}
+	if acceptReq.Token == "" {
+		return nil, errors.New("token is required")
+	}
+
+	return acceptReq, nil
+}
+
+func DecodeRequestNetworkRelationshipReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	var networkReq RequestNetworkRelationshipRequest
+
+	err := json.NewDecoder(req.Body).Decode(&networkReq)
+	if err != nil {
+		return nil, err
+	}
+	if networkReq.PartnerID == "" {
+		return nil, errors.New("partner_id is required")
+	}
+
+	networkReq.OrgID = pathVars["org_id"]
+
+	return networkReq, nil
+}
+
+func DecodeAcceptNetworkRelationshipReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return NetworkRelationshipActionRequest{OrgID: pathVars["org_id"], RelationshipID: pathVars["relationship_id"]}, nil
+}
+
+func DecodeTerminateNetworkRelationshipReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	return NetworkRelationshipActionRequest{OrgID: pathVars["org_id"], RelationshipID: pathVars["relationship_id"]}, nil
+}
+
+func DecodeListPayorsReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	return decodeListNetworkReq(req), nil
+}
+
+func DecodeListProvidersReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	return decodeListNetworkReq(req), nil
+}
+
+func decodeListNetworkReq(req *http.Request) ListNetworkRequest {
+	params := req.URL.Query()
+	return ListNetworkRequest{
+		OrgID: mux.Vars(req)["org_id"],
+		Query: NetworkQuery{
+			Status:      params.Get("status"),
+			EffectiveOn: params.Get("effective_on"),
+		},
+	}
+}
+
+func DecodeQueryAuditLogReq(ctx context.Context, req *http.Request) (interface{}, error) {
+	pathVars := mux.Vars(req)
+	params := req.URL.Query()
+
+	auditReq := QueryAuditLogRequest{
+		Query: AuditQuery{
+			OrgID:    pathVars["org_id"],
+			ActorID:  params.Get("actor_id"),
+			TargetID: params.Get("target_id"),
+			Action:   params.Get("action"),
+		},
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{
+		{"since", &auditReq.Query.Since},
+		{"until", &auditReq.Query.Until},
+	} {
+		if value := params.Get(p.name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q, expected an RFC 3339 time", p.name, value)
+			}
+			*p.dst = t
+		}
+	}
+
+	if limit := params.Get("limit"); limit != "" {
+		var err error
+		if auditReq.Query.Limit, err = strconv.Atoi(limit); err != nil || auditReq.Query.Limit < 1 {
+			return nil, fmt.Errorf("invalid limit %q", limit)
+		}
+	}
+
+	return auditReq, nil
+}
+
+func EncodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	if err == nil {
 		panic("encodeError with nil error")
 	}
+	body := map[string]interface{}{
+		"error": err.Error(),
+	}
+	// Hand the request ID back in the body too so whoever reports the error
+	// can give it to support and we can find it in the logs.
+	if id := RequestIDFromContext(ctx); id != "" {
+		body["request_id"] = id
+	}
+	if reason := ReasonFrom(err); reason != "" {
+		body["reason"] = reason
+	}
+	if errors.Is(err, ErrUnauthenticated) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		// Round up so callers never come back a moment too early
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rateLimitErr.RetryAfter.Seconds()))))
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(CodeFrom(err))
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": err.Error(),
-	})
+	json.NewEncoder(w).Encode(body)
 }
 
 func CodeFrom(err error) int {
-	switch err {
-	// case ErrNotFound:
-	// 	return http.StatusNotFound
-	// case ErrAlreadyExists, ErrInconsistentIDs:
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, ErrUnauthenticated):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrOrgHasMembers), errors.Is(err, ErrOrgHasChildren):
+		return http.StatusConflict
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	// case errors.Is(err, ErrAlreadyExists), errors.Is(err, ErrInconsistentIDs):
 	// 	return http.StatusBadRequest
 	default:
 		// return http.StatusInternalServerError
@@ -0,0 +1,175 @@
+package accountsrv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Every route has to be in openapi.json and everything in openapi.json has to be
+// routed
+func TestOpenAPISpecMatchesRoutes(t *testing.T) {
+	router, ok := NewHTTPServer(context.Background(), MakeEndpoints(nil)).(*mux.Router)
+	if !ok {
+		t.Fatal("NewHTTPServer didn't hand back a *mux.Router")
+	}
+
+	if err := VerifyOpenAPISpec(router); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Nothing we send back should ever have a password in it, however much of it
+// is filled in
+func TestResponsesCarryNoPasswords(t *testing.T) {
+	responses := []interface{}{
+		CreateUserResponse{},
+		GetUserAccountResponse{},
+		GetDetailedUserResponse{},
+		DeleteUserResponse{},
+		UserStatusResponse{},
+		UpdateAccountResponse{},
+		LoginResponse{},
+		UpdateProfileResponse{},
+		CreateOrgResponse{},
+		GetSessionResponse{},
+		GetOrgResponse{},
+		UpdateOrgAccountResponse{},
+		UpdateOrgProfileResponse{},
+		DeleteOrgResponse{},
+		OrgStatusResponse{},
+		ListOrgUsersResponse{},
+		ListChildOrgsResponse{},
+		GetOrgTreeResponse{},
+		UpdatePayorDetailsResponse{},
+		FindPayorResponse{},
+		CreateInviteResponse{},
+		ListInvitesResponse{},
+		RevokeInviteResponse{},
+		ResendInviteResponse{},
+		AcceptInviteResponse{},
+		NetworkRelationshipResponse{},
+		ListNetworkResponse{},
+		QueryAuditLogResponse{},
+		SendEmailVerificationResponse{},
+		VerifyEmailResponse{},
+		SendPhoneVerificationResponse{},
+		VerifyPhoneResponse{},
+		RequestPasswordResetResponse{},
+		ResetPasswordResponse{},
+		CompleteMFALoginResponse{},
+		EnrollTOTPResponse{},
+		ConfirmTOTPResponse{},
+		DisableMFAResponse{},
+		ResetMFAResponse{},
+		BeginPasskeyRegistrationResponse{},
+		FinishPasskeyRegistrationResponse{},
+		ListPasskeysResponse{},
+		DeletePasskeyResponse{},
+		BeginPasskeyLoginResponse{},
+		PasskeyLoginResponse{},
+		RequestMagicLinkResponse{},
+		RequestSMSCodeResponse{},
+		LoginWithoutOrgResponse{},
+		ListMyOrgsResponse{},
+	}
+
+	for _, response := range responses {
+		name := reflect.TypeOf(response).Name()
+		v := reflect.New(reflect.TypeOf(response)).Elem()
+		fill(v, 0)
+
+		rec := httptest.NewRecorder()
+		if err := EncodeResponse(context.Background(), rec, v.Interface()); err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+
+		var body interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		for _, key := range passwordKeys(body, "") {
+			t.Errorf("%s has %s in it", name, key)
+		}
+	}
+}
+
+// Sets everything in v that can be set to something other than its zero value,
+// so nothing gets left out of the JSON for being empty. Interfaces (errors
+// included) are left nil, and it gives up depth levels down so types that
+// contain themselves (e.g. OrgTree) come to an end.
+func fill(v reflect.Value, depth int) {
+	if depth > 6 {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	case reflect.Ptr:
+		p := reflect.New(v.Type().Elem())
+		fill(p.Elem(), depth+1)
+		v.Set(p)
+	case reflect.Slice:
+		if v.Type() == reflect.TypeOf(json.RawMessage{}) {
+			v.SetBytes([]byte(`{}`))
+			return
+		}
+		s := reflect.MakeSlice(v.Type(), 1, 1)
+		fill(s.Index(0), depth+1)
+		v.Set(s)
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		key := reflect.New(v.Type().Key()).Elem()
+		fill(key, depth+1)
+		elem := reflect.New(v.Type().Elem()).Elem()
+		fill(elem, depth+1)
+		m.SetMapIndex(key, elem)
+		v.Set(m)
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			v.Set(reflect.ValueOf(time.Now()))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				fill(v.Field(i), depth+1)
+			}
+		}
+	}
+}
+
+// Every key anywhere in the decoded JSON that looks like it holds a password
+func passwordKeys(node interface{}, path string) []string {
+	var keys []string
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, child := range n {
+			if strings.Contains(strings.ToLower(key), "password") {
+				keys = append(keys, path+"."+key)
+			}
+			keys = append(keys, passwordKeys(child, path+"."+key)...)
+		}
+	case []interface{}:
+		for _, child := range n {
+			keys = append(keys, passwordKeys(child, path+"[]")...)
+		}
+	}
+	return keys
+}
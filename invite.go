@@ -0,0 +1,138 @@
+package accountsrv
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How long an invite (or a resent one) can be accepted for
+const inviteTTL = 7 * 24 * time.Hour
+
+// Where an invite stands, worked out from its timestamps rather than stored
+const (
+	InviteStatusPending  = "pending"
+	InviteStatusAccepted = "accepted"
+	InviteStatusRevoked  = "revoked"
+	InviteStatusExpired  = "expired"
+)
+
+// An invitation for whoever holds the email address to join the org with the role
+type Invite struct {
+	ID         string     `db:"id" json:"id"`
+	OrgID      string     `db:"org_id" json:"org_id"`
+	Email      string     `db:"email" json:"email"`
+	Role       string     `db:"role" json:"role"`
+	InvitedBy  string     `db:"invited_by" json:"invited_by,omitempty"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	ExpiresAt  time.Time  `db:"expires_at" json:"expires_at"`
+	AcceptedAt *time.Time `db:"accepted_at" json:"accepted_at,omitempty"`
+	AcceptedBy string     `db:"accepted_by" json:"accepted_by,omitempty"`
+	RevokedAt  *time.Time `db:"revoked_at" json:"revoked_at,omitempty"`
+	Status     string     `json:"status"`
+}
+
+// What creating (or resending) an invite hands back. The token is what the
+// invitee accepts the invite with, it's only ever given out here.
+type IssuedInvite struct {
+	Invite Invite `json:"invite"`
+	Token  string `json:"token"`
+}
+
+var errInvalidInvite = errors.New("invite is invalid or has expired")
+
+// Works out the invite's status as of now
+func (i Invite) status(now time.Time) string {
+	switch {
+	case i.AcceptedAt != nil:
+		return InviteStatusAccepted
+	case i.RevokedAt != nil:
+		return InviteStatusRevoked
+	case !now.Before(i.ExpiresAt):
+		return InviteStatusExpired
+	default:
+		return InviteStatusPending
+	}
+}
+
+// Checks the email address is one we can send an invite to and hands back just
+// the address part of it, lower cased.
+func normalizeInviteEmail(email string) (string, error) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return "", errors.New("invalid email address")
+	}
+	return strings.ToLower(addr.Address), nil
+}
+
+/*
+Invite tokens are <payload>.<signature>, both base64url. The payload is the invite
+ID and its expiry, the signature is an HMAC-SHA256 of it under the service's
+signing key. So a token can be turned away without going to the DB at all when
+it's been tampered with or has expired, and since the expiry is part of what's
+signed, resending an invite (which moves the expiry) leaves the old token dead.
+*/
+
+// Signs the payload for the given purpose, which is mixed into the MAC so a token
+// issued for one thing can never pass for another.
+func signToken(key []byte, purpose string, payload string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose + "." + payload))
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Checks the token's signature and hands back its payload
+func verifyToken(key []byte, purpose string, token string) (string, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return "", false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", false
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose + "." + string(payload)))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return "", false
+	}
+	return string(payload), true
+}
+
+func newInviteToken(key []byte, invite Invite) string {
+	return signToken(key, "invite", invite.ID+"."+strconv.FormatInt(invite.ExpiresAt.Unix(), 10))
+}
+
+// Hands back the invite ID and expiry out of a token that checks out and hasn't expired
+func parseInviteToken(key []byte, token string) (string, time.Time, error) {
+	payload, ok := verifyToken(key, "invite", token)
+	if !ok {
+		return "", time.Time{}, errInvalidInvite
+	}
+
+	id, expires, ok := strings.Cut(payload, ".")
+	if !ok {
+		return "", time.Time{}, errInvalidInvite
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return "", time.Time{}, errInvalidInvite
+	}
+	expiresAt := time.Unix(unix, 0)
+	if !time.Now().Before(expiresAt) {
+		return "", time.Time{}, errInvalidInvite
+	}
+
+	return id, expiresAt, nil
+}
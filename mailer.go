@@ -0,0 +1,224 @@
+package accountsrv
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+// One plain text email
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Whatever actually gets emails out the door. The service only ever talks to this,
+// main decides which one it gets (SMTP in production, a file or the log locally).
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// Sends through an SMTP server (e.g. smtp.example.com:587), authenticating with
+// auth when it isn't nil. STARTTLS is used whenever the server offers it.
+func NewSMTPMailer(addr string, from string, auth smtp.Auth) Mailer {
+	return smtpMailer{addr: addr, from: from, auth: auth}
+}
+
+type smtpMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func (m smtpMailer) Send(ctx context.Context, email Email) error {
+	msg, err := formatEmail(m.from, email)
+	if err != nil {
+		return err
+	}
+	to, _ := mail.ParseAddress(email.To)
+
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{to.Address}, msg); err != nil {
+		return fmt.Errorf("error sending email: %v", err)
+	}
+	return nil
+}
+
+// Writes every email to its own .eml file in dir instead of sending it, handy for
+// running locally and looking at what would have gone out.
+func NewFileMailer(dir string, from string) Mailer {
+	return fileMailer{dir: dir, from: from}
+}
+
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+func (m fileMailer) Send(ctx context.Context, email Email) error {
+	msg, err := formatEmail(m.from, email)
+	if err != nil {
+		return err
+	}
+
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	name := time.Now().UTC().Format("20060102T150405.000000000") + "-" + hex.EncodeToString(suffix) + ".eml"
+
+	if err := os.WriteFile(filepath.Join(m.dir, name), msg, 0600); err != nil {
+		return fmt.Errorf("error writing email: %v", err)
+	}
+	return nil
+}
+
+// Logs every email instead of sending it. What main falls back to when no other
+// mailer is configured, the emails carry tokens so this is for local use only.
+func NewLogMailer(logger log.Logger) Mailer {
+	return logMailer{logger: log.With(logger, "mailer", "log")}
+}
+
+type logMailer struct {
+	logger log.Logger
+}
+
+func (m logMailer) Send(ctx context.Context, email Email) error {
+	if _, err := mail.ParseAddress(email.To); err != nil {
+		return errors.New("invalid email address")
+	}
+	level.Info(loggerWithRequestID(ctx, m.logger)).Log("to", email.To, "subject", email.Subject, "body", email.Body)
+	return nil
+}
+
+// Renders the email as an RFC 5322 message. The addresses are parsed and the
+// subject encoded rather than pasted into the headers, so nothing in them can
+// smuggle in headers of its own.
+func formatEmail(from string, email Email) ([]byte, error) {
+	fromAddr, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address %q", from)
+	}
+	toAddr, err := mail.ParseAddress(email.To)
+	if err != nil {
+		return nil, errors.New("invalid email address")
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", fromAddr.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", toAddr.String())
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.ReplaceAll(email.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return msg.Bytes(), nil
+}
+
+// A subject and body, both text/templates over the same data
+type emailTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func newEmailTemplate(name string, subject string, body string) emailTemplate {
+	return emailTemplate{
+		subject: template.Must(template.New(name + ".subject").Parse(subject)),
+		body:    template.Must(template.New(name + ".body").Parse(body)),
+	}
+}
+
+func (t emailTemplate) render(to string, data interface{}) (Email, error) {
+	var subject, body strings.Builder
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return Email{}, err
+	}
+	if err := t.body.Execute(&body, data); err != nil {
+		return Email{}, err
+	}
+	return Email{To: to, Subject: subject.String(), Body: body.String()}, nil
+}
+
+// The emails the service sends
+
+var verifyEmailTemplate = newEmailTemplate("verify_email",
+	`Confirm your email address`,
+	`Hi {{.FirstName}},
+
+Please confirm this is your email address by following the link below:
+
+{{.Link}}
+
+The link is good until {{.ExpiresAt.Format "Jan 2, 2006 15:04 MST"}} and can only be used once.
+If you didn't ask for this, you can ignore this email.
+`)
+
+var emailChangedTemplate = newEmailTemplate("email_changed",
+	`Your email address was changed`,
+	`Hi {{.FirstName}},
+
+The email address on your account was just changed from {{.OldEmail}} to {{.NewEmail}}.
+We won't send anything to this address anymore.
+
+If you didn't make this change, contact your organization's administrator right away.
+`)
+
+var passwordResetTemplate = newEmailTemplate("password_reset",
+	`Reset your password`,
+	`Hi {{.FirstName}},
+
+Someone asked to reset the password for the account {{.Username}}. If that was you,
+you can pick a new password by following the link below:
+
+{{.Link}}
+
+The link is good until {{.ExpiresAt.Format "Jan 2, 2006 15:04 MST"}} and can only be used once.
+Resetting your password signs you out everywhere you're signed in.
+
+If you didn't ask for this, you can ignore this email, your password hasn't changed.
+`)
+
+var inviteTemplate = newEmailTemplate("invite",
+	`You're invited to join {{.OrgName}}`,
+	`Hi,
+
+You've been invited to join {{.OrgName}} with the {{.Role}} role. To accept, follow
+the link below:
+
+{{.Link}}
+
+The link is good until {{.ExpiresAt.Format "Jan 2, 2006 15:04 MST"}}. If you already have an
+account, log in to it first and the invite will be added to it.
+
+If you weren't expecting this, you can ignore this email.
+`)
+
+var magicLinkTemplate = newEmailTemplate("magic_link",
+	`Your login link for {{.OrgName}}`,
+	`Hi {{.FirstName}},
+
+Someone asked to log in to {{.OrgName}} as you without a password. If that was you,
+follow the link below to log in:
+
+{{.Link}}
+
+The link is good until {{.ExpiresAt.Format "Jan 2, 2006 15:04 MST"}} and can only be used once.
+Don't forward this email, anyone with the link can log in as you.
+
+If you didn't ask for this, you can ignore this email.
+`)
@@ -0,0 +1,167 @@
+package accountsrv
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP (RFC 6238) the way every authenticator app does it by default: SHA-1,
+// 6 digit codes, a new one every 30 seconds.
+const (
+	totpDigits = 6
+	totpPeriod = 30
+	totpSkew   = 1 // How many steps either side of now are still accepted, for clocks that drift
+)
+
+const (
+	mfaChallengeTTL      = 5 * time.Minute
+	mfaChallengeAttempts = 5 // Wrong codes a challenge takes before it's dead
+	recoveryCodeCount    = 10
+)
+
+// A user's TOTP secret as we keep it. None of it is ever serialized, the secret
+// only goes out once, in the TOTPEnrollment.
+type TOTPFactor struct {
+	UserID       string     `db:"user_id" json:"-"`
+	Secret       string     `db:"secret" json:"-"`
+	CreatedAt    time.Time  `db:"created_at" json:"-"`
+	ConfirmedAt  *time.Time `db:"confirmed_at" json:"-"` // nil until the user sends a first code
+	LastUsedStep int64      `db:"last_used_step" json:"-"`
+}
+
+// What the user sets up their authenticator app with. The URI is what goes in
+// the QR code, the secret is for typing in by hand.
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// What logging in hands back instead of a session when the account needs a
+// second factor. The token goes to CompleteMFALogin along with a code. When the
+// org requires MFA and the user hasn't set it up yet, Enrollment is what to set
+// it up with and the first code from it finishes both.
+type MFAChallenge struct {
+	Token      string          `json:"token"`
+	ExpiresAt  time.Time       `json:"expires_at"`
+	Enrollment *TOTPEnrollment `json:"enrollment,omitempty"`
+}
+
+// An MFAChallenge as we keep it in the DB, only the hash of the token is stored
+type LoginChallenge struct {
+	TokenHash  string    `db:"token_hash" json:"-"`
+	UserID     string    `db:"user_id" json:"-"`
+	OrgID      string    `db:"org_id" json:"-"`
+	AuthMethod string    `db:"auth_method" json:"-"` // How they got as far as the challenge
+	ExpiresAt  time.Time `db:"expires_at" json:"-"`
+	Attempts   int       `db:"attempts" json:"-"`
+}
+
+var (
+	errInvalidMFACode      = errors.New("invalid MFA code")
+	errInvalidMFAChallenge = errors.New("MFA challenge is invalid or has expired")
+	errMFAEnabled          = errors.New("MFA is already enabled")
+	errMFANotEnabled       = errors.New("MFA isn't enabled")
+	errMFARequired         = fmt.Errorf("%w: this organization requires MFA, log in to it with a second factor", ErrForbidden)
+)
+
+// Generates a new random TOTP secret, base32 like the apps want it
+func newTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
+
+// The otpauth:// URI for the secret, see
+// https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+func totpURI(issuer string, account string, secret string) string {
+	params := url.Values{
+		"secret":    {secret},
+		"issuer":    {issuer},
+		"algorithm": {"SHA1"},
+		"digits":    {fmt.Sprint(totpDigits)},
+		"period":    {fmt.Sprint(totpPeriod)},
+	}
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+account) + "?" + params.Encode()
+}
+
+// The code for the secret at the given time step
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulus := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		modulus *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%modulus)
+}
+
+// Finds the time step (around now) the code is for. ok is false if it isn't the
+// code for any of them.
+func totpMatch(secret string, code string, now time.Time) (step int64, ok bool) {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil || !isTOTPCode(code) {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if hmac.Equal([]byte(totpCode(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// Whether the code looks like a TOTP code rather than a recovery code
+func isTOTPCode(code string) bool {
+	if len(code) != totpDigits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Generates a fresh set of recovery codes, handing back the codes for the user
+// and the hashes for us. Each one is 80 random bits (XXXX-XXXX-XXXX-XXXX) so a
+// plain SHA-256 is enough, same as tokens.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := base32.StdEncoding.EncodeToString(b)
+		codes[i] = raw[0:4] + "-" + raw[4:8] + "-" + raw[8:12] + "-" + raw[12:16]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// Hashes the recovery code the same however it was typed in
+func hashRecoveryCode(code string) string {
+	code = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return hashToken(code)
+}
@@ -0,0 +1,18 @@
+-- Memberships get a role, admins of an org can do things regular members can't
+-- (e.g. looking after the accounts of the org's members). Whoever joins an org
+-- first becomes its admin.
+ALTER TABLE org_users ADD COLUMN role TEXT NOT NULL DEFAULT 'member';
+
+-- Sessions handed out on login. Only a hash of the token is stored, the token
+-- itself only ever exists in the login response and the caller's requests.
+CREATE TABLE sessions (
+    id         UUID PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    user_id    UUID NOT NULL REFERENCES user_accounts (id) ON DELETE CASCADE,
+    org_id     UUID NOT NULL REFERENCES org_accounts (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX sessions_user_id_idx ON sessions (user_id);
@@ -0,0 +1,6 @@
+-- Where a user's account stands. Everyone starts out (and for now stays) active,
+-- listing an org's users can filter on it.
+ALTER TABLE user_accounts ADD COLUMN status TEXT NOT NULL DEFAULT 'active';
+
+-- Paging through an org's users is always done by org first
+CREATE INDEX org_users_org_id_idx ON org_users (org_id);
@@ -0,0 +1,7 @@
+-- The identifiers a provider org is known by. An NPI belongs to exactly one
+-- provider, so it can't be registered twice.
+CREATE TABLE provider_details (
+    account_id UUID PRIMARY KEY REFERENCES org_accounts (id) ON DELETE CASCADE,
+    npi        TEXT NOT NULL UNIQUE,
+    tax_id     TEXT NOT NULL
+);
@@ -0,0 +1,9 @@
+-- The payer IDs a payor org goes by, e.g. one per clearinghouse or line of
+-- business. A payer ID points at exactly one payor across the whole system.
+CREATE TABLE payer_ids (
+    payer_id   TEXT PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES org_accounts (id) ON DELETE CASCADE,
+    label      TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX payer_ids_account_id_idx ON payer_ids (account_id);
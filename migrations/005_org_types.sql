@@ -0,0 +1,7 @@
+-- Org types are a fixed set now (see orgtype.go). NOT VALID so rows from before
+-- this don't stop the migration, they'll want cleaning up by hand.
+ALTER TABLE org_accounts ADD CONSTRAINT org_accounts_type_check
+    CHECK (type IN ('provider', 'payor', 'clearinghouse', 'internal')) NOT VALID;
+
+ALTER TABLE user_accounts ADD CONSTRAINT user_accounts_org_type_check
+    CHECK (org_type IN ('provider', 'payor', 'clearinghouse', 'internal')) NOT VALID;
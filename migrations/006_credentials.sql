@@ -0,0 +1,16 @@
+-- Passwords move out of user_accounts into their own table, so nothing that
+-- selects an account can pick one up by accident. What was there so far was
+-- stored as is, it's carried over marked as plaintext and rehashed the next time
+-- its user logs in.
+CREATE TABLE credentials (
+    user_id         UUID PRIMARY KEY REFERENCES user_accounts (id) ON DELETE CASCADE,
+    hash            TEXT NOT NULL,
+    algorithm       TEXT NOT NULL,
+    changed_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    failed_attempts INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO credentials (user_id, hash, algorithm)
+SELECT id, password, 'plaintext' FROM user_accounts;
+
+ALTER TABLE user_accounts DROP COLUMN password;
@@ -0,0 +1,21 @@
+-- Invitations to join an org. The token the invitee accepts with is signed rather
+-- than stored, it carries the invite's ID and expiry so the expiry here has to
+-- match the token's for it to be accepted (see invite.go).
+CREATE TABLE org_invites (
+    id          UUID PRIMARY KEY,
+    org_id      UUID NOT NULL REFERENCES org_accounts (id) ON DELETE CASCADE,
+    email       TEXT NOT NULL,
+    role        TEXT NOT NULL,
+    invited_by  UUID REFERENCES user_accounts (id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at  TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    accepted_by UUID REFERENCES user_accounts (id) ON DELETE SET NULL,
+    revoked_at  TIMESTAMPTZ
+);
+
+CREATE INDEX org_invites_org_id_idx ON org_invites (org_id, created_at);
+
+-- Only one open invite per address per org, a second one should be a resend
+CREATE UNIQUE INDEX org_invites_open_email_idx ON org_invites (org_id, email)
+    WHERE accepted_at IS NULL AND revoked_at IS NULL;
@@ -0,0 +1,16 @@
+-- Emails are unverified until their owner follows the link we send to them.
+-- Changing the email puts it back to unverified.
+ALTER TABLE user_profiles ADD COLUMN email_verified_at TIMESTAMPTZ;
+
+-- The verification emails sent out. Only the hash of the token is stored, the
+-- token itself only ever exists in the email. Each one works once.
+CREATE TABLE email_verifications (
+    token_hash TEXT PRIMARY KEY,
+    user_id    UUID NOT NULL REFERENCES user_accounts (id) ON DELETE CASCADE,
+    email      TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ
+);
+
+CREATE INDEX email_verifications_user_id_idx ON email_verifications (user_id);
@@ -0,0 +1,23 @@
+-- Password resets that were asked for. Only the hash of the token is stored, the
+-- token itself only ever exists in the email. Using one uses up every other
+-- outstanding reset of the same user as well.
+CREATE TABLE password_resets (
+    token_hash TEXT PRIMARY KEY,
+    user_id    UUID NOT NULL REFERENCES user_accounts (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ
+);
+
+CREATE INDEX password_resets_user_id_idx ON password_resets (user_id);
+
+-- The passwords users had before their current one, so they can't just set an
+-- old one again. Only the last few are kept.
+CREATE TABLE password_history (
+    user_id    UUID NOT NULL REFERENCES user_accounts (id) ON DELETE CASCADE,
+    hash       TEXT NOT NULL,
+    algorithm  TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX password_history_user_id_idx ON password_history (user_id, created_at DESC);
@@ -0,0 +1,38 @@
+-- TOTP multi-factor authentication. The secret is shared with the user's
+-- authenticator app and has to be readable to check codes against, so unlike
+-- everything else here it can't be hashed. It's unconfirmed (and not enforced)
+-- until the user proves they've set it up by sending a first code.
+CREATE TABLE user_totp (
+    user_id        UUID PRIMARY KEY REFERENCES user_accounts (id) ON DELETE CASCADE,
+    secret         TEXT NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    confirmed_at   TIMESTAMPTZ,
+    -- The last time step a code was accepted for, a code can't be used twice
+    last_used_step BIGINT NOT NULL DEFAULT 0
+);
+
+-- One time codes for when the authenticator is lost, only the hashes are stored
+CREATE TABLE mfa_recovery_codes (
+    user_id    UUID NOT NULL REFERENCES user_accounts (id) ON DELETE CASCADE,
+    code_hash  TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    used_at    TIMESTAMPTZ,
+    PRIMARY KEY (user_id, code_hash)
+);
+
+-- The second step of logging in to an account with MFA, what the password step
+-- hands back instead of a session. Each one works once.
+CREATE TABLE mfa_challenges (
+    token_hash TEXT PRIMARY KEY,
+    user_id    UUID NOT NULL REFERENCES user_accounts (id) ON DELETE CASCADE,
+    org_id     UUID NOT NULL REFERENCES org_accounts (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    attempts   INT NOT NULL DEFAULT 0,
+    used_at    TIMESTAMPTZ
+);
+
+CREATE INDEX mfa_challenges_user_id_idx ON mfa_challenges (user_id);
+
+-- Orgs can make MFA mandatory for their members
+ALTER TABLE org_accounts ADD COLUMN mfa_required BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,36 @@
+-- WebAuthn credentials (passkeys, security keys). The ID is the credential ID
+-- the authenticator made up, base64url encoded.
+CREATE TABLE passkeys (
+    id               TEXT PRIMARY KEY,
+    user_id          UUID NOT NULL REFERENCES user_accounts (id) ON DELETE CASCADE,
+    name             TEXT NOT NULL DEFAULT '',
+    public_key       BYTEA NOT NULL,
+    attestation_type TEXT NOT NULL DEFAULT '',
+    transports       TEXT[] NOT NULL DEFAULT '{}',
+    aaguid           BYTEA,
+    sign_count       BIGINT NOT NULL DEFAULT 0,
+    user_verified    BOOLEAN NOT NULL DEFAULT false,
+    backup_eligible  BOOLEAN NOT NULL DEFAULT false,
+    backup_state     BOOLEAN NOT NULL DEFAULT false,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at     TIMESTAMPTZ
+);
+
+CREATE INDEX passkeys_user_id_idx ON passkeys (user_id);
+
+-- What's kept between the two halves of a registration or login ceremony: the
+-- library's session data (the challenge and what it expects back). Each one
+-- works once.
+CREATE TABLE passkey_ceremonies (
+    token_hash TEXT PRIMARY KEY,
+    kind       TEXT NOT NULL CHECK (kind IN ('registration', 'login')),
+    user_id    UUID REFERENCES user_accounts (id) ON DELETE CASCADE,
+    org_id     UUID REFERENCES org_accounts (id) ON DELETE CASCADE,
+    session    JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ
+);
+
+-- Orgs that only let their members in with a passkey
+ALTER TABLE org_accounts ADD COLUMN passkey_required BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,16 @@
+-- Logins without a password, by a magic link emailed to the user or a code
+-- texted to them. Only hashes of the token (and the code) are stored. Each one
+-- works once, and an SMS code only takes so many wrong guesses.
+CREATE TABLE passwordless_logins (
+    token_hash TEXT PRIMARY KEY,
+    method     TEXT NOT NULL CHECK (method IN ('email', 'sms')),
+    user_id    UUID NOT NULL REFERENCES user_accounts (id) ON DELETE CASCADE,
+    org_id     UUID NOT NULL REFERENCES org_accounts (id) ON DELETE CASCADE,
+    code_hash  TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    attempts   INTEGER NOT NULL DEFAULT 0,
+    used_at    TIMESTAMPTZ
+);
+
+CREATE INDEX passwordless_logins_user_id_idx ON passwordless_logins (user_id);
@@ -0,0 +1,9 @@
+-- Remembers how the user logged in for each session (and each MFA challenge on
+-- the way to one), so switching the session to another org can hold them to
+-- that org's rules, e.g. it wanting passkeys or a second factor.
+ALTER TABLE sessions
+    ADD COLUMN auth_method TEXT NOT NULL DEFAULT 'password' CHECK (auth_method IN ('password', 'passkey', 'email', 'sms')),
+    ADD COLUMN mfa         BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE mfa_challenges
+    ADD COLUMN auth_method TEXT NOT NULL DEFAULT 'password' CHECK (auth_method IN ('password', 'passkey', 'email', 'sms'));
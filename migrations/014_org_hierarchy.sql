@@ -0,0 +1,8 @@
+-- Orgs can sit under a parent org, e.g. a health system's practices or a payor's
+-- regional subsidiaries. Admins of a parent are admins of everything under it.
+-- The service keeps it a tree (an org can't end up under itself) and no more
+-- than a handful of levels deep, the recursive queries stop there either way.
+ALTER TABLE org_accounts ADD COLUMN parent_id UUID REFERENCES org_accounts (id);
+ALTER TABLE org_accounts ADD CONSTRAINT org_accounts_parent_check CHECK (parent_id <> id);
+
+CREATE INDEX org_accounts_parent_id_idx ON org_accounts (parent_id);
@@ -0,0 +1,26 @@
+-- A provider being in-network with a payor. One side requests it and the other
+-- accepts, and it's in effect from effective_date up to (not including)
+-- termination_date. Terminating it early pulls termination_date in to the day
+-- it was terminated, so the dates always say when it really was in effect.
+CREATE TABLE network_relationships (
+    id               UUID PRIMARY KEY,
+    provider_id      UUID NOT NULL REFERENCES org_accounts (id) ON DELETE CASCADE,
+    payor_id         UUID NOT NULL REFERENCES org_accounts (id) ON DELETE CASCADE,
+    status           TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'active', 'terminated')),
+    effective_date   DATE NOT NULL,
+    termination_date DATE CHECK (termination_date >= effective_date),
+    -- Which of the two orgs asked, the other one is who gets to accept
+    requested_by     UUID NOT NULL REFERENCES org_accounts (id) ON DELETE CASCADE,
+    requested_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    accepted_at      TIMESTAMPTZ,
+    terminated_at    TIMESTAMPTZ,
+    CHECK (requested_by IN (provider_id, payor_id))
+);
+
+CREATE INDEX network_relationships_provider_id_idx ON network_relationships (provider_id, effective_date);
+CREATE INDEX network_relationships_payor_id_idx ON network_relationships (payor_id, effective_date);
+
+-- Only one relationship between a provider and a payor that's still going, a
+-- terminated one can be followed by a new one though
+CREATE UNIQUE INDEX network_relationships_open_idx ON network_relationships (provider_id, payor_id)
+    WHERE status <> 'terminated';
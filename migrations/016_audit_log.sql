@@ -0,0 +1,44 @@
+-- Who did what to which user or org, and when. Rows are only ever appended: each
+-- one carries the hash of the one before it, so editing or removing a row breaks
+-- the chain from there on (see AuditSink.Verify). The trigger stops it happening
+-- by accident, the chain catches whoever gets around the trigger.
+--
+-- No foreign keys (or UUID types) on purpose, the trail has to outlive the users
+-- and orgs in it and keep the IDs callers made up too.
+CREATE TABLE audit_log (
+    seq         BIGINT PRIMARY KEY,
+    id          UUID NOT NULL UNIQUE,
+    time        TIMESTAMPTZ NOT NULL,
+    actor_id    TEXT,
+    org_id      TEXT,
+    action      TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_id   TEXT,
+    outcome     TEXT NOT NULL CHECK (outcome IN ('success', 'failure', 'challenged')),
+    error       TEXT,
+    changes     JSONB,
+    request_id  TEXT,
+    client_ip   TEXT,
+    prev_hash   TEXT NOT NULL,
+    hash        TEXT NOT NULL
+);
+
+CREATE INDEX audit_log_org_id_idx ON audit_log (org_id, seq);
+CREATE INDEX audit_log_actor_id_idx ON audit_log (actor_id, seq);
+CREATE INDEX audit_log_target_id_idx ON audit_log (target_id, seq);
+CREATE INDEX audit_log_action_idx ON audit_log (action, seq);
+CREATE INDEX audit_log_time_idx ON audit_log (time);
+
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE PROCEDURE audit_log_append_only();
+
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE PROCEDURE audit_log_append_only();
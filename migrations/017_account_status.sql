@@ -0,0 +1,19 @@
+-- Users and orgs are no longer deleted outright, the row stays behind (and
+-- everything hanging off of it) with a status saying where it stands, so there's
+-- still a record of it and it can be restored. Only active ones can log in or
+-- turn up in lookups.
+--
+--   suspended   an admin blocked it, logging in says so
+--   deactivated turned off, logging in fails as if it weren't there
+--   deleted     deleted_at says when, restoring brings it back
+ALTER TABLE user_accounts ADD CONSTRAINT user_accounts_status_check
+    CHECK (status IN ('active', 'suspended', 'deactivated', 'deleted'));
+ALTER TABLE user_accounts ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE user_accounts ADD CONSTRAINT user_accounts_deleted_at_check
+    CHECK ((status = 'deleted') = (deleted_at IS NOT NULL));
+
+ALTER TABLE org_accounts ADD COLUMN status TEXT NOT NULL DEFAULT 'active'
+    CHECK (status IN ('active', 'suspended', 'deactivated', 'deleted'));
+ALTER TABLE org_accounts ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE org_accounts ADD CONSTRAINT org_accounts_deleted_at_check
+    CHECK ((status = 'deleted') = (deleted_at IS NOT NULL));
@@ -0,0 +1,16 @@
+-- Phone numbers are unverified until their owner types back a code we text to
+-- them, and only a verified number gets sent login codes. Changing the number
+-- puts it back to unverified.
+ALTER TABLE user_profiles ADD COLUMN phone_verified_at TIMESTAMPTZ;
+
+-- The verification code each user was last texted, sending another replaces it.
+-- Only the hash of the code is stored, and it only takes so many wrong guesses.
+CREATE TABLE phone_verifications (
+    user_id    UUID PRIMARY KEY REFERENCES user_accounts (id) ON DELETE CASCADE,
+    phone      TEXT NOT NULL,
+    code_hash  TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    attempts   INTEGER NOT NULL DEFAULT 0,
+    used_at    TIMESTAMPTZ
+);
@@ -0,0 +1,12 @@
+-- What logging in without an org hands back to pick one with. Only the hash of
+-- the token is stored, and each one works once. Changing the password uses up
+-- any the user still has outstanding.
+CREATE TABLE org_selections (
+    token_hash TEXT PRIMARY KEY,
+    user_id    UUID NOT NULL REFERENCES user_accounts (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ
+);
+
+CREATE INDEX org_selections_user_id_idx ON org_selections (user_id);
@@ -0,0 +1,91 @@
+package accountsrv
+
+import (
+	"errors"
+	"time"
+)
+
+// Where a provider's relationship with a payor stands
+const (
+	NetworkStatusPending    = "pending"    // One side asked, the other hasn't accepted yet
+	NetworkStatusActive     = "active"     // Accepted, in network between its dates
+	NetworkStatusTerminated = "terminated" // Ended by either side, or turned down while pending
+)
+
+// How dates without a time (effective and termination dates) are written
+const dateLayout = "2006-01-02"
+
+// A provider being in-network with a payor. One of the two requests it and the
+// other accepts, from then on it's in effect from EffectiveDate up to (but not
+// including) TerminationDate.
+type NetworkRelationship struct {
+	ID              string     `db:"id" json:"id"`
+	ProviderID      string     `db:"provider_id" json:"provider_id"`
+	ProviderName    string     `db:"provider_name" json:"provider_name"`
+	PayorID         string     `db:"payor_id" json:"payor_id"`
+	PayorName       string     `db:"payor_name" json:"payor_name"`
+	Status          string     `db:"status" json:"status"`
+	EffectiveDate   string     `db:"effective_date" json:"effective_date"`               // YYYY-MM-DD
+	TerminationDate string     `db:"termination_date" json:"termination_date,omitempty"` // Same, open ended when empty
+	RequestedBy     string     `db:"requested_by" json:"requested_by"`                   // Which of the two orgs asked
+	RequestedAt     time.Time  `db:"requested_at" json:"requested_at"`
+	AcceptedAt      *time.Time `db:"accepted_at" json:"accepted_at,omitempty"`
+	TerminatedAt    *time.Time `db:"terminated_at" json:"terminated_at,omitempty"`
+}
+
+// What to list an org's network by, both are optional
+type NetworkQuery struct {
+	Status      string // Only relationships with this status
+	EffectiveOn string // Only relationships in effect on this date (YYYY-MM-DD)
+}
+
+var (
+	errNetworkOrgType  = errors.New("network relationships are between a provider and a payor")
+	errNetworkAccepted = errors.New("only the organization that didn't request the relationship can accept it, and only while it's pending")
+)
+
+// Checks the dates are YYYY-MM-DD, with the termination date (if there is one)
+// after the effective date
+func validateNetworkDates(effectiveDate string, terminationDate string) error {
+	effective, err := time.Parse(dateLayout, effectiveDate)
+	if err != nil {
+		return errors.New("effective_date has to be a date (YYYY-MM-DD)")
+	}
+	if terminationDate == "" {
+		return nil
+	}
+	termination, err := time.Parse(dateLayout, terminationDate)
+	if err != nil {
+		return errors.New("termination_date has to be a date (YYYY-MM-DD)")
+	}
+	if !termination.After(effective) {
+		return errors.New("termination_date has to be after effective_date")
+	}
+	return nil
+}
+
+func validateNetworkQuery(query NetworkQuery) error {
+	switch query.Status {
+	case "", NetworkStatusPending, NetworkStatusActive, NetworkStatusTerminated:
+	default:
+		return errors.New("unknown status " + query.Status)
+	}
+	if query.EffectiveOn != "" {
+		if _, err := time.Parse(dateLayout, query.EffectiveOn); err != nil {
+			return errors.New("effective_on has to be a date (YYYY-MM-DD)")
+		}
+	}
+	return nil
+}
+
+// Works out which of the two orgs is the provider and which the payor
+func networkSides(org OrgAccount, partner OrgAccount) (providerID string, payorID string, err error) {
+	switch {
+	case org.Type == OrgTypeProvider && partner.Type == OrgTypePayor:
+		return org.ID, partner.ID, nil
+	case org.Type == OrgTypePayor && partner.Type == OrgTypeProvider:
+		return partner.ID, org.ID, nil
+	default:
+		return "", "", errNetworkOrgType
+	}
+}
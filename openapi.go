@@ -0,0 +1,204 @@
+package accountsrv
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// The OpenAPI 3 document describing every route NewHTTPServer sets up. It's
+// baked into the binary so the docs always match the build that's running.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// Serves the raw OpenAPI document
+func openAPIHandler(respWriter http.ResponseWriter, req *http.Request) {
+	respWriter.Header().Set("Content-Type", "application/json; charset=utf-8")
+	respWriter.Write(openAPISpec)
+}
+
+// Compares the routes registered on the router against the paths in the OpenAPI
+// document and reports anything one has that the other doesn't. The tests run
+// this against the router NewHTTPServer builds, so adding a route without
+// documenting it (or the other way around) fails the build rather than going
+// out with docs that lie. It also refuses a document where any response could
+// carry a password.
+func VerifyOpenAPISpec(router *mux.Router) error {
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openAPISpec, &spec); err != nil {
+		return fmt.Errorf("openapi.json is not valid JSON: %v", err)
+	}
+
+	documented := map[string]bool{}
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	registered := map[string]bool{}
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range methods {
+			registered[method+" "+path] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	for route := range registered {
+		if !documented[route] {
+			problems = append(problems, route+" is not documented in openapi.json")
+		}
+	}
+	for route := range documented {
+		if !registered[route] {
+			problems = append(problems, route+" is documented in openapi.json but not routed")
+		}
+	}
+	secrets, err := responseSecrets()
+	if err != nil {
+		return err
+	}
+	problems = append(problems, secrets...)
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("openapi.json is out of sync with the router: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// Walks every response schema in the document (following $refs) and reports any
+// property that looks like it holds a password. Requests can have them, nothing
+// we send back ever should.
+func responseSecrets() ([]string, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(openAPISpec, &doc); err != nil {
+		return nil, fmt.Errorf("openapi.json is not valid JSON: %v", err)
+	}
+
+	// Resolves a local "#/a/b/c" reference against the document
+	resolve := func(ref string) interface{} {
+		var node interface{} = doc
+		for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			m, ok := node.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			node = m[part]
+		}
+		return node
+	}
+
+	var problems []string
+	seen := map[string]bool{}
+	var walk func(where string, node interface{})
+	walk = func(where string, node interface{}) {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			if ref, ok := n["$ref"].(string); ok {
+				if !seen[ref] {
+					seen[ref] = true
+					walk(ref, resolve(ref))
+				}
+				return
+			}
+			if properties, ok := n["properties"].(map[string]interface{}); ok {
+				for name := range properties {
+					if strings.Contains(strings.ToLower(name), "password") {
+						problems = append(problems, fmt.Sprintf("%s has a %s property but is sent back in a response", where, name))
+					}
+				}
+			}
+			for _, child := range n {
+				walk(where, child)
+			}
+		case []interface{}:
+			for _, child := range n {
+				walk(where, child)
+			}
+		}
+	}
+
+	paths, _ := doc["paths"].(map[string]interface{})
+	for path, operations := range paths {
+		operations, _ := operations.(map[string]interface{})
+		for method, operation := range operations {
+			operation, _ := operation.(map[string]interface{})
+			walk(strings.ToUpper(method)+" "+path, operation["responses"])
+		}
+	}
+
+	return problems, nil
+}
+
+// The Swagger UI itself, straight out of the dist folder of swagger-ui 5.18.2
+// (Apache 2.0, see swaggerui/LICENSE). It's baked into the binary like the
+// document is, so the docs work offline and don't pull in anyone else's script.
+//
+//go:embed swaggerui
+var swaggerUIFiles embed.FS
+
+// The Swagger UI page, pointed at /openapi.json
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>accountsrv API</title>
+  <link rel="stylesheet" href="/docs/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="/docs/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// Wraps the HTTP server so GET /docs serves a Swagger UI for the OpenAPI document
+// (and /docs/* the files it needs), everything else is handed through untouched.
+// It's opt-in (see the -docs flag).
+func WithSwaggerUI(next http.Handler) http.Handler {
+	files, err := fs.Sub(swaggerUIFiles, "swaggerui")
+	if err != nil {
+		// Can't happen, the directory is embedded above
+		panic(err)
+	}
+	fileServer := http.StripPrefix("/docs/", http.FileServer(http.FS(files)))
+
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" && (req.URL.Path == "/docs" || req.URL.Path == "/docs/") {
+			respWriter.Header().Set("Content-Type", "text/html; charset=utf-8")
+			respWriter.Write([]byte(swaggerUIPage))
+			return
+		}
+		if req.Method == "GET" && strings.HasPrefix(req.URL.Path, "/docs/") {
+			fileServer.ServeHTTP(respWriter, req)
+			return
+		}
+		next.ServeHTTP(respWriter, req)
+	})
+}
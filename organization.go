@@ -1,17 +1,40 @@
 package accountsrv
 
+import "time"
+
+// The roles a user can have within an org
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+// Orgs go through the same statuses users do, see UserStatusActive
+const (
+	OrgStatusActive      = UserStatusActive
+	OrgStatusSuspended   = UserStatusSuspended
+	OrgStatusDeactivated = UserStatusDeactivated
+	OrgStatusDeleted     = UserStatusDeleted
+)
+
 type OrgAccount struct {
-	ID       string `db:"id" json:"id"`
-	Name     string `db:"name" json:"name"`
-	Type     string `db:"type" json:"type"`
-	JoinedOn string `db:"joined_on" json:"joined_on"`
+	ID       string    `db:"id" json:"id"`
+	Name     string    `db:"name" json:"name"`
+	Type     string    `db:"type" json:"type"`
+	JoinedOn time.Time `db:"joined_on" json:"joined_on"`
+	ParentID string    `db:"parent_id" json:"parent_id,omitempty"` // The org it sits under, if any (see OrgTree)
+	Status   string    `db:"status" json:"status"`
+
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"` // nil unless it's deleted
+
+	MFARequired     bool `db:"mfa_required" json:"mfa_required"`         // Members have to log in with MFA
+	PasskeyRequired bool `db:"passkey_required" json:"passkey_required"` // Members can only log in with a passkey, not a password
 }
 
 type OrgProfile struct {
 	AccountID string `db:"account_id" json:"account_id"`
 	Phone     string `db:"phone" json:"phone"`
 	Address   string `db:"address" json:"address"`
-	Timezone  string `db:"timezone" json:"timezone"`
+	Timezone  string `db:"timezone" json:"timezone"` // IANA name, e.g. America/New_York
 	Website   string `db:"website" json:"website"`
 }
 
@@ -22,13 +45,54 @@ type ProviderDetails struct {
 }
 
 type PayorDetails struct {
-	AccountID string `db:"account_id" json:"account_id"`
-	PayorID   string `db:"payor_id" json:"payor_id"`
+	AccountID string    `db:"account_id" json:"account_id"`
+	PayerIDs  []PayerID `json:"payer_ids"`
+}
+
+// One of the IDs a payor is known by, no two payors can share one
+type PayerID struct {
+	PayerID string `db:"payer_id" json:"payer_id"`
+	Label   string `db:"label" json:"label,omitempty"` // e.g. the clearinghouse or line of business it's for
+}
+
+// One of the orgs a user belongs to (through org_users)
+type OrgMembership struct {
+	OrgID   string `db:"org_id" json:"org_id"`
+	OrgName string `db:"name" json:"org_name"`
+	OrgType string `db:"type" json:"org_type"`
+	Role    string `db:"role" json:"role"`
 }
 
 type DetailedOrg struct {
-	Account         OrgAccount      `json:"account"`
-	Profile         OrgProfile      `json:"profile"`
-	ProviderDetails ProviderDetails `json:"provider_details,omitempty"`
-	PayorDetails    PayorDetails    `json:"payor_details,omitempty"`
+	Account         OrgAccount       `json:"account"`
+	Profile         OrgProfile       `json:"profile"`
+	ProviderDetails *ProviderDetails `json:"provider_details,omitempty"` // Only for provider orgs
+	PayorDetails    *PayorDetails    `json:"payor_details,omitempty"`    // Only for payor orgs
+}
+
+// A user as seen from one of the orgs they belong to
+type OrgMember struct {
+	Account UserAccount `json:"account"`
+	Profile UserProfile `json:"profile"`
+	Role    string      `json:"role"`
+}
+
+// What to list an org's users by. Everything but the org is optional.
+type OrgUserQuery struct {
+	Role   string // Only members with this role
+	Status string // Only users whose account has this status, active when empty
+	Text   string // Only users whose name or email contains this
+	Sort   string // name (the default), joined_on or last_login
+	Desc   bool
+	Limit  int
+	Cursor string // next_cursor or prev_cursor from the page before
+}
+
+// One page of an org's users. Total counts every user matching the query, not
+// just the ones on this page.
+type OrgUserPage struct {
+	Users      []OrgMember `json:"users"`
+	Total      int         `json:"total"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	PrevCursor string      `json:"prev_cursor,omitempty"`
 }
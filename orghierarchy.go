@@ -0,0 +1,59 @@
+package accountsrv
+
+import (
+	"errors"
+	"fmt"
+)
+
+// How many levels deep the org tree can go, top to bottom. The recursive
+// queries never go further than this either, whatever's in the table.
+const maxOrgDepth = 8
+
+// An org and every org under it
+type OrgTree struct {
+	Org      OrgAccount `json:"org"`
+	Children []OrgTree  `json:"children"`
+}
+
+// Where an org sits: the orgs above it and the tree below it
+type OrgHierarchy struct {
+	Ancestors []OrgAccount `json:"ancestors"` // From the top of the tree down to the org's parent
+	Tree      OrgTree      `json:"tree"`
+}
+
+var (
+	errOrgParentType = errors.New("an organization's parent has to be the same type of organization")
+	errOrgCycle      = errors.New("an organization can't be put under itself or one of its own children")
+	errOrgTooDeep    = fmt.Errorf("organizations can't be nested more than %d deep", maxOrgDepth)
+)
+
+// Builds the tree under root out of its descendants, which need every org's
+// parent to come before it (the repo hands them back by depth).
+func buildOrgTree(root OrgAccount, descendants []OrgAccount) OrgTree {
+	children := map[string][]OrgAccount{}
+	for _, org := range descendants {
+		children[org.ParentID] = append(children[org.ParentID], org)
+	}
+
+	var build func(org OrgAccount) OrgTree
+	build = func(org OrgAccount) OrgTree {
+		tree := OrgTree{Org: org, Children: []OrgTree{}}
+		for _, child := range children[org.ID] {
+			tree.Children = append(tree.Children, build(child))
+		}
+		return tree
+	}
+
+	return build(root)
+}
+
+// How many levels there are below the top of the tree
+func (t OrgTree) height() int {
+	height := 0
+	for _, child := range t.Children {
+		if h := child.height() + 1; h > height {
+			height = h
+		}
+	}
+	return height
+}
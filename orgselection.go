@@ -0,0 +1,28 @@
+package accountsrv
+
+import (
+	"fmt"
+	"time"
+)
+
+// How long the caller has to pick an org after logging in without one
+const orgSelectionTTL = 5 * time.Minute
+
+// What logging in without an org hands back: the orgs the user can log in to and
+// a token that picks one of them (see ChooseLoginOrg). The token only stands for
+// the password having checked out, the org still gets its say on MFA and passkeys
+// once one is picked.
+type OrgSelection struct {
+	Token     string          `json:"token"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Orgs      []OrgMembership `json:"orgs"`
+}
+
+// An OrgSelection as we keep it in the DB, only the hash of the token is stored
+type PendingOrgSelection struct {
+	TokenHash string    `db:"token_hash" json:"-"`
+	UserID    string    `db:"user_id" json:"-"`
+	ExpiresAt time.Time `db:"expires_at" json:"-"`
+}
+
+var errInvalidOrgSelection = fmt.Errorf("%w: org selection token is invalid or has expired", ErrUnauthenticated)
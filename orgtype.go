@@ -0,0 +1,136 @@
+package accountsrv
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The kinds of org there are (see orgTypes for what each one means)
+const (
+	OrgTypeProvider      = "provider"
+	OrgTypePayor         = "payor"
+	OrgTypeClearinghouse = "clearinghouse"
+	OrgTypeInternal      = "internal"
+)
+
+// Everything that sets one type of org apart from the others
+type orgType struct {
+	// The roles members of an org of this type can have
+	roles []string
+	// Checks the type specific details an org is created (or updated) with, and
+	// puts them into the form they're stored in. Details belonging to other types
+	// are refused.
+	validate func(org *DetailedOrg) error
+}
+
+// The registry of org types. A user's OrgType always matches the type of the
+// orgs they belong to, so anything keyed on one is keyed on the other.
+var orgTypes = map[string]orgType{
+	OrgTypeProvider: {
+		roles:    []string{RoleAdmin, RoleMember},
+		validate: validateProviderOrg,
+	},
+	OrgTypePayor: {
+		roles:    []string{RoleAdmin, RoleMember},
+		validate: validatePayorOrg,
+	},
+	OrgTypeClearinghouse: {
+		roles:    []string{RoleAdmin, RoleMember},
+		validate: validateOrgWithoutDetails,
+	},
+	OrgTypeInternal: {
+		roles:    []string{RoleAdmin, RoleMember},
+		validate: validateOrgWithoutDetails,
+	},
+}
+
+// Looks up the org type, erroring out with the ones there are if it isn't one
+func lookupOrgType(name string) (orgType, error) {
+	spec, ok := orgTypes[name]
+	if !ok {
+		names := make([]string, 0, len(orgTypes))
+		for name := range orgTypes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return orgType{}, fmt.Errorf("unknown organization type %q, expected one of %s", name, strings.Join(names, ", "))
+	}
+	return spec, nil
+}
+
+// Whether members of an org of this type can have the role
+func (t orgType) allowsRole(role string) bool {
+	for _, r := range t.roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Providers have to come with their NPI and tax ID
+func validateProviderOrg(org *DetailedOrg) error {
+	if org.PayorDetails != nil {
+		return errors.New("only payor organizations have payor details")
+	}
+	if org.ProviderDetails == nil {
+		return errors.New("provider organizations require provider details")
+	}
+
+	details, err := normalizeProviderDetails(*org.ProviderDetails)
+	if err != nil {
+		return err
+	}
+	org.ProviderDetails = &details
+	return nil
+}
+
+// Payors have to go by at least one payer ID
+func validatePayorOrg(org *DetailedOrg) error {
+	if org.ProviderDetails != nil {
+		return errors.New("only provider organizations have provider details")
+	}
+	if org.PayorDetails == nil || len(org.PayorDetails.PayerIDs) == 0 {
+		return errors.New("payor organizations require at least one payer ID")
+	}
+
+	details, err := normalizePayorDetails(*org.PayorDetails)
+	if err != nil {
+		return err
+	}
+	org.PayorDetails = &details
+	return nil
+}
+
+// For the types that don't come with any details of their own
+func validateOrgWithoutDetails(org *DetailedOrg) error {
+	if org.ProviderDetails != nil {
+		return errors.New("only provider organizations have provider details")
+	}
+	if org.PayorDetails != nil {
+		return errors.New("only payor organizations have payor details")
+	}
+	return nil
+}
+
+// A user can only belong to orgs of their own type
+func checkUserOrgType(user UserAccount, org OrgAccount) error {
+	if user.OrgType != org.Type {
+		return fmt.Errorf("a %s user can't belong to a %s organization", user.OrgType, org.Type)
+	}
+	return nil
+}
+
+// Members of an org can only have the roles its type has
+func checkOrgRole(org OrgAccount, role string) error {
+	spec, err := lookupOrgType(org.Type)
+	if err != nil {
+		return err
+	}
+	if !spec.allowsRole(role) {
+		return fmt.Errorf("%s organizations don't have %s members", org.Type, role)
+	}
+	return nil
+}
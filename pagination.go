@@ -0,0 +1,50 @@
+package accountsrv
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// How many results a page holds when the caller doesn't say, and the most it can hold
+const (
+	defaultPageLimit = 25
+	maxPageLimit     = 100
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// A position in a sorted listing, i.e. the sort value and ID of the row a page
+// started or ended on. Callers only ever see it encoded as an opaque string they
+// hand back to get the next (or previous) page. It remembers the ordering it was
+// made for so it can't be replayed against a different one.
+type pageCursor struct {
+	Sort   string `json:"s"`
+	Desc   bool   `json:"d,omitempty"`
+	Value  string `json:"v"`
+	ID     string `json:"id"`
+	Before bool   `json:"b,omitempty"` // Page backwards from here rather than forwards
+}
+
+func (c pageCursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageCursor(s string) (pageCursor, error) {
+	var c pageCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, errInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+		return c, errInvalidCursor
+	}
+	return c, nil
+}
+
+// Escapes the LIKE wildcards in s so it only ever matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
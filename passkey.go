@@ -0,0 +1,172 @@
+package accountsrv
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/gofrs/uuid"
+)
+
+/*
+Passkeys are WebAuthn credentials. The ceremonies themselves (challenges, parsing
+and checking what the browser sends back) are left to go-webauthn, this side
+keeps the credentials and the state between the two halves of each ceremony.
+Registering one takes a logged in user; logging in with one takes nothing but
+the passkey, the user is whoever it belongs to.
+*/
+
+// How long the browser has to finish a ceremony once it's started
+const passkeyCeremonyTTL = 5 * time.Minute
+
+// The kinds of ceremony
+const (
+	passkeyRegistration = "registration"
+	passkeyLogin        = "login"
+)
+
+// A passkey as its owner sees it
+type Passkey struct {
+	ID             string     `db:"id" json:"id"`
+	Name           string     `db:"name" json:"name"`
+	Transports     []string   `db:"transports" json:"transports,omitempty"`
+	BackupEligible bool       `db:"backup_eligible" json:"backup_eligible"` // i.e. it syncs between devices
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	LastUsedAt     *time.Time `db:"last_used_at" json:"last_used_at,omitempty"`
+}
+
+// A passkey as we keep it, with everything it takes to check an assertion against it
+type PasskeyCredential struct {
+	Passkey
+	UserID          string `db:"user_id" json:"-"`
+	PublicKey       []byte `db:"public_key" json:"-"`
+	AttestationType string `db:"attestation_type" json:"-"`
+	AAGUID          []byte `db:"aaguid" json:"-"`
+	SignCount       uint32 `db:"sign_count" json:"-"`
+	UserVerified    bool   `db:"user_verified" json:"-"`
+	BackupState     bool   `db:"backup_state" json:"-"`
+}
+
+// The first half of a ceremony, handed back to the caller. Options go to
+// navigator.credentials.create() (registering) or .get() (logging in) as they
+// are, and the token goes back with whatever that resolves to.
+type PasskeyCeremony struct {
+	Token   string          `json:"token"`
+	Options json.RawMessage `json:"options"`
+}
+
+// What's kept between the two halves of a ceremony, only the hash of the token is stored
+type PasskeyCeremonyState struct {
+	TokenHash string    `db:"token_hash" json:"-"`
+	Kind      string    `db:"kind" json:"-"`
+	UserID    string    `db:"user_id" json:"-"` // Registration only
+	OrgID     string    `db:"org_id" json:"-"`  // Login only
+	Session   []byte    `db:"session" json:"-"` // The library's SessionData, as JSON
+	ExpiresAt time.Time `db:"expires_at" json:"-"`
+}
+
+var (
+	errPasskeysDisabled       = errors.New("passkeys aren't set up on this server")
+	errInvalidPasskeyCeremony = errors.New("passkey ceremony is invalid or has expired")
+	errPasskeyFailed          = errors.New("passkey couldn't be verified")
+	errPasskeyRequired        = fmt.Errorf("%w: this organization only allows logging in with a passkey", ErrForbidden)
+)
+
+// What go-webauthn wants to know about a user. The user handle is their account
+// ID's 16 bytes, which is how a discoverable login finds its way back to them.
+type passkeyUser struct {
+	account     UserAccount
+	displayName string
+	credentials []PasskeyCredential
+}
+
+func (u passkeyUser) WebAuthnID() []byte {
+	return uuid.FromStringOrNil(u.account.ID).Bytes()
+}
+
+func (u passkeyUser) WebAuthnName() string { return u.account.Username }
+
+func (u passkeyUser) WebAuthnDisplayName() string {
+	if u.displayName != "" {
+		return u.displayName
+	}
+	return u.account.Username
+}
+
+func (u passkeyUser) WebAuthnIcon() string { return "" }
+
+func (u passkeyUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, len(u.credentials))
+	for i, c := range u.credentials {
+		credentials[i] = c.webauthn()
+	}
+	return credentials
+}
+
+// The user a passkey's user handle points to, or "" for one that isn't one of ours
+func passkeyUserID(userHandle []byte) string {
+	id, err := uuid.FromBytes(userHandle)
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
+
+func passkeyID(credentialID []byte) string {
+	return base64.RawURLEncoding.EncodeToString(credentialID)
+}
+
+// Turns the passkey into what go-webauthn checks assertions against
+func (c PasskeyCredential) webauthn() webauthn.Credential {
+	id, _ := base64.RawURLEncoding.DecodeString(c.ID)
+	transports := make([]protocol.AuthenticatorTransport, len(c.Transports))
+	for i, t := range c.Transports {
+		transports[i] = protocol.AuthenticatorTransport(t)
+	}
+
+	return webauthn.Credential{
+		ID:              id,
+		PublicKey:       c.PublicKey,
+		AttestationType: c.AttestationType,
+		Transport:       transports,
+		Flags: webauthn.CredentialFlags{
+			UserPresent:    true,
+			UserVerified:   c.UserVerified,
+			BackupEligible: c.BackupEligible,
+			BackupState:    c.BackupState,
+		},
+		Authenticator: webauthn.Authenticator{
+			AAGUID:    c.AAGUID,
+			SignCount: c.SignCount,
+		},
+	}
+}
+
+// Turns a freshly registered credential into a passkey for the user
+func newPasskeyCredential(userID string, name string, credential *webauthn.Credential) PasskeyCredential {
+	transports := make([]string, len(credential.Transport))
+	for i, t := range credential.Transport {
+		transports[i] = string(t)
+	}
+
+	return PasskeyCredential{
+		Passkey: Passkey{
+			ID:             passkeyID(credential.ID),
+			Name:           strings.TrimSpace(name),
+			Transports:     transports,
+			BackupEligible: credential.Flags.BackupEligible,
+		},
+		UserID:          userID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+		UserVerified:    credential.Flags.UserVerified,
+		BackupState:     credential.Flags.BackupState,
+	}
+}
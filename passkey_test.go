@@ -0,0 +1,327 @@
+package accountsrv
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/gofrs/uuid"
+)
+
+const (
+	testRPID   = "accounts.example.com"
+	testOrigin = "https://accounts.example.com"
+)
+
+// fakeRepo plus the passkeys, ceremonies and sessions logging in with one goes through
+type passkeyRepo struct {
+	*fakeRepo
+	passkeys   map[string]PasskeyCredential
+	ceremonies map[string]PasskeyCeremonyState
+	sessions   []Session
+}
+
+func newPasskeyRepo() *passkeyRepo {
+	return &passkeyRepo{
+		fakeRepo:   newFakeRepo(),
+		passkeys:   map[string]PasskeyCredential{},
+		ceremonies: map[string]PasskeyCeremonyState{},
+	}
+}
+
+func (r *passkeyRepo) GetUserAccount(_ context.Context, id string) (UserAccount, error) {
+	if _, ok := r.profiles[id]; !ok {
+		return UserAccount{}, ErrNotFound
+	}
+	return UserAccount{ID: id, Username: "user", OrgType: OrgTypeInternal, Status: UserStatusActive}, nil
+}
+
+func (r *passkeyRepo) ListPasskeys(_ context.Context, userID string) ([]PasskeyCredential, error) {
+	passkeys := []PasskeyCredential{}
+	for _, passkey := range r.passkeys {
+		if passkey.UserID == userID {
+			passkeys = append(passkeys, passkey)
+		}
+	}
+	return passkeys, nil
+}
+
+func (r *passkeyRepo) CreatePasskey(_ context.Context, passkey PasskeyCredential) error {
+	r.passkeys[passkey.ID] = passkey
+	return nil
+}
+
+func (r *passkeyRepo) RecordPasskeyUse(_ context.Context, id string, signCount uint32, backupState bool) error {
+	passkey := r.passkeys[id]
+	passkey.SignCount = signCount
+	passkey.BackupState = backupState
+	r.passkeys[id] = passkey
+	return nil
+}
+
+func (r *passkeyRepo) CreatePasskeyCeremony(_ context.Context, ceremony PasskeyCeremonyState) error {
+	r.ceremonies[ceremony.TokenHash] = ceremony
+	return nil
+}
+
+func (r *passkeyRepo) UsePasskeyCeremony(_ context.Context, tokenHash string, kind string) (PasskeyCeremonyState, error) {
+	ceremony, ok := r.ceremonies[tokenHash]
+	if !ok || ceremony.Kind != kind {
+		return PasskeyCeremonyState{}, errInvalidPasskeyCeremony
+	}
+	delete(r.ceremonies, tokenHash)
+	return ceremony, nil
+}
+
+func (r *passkeyRepo) ConfirmUserToOrgAssociation(_ context.Context, userID string, orgID string) error {
+	if _, ok := r.members[orgID][userID]; !ok {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *passkeyRepo) GetOrgProfile(context.Context, string) (OrgProfile, error) {
+	return OrgProfile{}, nil
+}
+
+func (r *passkeyRepo) CreateSession(_ context.Context, session Session) error {
+	r.sessions = append(r.sessions, session)
+	return nil
+}
+
+// A software authenticator with a P-256 key, doing what a browser and a
+// security key would between them
+type softAuthenticator struct {
+	key        *ecdsa.PrivateKey
+	id         []byte
+	userHandle []byte
+	signCount  uint32
+}
+
+func newSoftAuthenticator(t *testing.T, userID string) *softAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := make([]byte, 16)
+	rand.Read(id)
+	return &softAuthenticator{key: key, id: id, userHandle: uuid.FromStringOrNil(userID).Bytes()}
+}
+
+var b64 = base64.RawURLEncoding.EncodeToString
+
+// The challenge out of the options a ceremony handed back, as the browser
+// would echo it in the client data
+func challengeFrom(t *testing.T, options json.RawMessage) string {
+	var parsed struct {
+		PublicKey struct {
+			Challenge string `json:"challenge"`
+		} `json:"publicKey"`
+	}
+	if err := json.Unmarshal(options, &parsed); err != nil || parsed.PublicKey.Challenge == "" {
+		t.Fatalf("no challenge in options %s (%v)", options, err)
+	}
+	return parsed.PublicKey.Challenge
+}
+
+func clientData(ceremony string, challenge string) []byte {
+	data, _ := json.Marshal(map[string]string{"type": ceremony, "challenge": challenge, "origin": testOrigin})
+	return data
+}
+
+// The authenticator data flags we set
+type authFlags byte
+
+const (
+	flagUserPresent  authFlags = 0x01
+	flagUserVerified authFlags = 0x04
+	flagAttested     authFlags = 0x40
+)
+
+// The RP ID hash, flags and sign count every authenticator data starts with
+func (a *softAuthenticator) authData(flags authFlags) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	data := append(rpIDHash[:], byte(flags))
+	return binary.BigEndian.AppendUint32(data, a.signCount)
+}
+
+// What navigator.credentials.create() resolves to, with "none" attestation
+func (a *softAuthenticator) create(t *testing.T, options json.RawMessage) []byte {
+	publicKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{KeyType: int64(webauthncose.EllipticKey), Algorithm: int64(webauthncose.AlgES256)},
+		Curve:         1, // P-256
+		XCoord:        a.key.PublicKey.X.FillBytes(make([]byte, 32)),
+		YCoord:        a.key.PublicKey.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authData := a.authData(flagUserPresent | flagUserVerified | flagAttested)
+	authData = append(authData, make([]byte, 16)...) // AAGUID
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.id)))
+	authData = append(authData, a.id...)
+	authData = append(authData, publicKey...)
+
+	attestation, err := webauthncbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": authData,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"id":    b64(a.id),
+		"rawId": b64(a.id),
+		"type":  "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    b64(clientData("webauthn.create", challengeFrom(t, options))),
+			"attestationObject": b64(attestation),
+		},
+	})
+	return body
+}
+
+// What navigator.credentials.get() resolves to, counting the use
+func (a *softAuthenticator) get(t *testing.T, options json.RawMessage) []byte {
+	a.signCount++
+	authData := a.authData(flagUserPresent | flagUserVerified)
+	client := clientData("webauthn.get", challengeFrom(t, options))
+
+	clientHash := sha256.Sum256(client)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"id":    b64(a.id),
+		"rawId": b64(a.id),
+		"type":  "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    b64(client),
+			"authenticatorData": b64(authData),
+			"signature":         b64(signature),
+			"userHandle":        b64(a.userHandle),
+		},
+	})
+	return body
+}
+
+// A user in an org with a passkey registered through the service, and the
+// authenticator that holds it
+func setupPasskey(t *testing.T) (*passkeyRepo, Service, *softAuthenticator, string) {
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:          testRPID,
+		RPDisplayName: "accountsrv",
+		RPOrigins:     []string{testOrigin},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userID := uuid.Must(uuid.NewV4()).String()
+	repo := newPasskeyRepo()
+	repo.orgs["org"] = OrgAccount{ID: "org", Type: OrgTypeInternal}
+	repo.orgs["other"] = OrgAccount{ID: "other", Type: OrgTypeInternal}
+	repo.addMember("org", userID, RoleMember)
+	repo.profiles[userID] = UserProfile{FirstName: "Pass", LastName: "Key"}
+	svc := NewService(repo, nopLogger, ServiceConfig{WebAuthn: webAuthn})
+
+	authenticator := newSoftAuthenticator(t, userID)
+	ctx := as(userID, "org")
+	ceremony, err := svc.BeginPasskeyRegistration(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	passkey, err := svc.FinishPasskeyRegistration(ctx, userID, ceremony.Token, "Laptop", authenticator.create(t, ceremony.Options))
+	if err != nil {
+		t.Fatalf("registering: %v", err)
+	}
+	if passkey.ID != b64(authenticator.id) || passkey.Name != "Laptop" {
+		t.Fatalf("registered %+v", passkey)
+	}
+
+	return repo, svc, authenticator, userID
+}
+
+func loginWithPasskey(t *testing.T, svc Service, authenticator *softAuthenticator, beginOrg, loginOrg string) (LoginUser, error) {
+	ceremony, err := svc.BeginPasskeyLogin(context.Background(), beginOrg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return svc.PasskeyLogin(context.Background(), loginOrg, ceremony.Token, authenticator.get(t, ceremony.Options))
+}
+
+func TestPasskeyRegistrationAndLogin(t *testing.T) {
+	repo, svc, authenticator, userID := setupPasskey(t)
+
+	stored := repo.passkeys[b64(authenticator.id)]
+	if stored.UserID != userID || !stored.UserVerified {
+		t.Fatalf("stored %+v", stored)
+	}
+
+	for i := 0; i < 2; i++ {
+		user, err := loginWithPasskey(t, svc, authenticator, "org", "org")
+		if err != nil {
+			t.Fatalf("login %d: %v", i+1, err)
+		}
+		if user.User.Account.ID != userID || user.Session.Token == "" {
+			t.Fatalf("login %d: logged in as %+v", i+1, user.User.Account)
+		}
+	}
+	if got := repo.passkeys[b64(authenticator.id)].SignCount; got != authenticator.signCount {
+		t.Fatalf("stored sign count %d, want %d", got, authenticator.signCount)
+	}
+	if last := repo.sessions[len(repo.sessions)-1]; last.AuthMethod != AuthMethodPasskey || !last.MFA {
+		t.Fatalf("session %+v should be a passkey one that counts as MFA", last)
+	}
+}
+
+// A counter that went backwards means another copy of the key is about
+func TestPasskeyLoginRefusesSignCountRegression(t *testing.T) {
+	repo, svc, authenticator, _ := setupPasskey(t)
+
+	authenticator.signCount = 10
+	if _, err := loginWithPasskey(t, svc, authenticator, "org", "org"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The clone, a few uses behind
+	authenticator.signCount = 5
+	sessions := len(repo.sessions)
+	if _, err := loginWithPasskey(t, svc, authenticator, "org", "org"); !errors.Is(err, errPasskeyFailed) {
+		t.Fatalf("got %v, want %v", err, errPasskeyFailed)
+	}
+	if len(repo.sessions) != sessions {
+		t.Fatal("a session was started anyway")
+	}
+	if got := repo.passkeys[b64(authenticator.id)].SignCount; got != 11 {
+		t.Fatalf("stored sign count went to %d", got)
+	}
+}
+
+// A ceremony started for one org can't log in to another
+func TestPasskeyLoginRefusesAnotherOrgsCeremony(t *testing.T) {
+	repo, svc, authenticator, _ := setupPasskey(t)
+
+	if _, err := loginWithPasskey(t, svc, authenticator, "other", "org"); !errors.Is(err, errInvalidPasskeyCeremony) {
+		t.Fatalf("got %v, want %v", err, errInvalidPasskeyCeremony)
+	}
+	if len(repo.sessions) != 0 {
+		t.Fatal("a session was started anyway")
+	}
+}
@@ -0,0 +1,76 @@
+package accountsrv
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// The ways in without a password. Both end up wherever a password Login would
+// have, MFA challenge included.
+const (
+	PasswordlessMethodEmail = "email" // A magic link emailed to the user
+	PasswordlessMethodSMS   = "sms"   // A one-time code texted to the user
+)
+
+const (
+	magicLinkTTL    = 15 * time.Minute
+	smsCodeTTL      = 10 * time.Minute
+	smsCodeDigits   = 6
+	smsCodeAttempts = 5 // Wrong codes before the whole login is dead
+)
+
+// What asking for an SMS code hands back. The token goes to SMSCodeLogin along
+// with the code from the text message.
+type SMSCodeChallenge struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// A magic link or SMS code login as we keep it in the DB. Only hashes of the
+// token and the code are kept.
+type PasswordlessLogin struct {
+	TokenHash string    `db:"token_hash" json:"-"`
+	Method    string    `db:"method" json:"-"`
+	UserID    string    `db:"user_id" json:"-"`
+	OrgID     string    `db:"org_id" json:"-"`
+	CodeHash  string    `db:"code_hash" json:"-"` // Only for SMS, the link's token is all there is to a magic link
+	ExpiresAt time.Time `db:"expires_at" json:"-"`
+	Attempts  int       `db:"attempts" json:"-"`
+}
+
+var (
+	errInvalidMagicLink = errors.New("login link is invalid, has expired or was already used")
+	errInvalidSMSCode   = errors.New("login code is invalid or has expired")
+	errSMSDisabled      = errors.New("text messages aren't set up on this server")
+)
+
+// What a login that's expired, used up or was never there comes back as
+func invalidPasswordlessLogin(method string) error {
+	if method == PasswordlessMethodSMS {
+		return errInvalidSMSCode
+	}
+	return errInvalidMagicLink
+}
+
+// Generates a random numeric code for a text message
+func newSMSCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < smsCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", smsCodeDigits, n), nil
+}
+
+// Whether the code matches what we keep for the login, without giving away how
+// much of it did through timing
+func (l PasswordlessLogin) codeMatches(code string) bool {
+	return subtle.ConstantTimeCompare([]byte(l.CodeHash), []byte(hashToken(code))) == 1
+}
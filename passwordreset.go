@@ -0,0 +1,20 @@
+package accountsrv
+
+import (
+	"errors"
+	"time"
+)
+
+// How long the link in a password reset email is good for. It's as good as the
+// password itself while it lasts, so not long.
+const passwordResetTTL = 30 * time.Minute
+
+// A password reset that was asked for. Only the hash of the token is kept, the
+// token itself only ever exists in the email.
+type PasswordReset struct {
+	TokenHash string    `db:"token_hash" json:"-"`
+	UserID    string    `db:"user_id" json:"-"`
+	ExpiresAt time.Time `db:"expires_at" json:"-"`
+}
+
+var errInvalidPasswordReset = errors.New("password reset link is invalid, has expired or was already used")
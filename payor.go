@@ -0,0 +1,39 @@
+package accountsrv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Longest payer ID we'll take, real ones are usually 5 characters
+const maxPayerIDLength = 32
+
+// Checks the payer IDs are well formed and not repeated, and puts them into the
+// one format we store them in (trimmed and upper case, payer IDs aren't case
+// sensitive).
+func normalizePayorDetails(details PayorDetails) (PayorDetails, error) {
+	seen := map[string]bool{}
+	payerIDs := make([]PayerID, 0, len(details.PayerIDs))
+
+	for _, payerID := range details.PayerIDs {
+		id := strings.ToUpper(strings.TrimSpace(payerID.PayerID))
+		if id == "" || len(id) > maxPayerIDLength {
+			return details, errors.New("payer IDs must be between 1 and 32 characters")
+		}
+		for _, r := range id {
+			if !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return details, fmt.Errorf("invalid payer ID %q, only letters, digits and dashes are allowed", payerID.PayerID)
+			}
+		}
+		if seen[id] {
+			return details, fmt.Errorf("payer ID %s is listed more than once", id)
+		}
+		seen[id] = true
+
+		payerIDs = append(payerIDs, PayerID{PayerID: id, Label: strings.TrimSpace(payerID.Label)})
+	}
+
+	details.PayerIDs = payerIDs
+	return details, nil
+}
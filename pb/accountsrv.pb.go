@@ -0,0 +1,8179 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: accountsrv.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UserAccount struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	OrgType  string                 `protobuf:"bytes,3,opt,name=org_type,json=orgType,proto3" json:"org_type,omitempty"`
+	JoinedOn string                 `protobuf:"bytes,4,opt,name=joined_on,json=joinedOn,proto3" json:"joined_on,omitempty"`
+	// active, suspended, deactivated or deleted
+	Status string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// Empty unless they're deleted
+	DeletedAt     string `protobuf:"bytes,6,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserAccount) Reset() {
+	*x = UserAccount{}
+	mi := &file_accountsrv_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserAccount) ProtoMessage() {}
+
+func (x *UserAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserAccount.ProtoReflect.Descriptor instead.
+func (*UserAccount) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserAccount) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserAccount) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserAccount) GetOrgType() string {
+	if x != nil {
+		return x.OrgType
+	}
+	return ""
+}
+
+func (x *UserAccount) GetJoinedOn() string {
+	if x != nil {
+		return x.JoinedOn
+	}
+	return ""
+}
+
+func (x *UserAccount) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UserAccount) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+type UserProfile struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	FirstName       string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName        string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Email           string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Phone           string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
+	LastLogin       string                 `protobuf:"bytes,6,opt,name=last_login,json=lastLogin,proto3" json:"last_login,omitempty"`
+	EmailVerifiedAt string                 `protobuf:"bytes,7,opt,name=email_verified_at,json=emailVerifiedAt,proto3" json:"email_verified_at,omitempty"`
+	PhoneVerifiedAt string                 `protobuf:"bytes,8,opt,name=phone_verified_at,json=phoneVerifiedAt,proto3" json:"phone_verified_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UserProfile) Reset() {
+	*x = UserProfile{}
+	mi := &file_accountsrv_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserProfile) ProtoMessage() {}
+
+func (x *UserProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
+func (*UserProfile) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserProfile) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *UserProfile) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *UserProfile) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *UserProfile) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserProfile) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *UserProfile) GetLastLogin() string {
+	if x != nil {
+		return x.LastLogin
+	}
+	return ""
+}
+
+func (x *UserProfile) GetEmailVerifiedAt() string {
+	if x != nil {
+		return x.EmailVerifiedAt
+	}
+	return ""
+}
+
+func (x *UserProfile) GetPhoneVerifiedAt() string {
+	if x != nil {
+		return x.PhoneVerifiedAt
+	}
+	return ""
+}
+
+type OrgMembership struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	OrgName       string                 `protobuf:"bytes,2,opt,name=org_name,json=orgName,proto3" json:"org_name,omitempty"`
+	OrgType       string                 `protobuf:"bytes,3,opt,name=org_type,json=orgType,proto3" json:"org_type,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgMembership) Reset() {
+	*x = OrgMembership{}
+	mi := &file_accountsrv_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgMembership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgMembership) ProtoMessage() {}
+
+func (x *OrgMembership) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgMembership.ProtoReflect.Descriptor instead.
+func (*OrgMembership) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrgMembership) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *OrgMembership) GetOrgName() string {
+	if x != nil {
+		return x.OrgName
+	}
+	return ""
+}
+
+func (x *OrgMembership) GetOrgType() string {
+	if x != nil {
+		return x.OrgType
+	}
+	return ""
+}
+
+func (x *OrgMembership) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type DetailedUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *UserAccount           `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Profile       *UserProfile           `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	Orgs          []*OrgMembership       `protobuf:"bytes,3,rep,name=orgs,proto3" json:"orgs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetailedUser) Reset() {
+	*x = DetailedUser{}
+	mi := &file_accountsrv_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetailedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailedUser) ProtoMessage() {}
+
+func (x *DetailedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailedUser.ProtoReflect.Descriptor instead.
+func (*DetailedUser) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DetailedUser) GetAccount() *UserAccount {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *DetailedUser) GetProfile() *UserProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *DetailedUser) GetOrgs() []*OrgMembership {
+	if x != nil {
+		return x.Orgs
+	}
+	return nil
+}
+
+type OrgAccount struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type            string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	JoinedOn        string                 `protobuf:"bytes,4,opt,name=joined_on,json=joinedOn,proto3" json:"joined_on,omitempty"`
+	MfaRequired     bool                   `protobuf:"varint,5,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"`
+	PasskeyRequired bool                   `protobuf:"varint,6,opt,name=passkey_required,json=passkeyRequired,proto3" json:"passkey_required,omitempty"`
+	// Empty for an org at the top of its tree
+	ParentId string `protobuf:"bytes,7,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Same as a user's
+	Status        string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	DeletedAt     string `protobuf:"bytes,9,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgAccount) Reset() {
+	*x = OrgAccount{}
+	mi := &file_accountsrv_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgAccount) ProtoMessage() {}
+
+func (x *OrgAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgAccount.ProtoReflect.Descriptor instead.
+func (*OrgAccount) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OrgAccount) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrgAccount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrgAccount) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OrgAccount) GetJoinedOn() string {
+	if x != nil {
+		return x.JoinedOn
+	}
+	return ""
+}
+
+func (x *OrgAccount) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *OrgAccount) GetPasskeyRequired() bool {
+	if x != nil {
+		return x.PasskeyRequired
+	}
+	return false
+}
+
+func (x *OrgAccount) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *OrgAccount) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrgAccount) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+type OrgProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Timezone      string                 `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Website       string                 `protobuf:"bytes,5,opt,name=website,proto3" json:"website,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgProfile) Reset() {
+	*x = OrgProfile{}
+	mi := &file_accountsrv_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgProfile) ProtoMessage() {}
+
+func (x *OrgProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgProfile.ProtoReflect.Descriptor instead.
+func (*OrgProfile) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OrgProfile) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *OrgProfile) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *OrgProfile) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *OrgProfile) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *OrgProfile) GetWebsite() string {
+	if x != nil {
+		return x.Website
+	}
+	return ""
+}
+
+type ProviderDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Npi           string                 `protobuf:"bytes,2,opt,name=npi,proto3" json:"npi,omitempty"`
+	TaxId         string                 `protobuf:"bytes,3,opt,name=tax_id,json=taxId,proto3" json:"tax_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderDetails) Reset() {
+	*x = ProviderDetails{}
+	mi := &file_accountsrv_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderDetails) ProtoMessage() {}
+
+func (x *ProviderDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderDetails.ProtoReflect.Descriptor instead.
+func (*ProviderDetails) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProviderDetails) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ProviderDetails) GetNpi() string {
+	if x != nil {
+		return x.Npi
+	}
+	return ""
+}
+
+func (x *ProviderDetails) GetTaxId() string {
+	if x != nil {
+		return x.TaxId
+	}
+	return ""
+}
+
+type PayerID struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	PayerId string                 `protobuf:"bytes,1,opt,name=payer_id,json=payerId,proto3" json:"payer_id,omitempty"`
+	// e.g. the clearinghouse or line of business it's for
+	Label         string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayerID) Reset() {
+	*x = PayerID{}
+	mi := &file_accountsrv_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayerID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayerID) ProtoMessage() {}
+
+func (x *PayerID) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayerID.ProtoReflect.Descriptor instead.
+func (*PayerID) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PayerID) GetPayerId() string {
+	if x != nil {
+		return x.PayerId
+	}
+	return ""
+}
+
+func (x *PayerID) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type PayorDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	PayerIds      []*PayerID             `protobuf:"bytes,3,rep,name=payer_ids,json=payerIds,proto3" json:"payer_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayorDetails) Reset() {
+	*x = PayorDetails{}
+	mi := &file_accountsrv_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayorDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayorDetails) ProtoMessage() {}
+
+func (x *PayorDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayorDetails.ProtoReflect.Descriptor instead.
+func (*PayorDetails) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PayorDetails) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *PayorDetails) GetPayerIds() []*PayerID {
+	if x != nil {
+		return x.PayerIds
+	}
+	return nil
+}
+
+type DetailedOrg struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Account         *OrgAccount            `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Profile         *OrgProfile            `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	ProviderDetails *ProviderDetails       `protobuf:"bytes,3,opt,name=provider_details,json=providerDetails,proto3" json:"provider_details,omitempty"`
+	PayorDetails    *PayorDetails          `protobuf:"bytes,4,opt,name=payor_details,json=payorDetails,proto3" json:"payor_details,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DetailedOrg) Reset() {
+	*x = DetailedOrg{}
+	mi := &file_accountsrv_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetailedOrg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailedOrg) ProtoMessage() {}
+
+func (x *DetailedOrg) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailedOrg.ProtoReflect.Descriptor instead.
+func (*DetailedOrg) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DetailedOrg) GetAccount() *OrgAccount {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *DetailedOrg) GetProfile() *OrgProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *DetailedOrg) GetProviderDetails() *ProviderDetails {
+	if x != nil {
+		return x.ProviderDetails
+	}
+	return nil
+}
+
+func (x *DetailedOrg) GetPayorDetails() *PayorDetails {
+	if x != nil {
+		return x.PayorDetails
+	}
+	return nil
+}
+
+type SessionToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionToken) Reset() {
+	*x = SessionToken{}
+	mi := &file_accountsrv_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionToken) ProtoMessage() {}
+
+func (x *SessionToken) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionToken.ProtoReflect.Descriptor instead.
+func (*SessionToken) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SessionToken) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SessionToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type LoginUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *DetailedUser          `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Org           *DetailedOrg           `protobuf:"bytes,2,opt,name=org,proto3" json:"org,omitempty"`
+	Session       *SessionToken          `protobuf:"bytes,3,opt,name=session,proto3" json:"session,omitempty"`
+	RecoveryCodes []string               `protobuf:"bytes,4,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginUser) Reset() {
+	*x = LoginUser{}
+	mi := &file_accountsrv_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginUser) ProtoMessage() {}
+
+func (x *LoginUser) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginUser.ProtoReflect.Descriptor instead.
+func (*LoginUser) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LoginUser) GetUser() *DetailedUser {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *LoginUser) GetOrg() *DetailedOrg {
+	if x != nil {
+		return x.Org
+	}
+	return nil
+}
+
+func (x *LoginUser) GetSession() *SessionToken {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+func (x *LoginUser) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	OrgType       string                 `protobuf:"bytes,4,opt,name=org_type,json=orgType,proto3" json:"org_type,omitempty"`
+	FirstName     string                 `protobuf:"bytes,5,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,6,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Email         string                 `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         string                 `protobuf:"bytes,8,opt,name=phone,proto3" json:"phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_accountsrv_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateUserRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetOrgType() string {
+	if x != nil {
+		return x.OrgType
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+type CreateUserReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserReply) Reset() {
+	*x = CreateUserReply{}
+	mi := &file_accountsrv_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserReply) ProtoMessage() {}
+
+func (x *CreateUserReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserReply.ProtoReflect.Descriptor instead.
+func (*CreateUserReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateUserReply) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// profile and/or orgs, when empty only user_account is filled in on the reply
+	Include       []string `protobuf:"bytes,2,rep,name=include,proto3" json:"include,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_accountsrv_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetUserRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+type GetUserReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserAccount   *UserAccount           `protobuf:"bytes,1,opt,name=user_account,json=userAccount,proto3" json:"user_account,omitempty"`
+	User          *DetailedUser          `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserReply) Reset() {
+	*x = GetUserReply{}
+	mi := &file_accountsrv_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserReply) ProtoMessage() {}
+
+func (x *GetUserReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserReply.ProtoReflect.Descriptor instead.
+func (*GetUserReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetUserReply) GetUserAccount() *UserAccount {
+	if x != nil {
+		return x.UserAccount
+	}
+	return nil
+}
+
+func (x *GetUserReply) GetUser() *DetailedUser {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_accountsrv_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteUserReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserReply) Reset() {
+	*x = DeleteUserReply{}
+	mi := &file_accountsrv_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserReply) ProtoMessage() {}
+
+func (x *DeleteUserReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserReply.ProtoReflect.Descriptor instead.
+func (*DeleteUserReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteUserReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+// Empty fields are left untouched
+type AccountUpdates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountUpdates) Reset() {
+	*x = AccountUpdates{}
+	mi := &file_accountsrv_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountUpdates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountUpdates) ProtoMessage() {}
+
+func (x *AccountUpdates) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountUpdates.ProtoReflect.Descriptor instead.
+func (*AccountUpdates) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AccountUpdates) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type UpdateAccountRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountUpdates *AccountUpdates        `protobuf:"bytes,2,opt,name=account_updates,json=accountUpdates,proto3" json:"account_updates,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateAccountRequest) Reset() {
+	*x = UpdateAccountRequest{}
+	mi := &file_accountsrv_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountRequest) ProtoMessage() {}
+
+func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateAccountRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAccountRequest) GetAccountUpdates() *AccountUpdates {
+	if x != nil {
+		return x.AccountUpdates
+	}
+	return nil
+}
+
+type UpdateAccountReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAccountReply) Reset() {
+	*x = UpdateAccountReply{}
+	mi := &file_accountsrv_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountReply) ProtoMessage() {}
+
+func (x *UpdateAccountReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountReply.ProtoReflect.Descriptor instead.
+func (*UpdateAccountReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateAccountReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_accountsrv_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LoginRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// One or the other, see LoginResponse
+type LoginReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LoginDetails  *LoginUser             `protobuf:"bytes,1,opt,name=login_details,json=loginDetails,proto3" json:"login_details,omitempty"`
+	Mfa           *MFAChallenge          `protobuf:"bytes,2,opt,name=mfa,proto3" json:"mfa,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginReply) Reset() {
+	*x = LoginReply{}
+	mi := &file_accountsrv_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginReply) ProtoMessage() {}
+
+func (x *LoginReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginReply.ProtoReflect.Descriptor instead.
+func (*LoginReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LoginReply) GetLoginDetails() *LoginUser {
+	if x != nil {
+		return x.LoginDetails
+	}
+	return nil
+}
+
+func (x *LoginReply) GetMfa() *MFAChallenge {
+	if x != nil {
+		return x.Mfa
+	}
+	return nil
+}
+
+type TOTPEnrollment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	OtpauthUri    string                 `protobuf:"bytes,2,opt,name=otpauth_uri,json=otpauthUri,proto3" json:"otpauth_uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TOTPEnrollment) Reset() {
+	*x = TOTPEnrollment{}
+	mi := &file_accountsrv_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TOTPEnrollment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TOTPEnrollment) ProtoMessage() {}
+
+func (x *TOTPEnrollment) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TOTPEnrollment.ProtoReflect.Descriptor instead.
+func (*TOTPEnrollment) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TOTPEnrollment) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *TOTPEnrollment) GetOtpauthUri() string {
+	if x != nil {
+		return x.OtpauthUri
+	}
+	return ""
+}
+
+type MFAChallenge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Enrollment    *TOTPEnrollment        `protobuf:"bytes,3,opt,name=enrollment,proto3" json:"enrollment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MFAChallenge) Reset() {
+	*x = MFAChallenge{}
+	mi := &file_accountsrv_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MFAChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MFAChallenge) ProtoMessage() {}
+
+func (x *MFAChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MFAChallenge.ProtoReflect.Descriptor instead.
+func (*MFAChallenge) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MFAChallenge) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *MFAChallenge) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *MFAChallenge) GetEnrollment() *TOTPEnrollment {
+	if x != nil {
+		return x.Enrollment
+	}
+	return nil
+}
+
+// Empty fields are left untouched, same as the HTTP side.
+type ProfileUpdates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FirstName     string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileUpdates) Reset() {
+	*x = ProfileUpdates{}
+	mi := &file_accountsrv_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileUpdates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileUpdates) ProtoMessage() {}
+
+func (x *ProfileUpdates) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileUpdates.ProtoReflect.Descriptor instead.
+func (*ProfileUpdates) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ProfileUpdates) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *ProfileUpdates) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *ProfileUpdates) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ProfileUpdates) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+type UpdateProfileRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ProfileUpdates *ProfileUpdates        `protobuf:"bytes,2,opt,name=profile_updates,json=profileUpdates,proto3" json:"profile_updates,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateProfileRequest) Reset() {
+	*x = UpdateProfileRequest{}
+	mi := &file_accountsrv_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileRequest) ProtoMessage() {}
+
+func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateProfileRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetProfileUpdates() *ProfileUpdates {
+	if x != nil {
+		return x.ProfileUpdates
+	}
+	return nil
+}
+
+type UpdateProfileReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProfileReply) Reset() {
+	*x = UpdateProfileReply{}
+	mi := &file_accountsrv_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileReply) ProtoMessage() {}
+
+func (x *UpdateProfileReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileReply.ProtoReflect.Descriptor instead.
+func (*UpdateProfileReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateProfileReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type CreateOrgRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type     string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Phone    string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	Address  string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Timezone string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Website  string                 `protobuf:"bytes,6,opt,name=website,proto3" json:"website,omitempty"`
+	// Only for provider orgs, account_id is ignored
+	ProviderDetails *ProviderDetails `protobuf:"bytes,7,opt,name=provider_details,json=providerDetails,proto3" json:"provider_details,omitempty"`
+	// Only for payor orgs, account_id is ignored
+	PayorDetails *PayorDetails `protobuf:"bytes,8,opt,name=payor_details,json=payorDetails,proto3" json:"payor_details,omitempty"`
+	// The org to create it under, if any
+	ParentId      string `protobuf:"bytes,9,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgRequest) Reset() {
+	*x = CreateOrgRequest{}
+	mi := &file_accountsrv_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgRequest) ProtoMessage() {}
+
+func (x *CreateOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrgRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateOrgRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOrgRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateOrgRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CreateOrgRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CreateOrgRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreateOrgRequest) GetWebsite() string {
+	if x != nil {
+		return x.Website
+	}
+	return ""
+}
+
+func (x *CreateOrgRequest) GetProviderDetails() *ProviderDetails {
+	if x != nil {
+		return x.ProviderDetails
+	}
+	return nil
+}
+
+func (x *CreateOrgRequest) GetPayorDetails() *PayorDetails {
+	if x != nil {
+		return x.PayorDetails
+	}
+	return nil
+}
+
+func (x *CreateOrgRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type CreateOrgReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgReply) Reset() {
+	*x = CreateOrgReply{}
+	mi := &file_accountsrv_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgReply) ProtoMessage() {}
+
+func (x *CreateOrgReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgReply.ProtoReflect.Descriptor instead.
+func (*CreateOrgReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateOrgReply) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgRequest) Reset() {
+	*x = GetOrgRequest{}
+	mi := &file_accountsrv_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgRequest) ProtoMessage() {}
+
+func (x *GetOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgRequest.ProtoReflect.Descriptor instead.
+func (*GetOrgRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetOrgRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOrgReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Org           *DetailedOrg           `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgReply) Reset() {
+	*x = GetOrgReply{}
+	mi := &file_accountsrv_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgReply) ProtoMessage() {}
+
+func (x *GetOrgReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgReply.ProtoReflect.Descriptor instead.
+func (*GetOrgReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetOrgReply) GetOrg() *DetailedOrg {
+	if x != nil {
+		return x.Org
+	}
+	return nil
+}
+
+// Empty fields are left untouched
+type OrgAccountUpdates struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MfaRequired     *bool                  `protobuf:"varint,2,opt,name=mfa_required,json=mfaRequired,proto3,oneof" json:"mfa_required,omitempty"`
+	PasskeyRequired *bool                  `protobuf:"varint,3,opt,name=passkey_required,json=passkeyRequired,proto3,oneof" json:"passkey_required,omitempty"`
+	// Empty takes it out from under its parent
+	ParentId      *string `protobuf:"bytes,4,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgAccountUpdates) Reset() {
+	*x = OrgAccountUpdates{}
+	mi := &file_accountsrv_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgAccountUpdates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgAccountUpdates) ProtoMessage() {}
+
+func (x *OrgAccountUpdates) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgAccountUpdates.ProtoReflect.Descriptor instead.
+func (*OrgAccountUpdates) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *OrgAccountUpdates) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrgAccountUpdates) GetMfaRequired() bool {
+	if x != nil && x.MfaRequired != nil {
+		return *x.MfaRequired
+	}
+	return false
+}
+
+func (x *OrgAccountUpdates) GetPasskeyRequired() bool {
+	if x != nil && x.PasskeyRequired != nil {
+		return *x.PasskeyRequired
+	}
+	return false
+}
+
+func (x *OrgAccountUpdates) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+type UpdateOrgAccountRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountUpdates *OrgAccountUpdates     `protobuf:"bytes,2,opt,name=account_updates,json=accountUpdates,proto3" json:"account_updates,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateOrgAccountRequest) Reset() {
+	*x = UpdateOrgAccountRequest{}
+	mi := &file_accountsrv_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrgAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrgAccountRequest) ProtoMessage() {}
+
+func (x *UpdateOrgAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrgAccountRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrgAccountRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateOrgAccountRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateOrgAccountRequest) GetAccountUpdates() *OrgAccountUpdates {
+	if x != nil {
+		return x.AccountUpdates
+	}
+	return nil
+}
+
+type UpdateOrgAccountReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrgAccountReply) Reset() {
+	*x = UpdateOrgAccountReply{}
+	mi := &file_accountsrv_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrgAccountReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrgAccountReply) ProtoMessage() {}
+
+func (x *UpdateOrgAccountReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrgAccountReply.ProtoReflect.Descriptor instead.
+func (*UpdateOrgAccountReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateOrgAccountReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+// Empty fields are left untouched
+type OrgProfileUpdates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phone         string                 `protobuf:"bytes,1,opt,name=phone,proto3" json:"phone,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Timezone      string                 `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Website       string                 `protobuf:"bytes,4,opt,name=website,proto3" json:"website,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgProfileUpdates) Reset() {
+	*x = OrgProfileUpdates{}
+	mi := &file_accountsrv_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgProfileUpdates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgProfileUpdates) ProtoMessage() {}
+
+func (x *OrgProfileUpdates) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgProfileUpdates.ProtoReflect.Descriptor instead.
+func (*OrgProfileUpdates) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *OrgProfileUpdates) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *OrgProfileUpdates) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *OrgProfileUpdates) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *OrgProfileUpdates) GetWebsite() string {
+	if x != nil {
+		return x.Website
+	}
+	return ""
+}
+
+type UpdateOrgProfileRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProfileUpdates *OrgProfileUpdates     `protobuf:"bytes,2,opt,name=profile_updates,json=profileUpdates,proto3" json:"profile_updates,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateOrgProfileRequest) Reset() {
+	*x = UpdateOrgProfileRequest{}
+	mi := &file_accountsrv_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrgProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrgProfileRequest) ProtoMessage() {}
+
+func (x *UpdateOrgProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrgProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrgProfileRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UpdateOrgProfileRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateOrgProfileRequest) GetProfileUpdates() *OrgProfileUpdates {
+	if x != nil {
+		return x.ProfileUpdates
+	}
+	return nil
+}
+
+type UpdateOrgProfileReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrgProfileReply) Reset() {
+	*x = UpdateOrgProfileReply{}
+	mi := &file_accountsrv_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrgProfileReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrgProfileReply) ProtoMessage() {}
+
+func (x *UpdateOrgProfileReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrgProfileReply.ProtoReflect.Descriptor instead.
+func (*UpdateOrgProfileReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UpdateOrgProfileReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type DeleteOrgRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Delete the org even though it still has members, takes an admin of the org
+	Force         bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrgRequest) Reset() {
+	*x = DeleteOrgRequest{}
+	mi := &file_accountsrv_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrgRequest) ProtoMessage() {}
+
+func (x *DeleteOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrgRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrgRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteOrgRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteOrgRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type DeleteOrgReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrgReply) Reset() {
+	*x = DeleteOrgReply{}
+	mi := &file_accountsrv_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrgReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrgReply) ProtoMessage() {}
+
+func (x *DeleteOrgReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrgReply.ProtoReflect.Descriptor instead.
+func (*DeleteOrgReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DeleteOrgReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type Principal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Principal) Reset() {
+	*x = Principal{}
+	mi := &file_accountsrv_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Principal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Principal) ProtoMessage() {}
+
+func (x *Principal) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Principal.ProtoReflect.Descriptor instead.
+func (*Principal) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Principal) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Principal) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *Principal) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// The caller is whoever the token in the authorization metadata says they are
+type GetSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSessionRequest) Reset() {
+	*x = GetSessionRequest{}
+	mi := &file_accountsrv_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionRequest) ProtoMessage() {}
+
+func (x *GetSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetSessionRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{41}
+}
+
+type GetSessionReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Principal     *Principal             `protobuf:"bytes,1,opt,name=principal,proto3" json:"principal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSessionReply) Reset() {
+	*x = GetSessionReply{}
+	mi := &file_accountsrv_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSessionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionReply) ProtoMessage() {}
+
+func (x *GetSessionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionReply.ProtoReflect.Descriptor instead.
+func (*GetSessionReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetSessionReply) GetPrincipal() *Principal {
+	if x != nil {
+		return x.Principal
+	}
+	return nil
+}
+
+type ListOrgUsersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	OrgId  string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Role   string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Status string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// Matched against the users' names and emails
+	Q string `protobuf:"bytes,4,opt,name=q,proto3" json:"q,omitempty"`
+	// name (the default), joined_on or last_login
+	Sort  string `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	Desc  bool   `protobuf:"varint,6,opt,name=desc,proto3" json:"desc,omitempty"`
+	Limit int32  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor or prev_cursor from the page before
+	Cursor        string `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrgUsersRequest) Reset() {
+	*x = ListOrgUsersRequest{}
+	mi := &file_accountsrv_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrgUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgUsersRequest) ProtoMessage() {}
+
+func (x *ListOrgUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrgUsersRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListOrgUsersRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ListOrgUsersRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListOrgUsersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListOrgUsersRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *ListOrgUsersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListOrgUsersRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *ListOrgUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListOrgUsersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type OrgMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *UserAccount           `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Profile       *UserProfile           `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgMember) Reset() {
+	*x = OrgMember{}
+	mi := &file_accountsrv_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgMember) ProtoMessage() {}
+
+func (x *OrgMember) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgMember.ProtoReflect.Descriptor instead.
+func (*OrgMember) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *OrgMember) GetAccount() *UserAccount {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *OrgMember) GetProfile() *UserProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *OrgMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ListOrgUsersReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*OrgMember           `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor    string                 `protobuf:"bytes,4,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrgUsersReply) Reset() {
+	*x = ListOrgUsersReply{}
+	mi := &file_accountsrv_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrgUsersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgUsersReply) ProtoMessage() {}
+
+func (x *ListOrgUsersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgUsersReply.ProtoReflect.Descriptor instead.
+func (*ListOrgUsersReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListOrgUsersReply) GetUsers() []*OrgMember {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListOrgUsersReply) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListOrgUsersReply) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListOrgUsersReply) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+// Replaces the payor's payer IDs
+type UpdatePayorDetailsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PayorDetails  *PayorDetails          `protobuf:"bytes,2,opt,name=payor_details,json=payorDetails,proto3" json:"payor_details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePayorDetailsRequest) Reset() {
+	*x = UpdatePayorDetailsRequest{}
+	mi := &file_accountsrv_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePayorDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePayorDetailsRequest) ProtoMessage() {}
+
+func (x *UpdatePayorDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePayorDetailsRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePayorDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UpdatePayorDetailsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdatePayorDetailsRequest) GetPayorDetails() *PayorDetails {
+	if x != nil {
+		return x.PayorDetails
+	}
+	return nil
+}
+
+type UpdatePayorDetailsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePayorDetailsReply) Reset() {
+	*x = UpdatePayorDetailsReply{}
+	mi := &file_accountsrv_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePayorDetailsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePayorDetailsReply) ProtoMessage() {}
+
+func (x *UpdatePayorDetailsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePayorDetailsReply.ProtoReflect.Descriptor instead.
+func (*UpdatePayorDetailsReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *UpdatePayorDetailsReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type FindPayorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PayerId       string                 `protobuf:"bytes,1,opt,name=payer_id,json=payerId,proto3" json:"payer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindPayorRequest) Reset() {
+	*x = FindPayorRequest{}
+	mi := &file_accountsrv_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindPayorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindPayorRequest) ProtoMessage() {}
+
+func (x *FindPayorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindPayorRequest.ProtoReflect.Descriptor instead.
+func (*FindPayorRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *FindPayorRequest) GetPayerId() string {
+	if x != nil {
+		return x.PayerId
+	}
+	return ""
+}
+
+type FindPayorReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Org           *DetailedOrg           `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindPayorReply) Reset() {
+	*x = FindPayorReply{}
+	mi := &file_accountsrv_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindPayorReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindPayorReply) ProtoMessage() {}
+
+func (x *FindPayorReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindPayorReply.ProtoReflect.Descriptor instead.
+func (*FindPayorReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *FindPayorReply) GetOrg() *DetailedOrg {
+	if x != nil {
+		return x.Org
+	}
+	return nil
+}
+
+type Invite struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId      string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Email      string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role       string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	InvitedBy  string                 `protobuf:"bytes,5,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	CreatedAt  string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt  string                 `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	AcceptedAt string                 `protobuf:"bytes,8,opt,name=accepted_at,json=acceptedAt,proto3" json:"accepted_at,omitempty"`
+	AcceptedBy string                 `protobuf:"bytes,9,opt,name=accepted_by,json=acceptedBy,proto3" json:"accepted_by,omitempty"`
+	RevokedAt  string                 `protobuf:"bytes,10,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	// pending, accepted, revoked or expired
+	Status        string `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invite) Reset() {
+	*x = Invite{}
+	mi := &file_accountsrv_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invite) ProtoMessage() {}
+
+func (x *Invite) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invite.ProtoReflect.Descriptor instead.
+func (*Invite) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *Invite) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Invite) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *Invite) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Invite) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Invite) GetInvitedBy() string {
+	if x != nil {
+		return x.InvitedBy
+	}
+	return ""
+}
+
+func (x *Invite) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Invite) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Invite) GetAcceptedAt() string {
+	if x != nil {
+		return x.AcceptedAt
+	}
+	return ""
+}
+
+func (x *Invite) GetAcceptedBy() string {
+	if x != nil {
+		return x.AcceptedBy
+	}
+	return ""
+}
+
+func (x *Invite) GetRevokedAt() string {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return ""
+}
+
+func (x *Invite) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type CreateInviteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	OrgId string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Email string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	// member when left out
+	Role          string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInviteRequest) Reset() {
+	*x = CreateInviteRequest{}
+	mi := &file_accountsrv_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteRequest) ProtoMessage() {}
+
+func (x *CreateInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInviteRequest.ProtoReflect.Descriptor instead.
+func (*CreateInviteRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CreateInviteRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *CreateInviteRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateInviteRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// The token is what the invitee accepts the invite with
+type CreateInviteReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invite        *Invite                `protobuf:"bytes,1,opt,name=invite,proto3" json:"invite,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInviteReply) Reset() {
+	*x = CreateInviteReply{}
+	mi := &file_accountsrv_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteReply) ProtoMessage() {}
+
+func (x *CreateInviteReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInviteReply.ProtoReflect.Descriptor instead.
+func (*CreateInviteReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CreateInviteReply) GetInvite() *Invite {
+	if x != nil {
+		return x.Invite
+	}
+	return nil
+}
+
+func (x *CreateInviteReply) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ListInvitesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitesRequest) Reset() {
+	*x = ListInvitesRequest{}
+	mi := &file_accountsrv_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitesRequest) ProtoMessage() {}
+
+func (x *ListInvitesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitesRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitesRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListInvitesRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type ListInvitesReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invites       []*Invite              `protobuf:"bytes,1,rep,name=invites,proto3" json:"invites,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitesReply) Reset() {
+	*x = ListInvitesReply{}
+	mi := &file_accountsrv_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitesReply) ProtoMessage() {}
+
+func (x *ListInvitesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitesReply.ProtoReflect.Descriptor instead.
+func (*ListInvitesReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListInvitesReply) GetInvites() []*Invite {
+	if x != nil {
+		return x.Invites
+	}
+	return nil
+}
+
+type RevokeInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	InviteId      string                 `protobuf:"bytes,2,opt,name=invite_id,json=inviteId,proto3" json:"invite_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInviteRequest) Reset() {
+	*x = RevokeInviteRequest{}
+	mi := &file_accountsrv_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInviteRequest) ProtoMessage() {}
+
+func (x *RevokeInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInviteRequest.ProtoReflect.Descriptor instead.
+func (*RevokeInviteRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RevokeInviteRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RevokeInviteRequest) GetInviteId() string {
+	if x != nil {
+		return x.InviteId
+	}
+	return ""
+}
+
+type RevokeInviteReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInviteReply) Reset() {
+	*x = RevokeInviteReply{}
+	mi := &file_accountsrv_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInviteReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInviteReply) ProtoMessage() {}
+
+func (x *RevokeInviteReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInviteReply.ProtoReflect.Descriptor instead.
+func (*RevokeInviteReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *RevokeInviteReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type ResendInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	InviteId      string                 `protobuf:"bytes,2,opt,name=invite_id,json=inviteId,proto3" json:"invite_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendInviteRequest) Reset() {
+	*x = ResendInviteRequest{}
+	mi := &file_accountsrv_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendInviteRequest) ProtoMessage() {}
+
+func (x *ResendInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendInviteRequest.ProtoReflect.Descriptor instead.
+func (*ResendInviteRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ResendInviteRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ResendInviteRequest) GetInviteId() string {
+	if x != nil {
+		return x.InviteId
+	}
+	return ""
+}
+
+type ResendInviteReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invite        *Invite                `protobuf:"bytes,1,opt,name=invite,proto3" json:"invite,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendInviteReply) Reset() {
+	*x = ResendInviteReply{}
+	mi := &file_accountsrv_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendInviteReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendInviteReply) ProtoMessage() {}
+
+func (x *ResendInviteReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendInviteReply.ProtoReflect.Descriptor instead.
+func (*ResendInviteReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ResendInviteReply) GetInvite() *Invite {
+	if x != nil {
+		return x.Invite
+	}
+	return nil
+}
+
+func (x *ResendInviteReply) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Everything but the token is only needed when the caller isn't logged in, i.e.
+// when accepting the invite creates their account.
+type AcceptInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	FirstName     string                 `protobuf:"bytes,4,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,5,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Phone         string                 `protobuf:"bytes,6,opt,name=phone,proto3" json:"phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptInviteRequest) Reset() {
+	*x = AcceptInviteRequest{}
+	mi := &file_accountsrv_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptInviteRequest) ProtoMessage() {}
+
+func (x *AcceptInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptInviteRequest.ProtoReflect.Descriptor instead.
+func (*AcceptInviteRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *AcceptInviteRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AcceptInviteRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AcceptInviteRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *AcceptInviteRequest) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *AcceptInviteRequest) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *AcceptInviteRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+type AcceptInviteReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptInviteReply) Reset() {
+	*x = AcceptInviteReply{}
+	mi := &file_accountsrv_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptInviteReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptInviteReply) ProtoMessage() {}
+
+func (x *AcceptInviteReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptInviteReply.ProtoReflect.Descriptor instead.
+func (*AcceptInviteReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *AcceptInviteReply) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Emails the user a new link to verify their address with
+type SendEmailVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendEmailVerificationRequest) Reset() {
+	*x = SendEmailVerificationRequest{}
+	mi := &file_accountsrv_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendEmailVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendEmailVerificationRequest) ProtoMessage() {}
+
+func (x *SendEmailVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendEmailVerificationRequest.ProtoReflect.Descriptor instead.
+func (*SendEmailVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *SendEmailVerificationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type SendEmailVerificationReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendEmailVerificationReply) Reset() {
+	*x = SendEmailVerificationReply{}
+	mi := &file_accountsrv_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendEmailVerificationReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendEmailVerificationReply) ProtoMessage() {}
+
+func (x *SendEmailVerificationReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendEmailVerificationReply.ProtoReflect.Descriptor instead.
+func (*SendEmailVerificationReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SendEmailVerificationReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+// The token is the one from the link in the verification email
+type VerifyEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_accountsrv_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *VerifyEmailRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *VerifyEmailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type VerifyEmailReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailReply) Reset() {
+	*x = VerifyEmailReply{}
+	mi := &file_accountsrv_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailReply) ProtoMessage() {}
+
+func (x *VerifyEmailReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailReply.ProtoReflect.Descriptor instead.
+func (*VerifyEmailReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *VerifyEmailReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+// Texts the user a new code to verify their phone number with
+type SendPhoneVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendPhoneVerificationRequest) Reset() {
+	*x = SendPhoneVerificationRequest{}
+	mi := &file_accountsrv_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendPhoneVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendPhoneVerificationRequest) ProtoMessage() {}
+
+func (x *SendPhoneVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendPhoneVerificationRequest.ProtoReflect.Descriptor instead.
+func (*SendPhoneVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *SendPhoneVerificationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type SendPhoneVerificationReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendPhoneVerificationReply) Reset() {
+	*x = SendPhoneVerificationReply{}
+	mi := &file_accountsrv_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendPhoneVerificationReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendPhoneVerificationReply) ProtoMessage() {}
+
+func (x *SendPhoneVerificationReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendPhoneVerificationReply.ProtoReflect.Descriptor instead.
+func (*SendPhoneVerificationReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *SendPhoneVerificationReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+// The code is the one from the verification text message
+type VerifyPhoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPhoneRequest) Reset() {
+	*x = VerifyPhoneRequest{}
+	mi := &file_accountsrv_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPhoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPhoneRequest) ProtoMessage() {}
+
+func (x *VerifyPhoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPhoneRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPhoneRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *VerifyPhoneRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *VerifyPhoneRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type VerifyPhoneReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPhoneReply) Reset() {
+	*x = VerifyPhoneReply{}
+	mi := &file_accountsrv_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPhoneReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPhoneReply) ProtoMessage() {}
+
+func (x *VerifyPhoneReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPhoneReply.ProtoReflect.Descriptor instead.
+func (*VerifyPhoneReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *VerifyPhoneReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type RequestPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_accountsrv_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *RequestPasswordResetRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RequestPasswordResetRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type RequestPasswordResetReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetReply) Reset() {
+	*x = RequestPasswordResetReply{}
+	mi := &file_accountsrv_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetReply) ProtoMessage() {}
+
+func (x *RequestPasswordResetReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetReply.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *RequestPasswordResetReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type ResetPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_accountsrv_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ResetPasswordRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type ResetPasswordReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordReply) Reset() {
+	*x = ResetPasswordReply{}
+	mi := &file_accountsrv_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordReply) ProtoMessage() {}
+
+func (x *ResetPasswordReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordReply.ProtoReflect.Descriptor instead.
+func (*ResetPasswordReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ResetPasswordReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type CompleteMFALoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteMFALoginRequest) Reset() {
+	*x = CompleteMFALoginRequest{}
+	mi := &file_accountsrv_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteMFALoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteMFALoginRequest) ProtoMessage() {}
+
+func (x *CompleteMFALoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteMFALoginRequest.ProtoReflect.Descriptor instead.
+func (*CompleteMFALoginRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CompleteMFALoginRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CompleteMFALoginRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type CompleteMFALoginReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LoginDetails  *LoginUser             `protobuf:"bytes,1,opt,name=login_details,json=loginDetails,proto3" json:"login_details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteMFALoginReply) Reset() {
+	*x = CompleteMFALoginReply{}
+	mi := &file_accountsrv_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteMFALoginReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteMFALoginReply) ProtoMessage() {}
+
+func (x *CompleteMFALoginReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteMFALoginReply.ProtoReflect.Descriptor instead.
+func (*CompleteMFALoginReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *CompleteMFALoginReply) GetLoginDetails() *LoginUser {
+	if x != nil {
+		return x.LoginDetails
+	}
+	return nil
+}
+
+type EnrollTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPRequest) Reset() {
+	*x = EnrollTOTPRequest{}
+	mi := &file_accountsrv_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPRequest) ProtoMessage() {}
+
+func (x *EnrollTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *EnrollTOTPRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type EnrollTOTPReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enrollment    *TOTPEnrollment        `protobuf:"bytes,1,opt,name=enrollment,proto3" json:"enrollment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPReply) Reset() {
+	*x = EnrollTOTPReply{}
+	mi := &file_accountsrv_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPReply) ProtoMessage() {}
+
+func (x *EnrollTOTPReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPReply.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *EnrollTOTPReply) GetEnrollment() *TOTPEnrollment {
+	if x != nil {
+		return x.Enrollment
+	}
+	return nil
+}
+
+type ConfirmTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPRequest) Reset() {
+	*x = ConfirmTOTPRequest{}
+	mi := &file_accountsrv_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPRequest) ProtoMessage() {}
+
+func (x *ConfirmTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ConfirmTOTPRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ConfirmTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmTOTPReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPReply) Reset() {
+	*x = ConfirmTOTPReply{}
+	mi := &file_accountsrv_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPReply) ProtoMessage() {}
+
+func (x *ConfirmTOTPReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPReply.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ConfirmTOTPReply) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type DisableMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableMFARequest) Reset() {
+	*x = DisableMFARequest{}
+	mi := &file_accountsrv_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableMFARequest) ProtoMessage() {}
+
+func (x *DisableMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableMFARequest.ProtoReflect.Descriptor instead.
+func (*DisableMFARequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *DisableMFARequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DisableMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type DisableMFAReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableMFAReply) Reset() {
+	*x = DisableMFAReply{}
+	mi := &file_accountsrv_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableMFAReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableMFAReply) ProtoMessage() {}
+
+func (x *DisableMFAReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableMFAReply.ProtoReflect.Descriptor instead.
+func (*DisableMFAReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *DisableMFAReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type ResetMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetMFARequest) Reset() {
+	*x = ResetMFARequest{}
+	mi := &file_accountsrv_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetMFARequest) ProtoMessage() {}
+
+func (x *ResetMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetMFARequest.ProtoReflect.Descriptor instead.
+func (*ResetMFARequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ResetMFARequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ResetMFAReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetMFAReply) Reset() {
+	*x = ResetMFAReply{}
+	mi := &file_accountsrv_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetMFAReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetMFAReply) ProtoMessage() {}
+
+func (x *ResetMFAReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetMFAReply.ProtoReflect.Descriptor instead.
+func (*ResetMFAReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ResetMFAReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type Passkey struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Transports     []string               `protobuf:"bytes,3,rep,name=transports,proto3" json:"transports,omitempty"`
+	BackupEligible bool                   `protobuf:"varint,4,opt,name=backup_eligible,json=backupEligible,proto3" json:"backup_eligible,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt     string                 `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Passkey) Reset() {
+	*x = Passkey{}
+	mi := &file_accountsrv_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Passkey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Passkey) ProtoMessage() {}
+
+func (x *Passkey) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Passkey.ProtoReflect.Descriptor instead.
+func (*Passkey) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *Passkey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Passkey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Passkey) GetTransports() []string {
+	if x != nil {
+		return x.Transports
+	}
+	return nil
+}
+
+func (x *Passkey) GetBackupEligible() bool {
+	if x != nil {
+		return x.BackupEligible
+	}
+	return false
+}
+
+func (x *Passkey) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Passkey) GetLastUsedAt() string {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return ""
+}
+
+// options is the JSON for navigator.credentials.create() or .get(), passed
+// through to the browser as it is
+type PasskeyCeremony struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Options       string                 `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PasskeyCeremony) Reset() {
+	*x = PasskeyCeremony{}
+	mi := &file_accountsrv_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasskeyCeremony) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasskeyCeremony) ProtoMessage() {}
+
+func (x *PasskeyCeremony) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasskeyCeremony.ProtoReflect.Descriptor instead.
+func (*PasskeyCeremony) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *PasskeyCeremony) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PasskeyCeremony) GetOptions() string {
+	if x != nil {
+		return x.Options
+	}
+	return ""
+}
+
+type BeginPasskeyRegistrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginPasskeyRegistrationRequest) Reset() {
+	*x = BeginPasskeyRegistrationRequest{}
+	mi := &file_accountsrv_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginPasskeyRegistrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginPasskeyRegistrationRequest) ProtoMessage() {}
+
+func (x *BeginPasskeyRegistrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginPasskeyRegistrationRequest.ProtoReflect.Descriptor instead.
+func (*BeginPasskeyRegistrationRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *BeginPasskeyRegistrationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type BeginPasskeyRegistrationReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ceremony      *PasskeyCeremony       `protobuf:"bytes,1,opt,name=ceremony,proto3" json:"ceremony,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginPasskeyRegistrationReply) Reset() {
+	*x = BeginPasskeyRegistrationReply{}
+	mi := &file_accountsrv_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginPasskeyRegistrationReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginPasskeyRegistrationReply) ProtoMessage() {}
+
+func (x *BeginPasskeyRegistrationReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginPasskeyRegistrationReply.ProtoReflect.Descriptor instead.
+func (*BeginPasskeyRegistrationReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *BeginPasskeyRegistrationReply) GetCeremony() *PasskeyCeremony {
+	if x != nil {
+		return x.Ceremony
+	}
+	return nil
+}
+
+// credential is the JSON of what navigator.credentials.create() resolved to
+type FinishPasskeyRegistrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Credential    string                 `protobuf:"bytes,4,opt,name=credential,proto3" json:"credential,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishPasskeyRegistrationRequest) Reset() {
+	*x = FinishPasskeyRegistrationRequest{}
+	mi := &file_accountsrv_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishPasskeyRegistrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishPasskeyRegistrationRequest) ProtoMessage() {}
+
+func (x *FinishPasskeyRegistrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishPasskeyRegistrationRequest.ProtoReflect.Descriptor instead.
+func (*FinishPasskeyRegistrationRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *FinishPasskeyRegistrationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FinishPasskeyRegistrationRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *FinishPasskeyRegistrationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FinishPasskeyRegistrationRequest) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+type FinishPasskeyRegistrationReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passkey       *Passkey               `protobuf:"bytes,1,opt,name=passkey,proto3" json:"passkey,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishPasskeyRegistrationReply) Reset() {
+	*x = FinishPasskeyRegistrationReply{}
+	mi := &file_accountsrv_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishPasskeyRegistrationReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishPasskeyRegistrationReply) ProtoMessage() {}
+
+func (x *FinishPasskeyRegistrationReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishPasskeyRegistrationReply.ProtoReflect.Descriptor instead.
+func (*FinishPasskeyRegistrationReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *FinishPasskeyRegistrationReply) GetPasskey() *Passkey {
+	if x != nil {
+		return x.Passkey
+	}
+	return nil
+}
+
+type ListPasskeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPasskeysRequest) Reset() {
+	*x = ListPasskeysRequest{}
+	mi := &file_accountsrv_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPasskeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPasskeysRequest) ProtoMessage() {}
+
+func (x *ListPasskeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPasskeysRequest.ProtoReflect.Descriptor instead.
+func (*ListPasskeysRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ListPasskeysRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListPasskeysReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passkeys      []*Passkey             `protobuf:"bytes,1,rep,name=passkeys,proto3" json:"passkeys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPasskeysReply) Reset() {
+	*x = ListPasskeysReply{}
+	mi := &file_accountsrv_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPasskeysReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPasskeysReply) ProtoMessage() {}
+
+func (x *ListPasskeysReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPasskeysReply.ProtoReflect.Descriptor instead.
+func (*ListPasskeysReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ListPasskeysReply) GetPasskeys() []*Passkey {
+	if x != nil {
+		return x.Passkeys
+	}
+	return nil
+}
+
+type DeletePasskeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PasskeyId     string                 `protobuf:"bytes,2,opt,name=passkey_id,json=passkeyId,proto3" json:"passkey_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePasskeyRequest) Reset() {
+	*x = DeletePasskeyRequest{}
+	mi := &file_accountsrv_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePasskeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePasskeyRequest) ProtoMessage() {}
+
+func (x *DeletePasskeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePasskeyRequest.ProtoReflect.Descriptor instead.
+func (*DeletePasskeyRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *DeletePasskeyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeletePasskeyRequest) GetPasskeyId() string {
+	if x != nil {
+		return x.PasskeyId
+	}
+	return ""
+}
+
+type DeletePasskeyReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePasskeyReply) Reset() {
+	*x = DeletePasskeyReply{}
+	mi := &file_accountsrv_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePasskeyReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePasskeyReply) ProtoMessage() {}
+
+func (x *DeletePasskeyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePasskeyReply.ProtoReflect.Descriptor instead.
+func (*DeletePasskeyReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *DeletePasskeyReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type BeginPasskeyLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginPasskeyLoginRequest) Reset() {
+	*x = BeginPasskeyLoginRequest{}
+	mi := &file_accountsrv_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginPasskeyLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginPasskeyLoginRequest) ProtoMessage() {}
+
+func (x *BeginPasskeyLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginPasskeyLoginRequest.ProtoReflect.Descriptor instead.
+func (*BeginPasskeyLoginRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *BeginPasskeyLoginRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type BeginPasskeyLoginReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ceremony      *PasskeyCeremony       `protobuf:"bytes,1,opt,name=ceremony,proto3" json:"ceremony,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginPasskeyLoginReply) Reset() {
+	*x = BeginPasskeyLoginReply{}
+	mi := &file_accountsrv_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginPasskeyLoginReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginPasskeyLoginReply) ProtoMessage() {}
+
+func (x *BeginPasskeyLoginReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginPasskeyLoginReply.ProtoReflect.Descriptor instead.
+func (*BeginPasskeyLoginReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *BeginPasskeyLoginReply) GetCeremony() *PasskeyCeremony {
+	if x != nil {
+		return x.Ceremony
+	}
+	return nil
+}
+
+// credential is the JSON of what navigator.credentials.get() resolved to
+type PasskeyLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Credential    string                 `protobuf:"bytes,3,opt,name=credential,proto3" json:"credential,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PasskeyLoginRequest) Reset() {
+	*x = PasskeyLoginRequest{}
+	mi := &file_accountsrv_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasskeyLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasskeyLoginRequest) ProtoMessage() {}
+
+func (x *PasskeyLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasskeyLoginRequest.ProtoReflect.Descriptor instead.
+func (*PasskeyLoginRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *PasskeyLoginRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *PasskeyLoginRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PasskeyLoginRequest) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+type PasskeyLoginReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LoginDetails  *LoginUser             `protobuf:"bytes,1,opt,name=login_details,json=loginDetails,proto3" json:"login_details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PasskeyLoginReply) Reset() {
+	*x = PasskeyLoginReply{}
+	mi := &file_accountsrv_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasskeyLoginReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasskeyLoginReply) ProtoMessage() {}
+
+func (x *PasskeyLoginReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasskeyLoginReply.ProtoReflect.Descriptor instead.
+func (*PasskeyLoginReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *PasskeyLoginReply) GetLoginDetails() *LoginUser {
+	if x != nil {
+		return x.LoginDetails
+	}
+	return nil
+}
+
+type RequestMagicLinkRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	OrgId string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// One or the other
+	Username      string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestMagicLinkRequest) Reset() {
+	*x = RequestMagicLinkRequest{}
+	mi := &file_accountsrv_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestMagicLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestMagicLinkRequest) ProtoMessage() {}
+
+func (x *RequestMagicLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestMagicLinkRequest.ProtoReflect.Descriptor instead.
+func (*RequestMagicLinkRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *RequestMagicLinkRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RequestMagicLinkRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RequestMagicLinkRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type RequestMagicLinkReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestMagicLinkReply) Reset() {
+	*x = RequestMagicLinkReply{}
+	mi := &file_accountsrv_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestMagicLinkReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestMagicLinkReply) ProtoMessage() {}
+
+func (x *RequestMagicLinkReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestMagicLinkReply.ProtoReflect.Descriptor instead.
+func (*RequestMagicLinkReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *RequestMagicLinkReply) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type MagicLinkLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MagicLinkLoginRequest) Reset() {
+	*x = MagicLinkLoginRequest{}
+	mi := &file_accountsrv_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MagicLinkLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MagicLinkLoginRequest) ProtoMessage() {}
+
+func (x *MagicLinkLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MagicLinkLoginRequest.ProtoReflect.Descriptor instead.
+func (*MagicLinkLoginRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *MagicLinkLoginRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *MagicLinkLoginRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type RequestSMSCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestSMSCodeRequest) Reset() {
+	*x = RequestSMSCodeRequest{}
+	mi := &file_accountsrv_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestSMSCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestSMSCodeRequest) ProtoMessage() {}
+
+func (x *RequestSMSCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestSMSCodeRequest.ProtoReflect.Descriptor instead.
+func (*RequestSMSCodeRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *RequestSMSCodeRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RequestSMSCodeRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type SMSCodeChallenge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SMSCodeChallenge) Reset() {
+	*x = SMSCodeChallenge{}
+	mi := &file_accountsrv_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SMSCodeChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SMSCodeChallenge) ProtoMessage() {}
+
+func (x *SMSCodeChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SMSCodeChallenge.ProtoReflect.Descriptor instead.
+func (*SMSCodeChallenge) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *SMSCodeChallenge) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SMSCodeChallenge) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type RequestSMSCodeReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Challenge     *SMSCodeChallenge      `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestSMSCodeReply) Reset() {
+	*x = RequestSMSCodeReply{}
+	mi := &file_accountsrv_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestSMSCodeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestSMSCodeReply) ProtoMessage() {}
+
+func (x *RequestSMSCodeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestSMSCodeReply.ProtoReflect.Descriptor instead.
+func (*RequestSMSCodeReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *RequestSMSCodeReply) GetChallenge() *SMSCodeChallenge {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+type SMSCodeLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SMSCodeLoginRequest) Reset() {
+	*x = SMSCodeLoginRequest{}
+	mi := &file_accountsrv_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SMSCodeLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SMSCodeLoginRequest) ProtoMessage() {}
+
+func (x *SMSCodeLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SMSCodeLoginRequest.ProtoReflect.Descriptor instead.
+func (*SMSCodeLoginRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *SMSCodeLoginRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SMSCodeLoginRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SMSCodeLoginRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type LoginWithoutOrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginWithoutOrgRequest) Reset() {
+	*x = LoginWithoutOrgRequest{}
+	mi := &file_accountsrv_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginWithoutOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginWithoutOrgRequest) ProtoMessage() {}
+
+func (x *LoginWithoutOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginWithoutOrgRequest.ProtoReflect.Descriptor instead.
+func (*LoginWithoutOrgRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *LoginWithoutOrgRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LoginWithoutOrgRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type OrgSelection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Orgs          []*OrgMembership       `protobuf:"bytes,3,rep,name=orgs,proto3" json:"orgs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgSelection) Reset() {
+	*x = OrgSelection{}
+	mi := &file_accountsrv_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgSelection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgSelection) ProtoMessage() {}
+
+func (x *OrgSelection) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgSelection.ProtoReflect.Descriptor instead.
+func (*OrgSelection) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *OrgSelection) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *OrgSelection) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *OrgSelection) GetOrgs() []*OrgMembership {
+	if x != nil {
+		return x.Orgs
+	}
+	return nil
+}
+
+type LoginWithoutOrgReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Selection     *OrgSelection          `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginWithoutOrgReply) Reset() {
+	*x = LoginWithoutOrgReply{}
+	mi := &file_accountsrv_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginWithoutOrgReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginWithoutOrgReply) ProtoMessage() {}
+
+func (x *LoginWithoutOrgReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginWithoutOrgReply.ProtoReflect.Descriptor instead.
+func (*LoginWithoutOrgReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *LoginWithoutOrgReply) GetSelection() *OrgSelection {
+	if x != nil {
+		return x.Selection
+	}
+	return nil
+}
+
+type ChooseLoginOrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChooseLoginOrgRequest) Reset() {
+	*x = ChooseLoginOrgRequest{}
+	mi := &file_accountsrv_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChooseLoginOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChooseLoginOrgRequest) ProtoMessage() {}
+
+func (x *ChooseLoginOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChooseLoginOrgRequest.ProtoReflect.Descriptor instead.
+func (*ChooseLoginOrgRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *ChooseLoginOrgRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ChooseLoginOrgRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+// The session being switched is whoever the token in the authorization metadata says
+type SwitchOrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwitchOrgRequest) Reset() {
+	*x = SwitchOrgRequest{}
+	mi := &file_accountsrv_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwitchOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwitchOrgRequest) ProtoMessage() {}
+
+func (x *SwitchOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwitchOrgRequest.ProtoReflect.Descriptor instead.
+func (*SwitchOrgRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *SwitchOrgRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type ListMyOrgsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyOrgsRequest) Reset() {
+	*x = ListMyOrgsRequest{}
+	mi := &file_accountsrv_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyOrgsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyOrgsRequest) ProtoMessage() {}
+
+func (x *ListMyOrgsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyOrgsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyOrgsRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{109}
+}
+
+type ListMyOrgsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orgs          []*OrgMembership       `protobuf:"bytes,1,rep,name=orgs,proto3" json:"orgs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyOrgsReply) Reset() {
+	*x = ListMyOrgsReply{}
+	mi := &file_accountsrv_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyOrgsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyOrgsReply) ProtoMessage() {}
+
+func (x *ListMyOrgsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyOrgsReply.ProtoReflect.Descriptor instead.
+func (*ListMyOrgsReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *ListMyOrgsReply) GetOrgs() []*OrgMembership {
+	if x != nil {
+		return x.Orgs
+	}
+	return nil
+}
+
+type ListChildOrgsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChildOrgsRequest) Reset() {
+	*x = ListChildOrgsRequest{}
+	mi := &file_accountsrv_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChildOrgsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChildOrgsRequest) ProtoMessage() {}
+
+func (x *ListChildOrgsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChildOrgsRequest.ProtoReflect.Descriptor instead.
+func (*ListChildOrgsRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *ListChildOrgsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type ListChildOrgsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orgs          []*OrgAccount          `protobuf:"bytes,1,rep,name=orgs,proto3" json:"orgs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChildOrgsReply) Reset() {
+	*x = ListChildOrgsReply{}
+	mi := &file_accountsrv_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChildOrgsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChildOrgsReply) ProtoMessage() {}
+
+func (x *ListChildOrgsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChildOrgsReply.ProtoReflect.Descriptor instead.
+func (*ListChildOrgsReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *ListChildOrgsReply) GetOrgs() []*OrgAccount {
+	if x != nil {
+		return x.Orgs
+	}
+	return nil
+}
+
+type GetOrgTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgTreeRequest) Reset() {
+	*x = GetOrgTreeRequest{}
+	mi := &file_accountsrv_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgTreeRequest) ProtoMessage() {}
+
+func (x *GetOrgTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetOrgTreeRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *GetOrgTreeRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type OrgTree struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Org           *OrgAccount            `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	Children      []*OrgTree             `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgTree) Reset() {
+	*x = OrgTree{}
+	mi := &file_accountsrv_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgTree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgTree) ProtoMessage() {}
+
+func (x *OrgTree) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgTree.ProtoReflect.Descriptor instead.
+func (*OrgTree) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *OrgTree) GetOrg() *OrgAccount {
+	if x != nil {
+		return x.Org
+	}
+	return nil
+}
+
+func (x *OrgTree) GetChildren() []*OrgTree {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type GetOrgTreeReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// From the top of the tree down to the org's parent
+	Ancestors     []*OrgAccount `protobuf:"bytes,1,rep,name=ancestors,proto3" json:"ancestors,omitempty"`
+	Tree          *OrgTree      `protobuf:"bytes,2,opt,name=tree,proto3" json:"tree,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgTreeReply) Reset() {
+	*x = GetOrgTreeReply{}
+	mi := &file_accountsrv_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgTreeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgTreeReply) ProtoMessage() {}
+
+func (x *GetOrgTreeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgTreeReply.ProtoReflect.Descriptor instead.
+func (*GetOrgTreeReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *GetOrgTreeReply) GetAncestors() []*OrgAccount {
+	if x != nil {
+		return x.Ancestors
+	}
+	return nil
+}
+
+func (x *GetOrgTreeReply) GetTree() *OrgTree {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+// A provider being in-network with a payor, dates are YYYY-MM-DD
+type NetworkRelationship struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProviderId   string                 `protobuf:"bytes,2,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	ProviderName string                 `protobuf:"bytes,3,opt,name=provider_name,json=providerName,proto3" json:"provider_name,omitempty"`
+	PayorId      string                 `protobuf:"bytes,4,opt,name=payor_id,json=payorId,proto3" json:"payor_id,omitempty"`
+	PayorName    string                 `protobuf:"bytes,5,opt,name=payor_name,json=payorName,proto3" json:"payor_name,omitempty"`
+	// pending, active or terminated
+	Status          string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	EffectiveDate   string `protobuf:"bytes,7,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	TerminationDate string `protobuf:"bytes,8,opt,name=termination_date,json=terminationDate,proto3" json:"termination_date,omitempty"`
+	RequestedBy     string `protobuf:"bytes,9,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	RequestedAt     string `protobuf:"bytes,10,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	AcceptedAt      string `protobuf:"bytes,11,opt,name=accepted_at,json=acceptedAt,proto3" json:"accepted_at,omitempty"`
+	TerminatedAt    string `protobuf:"bytes,12,opt,name=terminated_at,json=terminatedAt,proto3" json:"terminated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *NetworkRelationship) Reset() {
+	*x = NetworkRelationship{}
+	mi := &file_accountsrv_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkRelationship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkRelationship) ProtoMessage() {}
+
+func (x *NetworkRelationship) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkRelationship.ProtoReflect.Descriptor instead.
+func (*NetworkRelationship) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *NetworkRelationship) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NetworkRelationship) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *NetworkRelationship) GetProviderName() string {
+	if x != nil {
+		return x.ProviderName
+	}
+	return ""
+}
+
+func (x *NetworkRelationship) GetPayorId() string {
+	if x != nil {
+		return x.PayorId
+	}
+	return ""
+}
+
+func (x *NetworkRelationship) GetPayorName() string {
+	if x != nil {
+		return x.PayorName
+	}
+	return ""
+}
+
+func (x *NetworkRelationship) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *NetworkRelationship) GetEffectiveDate() string {
+	if x != nil {
+		return x.EffectiveDate
+	}
+	return ""
+}
+
+func (x *NetworkRelationship) GetTerminationDate() string {
+	if x != nil {
+		return x.TerminationDate
+	}
+	return ""
+}
+
+func (x *NetworkRelationship) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *NetworkRelationship) GetRequestedAt() string {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return ""
+}
+
+func (x *NetworkRelationship) GetAcceptedAt() string {
+	if x != nil {
+		return x.AcceptedAt
+	}
+	return ""
+}
+
+func (x *NetworkRelationship) GetTerminatedAt() string {
+	if x != nil {
+		return x.TerminatedAt
+	}
+	return ""
+}
+
+type RequestNetworkRelationshipRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OrgId           string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	PartnerId       string                 `protobuf:"bytes,2,opt,name=partner_id,json=partnerId,proto3" json:"partner_id,omitempty"`
+	EffectiveDate   string                 `protobuf:"bytes,3,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	TerminationDate string                 `protobuf:"bytes,4,opt,name=termination_date,json=terminationDate,proto3" json:"termination_date,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RequestNetworkRelationshipRequest) Reset() {
+	*x = RequestNetworkRelationshipRequest{}
+	mi := &file_accountsrv_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestNetworkRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestNetworkRelationshipRequest) ProtoMessage() {}
+
+func (x *RequestNetworkRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestNetworkRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*RequestNetworkRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *RequestNetworkRelationshipRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RequestNetworkRelationshipRequest) GetPartnerId() string {
+	if x != nil {
+		return x.PartnerId
+	}
+	return ""
+}
+
+func (x *RequestNetworkRelationshipRequest) GetEffectiveDate() string {
+	if x != nil {
+		return x.EffectiveDate
+	}
+	return ""
+}
+
+func (x *RequestNetworkRelationshipRequest) GetTerminationDate() string {
+	if x != nil {
+		return x.TerminationDate
+	}
+	return ""
+}
+
+// For both accepting and terminating a relationship
+type NetworkRelationshipActionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrgId          string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	RelationshipId string                 `protobuf:"bytes,2,opt,name=relationship_id,json=relationshipId,proto3" json:"relationship_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NetworkRelationshipActionRequest) Reset() {
+	*x = NetworkRelationshipActionRequest{}
+	mi := &file_accountsrv_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkRelationshipActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkRelationshipActionRequest) ProtoMessage() {}
+
+func (x *NetworkRelationshipActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkRelationshipActionRequest.ProtoReflect.Descriptor instead.
+func (*NetworkRelationshipActionRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *NetworkRelationshipActionRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *NetworkRelationshipActionRequest) GetRelationshipId() string {
+	if x != nil {
+		return x.RelationshipId
+	}
+	return ""
+}
+
+type NetworkRelationshipReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relationship  *NetworkRelationship   `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetworkRelationshipReply) Reset() {
+	*x = NetworkRelationshipReply{}
+	mi := &file_accountsrv_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkRelationshipReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkRelationshipReply) ProtoMessage() {}
+
+func (x *NetworkRelationshipReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkRelationshipReply.ProtoReflect.Descriptor instead.
+func (*NetworkRelationshipReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *NetworkRelationshipReply) GetRelationship() *NetworkRelationship {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+type ListNetworkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	EffectiveOn   string                 `protobuf:"bytes,3,opt,name=effective_on,json=effectiveOn,proto3" json:"effective_on,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNetworkRequest) Reset() {
+	*x = ListNetworkRequest{}
+	mi := &file_accountsrv_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNetworkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNetworkRequest) ProtoMessage() {}
+
+func (x *ListNetworkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNetworkRequest.ProtoReflect.Descriptor instead.
+func (*ListNetworkRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *ListNetworkRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ListNetworkRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListNetworkRequest) GetEffectiveOn() string {
+	if x != nil {
+		return x.EffectiveOn
+	}
+	return ""
+}
+
+type ListNetworkReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relationships []*NetworkRelationship `protobuf:"bytes,1,rep,name=relationships,proto3" json:"relationships,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNetworkReply) Reset() {
+	*x = ListNetworkReply{}
+	mi := &file_accountsrv_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNetworkReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNetworkReply) ProtoMessage() {}
+
+func (x *ListNetworkReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNetworkReply.ProtoReflect.Descriptor instead.
+func (*ListNetworkReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *ListNetworkReply) GetRelationships() []*NetworkRelationship {
+	if x != nil {
+		return x.Relationships
+	}
+	return nil
+}
+
+// One field an audited action changed
+type AuditChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Before        string                 `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After         string                 `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditChange) Reset() {
+	*x = AuditChange{}
+	mi := &file_accountsrv_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditChange) ProtoMessage() {}
+
+func (x *AuditChange) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditChange.ProtoReflect.Descriptor instead.
+func (*AuditChange) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *AuditChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *AuditChange) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *AuditChange) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+// One entry in the audit trail, hash covers everything else in it (prev_hash
+// included)
+type AuditEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Seq        int64                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Id         string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Time       string                 `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	ActorId    string                 `protobuf:"bytes,4,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	OrgId      string                 `protobuf:"bytes,5,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Action     string                 `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
+	TargetType string                 `protobuf:"bytes,7,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	TargetId   string                 `protobuf:"bytes,8,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	// success, failure or challenged
+	Outcome       string         `protobuf:"bytes,9,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Error         string         `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	Changes       []*AuditChange `protobuf:"bytes,11,rep,name=changes,proto3" json:"changes,omitempty"`
+	RequestId     string         `protobuf:"bytes,12,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	ClientIp      string         `protobuf:"bytes,13,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	PrevHash      string         `protobuf:"bytes,14,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Hash          string         `protobuf:"bytes,15,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_accountsrv_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *AuditEvent) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetChanges() []*AuditChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+// Everything but the org is optional, since and until are RFC 3339 times
+type QueryAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	ActorId       string                 `protobuf:"bytes,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Since         string                 `protobuf:"bytes,5,opt,name=since,proto3" json:"since,omitempty"`
+	Until         string                 `protobuf:"bytes,6,opt,name=until,proto3" json:"until,omitempty"`
+	Limit         int32                  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuditLogRequest) Reset() {
+	*x = QueryAuditLogRequest{}
+	mi := &file_accountsrv_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditLogRequest) ProtoMessage() {}
+
+func (x *QueryAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *QueryAuditLogRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type QueryAuditLogReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuditLogReply) Reset() {
+	*x = QueryAuditLogReply{}
+	mi := &file_accountsrv_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditLogReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditLogReply) ProtoMessage() {}
+
+func (x *QueryAuditLogReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditLogReply.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *QueryAuditLogReply) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// For suspending, deactivating, reactivating and restoring a user
+type UserStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserStatusRequest) Reset() {
+	*x = UserStatusRequest{}
+	mi := &file_accountsrv_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStatusRequest) ProtoMessage() {}
+
+func (x *UserStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStatusRequest.ProtoReflect.Descriptor instead.
+func (*UserStatusRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *UserStatusRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UserStatusReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *UserAccount           `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserStatusReply) Reset() {
+	*x = UserStatusReply{}
+	mi := &file_accountsrv_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserStatusReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStatusReply) ProtoMessage() {}
+
+func (x *UserStatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStatusReply.ProtoReflect.Descriptor instead.
+func (*UserStatusReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *UserStatusReply) GetAccount() *UserAccount {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+type OrgStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgStatusRequest) Reset() {
+	*x = OrgStatusRequest{}
+	mi := &file_accountsrv_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgStatusRequest) ProtoMessage() {}
+
+func (x *OrgStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgStatusRequest.ProtoReflect.Descriptor instead.
+func (*OrgStatusRequest) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *OrgStatusRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type OrgStatusReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *OrgAccount            `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgStatusReply) Reset() {
+	*x = OrgStatusReply{}
+	mi := &file_accountsrv_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgStatusReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgStatusReply) ProtoMessage() {}
+
+func (x *OrgStatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_accountsrv_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgStatusReply.ProtoReflect.Descriptor instead.
+func (*OrgStatusReply) Descriptor() ([]byte, []int) {
+	return file_accountsrv_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *OrgStatusReply) GetAccount() *OrgAccount {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+var File_accountsrv_proto protoreflect.FileDescriptor
+
+const file_accountsrv_proto_rawDesc = "" +
+	"\n" +
+	"\x10accountsrv.proto\x12\n" +
+	"accountsrv\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa8\x01\n" +
+	"\vUserAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x19\n" +
+	"\borg_type\x18\x03 \x01(\tR\aorgType\x12\x1b\n" +
+	"\tjoined_on\x18\x04 \x01(\tR\bjoinedOn\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x06 \x01(\tR\tdeletedAt\"\x8b\x02\n" +
+	"\vUserProfile\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x05 \x01(\tR\x05phone\x12\x1d\n" +
+	"\n" +
+	"last_login\x18\x06 \x01(\tR\tlastLogin\x12*\n" +
+	"\x11email_verified_at\x18\a \x01(\tR\x0femailVerifiedAt\x12*\n" +
+	"\x11phone_verified_at\x18\b \x01(\tR\x0fphoneVerifiedAt\"p\n" +
+	"\rOrgMembership\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x19\n" +
+	"\borg_name\x18\x02 \x01(\tR\aorgName\x12\x19\n" +
+	"\borg_type\x18\x03 \x01(\tR\aorgType\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\"\xa3\x01\n" +
+	"\fDetailedUser\x121\n" +
+	"\aaccount\x18\x01 \x01(\v2\x17.accountsrv.UserAccountR\aaccount\x121\n" +
+	"\aprofile\x18\x02 \x01(\v2\x17.accountsrv.UserProfileR\aprofile\x12-\n" +
+	"\x04orgs\x18\x03 \x03(\v2\x19.accountsrv.OrgMembershipR\x04orgs\"\x83\x02\n" +
+	"\n" +
+	"OrgAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1b\n" +
+	"\tjoined_on\x18\x04 \x01(\tR\bjoinedOn\x12!\n" +
+	"\fmfa_required\x18\x05 \x01(\bR\vmfaRequired\x12)\n" +
+	"\x10passkey_required\x18\x06 \x01(\bR\x0fpasskeyRequired\x12\x1b\n" +
+	"\tparent_id\x18\a \x01(\tR\bparentId\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\t \x01(\tR\tdeletedAt\"\x91\x01\n" +
+	"\n" +
+	"OrgProfile\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x1a\n" +
+	"\btimezone\x18\x04 \x01(\tR\btimezone\x12\x18\n" +
+	"\awebsite\x18\x05 \x01(\tR\awebsite\"Y\n" +
+	"\x0fProviderDetails\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x10\n" +
+	"\x03npi\x18\x02 \x01(\tR\x03npi\x12\x15\n" +
+	"\x06tax_id\x18\x03 \x01(\tR\x05taxId\":\n" +
+	"\aPayerID\x12\x19\n" +
+	"\bpayer_id\x18\x01 \x01(\tR\apayerId\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\"e\n" +
+	"\fPayorDetails\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x120\n" +
+	"\tpayer_ids\x18\x03 \x03(\v2\x13.accountsrv.PayerIDR\bpayerIdsJ\x04\b\x02\x10\x03\"\xf8\x01\n" +
+	"\vDetailedOrg\x120\n" +
+	"\aaccount\x18\x01 \x01(\v2\x16.accountsrv.OrgAccountR\aaccount\x120\n" +
+	"\aprofile\x18\x02 \x01(\v2\x16.accountsrv.OrgProfileR\aprofile\x12F\n" +
+	"\x10provider_details\x18\x03 \x01(\v2\x1b.accountsrv.ProviderDetailsR\x0fproviderDetails\x12=\n" +
+	"\rpayor_details\x18\x04 \x01(\v2\x18.accountsrv.PayorDetailsR\fpayorDetails\"_\n" +
+	"\fSessionToken\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xbf\x01\n" +
+	"\tLoginUser\x12,\n" +
+	"\x04user\x18\x01 \x01(\v2\x18.accountsrv.DetailedUserR\x04user\x12)\n" +
+	"\x03org\x18\x02 \x01(\v2\x17.accountsrv.DetailedOrgR\x03org\x122\n" +
+	"\asession\x18\x03 \x01(\v2\x18.accountsrv.SessionTokenR\asession\x12%\n" +
+	"\x0erecovery_codes\x18\x04 \x03(\tR\rrecoveryCodes\"\xe5\x01\n" +
+	"\x11CreateUserRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x19\n" +
+	"\borg_type\x18\x04 \x01(\tR\aorgType\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x05 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x06 \x01(\tR\blastName\x12\x14\n" +
+	"\x05email\x18\a \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\b \x01(\tR\x05phone\"!\n" +
+	"\x0fCreateUserReply\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\":\n" +
+	"\x0eGetUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\ainclude\x18\x02 \x03(\tR\ainclude\"x\n" +
+	"\fGetUserReply\x12:\n" +
+	"\fuser_account\x18\x01 \x01(\v2\x17.accountsrv.UserAccountR\vuserAccount\x12,\n" +
+	"\x04user\x18\x02 \x01(\v2\x18.accountsrv.DetailedUserR\x04user\"#\n" +
+	"\x11DeleteUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"!\n" +
+	"\x0fDeleteUserReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\",\n" +
+	"\x0eAccountUpdates\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"k\n" +
+	"\x14UpdateAccountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12C\n" +
+	"\x0faccount_updates\x18\x02 \x01(\v2\x1a.accountsrv.AccountUpdatesR\x0eaccountUpdates\"$\n" +
+	"\x12UpdateAccountReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"]\n" +
+	"\fLoginRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"t\n" +
+	"\n" +
+	"LoginReply\x12:\n" +
+	"\rlogin_details\x18\x01 \x01(\v2\x15.accountsrv.LoginUserR\floginDetails\x12*\n" +
+	"\x03mfa\x18\x02 \x01(\v2\x18.accountsrv.MFAChallengeR\x03mfa\"I\n" +
+	"\x0eTOTPEnrollment\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1f\n" +
+	"\votpauth_uri\x18\x02 \x01(\tR\n" +
+	"otpauthUri\"\x9b\x01\n" +
+	"\fMFAChallenge\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12:\n" +
+	"\n" +
+	"enrollment\x18\x03 \x01(\v2\x1a.accountsrv.TOTPEnrollmentR\n" +
+	"enrollment\"x\n" +
+	"\x0eProfileUpdates\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x02 \x01(\tR\blastName\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x04 \x01(\tR\x05phone\"z\n" +
+	"\x14UpdateProfileRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12C\n" +
+	"\x0fprofile_updates\x18\x02 \x01(\v2\x1a.accountsrv.ProfileUpdatesR\x0eprofileUpdates\"$\n" +
+	"\x12UpdateProfileReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"\xc4\x02\n" +
+	"\x10CreateOrgRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12\x18\n" +
+	"\awebsite\x18\x06 \x01(\tR\awebsite\x12F\n" +
+	"\x10provider_details\x18\a \x01(\v2\x1b.accountsrv.ProviderDetailsR\x0fproviderDetails\x12=\n" +
+	"\rpayor_details\x18\b \x01(\v2\x18.accountsrv.PayorDetailsR\fpayorDetails\x12\x1b\n" +
+	"\tparent_id\x18\t \x01(\tR\bparentId\" \n" +
+	"\x0eCreateOrgReply\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1f\n" +
+	"\rGetOrgRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"8\n" +
+	"\vGetOrgReply\x12)\n" +
+	"\x03org\x18\x01 \x01(\v2\x17.accountsrv.DetailedOrgR\x03org\"\xd5\x01\n" +
+	"\x11OrgAccountUpdates\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12&\n" +
+	"\fmfa_required\x18\x02 \x01(\bH\x00R\vmfaRequired\x88\x01\x01\x12.\n" +
+	"\x10passkey_required\x18\x03 \x01(\bH\x01R\x0fpasskeyRequired\x88\x01\x01\x12 \n" +
+	"\tparent_id\x18\x04 \x01(\tH\x02R\bparentId\x88\x01\x01B\x0f\n" +
+	"\r_mfa_requiredB\x13\n" +
+	"\x11_passkey_requiredB\f\n" +
+	"\n" +
+	"_parent_id\"q\n" +
+	"\x17UpdateOrgAccountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12F\n" +
+	"\x0faccount_updates\x18\x02 \x01(\v2\x1d.accountsrv.OrgAccountUpdatesR\x0eaccountUpdates\"'\n" +
+	"\x15UpdateOrgAccountReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"y\n" +
+	"\x11OrgProfileUpdates\x12\x14\n" +
+	"\x05phone\x18\x01 \x01(\tR\x05phone\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x12\x18\n" +
+	"\awebsite\x18\x04 \x01(\tR\awebsite\"q\n" +
+	"\x17UpdateOrgProfileRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12F\n" +
+	"\x0fprofile_updates\x18\x02 \x01(\v2\x1d.accountsrv.OrgProfileUpdatesR\x0eprofileUpdates\"'\n" +
+	"\x15UpdateOrgProfileReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"8\n" +
+	"\x10DeleteOrgRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\" \n" +
+	"\x0eDeleteOrgReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"Z\n" +
+	"\tPrincipal\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\"\x13\n" +
+	"\x11GetSessionRequest\"F\n" +
+	"\x0fGetSessionReply\x123\n" +
+	"\tprincipal\x18\x01 \x01(\v2\x15.accountsrv.PrincipalR\tprincipal\"\xbc\x01\n" +
+	"\x13ListOrgUsersRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\f\n" +
+	"\x01q\x18\x04 \x01(\tR\x01q\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\x12\x12\n" +
+	"\x04desc\x18\x06 \x01(\bR\x04desc\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\b \x01(\tR\x06cursor\"\x85\x01\n" +
+	"\tOrgMember\x121\n" +
+	"\aaccount\x18\x01 \x01(\v2\x17.accountsrv.UserAccountR\aaccount\x121\n" +
+	"\aprofile\x18\x02 \x01(\v2\x17.accountsrv.UserProfileR\aprofile\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"\x98\x01\n" +
+	"\x11ListOrgUsersReply\x12+\n" +
+	"\x05users\x18\x01 \x03(\v2\x15.accountsrv.OrgMemberR\x05users\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x04 \x01(\tR\n" +
+	"prevCursor\"j\n" +
+	"\x19UpdatePayorDetailsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
+	"\rpayor_details\x18\x02 \x01(\v2\x18.accountsrv.PayorDetailsR\fpayorDetails\")\n" +
+	"\x17UpdatePayorDetailsReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"-\n" +
+	"\x10FindPayorRequest\x12\x19\n" +
+	"\bpayer_id\x18\x01 \x01(\tR\apayerId\";\n" +
+	"\x0eFindPayorReply\x12)\n" +
+	"\x03org\x18\x01 \x01(\v2\x17.accountsrv.DetailedOrgR\x03org\"\xaf\x02\n" +
+	"\x06Invite\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12\x1d\n" +
+	"\n" +
+	"invited_by\x18\x05 \x01(\tR\tinvitedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\tR\texpiresAt\x12\x1f\n" +
+	"\vaccepted_at\x18\b \x01(\tR\n" +
+	"acceptedAt\x12\x1f\n" +
+	"\vaccepted_by\x18\t \x01(\tR\n" +
+	"acceptedBy\x12\x1d\n" +
+	"\n" +
+	"revoked_at\x18\n" +
+	" \x01(\tR\trevokedAt\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\"V\n" +
+	"\x13CreateInviteRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"U\n" +
+	"\x11CreateInviteReply\x12*\n" +
+	"\x06invite\x18\x01 \x01(\v2\x12.accountsrv.InviteR\x06invite\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"+\n" +
+	"\x12ListInvitesRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"@\n" +
+	"\x10ListInvitesReply\x12,\n" +
+	"\ainvites\x18\x01 \x03(\v2\x12.accountsrv.InviteR\ainvites\"I\n" +
+	"\x13RevokeInviteRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x1b\n" +
+	"\tinvite_id\x18\x02 \x01(\tR\binviteId\"#\n" +
+	"\x11RevokeInviteReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"I\n" +
+	"\x13ResendInviteRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x1b\n" +
+	"\tinvite_id\x18\x02 \x01(\tR\binviteId\"U\n" +
+	"\x11ResendInviteReply\x12*\n" +
+	"\x06invite\x18\x01 \x01(\v2\x12.accountsrv.InviteR\x06invite\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\xb5\x01\n" +
+	"\x13AcceptInviteRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x04 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x05 \x01(\tR\blastName\x12\x14\n" +
+	"\x05phone\x18\x06 \x01(\tR\x05phone\",\n" +
+	"\x11AcceptInviteReply\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"7\n" +
+	"\x1cSendEmailVerificationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\",\n" +
+	"\x1aSendEmailVerificationReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"C\n" +
+	"\x12VerifyEmailRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\"\n" +
+	"\x10VerifyEmailReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"7\n" +
+	"\x1cSendPhoneVerificationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\",\n" +
+	"\x1aSendPhoneVerificationReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"A\n" +
+	"\x12VerifyPhoneRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\"\n" +
+	"\x10VerifyPhoneReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"O\n" +
+	"\x1bRequestPasswordResetRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"+\n" +
+	"\x19RequestPasswordResetReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"H\n" +
+	"\x14ResetPasswordRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"$\n" +
+	"\x12ResetPasswordReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"C\n" +
+	"\x17CompleteMFALoginRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"S\n" +
+	"\x15CompleteMFALoginReply\x12:\n" +
+	"\rlogin_details\x18\x01 \x01(\v2\x15.accountsrv.LoginUserR\floginDetails\",\n" +
+	"\x11EnrollTOTPRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"M\n" +
+	"\x0fEnrollTOTPReply\x12:\n" +
+	"\n" +
+	"enrollment\x18\x01 \x01(\v2\x1a.accountsrv.TOTPEnrollmentR\n" +
+	"enrollment\"A\n" +
+	"\x12ConfirmTOTPRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"9\n" +
+	"\x10ConfirmTOTPReply\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\"@\n" +
+	"\x11DisableMFARequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"!\n" +
+	"\x0fDisableMFAReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"*\n" +
+	"\x0fResetMFARequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x1f\n" +
+	"\rResetMFAReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"\xb7\x01\n" +
+	"\aPasskey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"transports\x18\x03 \x03(\tR\n" +
+	"transports\x12'\n" +
+	"\x0fbackup_eligible\x18\x04 \x01(\bR\x0ebackupEligible\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12 \n" +
+	"\flast_used_at\x18\x06 \x01(\tR\n" +
+	"lastUsedAt\"A\n" +
+	"\x0fPasskeyCeremony\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x18\n" +
+	"\aoptions\x18\x02 \x01(\tR\aoptions\":\n" +
+	"\x1fBeginPasskeyRegistrationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"X\n" +
+	"\x1dBeginPasskeyRegistrationReply\x127\n" +
+	"\bceremony\x18\x01 \x01(\v2\x1b.accountsrv.PasskeyCeremonyR\bceremony\"\x85\x01\n" +
+	" FinishPasskeyRegistrationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"credential\x18\x04 \x01(\tR\n" +
+	"credential\"O\n" +
+	"\x1eFinishPasskeyRegistrationReply\x12-\n" +
+	"\apasskey\x18\x01 \x01(\v2\x13.accountsrv.PasskeyR\apasskey\".\n" +
+	"\x13ListPasskeysRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"D\n" +
+	"\x11ListPasskeysReply\x12/\n" +
+	"\bpasskeys\x18\x01 \x03(\v2\x13.accountsrv.PasskeyR\bpasskeys\"N\n" +
+	"\x14DeletePasskeyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"passkey_id\x18\x02 \x01(\tR\tpasskeyId\"$\n" +
+	"\x12DeletePasskeyReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"1\n" +
+	"\x18BeginPasskeyLoginRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"Q\n" +
+	"\x16BeginPasskeyLoginReply\x127\n" +
+	"\bceremony\x18\x01 \x01(\v2\x1b.accountsrv.PasskeyCeremonyR\bceremony\"b\n" +
+	"\x13PasskeyLoginRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x1e\n" +
+	"\n" +
+	"credential\x18\x03 \x01(\tR\n" +
+	"credential\"O\n" +
+	"\x11PasskeyLoginReply\x12:\n" +
+	"\rlogin_details\x18\x01 \x01(\v2\x15.accountsrv.LoginUserR\floginDetails\"b\n" +
+	"\x17RequestMagicLinkRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"'\n" +
+	"\x15RequestMagicLinkReply\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02ok\"D\n" +
+	"\x15MagicLinkLoginRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"J\n" +
+	"\x15RequestSMSCodeRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"G\n" +
+	"\x10SMSCodeChallenge\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\"Q\n" +
+	"\x13RequestSMSCodeReply\x12:\n" +
+	"\tchallenge\x18\x01 \x01(\v2\x1c.accountsrv.SMSCodeChallengeR\tchallenge\"V\n" +
+	"\x13SMSCodeLoginRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\"P\n" +
+	"\x16LoginWithoutOrgRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"r\n" +
+	"\fOrgSelection\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\x12-\n" +
+	"\x04orgs\x18\x03 \x03(\v2\x19.accountsrv.OrgMembershipR\x04orgs\"N\n" +
+	"\x14LoginWithoutOrgReply\x126\n" +
+	"\tselection\x18\x01 \x01(\v2\x18.accountsrv.OrgSelectionR\tselection\"D\n" +
+	"\x15ChooseLoginOrgRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\")\n" +
+	"\x10SwitchOrgRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"\x13\n" +
+	"\x11ListMyOrgsRequest\"@\n" +
+	"\x0fListMyOrgsReply\x12-\n" +
+	"\x04orgs\x18\x01 \x03(\v2\x19.accountsrv.OrgMembershipR\x04orgs\"-\n" +
+	"\x14ListChildOrgsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"@\n" +
+	"\x12ListChildOrgsReply\x12*\n" +
+	"\x04orgs\x18\x01 \x03(\v2\x16.accountsrv.OrgAccountR\x04orgs\"*\n" +
+	"\x11GetOrgTreeRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"d\n" +
+	"\aOrgTree\x12(\n" +
+	"\x03org\x18\x01 \x01(\v2\x16.accountsrv.OrgAccountR\x03org\x12/\n" +
+	"\bchildren\x18\x02 \x03(\v2\x13.accountsrv.OrgTreeR\bchildren\"p\n" +
+	"\x0fGetOrgTreeReply\x124\n" +
+	"\tancestors\x18\x01 \x03(\v2\x16.accountsrv.OrgAccountR\tancestors\x12'\n" +
+	"\x04tree\x18\x02 \x01(\v2\x13.accountsrv.OrgTreeR\x04tree\"\x9b\x03\n" +
+	"\x13NetworkRelationship\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vprovider_id\x18\x02 \x01(\tR\n" +
+	"providerId\x12#\n" +
+	"\rprovider_name\x18\x03 \x01(\tR\fproviderName\x12\x19\n" +
+	"\bpayor_id\x18\x04 \x01(\tR\apayorId\x12\x1d\n" +
+	"\n" +
+	"payor_name\x18\x05 \x01(\tR\tpayorName\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12%\n" +
+	"\x0eeffective_date\x18\a \x01(\tR\reffectiveDate\x12)\n" +
+	"\x10termination_date\x18\b \x01(\tR\x0fterminationDate\x12!\n" +
+	"\frequested_by\x18\t \x01(\tR\vrequestedBy\x12!\n" +
+	"\frequested_at\x18\n" +
+	" \x01(\tR\vrequestedAt\x12\x1f\n" +
+	"\vaccepted_at\x18\v \x01(\tR\n" +
+	"acceptedAt\x12#\n" +
+	"\rterminated_at\x18\f \x01(\tR\fterminatedAt\"\xab\x01\n" +
+	"!RequestNetworkRelationshipRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x1d\n" +
+	"\n" +
+	"partner_id\x18\x02 \x01(\tR\tpartnerId\x12%\n" +
+	"\x0eeffective_date\x18\x03 \x01(\tR\reffectiveDate\x12)\n" +
+	"\x10termination_date\x18\x04 \x01(\tR\x0fterminationDate\"b\n" +
+	" NetworkRelationshipActionRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12'\n" +
+	"\x0frelationship_id\x18\x02 \x01(\tR\x0erelationshipId\"_\n" +
+	"\x18NetworkRelationshipReply\x12C\n" +
+	"\frelationship\x18\x01 \x01(\v2\x1f.accountsrv.NetworkRelationshipR\frelationship\"f\n" +
+	"\x12ListNetworkRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
+	"\feffective_on\x18\x03 \x01(\tR\veffectiveOn\"Y\n" +
+	"\x10ListNetworkReply\x12E\n" +
+	"\rrelationships\x18\x01 \x03(\v2\x1f.accountsrv.NetworkRelationshipR\rrelationships\"Q\n" +
+	"\vAuditChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06before\x18\x02 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x03 \x01(\tR\x05after\"\x9a\x03\n" +
+	"\n" +
+	"AuditEvent\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x12\n" +
+	"\x04time\x18\x03 \x01(\tR\x04time\x12\x19\n" +
+	"\bactor_id\x18\x04 \x01(\tR\aactorId\x12\x15\n" +
+	"\x06org_id\x18\x05 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06action\x18\x06 \x01(\tR\x06action\x12\x1f\n" +
+	"\vtarget_type\x18\a \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\b \x01(\tR\btargetId\x12\x18\n" +
+	"\aoutcome\x18\t \x01(\tR\aoutcome\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\x121\n" +
+	"\achanges\x18\v \x03(\v2\x17.accountsrv.AuditChangeR\achanges\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\f \x01(\tR\trequestId\x12\x1b\n" +
+	"\tclient_ip\x18\r \x01(\tR\bclientIp\x12\x1b\n" +
+	"\tprev_hash\x18\x0e \x01(\tR\bprevHash\x12\x12\n" +
+	"\x04hash\x18\x0f \x01(\tR\x04hash\"\xbf\x01\n" +
+	"\x14QueryAuditLogRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\tR\aactorId\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x14\n" +
+	"\x05since\x18\x05 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x06 \x01(\tR\x05until\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\"D\n" +
+	"\x12QueryAuditLogReply\x12.\n" +
+	"\x06events\x18\x01 \x03(\v2\x16.accountsrv.AuditEventR\x06events\",\n" +
+	"\x11UserStatusRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"D\n" +
+	"\x0fUserStatusReply\x121\n" +
+	"\aaccount\x18\x01 \x01(\v2\x17.accountsrv.UserAccountR\aaccount\")\n" +
+	"\x10OrgStatusRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"B\n" +
+	"\x0eOrgStatusReply\x120\n" +
+	"\aaccount\x18\x01 \x01(\v2\x16.accountsrv.OrgAccountR\aaccount2\xb8(\n" +
+	"\aAccount\x12J\n" +
+	"\n" +
+	"CreateUser\x12\x1d.accountsrv.CreateUserRequest\x1a\x1b.accountsrv.CreateUserReply\"\x00\x12A\n" +
+	"\aGetUser\x12\x1a.accountsrv.GetUserRequest\x1a\x18.accountsrv.GetUserReply\"\x00\x12J\n" +
+	"\n" +
+	"DeleteUser\x12\x1d.accountsrv.DeleteUserRequest\x1a\x1b.accountsrv.DeleteUserReply\"\x00\x12W\n" +
+	"\x11UpdateUserAccount\x12 .accountsrv.UpdateAccountRequest\x1a\x1e.accountsrv.UpdateAccountReply\"\x00\x12K\n" +
+	"\vSuspendUser\x12\x1d.accountsrv.UserStatusRequest\x1a\x1b.accountsrv.UserStatusReply\"\x00\x12N\n" +
+	"\x0eDeactivateUser\x12\x1d.accountsrv.UserStatusRequest\x1a\x1b.accountsrv.UserStatusReply\"\x00\x12N\n" +
+	"\x0eReactivateUser\x12\x1d.accountsrv.UserStatusRequest\x1a\x1b.accountsrv.UserStatusReply\"\x00\x12K\n" +
+	"\vRestoreUser\x12\x1d.accountsrv.UserStatusRequest\x1a\x1b.accountsrv.UserStatusReply\"\x00\x12?\n" +
+	"\tLoginUser\x12\x18.accountsrv.LoginRequest\x1a\x16.accountsrv.LoginReply\"\x00\x12W\n" +
+	"\x11UpdateUserProfile\x12 .accountsrv.UpdateProfileRequest\x1a\x1e.accountsrv.UpdateProfileReply\"\x00\x12J\n" +
+	"\n" +
+	"GetSession\x12\x1d.accountsrv.GetSessionRequest\x1a\x1b.accountsrv.GetSessionReply\"\x00\x12k\n" +
+	"\x15SendEmailVerification\x12(.accountsrv.SendEmailVerificationRequest\x1a&.accountsrv.SendEmailVerificationReply\"\x00\x12M\n" +
+	"\vVerifyEmail\x12\x1e.accountsrv.VerifyEmailRequest\x1a\x1c.accountsrv.VerifyEmailReply\"\x00\x12k\n" +
+	"\x15SendPhoneVerification\x12(.accountsrv.SendPhoneVerificationRequest\x1a&.accountsrv.SendPhoneVerificationReply\"\x00\x12M\n" +
+	"\vVerifyPhone\x12\x1e.accountsrv.VerifyPhoneRequest\x1a\x1c.accountsrv.VerifyPhoneReply\"\x00\x12h\n" +
+	"\x14RequestPasswordReset\x12'.accountsrv.RequestPasswordResetRequest\x1a%.accountsrv.RequestPasswordResetReply\"\x00\x12S\n" +
+	"\rResetPassword\x12 .accountsrv.ResetPasswordRequest\x1a\x1e.accountsrv.ResetPasswordReply\"\x00\x12\\\n" +
+	"\x10CompleteMFALogin\x12#.accountsrv.CompleteMFALoginRequest\x1a!.accountsrv.CompleteMFALoginReply\"\x00\x12J\n" +
+	"\n" +
+	"EnrollTOTP\x12\x1d.accountsrv.EnrollTOTPRequest\x1a\x1b.accountsrv.EnrollTOTPReply\"\x00\x12M\n" +
+	"\vConfirmTOTP\x12\x1e.accountsrv.ConfirmTOTPRequest\x1a\x1c.accountsrv.ConfirmTOTPReply\"\x00\x12J\n" +
+	"\n" +
+	"DisableMFA\x12\x1d.accountsrv.DisableMFARequest\x1a\x1b.accountsrv.DisableMFAReply\"\x00\x12D\n" +
+	"\bResetMFA\x12\x1b.accountsrv.ResetMFARequest\x1a\x19.accountsrv.ResetMFAReply\"\x00\x12t\n" +
+	"\x18BeginPasskeyRegistration\x12+.accountsrv.BeginPasskeyRegistrationRequest\x1a).accountsrv.BeginPasskeyRegistrationReply\"\x00\x12w\n" +
+	"\x19FinishPasskeyRegistration\x12,.accountsrv.FinishPasskeyRegistrationRequest\x1a*.accountsrv.FinishPasskeyRegistrationReply\"\x00\x12P\n" +
+	"\fListPasskeys\x12\x1f.accountsrv.ListPasskeysRequest\x1a\x1d.accountsrv.ListPasskeysReply\"\x00\x12S\n" +
+	"\rDeletePasskey\x12 .accountsrv.DeletePasskeyRequest\x1a\x1e.accountsrv.DeletePasskeyReply\"\x00\x12_\n" +
+	"\x11BeginPasskeyLogin\x12$.accountsrv.BeginPasskeyLoginRequest\x1a\".accountsrv.BeginPasskeyLoginReply\"\x00\x12P\n" +
+	"\fPasskeyLogin\x12\x1f.accountsrv.PasskeyLoginRequest\x1a\x1d.accountsrv.PasskeyLoginReply\"\x00\x12\\\n" +
+	"\x10RequestMagicLink\x12#.accountsrv.RequestMagicLinkRequest\x1a!.accountsrv.RequestMagicLinkReply\"\x00\x12M\n" +
+	"\x0eMagicLinkLogin\x12!.accountsrv.MagicLinkLoginRequest\x1a\x16.accountsrv.LoginReply\"\x00\x12V\n" +
+	"\x0eRequestSMSCode\x12!.accountsrv.RequestSMSCodeRequest\x1a\x1f.accountsrv.RequestSMSCodeReply\"\x00\x12I\n" +
+	"\fSMSCodeLogin\x12\x1f.accountsrv.SMSCodeLoginRequest\x1a\x16.accountsrv.LoginReply\"\x00\x12Y\n" +
+	"\x0fLoginWithoutOrg\x12\".accountsrv.LoginWithoutOrgRequest\x1a .accountsrv.LoginWithoutOrgReply\"\x00\x12M\n" +
+	"\x0eChooseLoginOrg\x12!.accountsrv.ChooseLoginOrgRequest\x1a\x16.accountsrv.LoginReply\"\x00\x12C\n" +
+	"\tSwitchOrg\x12\x1c.accountsrv.SwitchOrgRequest\x1a\x16.accountsrv.LoginReply\"\x00\x12J\n" +
+	"\n" +
+	"ListMyOrgs\x12\x1d.accountsrv.ListMyOrgsRequest\x1a\x1b.accountsrv.ListMyOrgsReply\"\x00\x12G\n" +
+	"\tCreateOrg\x12\x1c.accountsrv.CreateOrgRequest\x1a\x1a.accountsrv.CreateOrgReply\"\x00\x12>\n" +
+	"\x06GetOrg\x12\x19.accountsrv.GetOrgRequest\x1a\x17.accountsrv.GetOrgReply\"\x00\x12\\\n" +
+	"\x10UpdateOrgAccount\x12#.accountsrv.UpdateOrgAccountRequest\x1a!.accountsrv.UpdateOrgAccountReply\"\x00\x12\\\n" +
+	"\x10UpdateOrgProfile\x12#.accountsrv.UpdateOrgProfileRequest\x1a!.accountsrv.UpdateOrgProfileReply\"\x00\x12G\n" +
+	"\tDeleteOrg\x12\x1c.accountsrv.DeleteOrgRequest\x1a\x1a.accountsrv.DeleteOrgReply\"\x00\x12H\n" +
+	"\n" +
+	"SuspendOrg\x12\x1c.accountsrv.OrgStatusRequest\x1a\x1a.accountsrv.OrgStatusReply\"\x00\x12K\n" +
+	"\rDeactivateOrg\x12\x1c.accountsrv.OrgStatusRequest\x1a\x1a.accountsrv.OrgStatusReply\"\x00\x12K\n" +
+	"\rReactivateOrg\x12\x1c.accountsrv.OrgStatusRequest\x1a\x1a.accountsrv.OrgStatusReply\"\x00\x12H\n" +
+	"\n" +
+	"RestoreOrg\x12\x1c.accountsrv.OrgStatusRequest\x1a\x1a.accountsrv.OrgStatusReply\"\x00\x12P\n" +
+	"\fListOrgUsers\x12\x1f.accountsrv.ListOrgUsersRequest\x1a\x1d.accountsrv.ListOrgUsersReply\"\x00\x12S\n" +
+	"\rListChildOrgs\x12 .accountsrv.ListChildOrgsRequest\x1a\x1e.accountsrv.ListChildOrgsReply\"\x00\x12J\n" +
+	"\n" +
+	"GetOrgTree\x12\x1d.accountsrv.GetOrgTreeRequest\x1a\x1b.accountsrv.GetOrgTreeReply\"\x00\x12b\n" +
+	"\x12UpdatePayorDetails\x12%.accountsrv.UpdatePayorDetailsRequest\x1a#.accountsrv.UpdatePayorDetailsReply\"\x00\x12G\n" +
+	"\tFindPayor\x12\x1c.accountsrv.FindPayorRequest\x1a\x1a.accountsrv.FindPayorReply\"\x00\x12P\n" +
+	"\fCreateInvite\x12\x1f.accountsrv.CreateInviteRequest\x1a\x1d.accountsrv.CreateInviteReply\"\x00\x12M\n" +
+	"\vListInvites\x12\x1e.accountsrv.ListInvitesRequest\x1a\x1c.accountsrv.ListInvitesReply\"\x00\x12P\n" +
+	"\fRevokeInvite\x12\x1f.accountsrv.RevokeInviteRequest\x1a\x1d.accountsrv.RevokeInviteReply\"\x00\x12P\n" +
+	"\fResendInvite\x12\x1f.accountsrv.ResendInviteRequest\x1a\x1d.accountsrv.ResendInviteReply\"\x00\x12P\n" +
+	"\fAcceptInvite\x12\x1f.accountsrv.AcceptInviteRequest\x1a\x1d.accountsrv.AcceptInviteReply\"\x00\x12s\n" +
+	"\x1aRequestNetworkRelationship\x12-.accountsrv.RequestNetworkRelationshipRequest\x1a$.accountsrv.NetworkRelationshipReply\"\x00\x12q\n" +
+	"\x19AcceptNetworkRelationship\x12,.accountsrv.NetworkRelationshipActionRequest\x1a$.accountsrv.NetworkRelationshipReply\"\x00\x12t\n" +
+	"\x1cTerminateNetworkRelationship\x12,.accountsrv.NetworkRelationshipActionRequest\x1a$.accountsrv.NetworkRelationshipReply\"\x00\x12L\n" +
+	"\n" +
+	"ListPayors\x12\x1e.accountsrv.ListNetworkRequest\x1a\x1c.accountsrv.ListNetworkReply\"\x00\x12O\n" +
+	"\rListProviders\x12\x1e.accountsrv.ListNetworkRequest\x1a\x1c.accountsrv.ListNetworkReply\"\x00\x12S\n" +
+	"\rQueryAuditLog\x12 .accountsrv.QueryAuditLogRequest\x1a\x1e.accountsrv.QueryAuditLogReply\"\x00B#Z!github.com/rjjp5294/accountsrv/pbb\x06proto3"
+
+var (
+	file_accountsrv_proto_rawDescOnce sync.Once
+	file_accountsrv_proto_rawDescData []byte
+)
+
+func file_accountsrv_proto_rawDescGZIP() []byte {
+	file_accountsrv_proto_rawDescOnce.Do(func() {
+		file_accountsrv_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_accountsrv_proto_rawDesc), len(file_accountsrv_proto_rawDesc)))
+	})
+	return file_accountsrv_proto_rawDescData
+}
+
+var file_accountsrv_proto_msgTypes = make([]protoimpl.MessageInfo, 130)
+var file_accountsrv_proto_goTypes = []any{
+	(*UserAccount)(nil),                       // 0: accountsrv.UserAccount
+	(*UserProfile)(nil),                       // 1: accountsrv.UserProfile
+	(*OrgMembership)(nil),                     // 2: accountsrv.OrgMembership
+	(*DetailedUser)(nil),                      // 3: accountsrv.DetailedUser
+	(*OrgAccount)(nil),                        // 4: accountsrv.OrgAccount
+	(*OrgProfile)(nil),                        // 5: accountsrv.OrgProfile
+	(*ProviderDetails)(nil),                   // 6: accountsrv.ProviderDetails
+	(*PayerID)(nil),                           // 7: accountsrv.PayerID
+	(*PayorDetails)(nil),                      // 8: accountsrv.PayorDetails
+	(*DetailedOrg)(nil),                       // 9: accountsrv.DetailedOrg
+	(*SessionToken)(nil),                      // 10: accountsrv.SessionToken
+	(*LoginUser)(nil),                         // 11: accountsrv.LoginUser
+	(*CreateUserRequest)(nil),                 // 12: accountsrv.CreateUserRequest
+	(*CreateUserReply)(nil),                   // 13: accountsrv.CreateUserReply
+	(*GetUserRequest)(nil),                    // 14: accountsrv.GetUserRequest
+	(*GetUserReply)(nil),                      // 15: accountsrv.GetUserReply
+	(*DeleteUserRequest)(nil),                 // 16: accountsrv.DeleteUserRequest
+	(*DeleteUserReply)(nil),                   // 17: accountsrv.DeleteUserReply
+	(*AccountUpdates)(nil),                    // 18: accountsrv.AccountUpdates
+	(*UpdateAccountRequest)(nil),              // 19: accountsrv.UpdateAccountRequest
+	(*UpdateAccountReply)(nil),                // 20: accountsrv.UpdateAccountReply
+	(*LoginRequest)(nil),                      // 21: accountsrv.LoginRequest
+	(*LoginReply)(nil),                        // 22: accountsrv.LoginReply
+	(*TOTPEnrollment)(nil),                    // 23: accountsrv.TOTPEnrollment
+	(*MFAChallenge)(nil),                      // 24: accountsrv.MFAChallenge
+	(*ProfileUpdates)(nil),                    // 25: accountsrv.ProfileUpdates
+	(*UpdateProfileRequest)(nil),              // 26: accountsrv.UpdateProfileRequest
+	(*UpdateProfileReply)(nil),                // 27: accountsrv.UpdateProfileReply
+	(*CreateOrgRequest)(nil),                  // 28: accountsrv.CreateOrgRequest
+	(*CreateOrgReply)(nil),                    // 29: accountsrv.CreateOrgReply
+	(*GetOrgRequest)(nil),                     // 30: accountsrv.GetOrgRequest
+	(*GetOrgReply)(nil),                       // 31: accountsrv.GetOrgReply
+	(*OrgAccountUpdates)(nil),                 // 32: accountsrv.OrgAccountUpdates
+	(*UpdateOrgAccountRequest)(nil),           // 33: accountsrv.UpdateOrgAccountRequest
+	(*UpdateOrgAccountReply)(nil),             // 34: accountsrv.UpdateOrgAccountReply
+	(*OrgProfileUpdates)(nil),                 // 35: accountsrv.OrgProfileUpdates
+	(*UpdateOrgProfileRequest)(nil),           // 36: accountsrv.UpdateOrgProfileRequest
+	(*UpdateOrgProfileReply)(nil),             // 37: accountsrv.UpdateOrgProfileReply
+	(*DeleteOrgRequest)(nil),                  // 38: accountsrv.DeleteOrgRequest
+	(*DeleteOrgReply)(nil),                    // 39: accountsrv.DeleteOrgReply
+	(*Principal)(nil),                         // 40: accountsrv.Principal
+	(*GetSessionRequest)(nil),                 // 41: accountsrv.GetSessionRequest
+	(*GetSessionReply)(nil),                   // 42: accountsrv.GetSessionReply
+	(*ListOrgUsersRequest)(nil),               // 43: accountsrv.ListOrgUsersRequest
+	(*OrgMember)(nil),                         // 44: accountsrv.OrgMember
+	(*ListOrgUsersReply)(nil),                 // 45: accountsrv.ListOrgUsersReply
+	(*UpdatePayorDetailsRequest)(nil),         // 46: accountsrv.UpdatePayorDetailsRequest
+	(*UpdatePayorDetailsReply)(nil),           // 47: accountsrv.UpdatePayorDetailsReply
+	(*FindPayorRequest)(nil),                  // 48: accountsrv.FindPayorRequest
+	(*FindPayorReply)(nil),                    // 49: accountsrv.FindPayorReply
+	(*Invite)(nil),                            // 50: accountsrv.Invite
+	(*CreateInviteRequest)(nil),               // 51: accountsrv.CreateInviteRequest
+	(*CreateInviteReply)(nil),                 // 52: accountsrv.CreateInviteReply
+	(*ListInvitesRequest)(nil),                // 53: accountsrv.ListInvitesRequest
+	(*ListInvitesReply)(nil),                  // 54: accountsrv.ListInvitesReply
+	(*RevokeInviteRequest)(nil),               // 55: accountsrv.RevokeInviteRequest
+	(*RevokeInviteReply)(nil),                 // 56: accountsrv.RevokeInviteReply
+	(*ResendInviteRequest)(nil),               // 57: accountsrv.ResendInviteRequest
+	(*ResendInviteReply)(nil),                 // 58: accountsrv.ResendInviteReply
+	(*AcceptInviteRequest)(nil),               // 59: accountsrv.AcceptInviteRequest
+	(*AcceptInviteReply)(nil),                 // 60: accountsrv.AcceptInviteReply
+	(*SendEmailVerificationRequest)(nil),      // 61: accountsrv.SendEmailVerificationRequest
+	(*SendEmailVerificationReply)(nil),        // 62: accountsrv.SendEmailVerificationReply
+	(*VerifyEmailRequest)(nil),                // 63: accountsrv.VerifyEmailRequest
+	(*VerifyEmailReply)(nil),                  // 64: accountsrv.VerifyEmailReply
+	(*SendPhoneVerificationRequest)(nil),      // 65: accountsrv.SendPhoneVerificationRequest
+	(*SendPhoneVerificationReply)(nil),        // 66: accountsrv.SendPhoneVerificationReply
+	(*VerifyPhoneRequest)(nil),                // 67: accountsrv.VerifyPhoneRequest
+	(*VerifyPhoneReply)(nil),                  // 68: accountsrv.VerifyPhoneReply
+	(*RequestPasswordResetRequest)(nil),       // 69: accountsrv.RequestPasswordResetRequest
+	(*RequestPasswordResetReply)(nil),         // 70: accountsrv.RequestPasswordResetReply
+	(*ResetPasswordRequest)(nil),              // 71: accountsrv.ResetPasswordRequest
+	(*ResetPasswordReply)(nil),                // 72: accountsrv.ResetPasswordReply
+	(*CompleteMFALoginRequest)(nil),           // 73: accountsrv.CompleteMFALoginRequest
+	(*CompleteMFALoginReply)(nil),             // 74: accountsrv.CompleteMFALoginReply
+	(*EnrollTOTPRequest)(nil),                 // 75: accountsrv.EnrollTOTPRequest
+	(*EnrollTOTPReply)(nil),                   // 76: accountsrv.EnrollTOTPReply
+	(*ConfirmTOTPRequest)(nil),                // 77: accountsrv.ConfirmTOTPRequest
+	(*ConfirmTOTPReply)(nil),                  // 78: accountsrv.ConfirmTOTPReply
+	(*DisableMFARequest)(nil),                 // 79: accountsrv.DisableMFARequest
+	(*DisableMFAReply)(nil),                   // 80: accountsrv.DisableMFAReply
+	(*ResetMFARequest)(nil),                   // 81: accountsrv.ResetMFARequest
+	(*ResetMFAReply)(nil),                     // 82: accountsrv.ResetMFAReply
+	(*Passkey)(nil),                           // 83: accountsrv.Passkey
+	(*PasskeyCeremony)(nil),                   // 84: accountsrv.PasskeyCeremony
+	(*BeginPasskeyRegistrationRequest)(nil),   // 85: accountsrv.BeginPasskeyRegistrationRequest
+	(*BeginPasskeyRegistrationReply)(nil),     // 86: accountsrv.BeginPasskeyRegistrationReply
+	(*FinishPasskeyRegistrationRequest)(nil),  // 87: accountsrv.FinishPasskeyRegistrationRequest
+	(*FinishPasskeyRegistrationReply)(nil),    // 88: accountsrv.FinishPasskeyRegistrationReply
+	(*ListPasskeysRequest)(nil),               // 89: accountsrv.ListPasskeysRequest
+	(*ListPasskeysReply)(nil),                 // 90: accountsrv.ListPasskeysReply
+	(*DeletePasskeyRequest)(nil),              // 91: accountsrv.DeletePasskeyRequest
+	(*DeletePasskeyReply)(nil),                // 92: accountsrv.DeletePasskeyReply
+	(*BeginPasskeyLoginRequest)(nil),          // 93: accountsrv.BeginPasskeyLoginRequest
+	(*BeginPasskeyLoginReply)(nil),            // 94: accountsrv.BeginPasskeyLoginReply
+	(*PasskeyLoginRequest)(nil),               // 95: accountsrv.PasskeyLoginRequest
+	(*PasskeyLoginReply)(nil),                 // 96: accountsrv.PasskeyLoginReply
+	(*RequestMagicLinkRequest)(nil),           // 97: accountsrv.RequestMagicLinkRequest
+	(*RequestMagicLinkReply)(nil),             // 98: accountsrv.RequestMagicLinkReply
+	(*MagicLinkLoginRequest)(nil),             // 99: accountsrv.MagicLinkLoginRequest
+	(*RequestSMSCodeRequest)(nil),             // 100: accountsrv.RequestSMSCodeRequest
+	(*SMSCodeChallenge)(nil),                  // 101: accountsrv.SMSCodeChallenge
+	(*RequestSMSCodeReply)(nil),               // 102: accountsrv.RequestSMSCodeReply
+	(*SMSCodeLoginRequest)(nil),               // 103: accountsrv.SMSCodeLoginRequest
+	(*LoginWithoutOrgRequest)(nil),            // 104: accountsrv.LoginWithoutOrgRequest
+	(*OrgSelection)(nil),                      // 105: accountsrv.OrgSelection
+	(*LoginWithoutOrgReply)(nil),              // 106: accountsrv.LoginWithoutOrgReply
+	(*ChooseLoginOrgRequest)(nil),             // 107: accountsrv.ChooseLoginOrgRequest
+	(*SwitchOrgRequest)(nil),                  // 108: accountsrv.SwitchOrgRequest
+	(*ListMyOrgsRequest)(nil),                 // 109: accountsrv.ListMyOrgsRequest
+	(*ListMyOrgsReply)(nil),                   // 110: accountsrv.ListMyOrgsReply
+	(*ListChildOrgsRequest)(nil),              // 111: accountsrv.ListChildOrgsRequest
+	(*ListChildOrgsReply)(nil),                // 112: accountsrv.ListChildOrgsReply
+	(*GetOrgTreeRequest)(nil),                 // 113: accountsrv.GetOrgTreeRequest
+	(*OrgTree)(nil),                           // 114: accountsrv.OrgTree
+	(*GetOrgTreeReply)(nil),                   // 115: accountsrv.GetOrgTreeReply
+	(*NetworkRelationship)(nil),               // 116: accountsrv.NetworkRelationship
+	(*RequestNetworkRelationshipRequest)(nil), // 117: accountsrv.RequestNetworkRelationshipRequest
+	(*NetworkRelationshipActionRequest)(nil),  // 118: accountsrv.NetworkRelationshipActionRequest
+	(*NetworkRelationshipReply)(nil),          // 119: accountsrv.NetworkRelationshipReply
+	(*ListNetworkRequest)(nil),                // 120: accountsrv.ListNetworkRequest
+	(*ListNetworkReply)(nil),                  // 121: accountsrv.ListNetworkReply
+	(*AuditChange)(nil),                       // 122: accountsrv.AuditChange
+	(*AuditEvent)(nil),                        // 123: accountsrv.AuditEvent
+	(*QueryAuditLogRequest)(nil),              // 124: accountsrv.QueryAuditLogRequest
+	(*QueryAuditLogReply)(nil),                // 125: accountsrv.QueryAuditLogReply
+	(*UserStatusRequest)(nil),                 // 126: accountsrv.UserStatusRequest
+	(*UserStatusReply)(nil),                   // 127: accountsrv.UserStatusReply
+	(*OrgStatusRequest)(nil),                  // 128: accountsrv.OrgStatusRequest
+	(*OrgStatusReply)(nil),                    // 129: accountsrv.OrgStatusReply
+	(*timestamppb.Timestamp)(nil),             // 130: google.protobuf.Timestamp
+}
+var file_accountsrv_proto_depIdxs = []int32{
+	0,   // 0: accountsrv.DetailedUser.account:type_name -> accountsrv.UserAccount
+	1,   // 1: accountsrv.DetailedUser.profile:type_name -> accountsrv.UserProfile
+	2,   // 2: accountsrv.DetailedUser.orgs:type_name -> accountsrv.OrgMembership
+	7,   // 3: accountsrv.PayorDetails.payer_ids:type_name -> accountsrv.PayerID
+	4,   // 4: accountsrv.DetailedOrg.account:type_name -> accountsrv.OrgAccount
+	5,   // 5: accountsrv.DetailedOrg.profile:type_name -> accountsrv.OrgProfile
+	6,   // 6: accountsrv.DetailedOrg.provider_details:type_name -> accountsrv.ProviderDetails
+	8,   // 7: accountsrv.DetailedOrg.payor_details:type_name -> accountsrv.PayorDetails
+	130, // 8: accountsrv.SessionToken.expires_at:type_name -> google.protobuf.Timestamp
+	3,   // 9: accountsrv.LoginUser.user:type_name -> accountsrv.DetailedUser
+	9,   // 10: accountsrv.LoginUser.org:type_name -> accountsrv.DetailedOrg
+	10,  // 11: accountsrv.LoginUser.session:type_name -> accountsrv.SessionToken
+	0,   // 12: accountsrv.GetUserReply.user_account:type_name -> accountsrv.UserAccount
+	3,   // 13: accountsrv.GetUserReply.user:type_name -> accountsrv.DetailedUser
+	18,  // 14: accountsrv.UpdateAccountRequest.account_updates:type_name -> accountsrv.AccountUpdates
+	11,  // 15: accountsrv.LoginReply.login_details:type_name -> accountsrv.LoginUser
+	24,  // 16: accountsrv.LoginReply.mfa:type_name -> accountsrv.MFAChallenge
+	130, // 17: accountsrv.MFAChallenge.expires_at:type_name -> google.protobuf.Timestamp
+	23,  // 18: accountsrv.MFAChallenge.enrollment:type_name -> accountsrv.TOTPEnrollment
+	25,  // 19: accountsrv.UpdateProfileRequest.profile_updates:type_name -> accountsrv.ProfileUpdates
+	6,   // 20: accountsrv.CreateOrgRequest.provider_details:type_name -> accountsrv.ProviderDetails
+	8,   // 21: accountsrv.CreateOrgRequest.payor_details:type_name -> accountsrv.PayorDetails
+	9,   // 22: accountsrv.GetOrgReply.org:type_name -> accountsrv.DetailedOrg
+	32,  // 23: accountsrv.UpdateOrgAccountRequest.account_updates:type_name -> accountsrv.OrgAccountUpdates
+	35,  // 24: accountsrv.UpdateOrgProfileRequest.profile_updates:type_name -> accountsrv.OrgProfileUpdates
+	40,  // 25: accountsrv.GetSessionReply.principal:type_name -> accountsrv.Principal
+	0,   // 26: accountsrv.OrgMember.account:type_name -> accountsrv.UserAccount
+	1,   // 27: accountsrv.OrgMember.profile:type_name -> accountsrv.UserProfile
+	44,  // 28: accountsrv.ListOrgUsersReply.users:type_name -> accountsrv.OrgMember
+	8,   // 29: accountsrv.UpdatePayorDetailsRequest.payor_details:type_name -> accountsrv.PayorDetails
+	9,   // 30: accountsrv.FindPayorReply.org:type_name -> accountsrv.DetailedOrg
+	50,  // 31: accountsrv.CreateInviteReply.invite:type_name -> accountsrv.Invite
+	50,  // 32: accountsrv.ListInvitesReply.invites:type_name -> accountsrv.Invite
+	50,  // 33: accountsrv.ResendInviteReply.invite:type_name -> accountsrv.Invite
+	11,  // 34: accountsrv.CompleteMFALoginReply.login_details:type_name -> accountsrv.LoginUser
+	23,  // 35: accountsrv.EnrollTOTPReply.enrollment:type_name -> accountsrv.TOTPEnrollment
+	84,  // 36: accountsrv.BeginPasskeyRegistrationReply.ceremony:type_name -> accountsrv.PasskeyCeremony
+	83,  // 37: accountsrv.FinishPasskeyRegistrationReply.passkey:type_name -> accountsrv.Passkey
+	83,  // 38: accountsrv.ListPasskeysReply.passkeys:type_name -> accountsrv.Passkey
+	84,  // 39: accountsrv.BeginPasskeyLoginReply.ceremony:type_name -> accountsrv.PasskeyCeremony
+	11,  // 40: accountsrv.PasskeyLoginReply.login_details:type_name -> accountsrv.LoginUser
+	101, // 41: accountsrv.RequestSMSCodeReply.challenge:type_name -> accountsrv.SMSCodeChallenge
+	2,   // 42: accountsrv.OrgSelection.orgs:type_name -> accountsrv.OrgMembership
+	105, // 43: accountsrv.LoginWithoutOrgReply.selection:type_name -> accountsrv.OrgSelection
+	2,   // 44: accountsrv.ListMyOrgsReply.orgs:type_name -> accountsrv.OrgMembership
+	4,   // 45: accountsrv.ListChildOrgsReply.orgs:type_name -> accountsrv.OrgAccount
+	4,   // 46: accountsrv.OrgTree.org:type_name -> accountsrv.OrgAccount
+	114, // 47: accountsrv.OrgTree.children:type_name -> accountsrv.OrgTree
+	4,   // 48: accountsrv.GetOrgTreeReply.ancestors:type_name -> accountsrv.OrgAccount
+	114, // 49: accountsrv.GetOrgTreeReply.tree:type_name -> accountsrv.OrgTree
+	116, // 50: accountsrv.NetworkRelationshipReply.relationship:type_name -> accountsrv.NetworkRelationship
+	116, // 51: accountsrv.ListNetworkReply.relationships:type_name -> accountsrv.NetworkRelationship
+	122, // 52: accountsrv.AuditEvent.changes:type_name -> accountsrv.AuditChange
+	123, // 53: accountsrv.QueryAuditLogReply.events:type_name -> accountsrv.AuditEvent
+	0,   // 54: accountsrv.UserStatusReply.account:type_name -> accountsrv.UserAccount
+	4,   // 55: accountsrv.OrgStatusReply.account:type_name -> accountsrv.OrgAccount
+	12,  // 56: accountsrv.Account.CreateUser:input_type -> accountsrv.CreateUserRequest
+	14,  // 57: accountsrv.Account.GetUser:input_type -> accountsrv.GetUserRequest
+	16,  // 58: accountsrv.Account.DeleteUser:input_type -> accountsrv.DeleteUserRequest
+	19,  // 59: accountsrv.Account.UpdateUserAccount:input_type -> accountsrv.UpdateAccountRequest
+	126, // 60: accountsrv.Account.SuspendUser:input_type -> accountsrv.UserStatusRequest
+	126, // 61: accountsrv.Account.DeactivateUser:input_type -> accountsrv.UserStatusRequest
+	126, // 62: accountsrv.Account.ReactivateUser:input_type -> accountsrv.UserStatusRequest
+	126, // 63: accountsrv.Account.RestoreUser:input_type -> accountsrv.UserStatusRequest
+	21,  // 64: accountsrv.Account.LoginUser:input_type -> accountsrv.LoginRequest
+	26,  // 65: accountsrv.Account.UpdateUserProfile:input_type -> accountsrv.UpdateProfileRequest
+	41,  // 66: accountsrv.Account.GetSession:input_type -> accountsrv.GetSessionRequest
+	61,  // 67: accountsrv.Account.SendEmailVerification:input_type -> accountsrv.SendEmailVerificationRequest
+	63,  // 68: accountsrv.Account.VerifyEmail:input_type -> accountsrv.VerifyEmailRequest
+	65,  // 69: accountsrv.Account.SendPhoneVerification:input_type -> accountsrv.SendPhoneVerificationRequest
+	67,  // 70: accountsrv.Account.VerifyPhone:input_type -> accountsrv.VerifyPhoneRequest
+	69,  // 71: accountsrv.Account.RequestPasswordReset:input_type -> accountsrv.RequestPasswordResetRequest
+	71,  // 72: accountsrv.Account.ResetPassword:input_type -> accountsrv.ResetPasswordRequest
+	73,  // 73: accountsrv.Account.CompleteMFALogin:input_type -> accountsrv.CompleteMFALoginRequest
+	75,  // 74: accountsrv.Account.EnrollTOTP:input_type -> accountsrv.EnrollTOTPRequest
+	77,  // 75: accountsrv.Account.ConfirmTOTP:input_type -> accountsrv.ConfirmTOTPRequest
+	79,  // 76: accountsrv.Account.DisableMFA:input_type -> accountsrv.DisableMFARequest
+	81,  // 77: accountsrv.Account.ResetMFA:input_type -> accountsrv.ResetMFARequest
+	85,  // 78: accountsrv.Account.BeginPasskeyRegistration:input_type -> accountsrv.BeginPasskeyRegistrationRequest
+	87,  // 79: accountsrv.Account.FinishPasskeyRegistration:input_type -> accountsrv.FinishPasskeyRegistrationRequest
+	89,  // 80: accountsrv.Account.ListPasskeys:input_type -> accountsrv.ListPasskeysRequest
+	91,  // 81: accountsrv.Account.DeletePasskey:input_type -> accountsrv.DeletePasskeyRequest
+	93,  // 82: accountsrv.Account.BeginPasskeyLogin:input_type -> accountsrv.BeginPasskeyLoginRequest
+	95,  // 83: accountsrv.Account.PasskeyLogin:input_type -> accountsrv.PasskeyLoginRequest
+	97,  // 84: accountsrv.Account.RequestMagicLink:input_type -> accountsrv.RequestMagicLinkRequest
+	99,  // 85: accountsrv.Account.MagicLinkLogin:input_type -> accountsrv.MagicLinkLoginRequest
+	100, // 86: accountsrv.Account.RequestSMSCode:input_type -> accountsrv.RequestSMSCodeRequest
+	103, // 87: accountsrv.Account.SMSCodeLogin:input_type -> accountsrv.SMSCodeLoginRequest
+	104, // 88: accountsrv.Account.LoginWithoutOrg:input_type -> accountsrv.LoginWithoutOrgRequest
+	107, // 89: accountsrv.Account.ChooseLoginOrg:input_type -> accountsrv.ChooseLoginOrgRequest
+	108, // 90: accountsrv.Account.SwitchOrg:input_type -> accountsrv.SwitchOrgRequest
+	109, // 91: accountsrv.Account.ListMyOrgs:input_type -> accountsrv.ListMyOrgsRequest
+	28,  // 92: accountsrv.Account.CreateOrg:input_type -> accountsrv.CreateOrgRequest
+	30,  // 93: accountsrv.Account.GetOrg:input_type -> accountsrv.GetOrgRequest
+	33,  // 94: accountsrv.Account.UpdateOrgAccount:input_type -> accountsrv.UpdateOrgAccountRequest
+	36,  // 95: accountsrv.Account.UpdateOrgProfile:input_type -> accountsrv.UpdateOrgProfileRequest
+	38,  // 96: accountsrv.Account.DeleteOrg:input_type -> accountsrv.DeleteOrgRequest
+	128, // 97: accountsrv.Account.SuspendOrg:input_type -> accountsrv.OrgStatusRequest
+	128, // 98: accountsrv.Account.DeactivateOrg:input_type -> accountsrv.OrgStatusRequest
+	128, // 99: accountsrv.Account.ReactivateOrg:input_type -> accountsrv.OrgStatusRequest
+	128, // 100: accountsrv.Account.RestoreOrg:input_type -> accountsrv.OrgStatusRequest
+	43,  // 101: accountsrv.Account.ListOrgUsers:input_type -> accountsrv.ListOrgUsersRequest
+	111, // 102: accountsrv.Account.ListChildOrgs:input_type -> accountsrv.ListChildOrgsRequest
+	113, // 103: accountsrv.Account.GetOrgTree:input_type -> accountsrv.GetOrgTreeRequest
+	46,  // 104: accountsrv.Account.UpdatePayorDetails:input_type -> accountsrv.UpdatePayorDetailsRequest
+	48,  // 105: accountsrv.Account.FindPayor:input_type -> accountsrv.FindPayorRequest
+	51,  // 106: accountsrv.Account.CreateInvite:input_type -> accountsrv.CreateInviteRequest
+	53,  // 107: accountsrv.Account.ListInvites:input_type -> accountsrv.ListInvitesRequest
+	55,  // 108: accountsrv.Account.RevokeInvite:input_type -> accountsrv.RevokeInviteRequest
+	57,  // 109: accountsrv.Account.ResendInvite:input_type -> accountsrv.ResendInviteRequest
+	59,  // 110: accountsrv.Account.AcceptInvite:input_type -> accountsrv.AcceptInviteRequest
+	117, // 111: accountsrv.Account.RequestNetworkRelationship:input_type -> accountsrv.RequestNetworkRelationshipRequest
+	118, // 112: accountsrv.Account.AcceptNetworkRelationship:input_type -> accountsrv.NetworkRelationshipActionRequest
+	118, // 113: accountsrv.Account.TerminateNetworkRelationship:input_type -> accountsrv.NetworkRelationshipActionRequest
+	120, // 114: accountsrv.Account.ListPayors:input_type -> accountsrv.ListNetworkRequest
+	120, // 115: accountsrv.Account.ListProviders:input_type -> accountsrv.ListNetworkRequest
+	124, // 116: accountsrv.Account.QueryAuditLog:input_type -> accountsrv.QueryAuditLogRequest
+	13,  // 117: accountsrv.Account.CreateUser:output_type -> accountsrv.CreateUserReply
+	15,  // 118: accountsrv.Account.GetUser:output_type -> accountsrv.GetUserReply
+	17,  // 119: accountsrv.Account.DeleteUser:output_type -> accountsrv.DeleteUserReply
+	20,  // 120: accountsrv.Account.UpdateUserAccount:output_type -> accountsrv.UpdateAccountReply
+	127, // 121: accountsrv.Account.SuspendUser:output_type -> accountsrv.UserStatusReply
+	127, // 122: accountsrv.Account.DeactivateUser:output_type -> accountsrv.UserStatusReply
+	127, // 123: accountsrv.Account.ReactivateUser:output_type -> accountsrv.UserStatusReply
+	127, // 124: accountsrv.Account.RestoreUser:output_type -> accountsrv.UserStatusReply
+	22,  // 125: accountsrv.Account.LoginUser:output_type -> accountsrv.LoginReply
+	27,  // 126: accountsrv.Account.UpdateUserProfile:output_type -> accountsrv.UpdateProfileReply
+	42,  // 127: accountsrv.Account.GetSession:output_type -> accountsrv.GetSessionReply
+	62,  // 128: accountsrv.Account.SendEmailVerification:output_type -> accountsrv.SendEmailVerificationReply
+	64,  // 129: accountsrv.Account.VerifyEmail:output_type -> accountsrv.VerifyEmailReply
+	66,  // 130: accountsrv.Account.SendPhoneVerification:output_type -> accountsrv.SendPhoneVerificationReply
+	68,  // 131: accountsrv.Account.VerifyPhone:output_type -> accountsrv.VerifyPhoneReply
+	70,  // 132: accountsrv.Account.RequestPasswordReset:output_type -> accountsrv.RequestPasswordResetReply
+	72,  // 133: accountsrv.Account.ResetPassword:output_type -> accountsrv.ResetPasswordReply
+	74,  // 134: accountsrv.Account.CompleteMFALogin:output_type -> accountsrv.CompleteMFALoginReply
+	76,  // 135: accountsrv.Account.EnrollTOTP:output_type -> accountsrv.EnrollTOTPReply
+	78,  // 136: accountsrv.Account.ConfirmTOTP:output_type -> accountsrv.ConfirmTOTPReply
+	80,  // 137: accountsrv.Account.DisableMFA:output_type -> accountsrv.DisableMFAReply
+	82,  // 138: accountsrv.Account.ResetMFA:output_type -> accountsrv.ResetMFAReply
+	86,  // 139: accountsrv.Account.BeginPasskeyRegistration:output_type -> accountsrv.BeginPasskeyRegistrationReply
+	88,  // 140: accountsrv.Account.FinishPasskeyRegistration:output_type -> accountsrv.FinishPasskeyRegistrationReply
+	90,  // 141: accountsrv.Account.ListPasskeys:output_type -> accountsrv.ListPasskeysReply
+	92,  // 142: accountsrv.Account.DeletePasskey:output_type -> accountsrv.DeletePasskeyReply
+	94,  // 143: accountsrv.Account.BeginPasskeyLogin:output_type -> accountsrv.BeginPasskeyLoginReply
+	96,  // 144: accountsrv.Account.PasskeyLogin:output_type -> accountsrv.PasskeyLoginReply
+	98,  // 145: accountsrv.Account.RequestMagicLink:output_type -> accountsrv.RequestMagicLinkReply
+	22,  // 146: accountsrv.Account.MagicLinkLogin:output_type -> accountsrv.LoginReply
+	102, // 147: accountsrv.Account.RequestSMSCode:output_type -> accountsrv.RequestSMSCodeReply
+	22,  // 148: accountsrv.Account.SMSCodeLogin:output_type -> accountsrv.LoginReply
+	106, // 149: accountsrv.Account.LoginWithoutOrg:output_type -> accountsrv.LoginWithoutOrgReply
+	22,  // 150: accountsrv.Account.ChooseLoginOrg:output_type -> accountsrv.LoginReply
+	22,  // 151: accountsrv.Account.SwitchOrg:output_type -> accountsrv.LoginReply
+	110, // 152: accountsrv.Account.ListMyOrgs:output_type -> accountsrv.ListMyOrgsReply
+	29,  // 153: accountsrv.Account.CreateOrg:output_type -> accountsrv.CreateOrgReply
+	31,  // 154: accountsrv.Account.GetOrg:output_type -> accountsrv.GetOrgReply
+	34,  // 155: accountsrv.Account.UpdateOrgAccount:output_type -> accountsrv.UpdateOrgAccountReply
+	37,  // 156: accountsrv.Account.UpdateOrgProfile:output_type -> accountsrv.UpdateOrgProfileReply
+	39,  // 157: accountsrv.Account.DeleteOrg:output_type -> accountsrv.DeleteOrgReply
+	129, // 158: accountsrv.Account.SuspendOrg:output_type -> accountsrv.OrgStatusReply
+	129, // 159: accountsrv.Account.DeactivateOrg:output_type -> accountsrv.OrgStatusReply
+	129, // 160: accountsrv.Account.ReactivateOrg:output_type -> accountsrv.OrgStatusReply
+	129, // 161: accountsrv.Account.RestoreOrg:output_type -> accountsrv.OrgStatusReply
+	45,  // 162: accountsrv.Account.ListOrgUsers:output_type -> accountsrv.ListOrgUsersReply
+	112, // 163: accountsrv.Account.ListChildOrgs:output_type -> accountsrv.ListChildOrgsReply
+	115, // 164: accountsrv.Account.GetOrgTree:output_type -> accountsrv.GetOrgTreeReply
+	47,  // 165: accountsrv.Account.UpdatePayorDetails:output_type -> accountsrv.UpdatePayorDetailsReply
+	49,  // 166: accountsrv.Account.FindPayor:output_type -> accountsrv.FindPayorReply
+	52,  // 167: accountsrv.Account.CreateInvite:output_type -> accountsrv.CreateInviteReply
+	54,  // 168: accountsrv.Account.ListInvites:output_type -> accountsrv.ListInvitesReply
+	56,  // 169: accountsrv.Account.RevokeInvite:output_type -> accountsrv.RevokeInviteReply
+	58,  // 170: accountsrv.Account.ResendInvite:output_type -> accountsrv.ResendInviteReply
+	60,  // 171: accountsrv.Account.AcceptInvite:output_type -> accountsrv.AcceptInviteReply
+	119, // 172: accountsrv.Account.RequestNetworkRelationship:output_type -> accountsrv.NetworkRelationshipReply
+	119, // 173: accountsrv.Account.AcceptNetworkRelationship:output_type -> accountsrv.NetworkRelationshipReply
+	119, // 174: accountsrv.Account.TerminateNetworkRelationship:output_type -> accountsrv.NetworkRelationshipReply
+	121, // 175: accountsrv.Account.ListPayors:output_type -> accountsrv.ListNetworkReply
+	121, // 176: accountsrv.Account.ListProviders:output_type -> accountsrv.ListNetworkReply
+	125, // 177: accountsrv.Account.QueryAuditLog:output_type -> accountsrv.QueryAuditLogReply
+	117, // [117:178] is the sub-list for method output_type
+	56,  // [56:117] is the sub-list for method input_type
+	56,  // [56:56] is the sub-list for extension type_name
+	56,  // [56:56] is the sub-list for extension extendee
+	0,   // [0:56] is the sub-list for field type_name
+}
+
+func init() { file_accountsrv_proto_init() }
+func file_accountsrv_proto_init() {
+	if File_accountsrv_proto != nil {
+		return
+	}
+	file_accountsrv_proto_msgTypes[32].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_accountsrv_proto_rawDesc), len(file_accountsrv_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   130,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_accountsrv_proto_goTypes,
+		DependencyIndexes: file_accountsrv_proto_depIdxs,
+		MessageInfos:      file_accountsrv_proto_msgTypes,
+	}.Build()
+	File_accountsrv_proto = out.File
+	file_accountsrv_proto_goTypes = nil
+	file_accountsrv_proto_depIdxs = nil
+}
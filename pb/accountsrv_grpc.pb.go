@@ -0,0 +1,2405 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: accountsrv.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Account_CreateUser_FullMethodName                   = "/accountsrv.Account/CreateUser"
+	Account_GetUser_FullMethodName                      = "/accountsrv.Account/GetUser"
+	Account_DeleteUser_FullMethodName                   = "/accountsrv.Account/DeleteUser"
+	Account_UpdateUserAccount_FullMethodName            = "/accountsrv.Account/UpdateUserAccount"
+	Account_SuspendUser_FullMethodName                  = "/accountsrv.Account/SuspendUser"
+	Account_DeactivateUser_FullMethodName               = "/accountsrv.Account/DeactivateUser"
+	Account_ReactivateUser_FullMethodName               = "/accountsrv.Account/ReactivateUser"
+	Account_RestoreUser_FullMethodName                  = "/accountsrv.Account/RestoreUser"
+	Account_LoginUser_FullMethodName                    = "/accountsrv.Account/LoginUser"
+	Account_UpdateUserProfile_FullMethodName            = "/accountsrv.Account/UpdateUserProfile"
+	Account_GetSession_FullMethodName                   = "/accountsrv.Account/GetSession"
+	Account_SendEmailVerification_FullMethodName        = "/accountsrv.Account/SendEmailVerification"
+	Account_VerifyEmail_FullMethodName                  = "/accountsrv.Account/VerifyEmail"
+	Account_SendPhoneVerification_FullMethodName        = "/accountsrv.Account/SendPhoneVerification"
+	Account_VerifyPhone_FullMethodName                  = "/accountsrv.Account/VerifyPhone"
+	Account_RequestPasswordReset_FullMethodName         = "/accountsrv.Account/RequestPasswordReset"
+	Account_ResetPassword_FullMethodName                = "/accountsrv.Account/ResetPassword"
+	Account_CompleteMFALogin_FullMethodName             = "/accountsrv.Account/CompleteMFALogin"
+	Account_EnrollTOTP_FullMethodName                   = "/accountsrv.Account/EnrollTOTP"
+	Account_ConfirmTOTP_FullMethodName                  = "/accountsrv.Account/ConfirmTOTP"
+	Account_DisableMFA_FullMethodName                   = "/accountsrv.Account/DisableMFA"
+	Account_ResetMFA_FullMethodName                     = "/accountsrv.Account/ResetMFA"
+	Account_BeginPasskeyRegistration_FullMethodName     = "/accountsrv.Account/BeginPasskeyRegistration"
+	Account_FinishPasskeyRegistration_FullMethodName    = "/accountsrv.Account/FinishPasskeyRegistration"
+	Account_ListPasskeys_FullMethodName                 = "/accountsrv.Account/ListPasskeys"
+	Account_DeletePasskey_FullMethodName                = "/accountsrv.Account/DeletePasskey"
+	Account_BeginPasskeyLogin_FullMethodName            = "/accountsrv.Account/BeginPasskeyLogin"
+	Account_PasskeyLogin_FullMethodName                 = "/accountsrv.Account/PasskeyLogin"
+	Account_RequestMagicLink_FullMethodName             = "/accountsrv.Account/RequestMagicLink"
+	Account_MagicLinkLogin_FullMethodName               = "/accountsrv.Account/MagicLinkLogin"
+	Account_RequestSMSCode_FullMethodName               = "/accountsrv.Account/RequestSMSCode"
+	Account_SMSCodeLogin_FullMethodName                 = "/accountsrv.Account/SMSCodeLogin"
+	Account_LoginWithoutOrg_FullMethodName              = "/accountsrv.Account/LoginWithoutOrg"
+	Account_ChooseLoginOrg_FullMethodName               = "/accountsrv.Account/ChooseLoginOrg"
+	Account_SwitchOrg_FullMethodName                    = "/accountsrv.Account/SwitchOrg"
+	Account_ListMyOrgs_FullMethodName                   = "/accountsrv.Account/ListMyOrgs"
+	Account_CreateOrg_FullMethodName                    = "/accountsrv.Account/CreateOrg"
+	Account_GetOrg_FullMethodName                       = "/accountsrv.Account/GetOrg"
+	Account_UpdateOrgAccount_FullMethodName             = "/accountsrv.Account/UpdateOrgAccount"
+	Account_UpdateOrgProfile_FullMethodName             = "/accountsrv.Account/UpdateOrgProfile"
+	Account_DeleteOrg_FullMethodName                    = "/accountsrv.Account/DeleteOrg"
+	Account_SuspendOrg_FullMethodName                   = "/accountsrv.Account/SuspendOrg"
+	Account_DeactivateOrg_FullMethodName                = "/accountsrv.Account/DeactivateOrg"
+	Account_ReactivateOrg_FullMethodName                = "/accountsrv.Account/ReactivateOrg"
+	Account_RestoreOrg_FullMethodName                   = "/accountsrv.Account/RestoreOrg"
+	Account_ListOrgUsers_FullMethodName                 = "/accountsrv.Account/ListOrgUsers"
+	Account_ListChildOrgs_FullMethodName                = "/accountsrv.Account/ListChildOrgs"
+	Account_GetOrgTree_FullMethodName                   = "/accountsrv.Account/GetOrgTree"
+	Account_UpdatePayorDetails_FullMethodName           = "/accountsrv.Account/UpdatePayorDetails"
+	Account_FindPayor_FullMethodName                    = "/accountsrv.Account/FindPayor"
+	Account_CreateInvite_FullMethodName                 = "/accountsrv.Account/CreateInvite"
+	Account_ListInvites_FullMethodName                  = "/accountsrv.Account/ListInvites"
+	Account_RevokeInvite_FullMethodName                 = "/accountsrv.Account/RevokeInvite"
+	Account_ResendInvite_FullMethodName                 = "/accountsrv.Account/ResendInvite"
+	Account_AcceptInvite_FullMethodName                 = "/accountsrv.Account/AcceptInvite"
+	Account_RequestNetworkRelationship_FullMethodName   = "/accountsrv.Account/RequestNetworkRelationship"
+	Account_AcceptNetworkRelationship_FullMethodName    = "/accountsrv.Account/AcceptNetworkRelationship"
+	Account_TerminateNetworkRelationship_FullMethodName = "/accountsrv.Account/TerminateNetworkRelationship"
+	Account_ListPayors_FullMethodName                   = "/accountsrv.Account/ListPayors"
+	Account_ListProviders_FullMethodName                = "/accountsrv.Account/ListProviders"
+	Account_QueryAuditLog_FullMethodName                = "/accountsrv.Account/QueryAuditLog"
+)
+
+// AccountClient is the client API for Account service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// The gRPC flavor of the account service, one rpc per entry in Endpoints.
+type AccountClient interface {
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserReply, error)
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserReply, error)
+	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserReply, error)
+	UpdateUserAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*UpdateAccountReply, error)
+	SuspendUser(ctx context.Context, in *UserStatusRequest, opts ...grpc.CallOption) (*UserStatusReply, error)
+	DeactivateUser(ctx context.Context, in *UserStatusRequest, opts ...grpc.CallOption) (*UserStatusReply, error)
+	ReactivateUser(ctx context.Context, in *UserStatusRequest, opts ...grpc.CallOption) (*UserStatusReply, error)
+	RestoreUser(ctx context.Context, in *UserStatusRequest, opts ...grpc.CallOption) (*UserStatusReply, error)
+	LoginUser(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginReply, error)
+	UpdateUserProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UpdateProfileReply, error)
+	GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionReply, error)
+	SendEmailVerification(ctx context.Context, in *SendEmailVerificationRequest, opts ...grpc.CallOption) (*SendEmailVerificationReply, error)
+	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailReply, error)
+	SendPhoneVerification(ctx context.Context, in *SendPhoneVerificationRequest, opts ...grpc.CallOption) (*SendPhoneVerificationReply, error)
+	VerifyPhone(ctx context.Context, in *VerifyPhoneRequest, opts ...grpc.CallOption) (*VerifyPhoneReply, error)
+	RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*RequestPasswordResetReply, error)
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordReply, error)
+	CompleteMFALogin(ctx context.Context, in *CompleteMFALoginRequest, opts ...grpc.CallOption) (*CompleteMFALoginReply, error)
+	EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*EnrollTOTPReply, error)
+	ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*ConfirmTOTPReply, error)
+	DisableMFA(ctx context.Context, in *DisableMFARequest, opts ...grpc.CallOption) (*DisableMFAReply, error)
+	ResetMFA(ctx context.Context, in *ResetMFARequest, opts ...grpc.CallOption) (*ResetMFAReply, error)
+	BeginPasskeyRegistration(ctx context.Context, in *BeginPasskeyRegistrationRequest, opts ...grpc.CallOption) (*BeginPasskeyRegistrationReply, error)
+	FinishPasskeyRegistration(ctx context.Context, in *FinishPasskeyRegistrationRequest, opts ...grpc.CallOption) (*FinishPasskeyRegistrationReply, error)
+	ListPasskeys(ctx context.Context, in *ListPasskeysRequest, opts ...grpc.CallOption) (*ListPasskeysReply, error)
+	DeletePasskey(ctx context.Context, in *DeletePasskeyRequest, opts ...grpc.CallOption) (*DeletePasskeyReply, error)
+	BeginPasskeyLogin(ctx context.Context, in *BeginPasskeyLoginRequest, opts ...grpc.CallOption) (*BeginPasskeyLoginReply, error)
+	PasskeyLogin(ctx context.Context, in *PasskeyLoginRequest, opts ...grpc.CallOption) (*PasskeyLoginReply, error)
+	RequestMagicLink(ctx context.Context, in *RequestMagicLinkRequest, opts ...grpc.CallOption) (*RequestMagicLinkReply, error)
+	MagicLinkLogin(ctx context.Context, in *MagicLinkLoginRequest, opts ...grpc.CallOption) (*LoginReply, error)
+	RequestSMSCode(ctx context.Context, in *RequestSMSCodeRequest, opts ...grpc.CallOption) (*RequestSMSCodeReply, error)
+	SMSCodeLogin(ctx context.Context, in *SMSCodeLoginRequest, opts ...grpc.CallOption) (*LoginReply, error)
+	LoginWithoutOrg(ctx context.Context, in *LoginWithoutOrgRequest, opts ...grpc.CallOption) (*LoginWithoutOrgReply, error)
+	ChooseLoginOrg(ctx context.Context, in *ChooseLoginOrgRequest, opts ...grpc.CallOption) (*LoginReply, error)
+	SwitchOrg(ctx context.Context, in *SwitchOrgRequest, opts ...grpc.CallOption) (*LoginReply, error)
+	ListMyOrgs(ctx context.Context, in *ListMyOrgsRequest, opts ...grpc.CallOption) (*ListMyOrgsReply, error)
+	CreateOrg(ctx context.Context, in *CreateOrgRequest, opts ...grpc.CallOption) (*CreateOrgReply, error)
+	GetOrg(ctx context.Context, in *GetOrgRequest, opts ...grpc.CallOption) (*GetOrgReply, error)
+	UpdateOrgAccount(ctx context.Context, in *UpdateOrgAccountRequest, opts ...grpc.CallOption) (*UpdateOrgAccountReply, error)
+	UpdateOrgProfile(ctx context.Context, in *UpdateOrgProfileRequest, opts ...grpc.CallOption) (*UpdateOrgProfileReply, error)
+	DeleteOrg(ctx context.Context, in *DeleteOrgRequest, opts ...grpc.CallOption) (*DeleteOrgReply, error)
+	SuspendOrg(ctx context.Context, in *OrgStatusRequest, opts ...grpc.CallOption) (*OrgStatusReply, error)
+	DeactivateOrg(ctx context.Context, in *OrgStatusRequest, opts ...grpc.CallOption) (*OrgStatusReply, error)
+	ReactivateOrg(ctx context.Context, in *OrgStatusRequest, opts ...grpc.CallOption) (*OrgStatusReply, error)
+	RestoreOrg(ctx context.Context, in *OrgStatusRequest, opts ...grpc.CallOption) (*OrgStatusReply, error)
+	ListOrgUsers(ctx context.Context, in *ListOrgUsersRequest, opts ...grpc.CallOption) (*ListOrgUsersReply, error)
+	ListChildOrgs(ctx context.Context, in *ListChildOrgsRequest, opts ...grpc.CallOption) (*ListChildOrgsReply, error)
+	GetOrgTree(ctx context.Context, in *GetOrgTreeRequest, opts ...grpc.CallOption) (*GetOrgTreeReply, error)
+	UpdatePayorDetails(ctx context.Context, in *UpdatePayorDetailsRequest, opts ...grpc.CallOption) (*UpdatePayorDetailsReply, error)
+	FindPayor(ctx context.Context, in *FindPayorRequest, opts ...grpc.CallOption) (*FindPayorReply, error)
+	CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*CreateInviteReply, error)
+	ListInvites(ctx context.Context, in *ListInvitesRequest, opts ...grpc.CallOption) (*ListInvitesReply, error)
+	RevokeInvite(ctx context.Context, in *RevokeInviteRequest, opts ...grpc.CallOption) (*RevokeInviteReply, error)
+	ResendInvite(ctx context.Context, in *ResendInviteRequest, opts ...grpc.CallOption) (*ResendInviteReply, error)
+	AcceptInvite(ctx context.Context, in *AcceptInviteRequest, opts ...grpc.CallOption) (*AcceptInviteReply, error)
+	RequestNetworkRelationship(ctx context.Context, in *RequestNetworkRelationshipRequest, opts ...grpc.CallOption) (*NetworkRelationshipReply, error)
+	AcceptNetworkRelationship(ctx context.Context, in *NetworkRelationshipActionRequest, opts ...grpc.CallOption) (*NetworkRelationshipReply, error)
+	TerminateNetworkRelationship(ctx context.Context, in *NetworkRelationshipActionRequest, opts ...grpc.CallOption) (*NetworkRelationshipReply, error)
+	ListPayors(ctx context.Context, in *ListNetworkRequest, opts ...grpc.CallOption) (*ListNetworkReply, error)
+	ListProviders(ctx context.Context, in *ListNetworkRequest, opts ...grpc.CallOption) (*ListNetworkReply, error)
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogReply, error)
+}
+
+type accountClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccountClient(cc grpc.ClientConnInterface) AccountClient {
+	return &accountClient{cc}
+}
+
+func (c *accountClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateUserReply)
+	err := c.cc.Invoke(ctx, Account_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserReply)
+	err := c.cc.Invoke(ctx, Account_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserReply)
+	err := c.cc.Invoke(ctx, Account_DeleteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) UpdateUserAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*UpdateAccountReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateAccountReply)
+	err := c.cc.Invoke(ctx, Account_UpdateUserAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) SuspendUser(ctx context.Context, in *UserStatusRequest, opts ...grpc.CallOption) (*UserStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserStatusReply)
+	err := c.cc.Invoke(ctx, Account_SuspendUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) DeactivateUser(ctx context.Context, in *UserStatusRequest, opts ...grpc.CallOption) (*UserStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserStatusReply)
+	err := c.cc.Invoke(ctx, Account_DeactivateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ReactivateUser(ctx context.Context, in *UserStatusRequest, opts ...grpc.CallOption) (*UserStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserStatusReply)
+	err := c.cc.Invoke(ctx, Account_ReactivateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) RestoreUser(ctx context.Context, in *UserStatusRequest, opts ...grpc.CallOption) (*UserStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserStatusReply)
+	err := c.cc.Invoke(ctx, Account_RestoreUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) LoginUser(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginReply)
+	err := c.cc.Invoke(ctx, Account_LoginUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) UpdateUserProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UpdateProfileReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateProfileReply)
+	err := c.cc.Invoke(ctx, Account_UpdateUserProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSessionReply)
+	err := c.cc.Invoke(ctx, Account_GetSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) SendEmailVerification(ctx context.Context, in *SendEmailVerificationRequest, opts ...grpc.CallOption) (*SendEmailVerificationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendEmailVerificationReply)
+	err := c.cc.Invoke(ctx, Account_SendEmailVerification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyEmailReply)
+	err := c.cc.Invoke(ctx, Account_VerifyEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) SendPhoneVerification(ctx context.Context, in *SendPhoneVerificationRequest, opts ...grpc.CallOption) (*SendPhoneVerificationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendPhoneVerificationReply)
+	err := c.cc.Invoke(ctx, Account_SendPhoneVerification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) VerifyPhone(ctx context.Context, in *VerifyPhoneRequest, opts ...grpc.CallOption) (*VerifyPhoneReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyPhoneReply)
+	err := c.cc.Invoke(ctx, Account_VerifyPhone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*RequestPasswordResetReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestPasswordResetReply)
+	err := c.cc.Invoke(ctx, Account_RequestPasswordReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetPasswordReply)
+	err := c.cc.Invoke(ctx, Account_ResetPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) CompleteMFALogin(ctx context.Context, in *CompleteMFALoginRequest, opts ...grpc.CallOption) (*CompleteMFALoginReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteMFALoginReply)
+	err := c.cc.Invoke(ctx, Account_CompleteMFALogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*EnrollTOTPReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollTOTPReply)
+	err := c.cc.Invoke(ctx, Account_EnrollTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*ConfirmTOTPReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmTOTPReply)
+	err := c.cc.Invoke(ctx, Account_ConfirmTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) DisableMFA(ctx context.Context, in *DisableMFARequest, opts ...grpc.CallOption) (*DisableMFAReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableMFAReply)
+	err := c.cc.Invoke(ctx, Account_DisableMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ResetMFA(ctx context.Context, in *ResetMFARequest, opts ...grpc.CallOption) (*ResetMFAReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetMFAReply)
+	err := c.cc.Invoke(ctx, Account_ResetMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) BeginPasskeyRegistration(ctx context.Context, in *BeginPasskeyRegistrationRequest, opts ...grpc.CallOption) (*BeginPasskeyRegistrationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeginPasskeyRegistrationReply)
+	err := c.cc.Invoke(ctx, Account_BeginPasskeyRegistration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) FinishPasskeyRegistration(ctx context.Context, in *FinishPasskeyRegistrationRequest, opts ...grpc.CallOption) (*FinishPasskeyRegistrationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FinishPasskeyRegistrationReply)
+	err := c.cc.Invoke(ctx, Account_FinishPasskeyRegistration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ListPasskeys(ctx context.Context, in *ListPasskeysRequest, opts ...grpc.CallOption) (*ListPasskeysReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPasskeysReply)
+	err := c.cc.Invoke(ctx, Account_ListPasskeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) DeletePasskey(ctx context.Context, in *DeletePasskeyRequest, opts ...grpc.CallOption) (*DeletePasskeyReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePasskeyReply)
+	err := c.cc.Invoke(ctx, Account_DeletePasskey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) BeginPasskeyLogin(ctx context.Context, in *BeginPasskeyLoginRequest, opts ...grpc.CallOption) (*BeginPasskeyLoginReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeginPasskeyLoginReply)
+	err := c.cc.Invoke(ctx, Account_BeginPasskeyLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) PasskeyLogin(ctx context.Context, in *PasskeyLoginRequest, opts ...grpc.CallOption) (*PasskeyLoginReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PasskeyLoginReply)
+	err := c.cc.Invoke(ctx, Account_PasskeyLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) RequestMagicLink(ctx context.Context, in *RequestMagicLinkRequest, opts ...grpc.CallOption) (*RequestMagicLinkReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestMagicLinkReply)
+	err := c.cc.Invoke(ctx, Account_RequestMagicLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) MagicLinkLogin(ctx context.Context, in *MagicLinkLoginRequest, opts ...grpc.CallOption) (*LoginReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginReply)
+	err := c.cc.Invoke(ctx, Account_MagicLinkLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) RequestSMSCode(ctx context.Context, in *RequestSMSCodeRequest, opts ...grpc.CallOption) (*RequestSMSCodeReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestSMSCodeReply)
+	err := c.cc.Invoke(ctx, Account_RequestSMSCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) SMSCodeLogin(ctx context.Context, in *SMSCodeLoginRequest, opts ...grpc.CallOption) (*LoginReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginReply)
+	err := c.cc.Invoke(ctx, Account_SMSCodeLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) LoginWithoutOrg(ctx context.Context, in *LoginWithoutOrgRequest, opts ...grpc.CallOption) (*LoginWithoutOrgReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginWithoutOrgReply)
+	err := c.cc.Invoke(ctx, Account_LoginWithoutOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ChooseLoginOrg(ctx context.Context, in *ChooseLoginOrgRequest, opts ...grpc.CallOption) (*LoginReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginReply)
+	err := c.cc.Invoke(ctx, Account_ChooseLoginOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) SwitchOrg(ctx context.Context, in *SwitchOrgRequest, opts ...grpc.CallOption) (*LoginReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginReply)
+	err := c.cc.Invoke(ctx, Account_SwitchOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ListMyOrgs(ctx context.Context, in *ListMyOrgsRequest, opts ...grpc.CallOption) (*ListMyOrgsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMyOrgsReply)
+	err := c.cc.Invoke(ctx, Account_ListMyOrgs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) CreateOrg(ctx context.Context, in *CreateOrgRequest, opts ...grpc.CallOption) (*CreateOrgReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOrgReply)
+	err := c.cc.Invoke(ctx, Account_CreateOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) GetOrg(ctx context.Context, in *GetOrgRequest, opts ...grpc.CallOption) (*GetOrgReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrgReply)
+	err := c.cc.Invoke(ctx, Account_GetOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) UpdateOrgAccount(ctx context.Context, in *UpdateOrgAccountRequest, opts ...grpc.CallOption) (*UpdateOrgAccountReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateOrgAccountReply)
+	err := c.cc.Invoke(ctx, Account_UpdateOrgAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) UpdateOrgProfile(ctx context.Context, in *UpdateOrgProfileRequest, opts ...grpc.CallOption) (*UpdateOrgProfileReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateOrgProfileReply)
+	err := c.cc.Invoke(ctx, Account_UpdateOrgProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) DeleteOrg(ctx context.Context, in *DeleteOrgRequest, opts ...grpc.CallOption) (*DeleteOrgReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteOrgReply)
+	err := c.cc.Invoke(ctx, Account_DeleteOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) SuspendOrg(ctx context.Context, in *OrgStatusRequest, opts ...grpc.CallOption) (*OrgStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrgStatusReply)
+	err := c.cc.Invoke(ctx, Account_SuspendOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) DeactivateOrg(ctx context.Context, in *OrgStatusRequest, opts ...grpc.CallOption) (*OrgStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrgStatusReply)
+	err := c.cc.Invoke(ctx, Account_DeactivateOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ReactivateOrg(ctx context.Context, in *OrgStatusRequest, opts ...grpc.CallOption) (*OrgStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrgStatusReply)
+	err := c.cc.Invoke(ctx, Account_ReactivateOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) RestoreOrg(ctx context.Context, in *OrgStatusRequest, opts ...grpc.CallOption) (*OrgStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrgStatusReply)
+	err := c.cc.Invoke(ctx, Account_RestoreOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ListOrgUsers(ctx context.Context, in *ListOrgUsersRequest, opts ...grpc.CallOption) (*ListOrgUsersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrgUsersReply)
+	err := c.cc.Invoke(ctx, Account_ListOrgUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ListChildOrgs(ctx context.Context, in *ListChildOrgsRequest, opts ...grpc.CallOption) (*ListChildOrgsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChildOrgsReply)
+	err := c.cc.Invoke(ctx, Account_ListChildOrgs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) GetOrgTree(ctx context.Context, in *GetOrgTreeRequest, opts ...grpc.CallOption) (*GetOrgTreeReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrgTreeReply)
+	err := c.cc.Invoke(ctx, Account_GetOrgTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) UpdatePayorDetails(ctx context.Context, in *UpdatePayorDetailsRequest, opts ...grpc.CallOption) (*UpdatePayorDetailsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePayorDetailsReply)
+	err := c.cc.Invoke(ctx, Account_UpdatePayorDetails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) FindPayor(ctx context.Context, in *FindPayorRequest, opts ...grpc.CallOption) (*FindPayorReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindPayorReply)
+	err := c.cc.Invoke(ctx, Account_FindPayor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*CreateInviteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateInviteReply)
+	err := c.cc.Invoke(ctx, Account_CreateInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ListInvites(ctx context.Context, in *ListInvitesRequest, opts ...grpc.CallOption) (*ListInvitesReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInvitesReply)
+	err := c.cc.Invoke(ctx, Account_ListInvites_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) RevokeInvite(ctx context.Context, in *RevokeInviteRequest, opts ...grpc.CallOption) (*RevokeInviteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeInviteReply)
+	err := c.cc.Invoke(ctx, Account_RevokeInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ResendInvite(ctx context.Context, in *ResendInviteRequest, opts ...grpc.CallOption) (*ResendInviteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResendInviteReply)
+	err := c.cc.Invoke(ctx, Account_ResendInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) AcceptInvite(ctx context.Context, in *AcceptInviteRequest, opts ...grpc.CallOption) (*AcceptInviteReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptInviteReply)
+	err := c.cc.Invoke(ctx, Account_AcceptInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) RequestNetworkRelationship(ctx context.Context, in *RequestNetworkRelationshipRequest, opts ...grpc.CallOption) (*NetworkRelationshipReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NetworkRelationshipReply)
+	err := c.cc.Invoke(ctx, Account_RequestNetworkRelationship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) AcceptNetworkRelationship(ctx context.Context, in *NetworkRelationshipActionRequest, opts ...grpc.CallOption) (*NetworkRelationshipReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NetworkRelationshipReply)
+	err := c.cc.Invoke(ctx, Account_AcceptNetworkRelationship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) TerminateNetworkRelationship(ctx context.Context, in *NetworkRelationshipActionRequest, opts ...grpc.CallOption) (*NetworkRelationshipReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NetworkRelationshipReply)
+	err := c.cc.Invoke(ctx, Account_TerminateNetworkRelationship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ListPayors(ctx context.Context, in *ListNetworkRequest, opts ...grpc.CallOption) (*ListNetworkReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNetworkReply)
+	err := c.cc.Invoke(ctx, Account_ListPayors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ListProviders(ctx context.Context, in *ListNetworkRequest, opts ...grpc.CallOption) (*ListNetworkReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNetworkReply)
+	err := c.cc.Invoke(ctx, Account_ListProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryAuditLogReply)
+	err := c.cc.Invoke(ctx, Account_QueryAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AccountServer is the server API for Account service.
+// All implementations must embed UnimplementedAccountServer
+// for forward compatibility.
+//
+// The gRPC flavor of the account service, one rpc per entry in Endpoints.
+type AccountServer interface {
+	CreateUser(context.Context, *CreateUserRequest) (*CreateUserReply, error)
+	GetUser(context.Context, *GetUserRequest) (*GetUserReply, error)
+	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserReply, error)
+	UpdateUserAccount(context.Context, *UpdateAccountRequest) (*UpdateAccountReply, error)
+	SuspendUser(context.Context, *UserStatusRequest) (*UserStatusReply, error)
+	DeactivateUser(context.Context, *UserStatusRequest) (*UserStatusReply, error)
+	ReactivateUser(context.Context, *UserStatusRequest) (*UserStatusReply, error)
+	RestoreUser(context.Context, *UserStatusRequest) (*UserStatusReply, error)
+	LoginUser(context.Context, *LoginRequest) (*LoginReply, error)
+	UpdateUserProfile(context.Context, *UpdateProfileRequest) (*UpdateProfileReply, error)
+	GetSession(context.Context, *GetSessionRequest) (*GetSessionReply, error)
+	SendEmailVerification(context.Context, *SendEmailVerificationRequest) (*SendEmailVerificationReply, error)
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailReply, error)
+	SendPhoneVerification(context.Context, *SendPhoneVerificationRequest) (*SendPhoneVerificationReply, error)
+	VerifyPhone(context.Context, *VerifyPhoneRequest) (*VerifyPhoneReply, error)
+	RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*RequestPasswordResetReply, error)
+	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordReply, error)
+	CompleteMFALogin(context.Context, *CompleteMFALoginRequest) (*CompleteMFALoginReply, error)
+	EnrollTOTP(context.Context, *EnrollTOTPRequest) (*EnrollTOTPReply, error)
+	ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*ConfirmTOTPReply, error)
+	DisableMFA(context.Context, *DisableMFARequest) (*DisableMFAReply, error)
+	ResetMFA(context.Context, *ResetMFARequest) (*ResetMFAReply, error)
+	BeginPasskeyRegistration(context.Context, *BeginPasskeyRegistrationRequest) (*BeginPasskeyRegistrationReply, error)
+	FinishPasskeyRegistration(context.Context, *FinishPasskeyRegistrationRequest) (*FinishPasskeyRegistrationReply, error)
+	ListPasskeys(context.Context, *ListPasskeysRequest) (*ListPasskeysReply, error)
+	DeletePasskey(context.Context, *DeletePasskeyRequest) (*DeletePasskeyReply, error)
+	BeginPasskeyLogin(context.Context, *BeginPasskeyLoginRequest) (*BeginPasskeyLoginReply, error)
+	PasskeyLogin(context.Context, *PasskeyLoginRequest) (*PasskeyLoginReply, error)
+	RequestMagicLink(context.Context, *RequestMagicLinkRequest) (*RequestMagicLinkReply, error)
+	MagicLinkLogin(context.Context, *MagicLinkLoginRequest) (*LoginReply, error)
+	RequestSMSCode(context.Context, *RequestSMSCodeRequest) (*RequestSMSCodeReply, error)
+	SMSCodeLogin(context.Context, *SMSCodeLoginRequest) (*LoginReply, error)
+	LoginWithoutOrg(context.Context, *LoginWithoutOrgRequest) (*LoginWithoutOrgReply, error)
+	ChooseLoginOrg(context.Context, *ChooseLoginOrgRequest) (*LoginReply, error)
+	SwitchOrg(context.Context, *SwitchOrgRequest) (*LoginReply, error)
+	ListMyOrgs(context.Context, *ListMyOrgsRequest) (*ListMyOrgsReply, error)
+	CreateOrg(context.Context, *CreateOrgRequest) (*CreateOrgReply, error)
+	GetOrg(context.Context, *GetOrgRequest) (*GetOrgReply, error)
+	UpdateOrgAccount(context.Context, *UpdateOrgAccountRequest) (*UpdateOrgAccountReply, error)
+	UpdateOrgProfile(context.Context, *UpdateOrgProfileRequest) (*UpdateOrgProfileReply, error)
+	DeleteOrg(context.Context, *DeleteOrgRequest) (*DeleteOrgReply, error)
+	SuspendOrg(context.Context, *OrgStatusRequest) (*OrgStatusReply, error)
+	DeactivateOrg(context.Context, *OrgStatusRequest) (*OrgStatusReply, error)
+	ReactivateOrg(context.Context, *OrgStatusRequest) (*OrgStatusReply, error)
+	RestoreOrg(context.Context, *OrgStatusRequest) (*OrgStatusReply, error)
+	ListOrgUsers(context.Context, *ListOrgUsersRequest) (*ListOrgUsersReply, error)
+	ListChildOrgs(context.Context, *ListChildOrgsRequest) (*ListChildOrgsReply, error)
+	GetOrgTree(context.Context, *GetOrgTreeRequest) (*GetOrgTreeReply, error)
+	UpdatePayorDetails(context.Context, *UpdatePayorDetailsRequest) (*UpdatePayorDetailsReply, error)
+	FindPayor(context.Context, *FindPayorRequest) (*FindPayorReply, error)
+	CreateInvite(context.Context, *CreateInviteRequest) (*CreateInviteReply, error)
+	ListInvites(context.Context, *ListInvitesRequest) (*ListInvitesReply, error)
+	RevokeInvite(context.Context, *RevokeInviteRequest) (*RevokeInviteReply, error)
+	ResendInvite(context.Context, *ResendInviteRequest) (*ResendInviteReply, error)
+	AcceptInvite(context.Context, *AcceptInviteRequest) (*AcceptInviteReply, error)
+	RequestNetworkRelationship(context.Context, *RequestNetworkRelationshipRequest) (*NetworkRelationshipReply, error)
+	AcceptNetworkRelationship(context.Context, *NetworkRelationshipActionRequest) (*NetworkRelationshipReply, error)
+	TerminateNetworkRelationship(context.Context, *NetworkRelationshipActionRequest) (*NetworkRelationshipReply, error)
+	ListPayors(context.Context, *ListNetworkRequest) (*ListNetworkReply, error)
+	ListProviders(context.Context, *ListNetworkRequest) (*ListNetworkReply, error)
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogReply, error)
+	mustEmbedUnimplementedAccountServer()
+}
+
+// UnimplementedAccountServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAccountServer struct{}
+
+func (UnimplementedAccountServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedAccountServer) GetUser(context.Context, *GetUserRequest) (*GetUserReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedAccountServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedAccountServer) UpdateUserAccount(context.Context, *UpdateAccountRequest) (*UpdateAccountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserAccount not implemented")
+}
+func (UnimplementedAccountServer) SuspendUser(context.Context, *UserStatusRequest) (*UserStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendUser not implemented")
+}
+func (UnimplementedAccountServer) DeactivateUser(context.Context, *UserStatusRequest) (*UserStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivateUser not implemented")
+}
+func (UnimplementedAccountServer) ReactivateUser(context.Context, *UserStatusRequest) (*UserStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReactivateUser not implemented")
+}
+func (UnimplementedAccountServer) RestoreUser(context.Context, *UserStatusRequest) (*UserStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreUser not implemented")
+}
+func (UnimplementedAccountServer) LoginUser(context.Context, *LoginRequest) (*LoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginUser not implemented")
+}
+func (UnimplementedAccountServer) UpdateUserProfile(context.Context, *UpdateProfileRequest) (*UpdateProfileReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserProfile not implemented")
+}
+func (UnimplementedAccountServer) GetSession(context.Context, *GetSessionRequest) (*GetSessionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSession not implemented")
+}
+func (UnimplementedAccountServer) SendEmailVerification(context.Context, *SendEmailVerificationRequest) (*SendEmailVerificationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendEmailVerification not implemented")
+}
+func (UnimplementedAccountServer) VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedAccountServer) SendPhoneVerification(context.Context, *SendPhoneVerificationRequest) (*SendPhoneVerificationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendPhoneVerification not implemented")
+}
+func (UnimplementedAccountServer) VerifyPhone(context.Context, *VerifyPhoneRequest) (*VerifyPhoneReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyPhone not implemented")
+}
+func (UnimplementedAccountServer) RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*RequestPasswordResetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPasswordReset not implemented")
+}
+func (UnimplementedAccountServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedAccountServer) CompleteMFALogin(context.Context, *CompleteMFALoginRequest) (*CompleteMFALoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteMFALogin not implemented")
+}
+func (UnimplementedAccountServer) EnrollTOTP(context.Context, *EnrollTOTPRequest) (*EnrollTOTPReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTOTP not implemented")
+}
+func (UnimplementedAccountServer) ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*ConfirmTOTPReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTOTP not implemented")
+}
+func (UnimplementedAccountServer) DisableMFA(context.Context, *DisableMFARequest) (*DisableMFAReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableMFA not implemented")
+}
+func (UnimplementedAccountServer) ResetMFA(context.Context, *ResetMFARequest) (*ResetMFAReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetMFA not implemented")
+}
+func (UnimplementedAccountServer) BeginPasskeyRegistration(context.Context, *BeginPasskeyRegistrationRequest) (*BeginPasskeyRegistrationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginPasskeyRegistration not implemented")
+}
+func (UnimplementedAccountServer) FinishPasskeyRegistration(context.Context, *FinishPasskeyRegistrationRequest) (*FinishPasskeyRegistrationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishPasskeyRegistration not implemented")
+}
+func (UnimplementedAccountServer) ListPasskeys(context.Context, *ListPasskeysRequest) (*ListPasskeysReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPasskeys not implemented")
+}
+func (UnimplementedAccountServer) DeletePasskey(context.Context, *DeletePasskeyRequest) (*DeletePasskeyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePasskey not implemented")
+}
+func (UnimplementedAccountServer) BeginPasskeyLogin(context.Context, *BeginPasskeyLoginRequest) (*BeginPasskeyLoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginPasskeyLogin not implemented")
+}
+func (UnimplementedAccountServer) PasskeyLogin(context.Context, *PasskeyLoginRequest) (*PasskeyLoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PasskeyLogin not implemented")
+}
+func (UnimplementedAccountServer) RequestMagicLink(context.Context, *RequestMagicLinkRequest) (*RequestMagicLinkReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestMagicLink not implemented")
+}
+func (UnimplementedAccountServer) MagicLinkLogin(context.Context, *MagicLinkLoginRequest) (*LoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MagicLinkLogin not implemented")
+}
+func (UnimplementedAccountServer) RequestSMSCode(context.Context, *RequestSMSCodeRequest) (*RequestSMSCodeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestSMSCode not implemented")
+}
+func (UnimplementedAccountServer) SMSCodeLogin(context.Context, *SMSCodeLoginRequest) (*LoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SMSCodeLogin not implemented")
+}
+func (UnimplementedAccountServer) LoginWithoutOrg(context.Context, *LoginWithoutOrgRequest) (*LoginWithoutOrgReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginWithoutOrg not implemented")
+}
+func (UnimplementedAccountServer) ChooseLoginOrg(context.Context, *ChooseLoginOrgRequest) (*LoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChooseLoginOrg not implemented")
+}
+func (UnimplementedAccountServer) SwitchOrg(context.Context, *SwitchOrgRequest) (*LoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwitchOrg not implemented")
+}
+func (UnimplementedAccountServer) ListMyOrgs(context.Context, *ListMyOrgsRequest) (*ListMyOrgsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyOrgs not implemented")
+}
+func (UnimplementedAccountServer) CreateOrg(context.Context, *CreateOrgRequest) (*CreateOrgReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrg not implemented")
+}
+func (UnimplementedAccountServer) GetOrg(context.Context, *GetOrgRequest) (*GetOrgReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrg not implemented")
+}
+func (UnimplementedAccountServer) UpdateOrgAccount(context.Context, *UpdateOrgAccountRequest) (*UpdateOrgAccountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrgAccount not implemented")
+}
+func (UnimplementedAccountServer) UpdateOrgProfile(context.Context, *UpdateOrgProfileRequest) (*UpdateOrgProfileReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrgProfile not implemented")
+}
+func (UnimplementedAccountServer) DeleteOrg(context.Context, *DeleteOrgRequest) (*DeleteOrgReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOrg not implemented")
+}
+func (UnimplementedAccountServer) SuspendOrg(context.Context, *OrgStatusRequest) (*OrgStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendOrg not implemented")
+}
+func (UnimplementedAccountServer) DeactivateOrg(context.Context, *OrgStatusRequest) (*OrgStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivateOrg not implemented")
+}
+func (UnimplementedAccountServer) ReactivateOrg(context.Context, *OrgStatusRequest) (*OrgStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReactivateOrg not implemented")
+}
+func (UnimplementedAccountServer) RestoreOrg(context.Context, *OrgStatusRequest) (*OrgStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreOrg not implemented")
+}
+func (UnimplementedAccountServer) ListOrgUsers(context.Context, *ListOrgUsersRequest) (*ListOrgUsersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrgUsers not implemented")
+}
+func (UnimplementedAccountServer) ListChildOrgs(context.Context, *ListChildOrgsRequest) (*ListChildOrgsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChildOrgs not implemented")
+}
+func (UnimplementedAccountServer) GetOrgTree(context.Context, *GetOrgTreeRequest) (*GetOrgTreeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrgTree not implemented")
+}
+func (UnimplementedAccountServer) UpdatePayorDetails(context.Context, *UpdatePayorDetailsRequest) (*UpdatePayorDetailsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePayorDetails not implemented")
+}
+func (UnimplementedAccountServer) FindPayor(context.Context, *FindPayorRequest) (*FindPayorReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindPayor not implemented")
+}
+func (UnimplementedAccountServer) CreateInvite(context.Context, *CreateInviteRequest) (*CreateInviteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInvite not implemented")
+}
+func (UnimplementedAccountServer) ListInvites(context.Context, *ListInvitesRequest) (*ListInvitesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvites not implemented")
+}
+func (UnimplementedAccountServer) RevokeInvite(context.Context, *RevokeInviteRequest) (*RevokeInviteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeInvite not implemented")
+}
+func (UnimplementedAccountServer) ResendInvite(context.Context, *ResendInviteRequest) (*ResendInviteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendInvite not implemented")
+}
+func (UnimplementedAccountServer) AcceptInvite(context.Context, *AcceptInviteRequest) (*AcceptInviteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvite not implemented")
+}
+func (UnimplementedAccountServer) RequestNetworkRelationship(context.Context, *RequestNetworkRelationshipRequest) (*NetworkRelationshipReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestNetworkRelationship not implemented")
+}
+func (UnimplementedAccountServer) AcceptNetworkRelationship(context.Context, *NetworkRelationshipActionRequest) (*NetworkRelationshipReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptNetworkRelationship not implemented")
+}
+func (UnimplementedAccountServer) TerminateNetworkRelationship(context.Context, *NetworkRelationshipActionRequest) (*NetworkRelationshipReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TerminateNetworkRelationship not implemented")
+}
+func (UnimplementedAccountServer) ListPayors(context.Context, *ListNetworkRequest) (*ListNetworkReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPayors not implemented")
+}
+func (UnimplementedAccountServer) ListProviders(context.Context, *ListNetworkRequest) (*ListNetworkReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProviders not implemented")
+}
+func (UnimplementedAccountServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLog not implemented")
+}
+func (UnimplementedAccountServer) mustEmbedUnimplementedAccountServer() {}
+func (UnimplementedAccountServer) testEmbeddedByValue()                 {}
+
+// UnsafeAccountServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AccountServer will
+// result in compilation errors.
+type UnsafeAccountServer interface {
+	mustEmbedUnimplementedAccountServer()
+}
+
+func RegisterAccountServer(s grpc.ServiceRegistrar, srv AccountServer) {
+	// If the following call pancis, it indicates UnimplementedAccountServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Account_ServiceDesc, srv)
+}
+
+func _Account_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_UpdateUserAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).UpdateUserAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_UpdateUserAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).UpdateUserAccount(ctx, req.(*UpdateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_SuspendUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).SuspendUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_SuspendUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).SuspendUser(ctx, req.(*UserStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_DeactivateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).DeactivateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_DeactivateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).DeactivateUser(ctx, req.(*UserStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ReactivateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ReactivateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ReactivateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ReactivateUser(ctx, req.(*UserStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_RestoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).RestoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_RestoreUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).RestoreUser(ctx, req.(*UserStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_LoginUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).LoginUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_LoginUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).LoginUser(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_UpdateUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).UpdateUserProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_UpdateUserProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).UpdateUserProfile(ctx, req.(*UpdateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).GetSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_GetSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).GetSession(ctx, req.(*GetSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_SendEmailVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendEmailVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).SendEmailVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_SendEmailVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).SendEmailVerification(ctx, req.(*SendEmailVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_VerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).VerifyEmail(ctx, req.(*VerifyEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_SendPhoneVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendPhoneVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).SendPhoneVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_SendPhoneVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).SendPhoneVerification(ctx, req.(*SendPhoneVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_VerifyPhone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyPhoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).VerifyPhone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_VerifyPhone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).VerifyPhone(ctx, req.(*VerifyPhoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPasswordResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).RequestPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_RequestPasswordReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).RequestPasswordReset(ctx, req.(*RequestPasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_CompleteMFALogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteMFALoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).CompleteMFALogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_CompleteMFALogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).CompleteMFALogin(ctx, req.(*CompleteMFALoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_EnrollTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).EnrollTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_EnrollTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).EnrollTOTP(ctx, req.(*EnrollTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ConfirmTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ConfirmTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ConfirmTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ConfirmTOTP(ctx, req.(*ConfirmTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_DisableMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableMFARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).DisableMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_DisableMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).DisableMFA(ctx, req.(*DisableMFARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ResetMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetMFARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ResetMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ResetMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ResetMFA(ctx, req.(*ResetMFARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_BeginPasskeyRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginPasskeyRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).BeginPasskeyRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_BeginPasskeyRegistration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).BeginPasskeyRegistration(ctx, req.(*BeginPasskeyRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_FinishPasskeyRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishPasskeyRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).FinishPasskeyRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_FinishPasskeyRegistration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).FinishPasskeyRegistration(ctx, req.(*FinishPasskeyRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ListPasskeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPasskeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ListPasskeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ListPasskeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ListPasskeys(ctx, req.(*ListPasskeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_DeletePasskey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePasskeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).DeletePasskey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_DeletePasskey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).DeletePasskey(ctx, req.(*DeletePasskeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_BeginPasskeyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginPasskeyLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).BeginPasskeyLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_BeginPasskeyLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).BeginPasskeyLogin(ctx, req.(*BeginPasskeyLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_PasskeyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasskeyLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).PasskeyLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_PasskeyLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).PasskeyLogin(ctx, req.(*PasskeyLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_RequestMagicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestMagicLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).RequestMagicLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_RequestMagicLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).RequestMagicLink(ctx, req.(*RequestMagicLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_MagicLinkLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MagicLinkLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).MagicLinkLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_MagicLinkLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).MagicLinkLogin(ctx, req.(*MagicLinkLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_RequestSMSCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestSMSCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).RequestSMSCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_RequestSMSCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).RequestSMSCode(ctx, req.(*RequestSMSCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_SMSCodeLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SMSCodeLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).SMSCodeLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_SMSCodeLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).SMSCodeLogin(ctx, req.(*SMSCodeLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_LoginWithoutOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginWithoutOrgRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).LoginWithoutOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_LoginWithoutOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).LoginWithoutOrg(ctx, req.(*LoginWithoutOrgRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ChooseLoginOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChooseLoginOrgRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ChooseLoginOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ChooseLoginOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ChooseLoginOrg(ctx, req.(*ChooseLoginOrgRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_SwitchOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwitchOrgRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).SwitchOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_SwitchOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).SwitchOrg(ctx, req.(*SwitchOrgRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ListMyOrgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyOrgsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ListMyOrgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ListMyOrgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ListMyOrgs(ctx, req.(*ListMyOrgsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_CreateOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrgRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).CreateOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_CreateOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).CreateOrg(ctx, req.(*CreateOrgRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_GetOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrgRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).GetOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_GetOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).GetOrg(ctx, req.(*GetOrgRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_UpdateOrgAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrgAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).UpdateOrgAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_UpdateOrgAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).UpdateOrgAccount(ctx, req.(*UpdateOrgAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_UpdateOrgProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrgProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).UpdateOrgProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_UpdateOrgProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).UpdateOrgProfile(ctx, req.(*UpdateOrgProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_DeleteOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOrgRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).DeleteOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_DeleteOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).DeleteOrg(ctx, req.(*DeleteOrgRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_SuspendOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrgStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).SuspendOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_SuspendOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).SuspendOrg(ctx, req.(*OrgStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_DeactivateOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrgStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).DeactivateOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_DeactivateOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).DeactivateOrg(ctx, req.(*OrgStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ReactivateOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrgStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ReactivateOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ReactivateOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ReactivateOrg(ctx, req.(*OrgStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_RestoreOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrgStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).RestoreOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_RestoreOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).RestoreOrg(ctx, req.(*OrgStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ListOrgUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrgUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ListOrgUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ListOrgUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ListOrgUsers(ctx, req.(*ListOrgUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ListChildOrgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChildOrgsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ListChildOrgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ListChildOrgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ListChildOrgs(ctx, req.(*ListChildOrgsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_GetOrgTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrgTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).GetOrgTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_GetOrgTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).GetOrgTree(ctx, req.(*GetOrgTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_UpdatePayorDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePayorDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).UpdatePayorDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_UpdatePayorDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).UpdatePayorDetails(ctx, req.(*UpdatePayorDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_FindPayor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindPayorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).FindPayor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_FindPayor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).FindPayor(ctx, req.(*FindPayorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_CreateInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).CreateInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_CreateInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).CreateInvite(ctx, req.(*CreateInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ListInvites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ListInvites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ListInvites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ListInvites(ctx, req.(*ListInvitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_RevokeInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).RevokeInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_RevokeInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).RevokeInvite(ctx, req.(*RevokeInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ResendInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ResendInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ResendInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ResendInvite(ctx, req.(*ResendInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_AcceptInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).AcceptInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_AcceptInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).AcceptInvite(ctx, req.(*AcceptInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_RequestNetworkRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestNetworkRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).RequestNetworkRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_RequestNetworkRelationship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).RequestNetworkRelationship(ctx, req.(*RequestNetworkRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_AcceptNetworkRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkRelationshipActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).AcceptNetworkRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_AcceptNetworkRelationship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).AcceptNetworkRelationship(ctx, req.(*NetworkRelationshipActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_TerminateNetworkRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkRelationshipActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).TerminateNetworkRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_TerminateNetworkRelationship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).TerminateNetworkRelationship(ctx, req.(*NetworkRelationshipActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ListPayors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ListPayors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ListPayors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ListPayors(ctx, req.(*ListNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ListProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ListProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_ListProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ListProviders(ctx, req.(*ListNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).QueryAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_QueryAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Account_ServiceDesc is the grpc.ServiceDesc for Account service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Account_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "accountsrv.Account",
+	HandlerType: (*AccountServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUser",
+			Handler:    _Account_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _Account_GetUser_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _Account_DeleteUser_Handler,
+		},
+		{
+			MethodName: "UpdateUserAccount",
+			Handler:    _Account_UpdateUserAccount_Handler,
+		},
+		{
+			MethodName: "SuspendUser",
+			Handler:    _Account_SuspendUser_Handler,
+		},
+		{
+			MethodName: "DeactivateUser",
+			Handler:    _Account_DeactivateUser_Handler,
+		},
+		{
+			MethodName: "ReactivateUser",
+			Handler:    _Account_ReactivateUser_Handler,
+		},
+		{
+			MethodName: "RestoreUser",
+			Handler:    _Account_RestoreUser_Handler,
+		},
+		{
+			MethodName: "LoginUser",
+			Handler:    _Account_LoginUser_Handler,
+		},
+		{
+			MethodName: "UpdateUserProfile",
+			Handler:    _Account_UpdateUserProfile_Handler,
+		},
+		{
+			MethodName: "GetSession",
+			Handler:    _Account_GetSession_Handler,
+		},
+		{
+			MethodName: "SendEmailVerification",
+			Handler:    _Account_SendEmailVerification_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _Account_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "SendPhoneVerification",
+			Handler:    _Account_SendPhoneVerification_Handler,
+		},
+		{
+			MethodName: "VerifyPhone",
+			Handler:    _Account_VerifyPhone_Handler,
+		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _Account_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _Account_ResetPassword_Handler,
+		},
+		{
+			MethodName: "CompleteMFALogin",
+			Handler:    _Account_CompleteMFALogin_Handler,
+		},
+		{
+			MethodName: "EnrollTOTP",
+			Handler:    _Account_EnrollTOTP_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTP",
+			Handler:    _Account_ConfirmTOTP_Handler,
+		},
+		{
+			MethodName: "DisableMFA",
+			Handler:    _Account_DisableMFA_Handler,
+		},
+		{
+			MethodName: "ResetMFA",
+			Handler:    _Account_ResetMFA_Handler,
+		},
+		{
+			MethodName: "BeginPasskeyRegistration",
+			Handler:    _Account_BeginPasskeyRegistration_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyRegistration",
+			Handler:    _Account_FinishPasskeyRegistration_Handler,
+		},
+		{
+			MethodName: "ListPasskeys",
+			Handler:    _Account_ListPasskeys_Handler,
+		},
+		{
+			MethodName: "DeletePasskey",
+			Handler:    _Account_DeletePasskey_Handler,
+		},
+		{
+			MethodName: "BeginPasskeyLogin",
+			Handler:    _Account_BeginPasskeyLogin_Handler,
+		},
+		{
+			MethodName: "PasskeyLogin",
+			Handler:    _Account_PasskeyLogin_Handler,
+		},
+		{
+			MethodName: "RequestMagicLink",
+			Handler:    _Account_RequestMagicLink_Handler,
+		},
+		{
+			MethodName: "MagicLinkLogin",
+			Handler:    _Account_MagicLinkLogin_Handler,
+		},
+		{
+			MethodName: "RequestSMSCode",
+			Handler:    _Account_RequestSMSCode_Handler,
+		},
+		{
+			MethodName: "SMSCodeLogin",
+			Handler:    _Account_SMSCodeLogin_Handler,
+		},
+		{
+			MethodName: "LoginWithoutOrg",
+			Handler:    _Account_LoginWithoutOrg_Handler,
+		},
+		{
+			MethodName: "ChooseLoginOrg",
+			Handler:    _Account_ChooseLoginOrg_Handler,
+		},
+		{
+			MethodName: "SwitchOrg",
+			Handler:    _Account_SwitchOrg_Handler,
+		},
+		{
+			MethodName: "ListMyOrgs",
+			Handler:    _Account_ListMyOrgs_Handler,
+		},
+		{
+			MethodName: "CreateOrg",
+			Handler:    _Account_CreateOrg_Handler,
+		},
+		{
+			MethodName: "GetOrg",
+			Handler:    _Account_GetOrg_Handler,
+		},
+		{
+			MethodName: "UpdateOrgAccount",
+			Handler:    _Account_UpdateOrgAccount_Handler,
+		},
+		{
+			MethodName: "UpdateOrgProfile",
+			Handler:    _Account_UpdateOrgProfile_Handler,
+		},
+		{
+			MethodName: "DeleteOrg",
+			Handler:    _Account_DeleteOrg_Handler,
+		},
+		{
+			MethodName: "SuspendOrg",
+			Handler:    _Account_SuspendOrg_Handler,
+		},
+		{
+			MethodName: "DeactivateOrg",
+			Handler:    _Account_DeactivateOrg_Handler,
+		},
+		{
+			MethodName: "ReactivateOrg",
+			Handler:    _Account_ReactivateOrg_Handler,
+		},
+		{
+			MethodName: "RestoreOrg",
+			Handler:    _Account_RestoreOrg_Handler,
+		},
+		{
+			MethodName: "ListOrgUsers",
+			Handler:    _Account_ListOrgUsers_Handler,
+		},
+		{
+			MethodName: "ListChildOrgs",
+			Handler:    _Account_ListChildOrgs_Handler,
+		},
+		{
+			MethodName: "GetOrgTree",
+			Handler:    _Account_GetOrgTree_Handler,
+		},
+		{
+			MethodName: "UpdatePayorDetails",
+			Handler:    _Account_UpdatePayorDetails_Handler,
+		},
+		{
+			MethodName: "FindPayor",
+			Handler:    _Account_FindPayor_Handler,
+		},
+		{
+			MethodName: "CreateInvite",
+			Handler:    _Account_CreateInvite_Handler,
+		},
+		{
+			MethodName: "ListInvites",
+			Handler:    _Account_ListInvites_Handler,
+		},
+		{
+			MethodName: "RevokeInvite",
+			Handler:    _Account_RevokeInvite_Handler,
+		},
+		{
+			MethodName: "ResendInvite",
+			Handler:    _Account_ResendInvite_Handler,
+		},
+		{
+			MethodName: "AcceptInvite",
+			Handler:    _Account_AcceptInvite_Handler,
+		},
+		{
+			MethodName: "RequestNetworkRelationship",
+			Handler:    _Account_RequestNetworkRelationship_Handler,
+		},
+		{
+			MethodName: "AcceptNetworkRelationship",
+			Handler:    _Account_AcceptNetworkRelationship_Handler,
+		},
+		{
+			MethodName: "TerminateNetworkRelationship",
+			Handler:    _Account_TerminateNetworkRelationship_Handler,
+		},
+		{
+			MethodName: "ListPayors",
+			Handler:    _Account_ListPayors_Handler,
+		},
+		{
+			MethodName: "ListProviders",
+			Handler:    _Account_ListProviders_Handler,
+		},
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _Account_QueryAuditLog_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "accountsrv.proto",
+}
@@ -0,0 +1,5 @@
+// Package pb holds the protobuf messages and gRPC service definition for the
+// account service. Everything except this file is generated from accountsrv.proto.
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative accountsrv.proto
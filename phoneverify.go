@@ -0,0 +1,30 @@
+package accountsrv
+
+import (
+	"crypto/subtle"
+	"errors"
+	"time"
+)
+
+// How long the code in a phone verification text is good for
+const phoneVerificationTTL = 10 * time.Minute
+
+// The verification code a user was last texted. Like SMS login codes only the
+// hash of the code is kept, and the number it went to is kept along with it so
+// a code sent to a number the user has since changed away from can't verify the
+// new one.
+type PhoneVerification struct {
+	UserID    string    `db:"user_id" json:"user_id"`
+	Phone     string    `db:"phone" json:"phone"`
+	CodeHash  string    `db:"code_hash" json:"-"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+	Attempts  int       `db:"attempts" json:"-"`
+}
+
+var errInvalidPhoneCode = errors.New("verification code is invalid or has expired")
+
+// Whether the code matches what we keep for the verification, without giving
+// away how much of it did through timing
+func (v PhoneVerification) codeMatches(code string) bool {
+	return subtle.ConstantTimeCompare([]byte(v.CodeHash), []byte(hashToken(code))) == 1
+}
@@ -0,0 +1,77 @@
+package accountsrv
+
+import (
+	"errors"
+	"strings"
+)
+
+// Checks the provider details are well formed and puts them into the one format
+// we store them in: the NPI as its 10 digits and the tax ID as an EIN (NN-NNNNNNN).
+func normalizeProviderDetails(details ProviderDetails) (ProviderDetails, error) {
+	npi := strings.TrimSpace(details.NPI)
+	if !validNPI(npi) {
+		return details, errors.New("invalid NPI")
+	}
+
+	taxID, ok := normalizeEIN(details.TaxID)
+	if !ok {
+		return details, errors.New("invalid tax ID, expected an EIN (NN-NNNNNNN)")
+	}
+
+	details.NPI = npi
+	details.TaxID = taxID
+	return details, nil
+}
+
+// An NPI is 10 digits, the last being a Luhn check digit. The check digit is
+// computed as if the NPI were prefixed with 80840 (the health industry's card
+// issuer prefix), which is how CMS defines it.
+func validNPI(npi string) bool {
+	if len(npi) != 10 || !allDigits(npi) {
+		return false
+	}
+
+	// Luhn over "80840" + npi, working from the rightmost digit and doubling every
+	// second one.
+	digits := "80840" + npi
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// The two digit prefixes the IRS has never assigned EINs under
+var invalidEINPrefixes = map[string]bool{
+	"00": true, "07": true, "08": true, "09": true, "17": true, "18": true,
+	"19": true, "28": true, "29": true, "49": true, "69": true, "70": true,
+	"78": true, "79": true, "89": true, "96": true, "97": true,
+}
+
+// Accepts an EIN with or without its dash and hands it back as NN-NNNNNNN
+func normalizeEIN(ein string) (string, bool) {
+	ein = strings.TrimSpace(ein)
+	if len(ein) == 10 && ein[2] == '-' {
+		ein = ein[:2] + ein[3:]
+	}
+	if len(ein) != 9 || !allDigits(ein) || invalidEINPrefixes[ein[:2]] {
+		return "", false
+	}
+	return ein[:2] + "-" + ein[2:], true
+}
+
+func allDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
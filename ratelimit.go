@@ -0,0 +1,252 @@
+package accountsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// Returned (wrapped in a RateLimitError) when a caller has run out of tokens
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// Carries how long the caller should wait before trying again so the transport
+// can tell them (e.g. the Retry-After header on HTTP).
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string { return ErrRateLimited.Error() }
+
+// Lets errors.Is(err, ErrRateLimited) work on the wrapped error
+func (e *RateLimitError) Unwrap() error { return ErrRateLimited }
+
+// A token bucket: Limit tokens refilled evenly over Per, with a burst of Limit.
+// A zero Limit means no limit at all.
+type RateLimit struct {
+	Limit int
+	Per   time.Duration
+}
+
+// Parses a limit in the form "<count>/<duration>", e.g. "10/1m". "off" or an
+// empty string turns the limit off.
+func ParseRateLimit(spec string) (RateLimit, error) {
+	if spec == "" || spec == "off" {
+		return RateLimit{}, nil
+	}
+
+	parts := strings.SplitN(spec, "/", 2)
+	if len(parts) != 2 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q, expected <count>/<duration>", spec)
+	}
+
+	limit, err := strconv.Atoi(parts[0])
+	if err != nil || limit < 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit count %q", parts[0])
+	}
+
+	per, err := time.ParseDuration(parts[1])
+	if err != nil || per <= 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit duration %q", parts[1])
+	}
+
+	return RateLimit{Limit: limit, Per: per}, nil
+}
+
+func (l RateLimit) enabled() bool { return l.Limit > 0 && l.Per > 0 }
+
+// The limits applied to a rate limited route, one bucket per client IP, per
+// username and per org. Any of them can be left zero to skip that dimension.
+type RateLimitConfig struct {
+	PerIP       RateLimit
+	PerUsername RateLimit
+	PerOrg      RateLimit
+}
+
+// One of the buckets a call takes a token from
+type RateLimitBucket struct {
+	Key   string
+	Limit RateLimit
+}
+
+// Where the buckets live. The in-memory store is fine for a single instance, but
+// anything shared (e.g. Redis) just needs to implement this to be swapped in.
+type RateLimitStore interface {
+	// Takes a token from every one of the buckets, or from none of them if any is
+	// empty, reporting whether the call is allowed and, if not, how long until it
+	// would be. All or nothing, so a call turned down for its username doesn't
+	// still use up its IP's tokens.
+	Take(ctx context.Context, buckets []RateLimitBucket) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// Implemented by the requests that can be rate limited by something other than the
+// client's IP, so the middleware doesn't need to know about every request type.
+type rateLimitKeyer interface {
+	rateLimitKeys() (username string, orgID string)
+}
+
+// Endpoint middleware that rate limits calls by client IP, username and org. The
+// name namespaces the buckets so separate routes don't share them.
+func RateLimitMiddleware(name string, store RateLimitStore, config RateLimitConfig) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			var username, orgID string
+			if k, ok := request.(rateLimitKeyer); ok {
+				username, orgID = k.rateLimitKeys()
+			}
+
+			checks := []struct {
+				key   string
+				limit RateLimit
+			}{
+				{ClientIPFromContext(ctx), config.PerIP},
+				{strings.ToLower(username), config.PerUsername},
+				{orgID, config.PerOrg},
+			}
+			dimensions := []string{"ip", "user", "org"}
+
+			var buckets []RateLimitBucket
+			for i, check := range checks {
+				if check.key == "" || !check.limit.enabled() {
+					continue
+				}
+				buckets = append(buckets, RateLimitBucket{
+					Key:   name + ":" + dimensions[i] + ":" + check.key,
+					Limit: check.limit,
+				})
+			}
+
+			if len(buckets) > 0 {
+				allowed, retryAfter, err := store.Take(ctx, buckets)
+				if err != nil {
+					return nil, err
+				}
+				if !allowed {
+					return nil, &RateLimitError{RetryAfter: retryAfter}
+				}
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+type clientIPKey struct{}
+
+// Returns a copy of the context carrying the caller's IP address
+func ContextWithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// Pulls the caller's IP address out of the context, or an empty string if there is none
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// httptransport.ServerBefore func that records the remote address of the caller
+func clientIPToContext(ctx context.Context, req *http.Request) context.Context {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	return ContextWithClientIP(ctx, host)
+}
+
+// How many full buckets the in-memory store holds on to before sweeping them out
+const memoryRateLimitSweepSize = 10000
+
+type tokenBucket struct {
+	tokens   float64
+	last     time.Time
+	capacity float64
+	perToken time.Duration
+}
+
+// Tops the bucket up for however long it's been since we last looked at it
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens = math.Min(b.capacity, b.tokens+float64(now.Sub(b.last))/float64(b.perToken))
+	b.last = now
+}
+
+type memoryRateLimitStore struct {
+	mtx     sync.Mutex
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+// Factory func for a RateLimitStore keeping its buckets in process memory
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{
+		buckets: map[string]*tokenBucket{},
+		now:     time.Now,
+	}
+}
+
+func (s *memoryRateLimitStore) Take(ctx context.Context, buckets []RateLimitBucket) (bool, time.Duration, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.now()
+
+	if len(s.buckets) >= memoryRateLimitSweepSize {
+		s.sweep(now)
+	}
+
+	// Look at every bucket before touching any of them, the call has to wait
+	// for whichever is furthest from its next token
+	var (
+		taking     []*tokenBucket
+		allowed    = true
+		retryAfter time.Duration
+	)
+	for _, bucket := range buckets {
+		if !bucket.Limit.enabled() {
+			continue
+		}
+
+		b, ok := s.buckets[bucket.Key]
+		if !ok {
+			b = &tokenBucket{tokens: float64(bucket.Limit.Limit), last: now}
+			s.buckets[bucket.Key] = b
+		}
+		// Always go by the limit we were handed in case the config changed
+		b.capacity = float64(bucket.Limit.Limit)
+		b.perToken = bucket.Limit.Per / time.Duration(bucket.Limit.Limit)
+		b.refill(now)
+
+		if b.tokens < 1 {
+			allowed = false
+			if wait := time.Duration((1 - b.tokens) * float64(b.perToken)); wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+		taking = append(taking, b)
+	}
+	if !allowed {
+		return false, retryAfter, nil
+	}
+
+	for _, b := range taking {
+		b.tokens--
+	}
+	return true, 0, nil
+}
+
+// Drops buckets that would have refilled completely by now, since they behave
+// exactly the same as a brand new bucket.
+func (s *memoryRateLimitStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if b.refill(now); b.tokens >= b.capacity {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -5,8 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/lib/pq"
 )
 
 // A custom error we can pass back in place of the SQL error in the event
@@ -19,20 +24,113 @@ var RepoErr = errors.New("repository error")
 type Repository interface {
 	CreateUserAccount(ctx context.Context, account UserAccount) error
 	DeleteUserAccount(ctx context.Context, id string) error
+	PurgeUserAccount(ctx context.Context, id string) error
+	SetUserStatus(ctx context.Context, id string, change statusChange) error
 	CreateUserProfile(ctx context.Context, profile UserProfile) error
 	GetUserProfile(ctx context.Context, accountID string) (UserProfile, error)
 	UpdateUserProfile(ctx context.Context, accountID string, updates map[string]interface{}) error
 	GetUserAccount(ctx context.Context, id string) (UserAccount, error)
-	GetAccountByLoginCredentials(ctx context.Context, username string, password string) (UserAccount, error)
+	UpdateUserAccount(ctx context.Context, id string, updates map[string]interface{}) error
+	GetAccountByUsername(ctx context.Context, username string) (UserAccount, error)
+	GetUserAccountAnyStatus(ctx context.Context, id string) (UserAccount, error)
+	GetAccountByUsernameAnyStatus(ctx context.Context, username string) (UserAccount, error)
+
+	CreateCredential(ctx context.Context, credential Credential) error
+	GetCredential(ctx context.Context, userID string) (Credential, error)
+	UpdateCredential(ctx context.Context, credential Credential) error
+	RecordFailedLogin(ctx context.Context, userID string) error
+	ResetFailedLogins(ctx context.Context, userID string) error
+	ChangePassword(ctx context.Context, credential Credential) error
+	GetPasswordHistory(ctx context.Context, userID string) ([]Credential, error)
+	FindAccountIDsByEmail(ctx context.Context, email string) ([]string, error)
+	CreatePasswordReset(ctx context.Context, reset PasswordReset) error
+	GetPasswordReset(ctx context.Context, tokenHash string) (PasswordReset, error)
+	UsePasswordReset(ctx context.Context, tokenHash string) error
+
+	CreateEmailVerification(ctx context.Context, verification EmailVerification) error
+	UseEmailVerification(ctx context.Context, userID string, tokenHash string) (EmailVerification, error)
+	MarkEmailVerified(ctx context.Context, userID string, email string) error
+
+	CreatePhoneVerification(ctx context.Context, verification PhoneVerification) error
+	GetPhoneVerification(ctx context.Context, userID string) (PhoneVerification, error)
+	RecordFailedPhoneVerification(ctx context.Context, userID string) error
+	UsePhoneVerification(ctx context.Context, userID string) error
+	MarkPhoneVerified(ctx context.Context, userID string, phone string) error
+
+	GetTOTPFactor(ctx context.Context, userID string) (TOTPFactor, error)
+	SetTOTPSecret(ctx context.Context, userID string, secret string) error
+	ConfirmTOTP(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error
+	UseTOTPStep(ctx context.Context, userID string, step int64) error
+	UseRecoveryCode(ctx context.Context, userID string, codeHash string) error
+	DeleteMFA(ctx context.Context, userID string) error
+	CreateLoginChallenge(ctx context.Context, challenge LoginChallenge) error
+	GetLoginChallenge(ctx context.Context, tokenHash string) (LoginChallenge, error)
+	RecordFailedChallenge(ctx context.Context, tokenHash string) error
+	UseLoginChallenge(ctx context.Context, tokenHash string) error
+
+	CreateOrgSelection(ctx context.Context, selection PendingOrgSelection) error
+	UseOrgSelection(ctx context.Context, tokenHash string) (PendingOrgSelection, error)
+	CreatePasswordlessLogin(ctx context.Context, login PasswordlessLogin) error
+	GetPasswordlessLogin(ctx context.Context, tokenHash string, method string) (PasswordlessLogin, error)
+	RecordFailedPasswordlessLogin(ctx context.Context, tokenHash string) error
+	UsePasswordlessLogin(ctx context.Context, tokenHash string, method string) error
+
+	CreatePasskey(ctx context.Context, passkey PasskeyCredential) error
+	ListPasskeys(ctx context.Context, userID string) ([]PasskeyCredential, error)
+	RecordPasskeyUse(ctx context.Context, id string, signCount uint32, backupState bool) error
+	DeletePasskey(ctx context.Context, userID string, id string) error
+	CreatePasskeyCeremony(ctx context.Context, ceremony PasskeyCeremonyState) error
+	UsePasskeyCeremony(ctx context.Context, tokenHash string, kind string) (PasskeyCeremonyState, error)
 
 	CreateOrgAccount(ctx context.Context, orgAccount OrgAccount) error
 	CreateOrgProfile(ctx context.Context, orgProfile OrgProfile) error
 	GetOrgAccount(ctx context.Context, id string) (OrgAccount, error)
+	GetOrgAccountAnyStatus(ctx context.Context, id string) (OrgAccount, error)
 	GetOrgProfile(ctx context.Context, accountID string) (OrgProfile, error)
+	UpdateOrgAccount(ctx context.Context, id string, updates map[string]interface{}) error
+	UpdateOrgProfile(ctx context.Context, accountID string, updates map[string]interface{}) error
 	DeleteOrgAccount(ctx context.Context, id string) error
-
-	AssociateUserToOrg(ctx context.Context, userID string, orgID string) error
+	PurgeOrgAccount(ctx context.Context, id string) error
+	SetOrgStatus(ctx context.Context, id string, change statusChange) error
+	CreateProviderDetails(ctx context.Context, details ProviderDetails) error
+	GetProviderDetails(ctx context.Context, accountID string) (*ProviderDetails, error)
+	SetPayerIDs(ctx context.Context, accountID string, payerIDs []PayerID) error
+	GetPayorDetails(ctx context.Context, accountID string) (*PayorDetails, error)
+	GetOrgIDByPayerID(ctx context.Context, payerID string) (string, error)
+	GetOrgChildren(ctx context.Context, id string) ([]OrgAccount, error)
+	GetOrgAncestors(ctx context.Context, id string) ([]OrgAccount, error)
+	GetOrgDescendants(ctx context.Context, id string) ([]OrgAccount, error)
+
+	AssociateUserToOrg(ctx context.Context, userID string, orgID string, role string) error
+	AssociateFirstUserToOrg(ctx context.Context, userID string, orgID string) error
 	ConfirmUserToOrgAssociation(ctx context.Context, userID string, orgID string) error
+	GetUserOrgs(ctx context.Context, userID string) ([]OrgMembership, error)
+	GetOrgMemberRole(ctx context.Context, userID string, orgID string) (string, error)
+	IsOrgAdmin(ctx context.Context, userID string, orgID string) (bool, error)
+	CountOrgMembers(ctx context.Context, orgID string) (int, error)
+	ListOrgUsers(ctx context.Context, orgID string, query OrgUserQuery) (OrgUserPage, error)
+	RemoveUserFromOrg(ctx context.Context, userID string, orgID string) error
+
+	CreateInvite(ctx context.Context, invite Invite) error
+	GetInvite(ctx context.Context, id string) (Invite, error)
+	ListInvites(ctx context.Context, orgID string) ([]Invite, error)
+	RevokeInvite(ctx context.Context, id string) error
+	RenewInvite(ctx context.Context, id string, expiresAt time.Time) error
+	AcceptInvite(ctx context.Context, id string, expiresAt time.Time, userID string) error
+
+	CreateNetworkRelationship(ctx context.Context, relationship NetworkRelationship) error
+	GetNetworkRelationship(ctx context.Context, id string) (NetworkRelationship, error)
+	AcceptNetworkRelationship(ctx context.Context, id string) error
+	TerminateNetworkRelationship(ctx context.Context, id string) error
+	ListNetworkPayors(ctx context.Context, providerID string, query NetworkQuery) ([]NetworkRelationship, error)
+	ListNetworkProviders(ctx context.Context, payorID string, query NetworkQuery) ([]NetworkRelationship, error)
+
+	CreateSession(ctx context.Context, session Session) error
+	GetSessionByTokenHash(ctx context.Context, tokenHash string) (Session, error)
+	GetSession(ctx context.Context, id string) (Session, error)
+	RevokeSession(ctx context.Context, id string) error
+	RevokeUserSessions(ctx context.Context, userID string) error
+	RevokeOrgSessions(ctx context.Context, orgID string) error
 }
 
 // Defining a struct we will create methods for to implement the Repository interface
@@ -61,30 +159,114 @@ func NewRepo(db *sql.DB, logger log.Logger) Repository {
 // repo struct directly if it wanted to...
 func (repo *repo) CreateUserAccount(ctx context.Context, account UserAccount) error {
 	sqlCmd := `
-		INSERT INTO user_accounts (id, username, password, org_type)
-		VALUES ($1, $2, $3, $4)`
-
-	// TODO: Add new tables for things like user details for the user's name, etc
+		INSERT INTO user_accounts (id, username, org_type)
+		VALUES ($1, $2, $3)`
 
 	// Validation framework?
-	if account.Username == "" || account.Password == "" {
-		return errors.New("username and password are required")
+	if account.Username == "" {
+		return errors.New("username is required")
 	}
 
-	_, err := repo.db.ExecContext(ctx, sqlCmd, account.ID, account.Username, account.Password, account.OrgType)
+	_, err := repo.db.ExecContext(ctx, sqlCmd, account.ID, account.Username, account.OrgType)
 	if err != nil {
 		return errors.New("error saving user account")
 	}
 	return nil
 }
 
+// Marks the account deleted, keeping it and everything hanging off of it (profile,
+// credentials and org memberships) so it can be restored. Their sessions are
+// revoked and whatever they were partway through logging in or resetting is
+// thrown away, all in one transaction.
 func (repo *repo) DeleteUserAccount(ctx context.Context, id string) error {
-	sqlCmd := `DELETE FROM user_accounts WHERE id=$1`
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.New("error deleting user account")
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE user_accounts SET status = 'deleted', deleted_at = now()
+		WHERE id = $1 AND status <> 'deleted'`, id)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "DeleteUserAccount", "err", err)
+		return errors.New("error deleting user account")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return fmt.Errorf("%w: no user account %s", ErrNotFound, id)
+	}
+
+	for _, sqlCmd := range []string{
+		`UPDATE sessions SET revoked_at = now() WHERE user_id=$1 AND revoked_at IS NULL`,
+		`DELETE FROM email_verifications WHERE user_id=$1`,
+		`DELETE FROM password_resets WHERE user_id=$1`,
+		`DELETE FROM mfa_challenges WHERE user_id=$1`,
+		`DELETE FROM passwordless_logins WHERE user_id=$1`,
+		`DELETE FROM passkey_ceremonies WHERE user_id=$1`,
+	} {
+		if _, err := tx.ExecContext(ctx, sqlCmd, id); err != nil {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "DeleteUserAccount", "err", err)
+			return errors.New("error deleting user account")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.New("error deleting user account")
+	}
+	return nil
+}
 
-	_, err := repo.db.ExecContext(ctx, sqlCmd, id)
+// Gets rid of the account for good, along with everything hanging off of it
+// (profile and org memberships) in one transaction, so we never end up with half
+// a user. Only for undoing a user we didn't finish creating, deleting a user
+// everyone's seen goes through DeleteUserAccount.
+func (repo *repo) PurgeUserAccount(ctx context.Context, id string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
 		return errors.New("error deleting user account")
 	}
+	defer tx.Rollback()
+
+	for _, sqlCmd := range []string{
+		`DELETE FROM org_users WHERE user_id=$1`,
+		`DELETE FROM credentials WHERE user_id=$1`,
+		`DELETE FROM email_verifications WHERE user_id=$1`,
+		`DELETE FROM password_resets WHERE user_id=$1`,
+		`DELETE FROM password_history WHERE user_id=$1`,
+		`DELETE FROM mfa_challenges WHERE user_id=$1`,
+		`DELETE FROM passwordless_logins WHERE user_id=$1`,
+		`DELETE FROM passkey_ceremonies WHERE user_id=$1`,
+		`DELETE FROM passkeys WHERE user_id=$1`,
+		`DELETE FROM mfa_recovery_codes WHERE user_id=$1`,
+		`DELETE FROM user_totp WHERE user_id=$1`,
+		`DELETE FROM user_profiles WHERE account_id=$1`,
+		`DELETE FROM user_accounts WHERE id=$1`,
+	} {
+		if _, err := tx.ExecContext(ctx, sqlCmd, id); err != nil {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "PurgeUserAccount", "err", err)
+			return errors.New("error deleting user account")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.New("error deleting user account")
+	}
+	return nil
+}
+
+// Moves the account to the change's status, as long as it's in one of the
+// statuses the change can be made from. Leaving "deleted" clears deleted_at.
+func (repo *repo) SetUserStatus(ctx context.Context, id string, change statusChange) error {
+	result, err := repo.db.ExecContext(ctx, `
+		UPDATE user_accounts SET status = $2, deleted_at = NULL
+		WHERE id = $1 AND status = ANY($3)`, id, change.to, pq.Array(change.from))
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "SetUserStatus", "err", err)
+		return errors.New("error updating user account status")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return fmt.Errorf("user account is not %s", strings.Join(change.from, " or "))
+	}
 	return nil
 }
 
@@ -109,12 +291,13 @@ func (repo *repo) GetUserProfile(ctx context.Context, accountID string) (UserPro
 	var profile UserProfile
 
 	err := repo.db.QueryRowContext(ctx,
-		`SELECT first_name, last_name, email, phone, last_login
+		`SELECT first_name, last_name, email, phone, last_login, email_verified_at, phone_verified_at
 	FROM user_profiles
 	WHERE account_id=$1`,
-		accountID).Scan(&profile.FirstName, &profile.LastName, &profile.Email, &profile.Phone, &profile.LastLogin)
+		accountID).Scan(&profile.FirstName, &profile.LastName, nullableString(&profile.Email), nullableString(&profile.Phone), nullableTime(&profile.LastLogin), nullableTime(&profile.EmailVerifiedAt), nullableTime(&profile.PhoneVerifiedAt))
 
 	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetUserProfile", "err", err)
 		return profile, errors.New("error getting user profile")
 	}
 
@@ -123,193 +306,2057 @@ func (repo *repo) GetUserProfile(ctx context.Context, accountID string) (UserPro
 
 func (repo *repo) UpdateUserProfile(ctx context.Context, accountID string, updates map[string]interface{}) error {
 
-	var updateStr string = createUpdateStringFromMap(updates)
-
-	sqlCmd := `UPDATE user_profiles SET ` + updateStr + `WHERE account_id = $1`
+	sqlCmd, args, err := buildUpdate("user_profiles", "account_id", userProfileColumns, updates)
+	if err != nil {
+		return err
+	}
 
-	_, err := repo.db.ExecContext(ctx,
+	_, err = repo.db.ExecContext(ctx,
 		sqlCmd,
-		accountID)
+		append([]interface{}{accountID}, args...)...)
 
 	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UpdateUserProfile", "err", err)
 		return errors.New("unable to update user profile")
 	}
 	return nil
 }
 
-// Defining the method that will handle finding a user in the DB for the
-// Repository interface to use
-func (repo *repo) GetUserAccount(ctx context.Context, id string) (UserAccount, error) {
+func (repo *repo) UpdateUserAccount(ctx context.Context, id string, updates map[string]interface{}) error {
+
+	sqlCmd, args, err := buildUpdate("user_accounts", "id", userAccountColumns, updates)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.db.ExecContext(ctx,
+		sqlCmd,
+		append([]interface{}{id}, args...)...)
+
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UpdateUserAccount", "err", err)
+		return errors.New("unable to update user account")
+	}
+	return nil
+}
+
+const userAccountSelect = `a.id, a.username, a.org_type, a.joined_on, a.status, a.deleted_at`
+
+func scanUserAccount(row interface{ Scan(...interface{}) error }) (UserAccount, error) {
 	var account UserAccount
+	err := row.Scan(&account.ID, &account.Username, &account.OrgType, &account.JoinedOn, &account.Status, nullableTime(&account.DeletedAt))
+	return account, err
+}
 
-	sqlCmd := `SELECT id, username, org_type ,joined_on FROM user_accounts WHERE id=$1`
+// Defining the method that will handle finding a user in the DB for the
+// Repository interface to use. Only active users are found, see
+// GetUserAccountAnyStatus for the rest.
+func (repo *repo) GetUserAccount(ctx context.Context, id string) (UserAccount, error) {
+	sqlCmd := `SELECT ` + userAccountSelect + ` FROM user_accounts a WHERE a.id=$1 AND a.status='active'`
 
-	err := repo.db.QueryRow(sqlCmd, id).Scan(&account.ID, &account.Username, &account.OrgType, &account.JoinedOn)
+	account, err := scanUserAccount(repo.db.QueryRowContext(ctx, sqlCmd, id))
 	if err != nil {
-		fmt.Println(err)
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetUserAccount", "err", err)
 		return account, errors.New("no user found")
 	}
 	return account, nil
 }
 
-func (repo *repo) GetAccountByLoginCredentials(ctx context.Context, username string, password string) (UserAccount, error) {
-	var account UserAccount
+// Only active users are found, same as GetUserAccount
+func (repo *repo) GetAccountByUsername(ctx context.Context, username string) (UserAccount, error) {
+	sqlCmd := `SELECT ` + userAccountSelect + ` FROM user_accounts a WHERE a.username=$1 AND a.status='active'`
 
-	// First find user account by username
-	err := repo.db.QueryRowContext(ctx,
-		`SELECT id, username, password, org_type, joined_on
-	FROM user_accounts
-	WHERE username=$1`,
-		username).Scan(&account.ID, &account.Username, &account.Password, &account.OrgType, &account.JoinedOn)
+	return repo.getAccountByUsername(ctx, "GetAccountByUsername", sqlCmd, username)
+}
 
-	// Check to see if the user account's password matches input password
+// The user whatever their status, for when that's what's being looked at (e.g.
+// restoring them)
+func (repo *repo) GetUserAccountAnyStatus(ctx context.Context, id string) (UserAccount, error) {
+	sqlCmd := `SELECT ` + userAccountSelect + ` FROM user_accounts a WHERE a.id=$1`
+
+	account, err := scanUserAccount(repo.db.QueryRowContext(ctx, sqlCmd, id))
+	if err == sql.ErrNoRows {
+		return account, fmt.Errorf("%w: no user account %s", ErrNotFound, id)
+	}
 	if err != nil {
-		fmt.Println(err)
-		return UserAccount{}, errors.New("invalid credentials")
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetUserAccountAnyStatus", "err", err)
+		return account, errors.New("error getting user account")
 	}
+	return account, nil
+}
+
+// For logging in, which has to know a suspended user when it sees one
+func (repo *repo) GetAccountByUsernameAnyStatus(ctx context.Context, username string) (UserAccount, error) {
+	sqlCmd := `SELECT ` + userAccountSelect + ` FROM user_accounts a WHERE a.username=$1`
 
-	if password != account.Password {
-		return UserAccount{}, errors.New("incorrect password")
+	return repo.getAccountByUsername(ctx, "GetAccountByUsernameAnyStatus", sqlCmd, username)
+}
+
+func (repo *repo) getAccountByUsername(ctx context.Context, method string, sqlCmd string, username string) (UserAccount, error) {
+	account, err := scanUserAccount(repo.db.QueryRowContext(ctx, sqlCmd, username))
+
+	if err != nil {
+		if err != sql.ErrNoRows {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", method, "err", err)
+		}
+		return UserAccount{}, errors.New("invalid credentials")
 	}
 
 	return account, nil
 }
 
-func (repo *repo) CreateOrgAccount(ctx context.Context, orgAccount OrgAccount) error {
+func (repo *repo) CreateCredential(ctx context.Context, credential Credential) error {
 	sqlCmd := `
-		INSERT INTO org_accounts (id, name, type)
+		INSERT INTO credentials (user_id, hash, algorithm)
 		VALUES ($1, $2, $3)`
 
-	_, err := repo.db.ExecContext(ctx, sqlCmd, orgAccount.ID, orgAccount.Name, orgAccount.Type)
+	_, err := repo.db.ExecContext(ctx, sqlCmd, credential.UserID, credential.Hash, credential.Algorithm)
 	if err != nil {
-		fmt.Println(err)
-		return errors.New("error saving organization account")
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreateCredential", "err", err)
+		return errors.New("error saving credentials")
 	}
 	return nil
 }
 
-func (repo *repo) CreateOrgProfile(ctx context.Context, orgProfile OrgProfile) error {
+func (repo *repo) GetCredential(ctx context.Context, userID string) (Credential, error) {
+	var credential Credential
+
 	sqlCmd := `
-	INSERT INTO org_profiles (account_id, address, phone, timezone, website)
-	VALUES ($1, $2, $3, $4, $5)`
+		SELECT user_id, hash, algorithm, changed_at, failed_attempts
+		FROM credentials
+		WHERE user_id = $1`
 
-	_, err := repo.db.ExecContext(ctx, sqlCmd, orgProfile.AccountID, orgProfile.Address, orgProfile.Phone, orgProfile.Timezone, orgProfile.Website)
+	err := repo.db.QueryRowContext(ctx, sqlCmd, userID).Scan(&credential.UserID, &credential.Hash, &credential.Algorithm, &credential.ChangedAt, &credential.FailedAttempts)
 
 	if err != nil {
-		fmt.Println(err)
-		return errors.New("error saving organization profile")
+		if err != sql.ErrNoRows {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetCredential", "err", err)
+		}
+		return Credential{}, errors.New("invalid credentials")
 	}
-	return nil
+
+	return credential, nil
 }
 
-func (repo *repo) GetOrgAccount(ctx context.Context, id string) (OrgAccount, error) {
+// Swaps in a new hash (e.g. a changed password or a rehash), which also clears
+// the failed attempts.
+func (repo *repo) UpdateCredential(ctx context.Context, credential Credential) error {
+	sqlCmd := `
+		UPDATE credentials
+		SET hash = $2, algorithm = $3, changed_at = now(), failed_attempts = 0
+		WHERE user_id = $1`
 
-	var account OrgAccount
+	_, err := repo.db.ExecContext(ctx, sqlCmd, credential.UserID, credential.Hash, credential.Algorithm)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UpdateCredential", "err", err)
+		return errors.New("error saving credentials")
+	}
+	return nil
+}
 
-	sqlCmd := `SELECT id, name, type, joined_on FROM org_accounts WHERE id = $1`
+func (repo *repo) RecordFailedLogin(ctx context.Context, userID string) error {
+	_, err := repo.db.ExecContext(ctx, `UPDATE credentials SET failed_attempts = failed_attempts + 1 WHERE user_id = $1`, userID)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "RecordFailedLogin", "err", err)
+		return errors.New("error saving credentials")
+	}
+	return nil
+}
 
-	err := repo.db.QueryRowContext(ctx, sqlCmd,
-		id).Scan(&account.ID, &account.Name, &account.Type, &account.JoinedOn)
+func (repo *repo) ResetFailedLogins(ctx context.Context, userID string) error {
+	_, err := repo.db.ExecContext(ctx, `UPDATE credentials SET failed_attempts = 0 WHERE user_id = $1`, userID)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "ResetFailedLogins", "err", err)
+		return errors.New("error saving credentials")
+	}
+	return nil
+}
 
+// Sets a new password, the one it replaces goes into the password history (which
+// only keeps the last passwordHistoryDepth of them). All in one transaction.
+func (repo *repo) ChangePassword(ctx context.Context, credential Credential) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
-		return account, errors.New("could not find organization account")
+		return errors.New("error saving credentials")
+	}
+	defer tx.Rollback()
+
+	for _, step := range []struct {
+		sqlCmd string
+		args   []interface{}
+	}{
+		{`INSERT INTO password_history (user_id, hash, algorithm, created_at)
+			SELECT user_id, hash, algorithm, changed_at FROM credentials WHERE user_id = $1`,
+			[]interface{}{credential.UserID}},
+		{`UPDATE credentials
+			SET hash = $2, algorithm = $3, changed_at = now(), failed_attempts = 0
+			WHERE user_id = $1`,
+			[]interface{}{credential.UserID, credential.Hash, credential.Algorithm}},
+		{`DELETE FROM password_history WHERE user_id = $1 AND ctid NOT IN (
+			SELECT ctid FROM password_history WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2)`,
+			[]interface{}{credential.UserID, passwordHistoryDepth}},
+		// An org selection stands for the old password having checked out
+		{`UPDATE org_selections SET used_at = now() WHERE user_id = $1 AND used_at IS NULL`,
+			[]interface{}{credential.UserID}},
+	} {
+		if _, err := tx.ExecContext(ctx, step.sqlCmd, step.args...); err != nil {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "ChangePassword", "err", err)
+			return errors.New("error saving credentials")
+		}
 	}
 
-	return account, nil
+	if err := tx.Commit(); err != nil {
+		return errors.New("error saving credentials")
+	}
+	return nil
 }
 
-func (repo *repo) GetOrgProfile(ctx context.Context, accountID string) (OrgProfile, error) {
+// The user's previous passwords, newest first
+func (repo *repo) GetPasswordHistory(ctx context.Context, userID string) ([]Credential, error) {
+	sqlCmd := `
+		SELECT user_id, hash, algorithm, created_at
+		FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
 
-	var profile OrgProfile
+	rows, err := repo.db.QueryContext(ctx, sqlCmd, userID, passwordHistoryDepth)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetPasswordHistory", "err", err)
+		return nil, errors.New("error getting password history")
+	}
+	defer rows.Close()
 
-	sqlCmd := `SELECT account_id, address, phone, timezone, website FROM org_profiles WHERE account_id = $1`
+	history := []Credential{}
+	for rows.Next() {
+		var credential Credential
+		if err := rows.Scan(&credential.UserID, &credential.Hash, &credential.Algorithm, &credential.ChangedAt); err != nil {
+			return nil, errors.New("error getting password history")
+		}
+		history = append(history, credential)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("error getting password history")
+	}
+
+	return history, nil
+}
 
-	err := repo.db.QueryRowContext(ctx, sqlCmd, accountID).Scan(&profile.AccountID, &profile.Address, &profile.Phone, &profile.Timezone, &profile.Website)
+// The active users with the email address on their profile. Nothing stops two
+// users from having the same one, so there can be more than one.
+func (repo *repo) FindAccountIDsByEmail(ctx context.Context, email string) ([]string, error) {
+	sqlCmd := `
+		SELECT p.account_id FROM user_profiles p
+		JOIN user_accounts a ON a.id = p.account_id
+		WHERE lower(p.email) = lower($1) AND a.status = 'active'`
 
+	rows, err := repo.db.QueryContext(ctx, sqlCmd, email)
 	if err != nil {
-		return profile, errors.New("could not find organization profile")
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "FindAccountIDsByEmail", "err", err)
+		return nil, errors.New("error looking up email")
 	}
+	defer rows.Close()
 
-	return profile, nil
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.New("error looking up email")
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("error looking up email")
+	}
+
+	return ids, nil
 }
 
-func (repo *repo) DeleteOrgAccount(ctx context.Context, id string) error {
-	sqlCmd := `DELETE FROM org_accounts WHERE id=$1`
+func (repo *repo) CreatePasswordReset(ctx context.Context, reset PasswordReset) error {
+	sqlCmd := `
+		INSERT INTO password_resets (token_hash, user_id, expires_at)
+		VALUES ($1, $2, $3)`
 
-	_, err := repo.db.ExecContext(ctx, sqlCmd, id)
+	_, err := repo.db.ExecContext(ctx, sqlCmd, reset.TokenHash, reset.UserID, reset.ExpiresAt)
 	if err != nil {
-		return errors.New("error deleting organization account")
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreatePasswordReset", "err", err)
+		return errors.New("error saving password reset")
 	}
 	return nil
 }
 
-func (repo *repo) AssociateUserToOrg(ctx context.Context, userID string, orgID string) error {
-	sqlCmd := `INSERT INTO org_users (user_id, org_id) VALUES ($1, $2)`
+// Only finds resets that can still be used, i.e. not expired and not used
+func (repo *repo) GetPasswordReset(ctx context.Context, tokenHash string) (PasswordReset, error) {
+	var reset PasswordReset
 
-	_, err := repo.db.ExecContext(ctx, sqlCmd, userID, orgID)
+	sqlCmd := `
+		SELECT token_hash, user_id, expires_at
+		FROM password_resets
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now()`
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, tokenHash).Scan(&reset.TokenHash, &reset.UserID, &reset.ExpiresAt)
 
+	if err == sql.ErrNoRows {
+		return PasswordReset{}, errInvalidPasswordReset
+	}
 	if err != nil {
-		return errors.New("error associating user to organization")
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetPasswordReset", "err", err)
+		return PasswordReset{}, errors.New("error getting password reset")
+	}
+
+	return reset, nil
+}
+
+// Uses up the reset, along with every other reset its user has outstanding, as
+// long as it can still be used. Two requests racing with the same token can't
+// both get through.
+func (repo *repo) UsePasswordReset(ctx context.Context, tokenHash string) error {
+	sqlCmd := `
+		UPDATE password_resets SET used_at = now()
+		WHERE used_at IS NULL AND user_id = (
+			SELECT user_id FROM password_resets
+			WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now())`
+
+	result, err := repo.db.ExecContext(ctx, sqlCmd, tokenHash)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UsePasswordReset", "err", err)
+		return errors.New("error using password reset")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return errInvalidPasswordReset
 	}
 	return nil
 }
 
-func (repo *repo) ConfirmUserToOrgAssociation(ctx context.Context, userID string, orgID string) error {
-	sqlCmd := `SELECT COUNT(id) FROM org_users WHERE user_id = $1 AND org_id = $2`
+func (repo *repo) GetTOTPFactor(ctx context.Context, userID string) (TOTPFactor, error) {
+	var factor TOTPFactor
 
-	var count int
+	sqlCmd := `
+		SELECT user_id, secret, created_at, confirmed_at, last_used_step
+		FROM user_totp
+		WHERE user_id = $1`
 
-	err := repo.db.QueryRowContext(ctx, sqlCmd, userID, orgID).Scan(&count)
+	err := repo.db.QueryRowContext(ctx, sqlCmd, userID).Scan(&factor.UserID, &factor.Secret, &factor.CreatedAt,
+		nullableTime(&factor.ConfirmedAt), &factor.LastUsedStep)
 
+	if err == sql.ErrNoRows {
+		return TOTPFactor{}, fmt.Errorf("%w: no TOTP set up", ErrNotFound)
+	}
 	if err != nil {
-		return errors.New("error confirming user association to organization")
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetTOTPFactor", "err", err)
+		return TOTPFactor{}, errors.New("error getting TOTP")
 	}
 
-	if count == 0 {
-		return errors.New("user not associated to organization")
-	}
+	return factor, nil
+}
+
+// Sets (or replaces) the user's unconfirmed TOTP secret. Once it's confirmed it
+// stays put until MFA is deleted.
+func (repo *repo) SetTOTPSecret(ctx context.Context, userID string, secret string) error {
+	sqlCmd := `
+		INSERT INTO user_totp (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+			SET secret = EXCLUDED.secret, created_at = now(), last_used_step = 0
+			WHERE user_totp.confirmed_at IS NULL`
 
+	result, err := repo.db.ExecContext(ctx, sqlCmd, userID, secret)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "SetTOTPSecret", "err", err)
+		return errors.New("error saving TOTP")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return errMFAEnabled
+	}
 	return nil
 }
 
-func createUpdateStringFromMap(updates map[string]interface{}) string {
-	var updateStr string = ``
+// Confirms the user's TOTP, turning MFA on, and gives them a new set of recovery
+// codes in place of any they had. step is the step of the code they confirmed
+// with, so it can't be used again.
+func (repo *repo) ConfirmTOTP(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.New("error confirming TOTP")
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE user_totp SET confirmed_at = now(), last_used_step = $2
+		WHERE user_id = $1 AND confirmed_at IS NULL`, userID, step)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "ConfirmTOTP", "err", err)
+		return errors.New("error confirming TOTP")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return errMFAEnabled
+	}
 
-	keysLeft := len(updates)
-	for k, v := range updates {
-		updateStr += genUpdateLine(k, v)
-		if keysLeft -= 1; keysLeft > 0 {
-			updateStr += ","
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "ConfirmTOTP", "err", err)
+		return errors.New("error confirming TOTP")
+	}
+	for _, hash := range recoveryCodeHashes {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, hash); err != nil {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "ConfirmTOTP", "err", err)
+			return errors.New("error confirming TOTP")
 		}
 	}
 
-	return updateStr
+	if err := tx.Commit(); err != nil {
+		return errors.New("error confirming TOTP")
+	}
+	return nil
+}
+
+// Records that a code for the step was used. A step at or before the last one
+// used is refused, so a code can't be replayed (even one from a step earlier).
+func (repo *repo) UseTOTPStep(ctx context.Context, userID string, step int64) error {
+	sqlCmd := `
+		UPDATE user_totp SET last_used_step = $2
+		WHERE user_id = $1 AND confirmed_at IS NOT NULL AND last_used_step < $2`
+
+	result, err := repo.db.ExecContext(ctx, sqlCmd, userID, step)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UseTOTPStep", "err", err)
+		return errors.New("error checking MFA code")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return errInvalidMFACode
+	}
+	return nil
 }
 
-func genUpdateLine(k string, v interface{}) string {
-	update := k + " = "
+func (repo *repo) UseRecoveryCode(ctx context.Context, userID string, codeHash string) error {
+	sqlCmd := `
+		UPDATE mfa_recovery_codes SET used_at = now()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	result, err := repo.db.ExecContext(ctx, sqlCmd, userID, codeHash)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UseRecoveryCode", "err", err)
+		return errors.New("error checking MFA code")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return errInvalidMFACode
+	}
+	return nil
+}
 
-	switch t := v.(type) {
-	case string:
-		if v == "DEFAULT" {
-			update += t
-		} else {
-			update += "'" + t + "'"
+// Turns MFA off for the user, taking their secret and recovery codes with it
+func (repo *repo) DeleteMFA(ctx context.Context, userID string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.New("error deleting MFA")
+	}
+	defer tx.Rollback()
+
+	for _, sqlCmd := range []string{
+		`DELETE FROM mfa_recovery_codes WHERE user_id=$1`,
+		`DELETE FROM user_totp WHERE user_id=$1`,
+	} {
+		if _, err := tx.ExecContext(ctx, sqlCmd, userID); err != nil {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "DeleteMFA", "err", err)
+			return errors.New("error deleting MFA")
 		}
-	case nil:
-		return ""
-	default:
-		update += fmt.Sprintf("%v", t)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.New("error deleting MFA")
+	}
+	return nil
+}
+
+func (repo *repo) CreateLoginChallenge(ctx context.Context, challenge LoginChallenge) error {
+	sqlCmd := `
+		INSERT INTO mfa_challenges (token_hash, user_id, org_id, auth_method, expires_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, challenge.TokenHash, challenge.UserID, challenge.OrgID, challenge.AuthMethod, challenge.ExpiresAt)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreateLoginChallenge", "err", err)
+		return errors.New("error saving MFA challenge")
+	}
+	return nil
+}
+
+// Only finds challenges that can still be answered, i.e. not expired, not used
+// and without too many wrong codes against them
+func (repo *repo) GetLoginChallenge(ctx context.Context, tokenHash string) (LoginChallenge, error) {
+	var challenge LoginChallenge
+
+	sqlCmd := `
+		SELECT token_hash, user_id, org_id, auth_method, expires_at, attempts
+		FROM mfa_challenges
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now() AND attempts < $2`
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, tokenHash, mfaChallengeAttempts).Scan(&challenge.TokenHash,
+		&challenge.UserID, &challenge.OrgID, &challenge.AuthMethod, &challenge.ExpiresAt, &challenge.Attempts)
+
+	if err == sql.ErrNoRows {
+		return LoginChallenge{}, errInvalidMFAChallenge
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetLoginChallenge", "err", err)
+		return LoginChallenge{}, errors.New("error getting MFA challenge")
+	}
+
+	return challenge, nil
+}
+
+func (repo *repo) RecordFailedChallenge(ctx context.Context, tokenHash string) error {
+	sqlCmd := `UPDATE mfa_challenges SET attempts = attempts + 1 WHERE token_hash = $1`
+
+	if _, err := repo.db.ExecContext(ctx, sqlCmd, tokenHash); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "RecordFailedChallenge", "err", err)
+		return errors.New("error saving MFA challenge")
+	}
+	return nil
+}
+
+// Uses up the challenge as long as it can still be answered
+func (repo *repo) UseLoginChallenge(ctx context.Context, tokenHash string) error {
+	sqlCmd := `
+		UPDATE mfa_challenges SET used_at = now()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now() AND attempts < $2`
+
+	result, err := repo.db.ExecContext(ctx, sqlCmd, tokenHash, mfaChallengeAttempts)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UseLoginChallenge", "err", err)
+		return errors.New("error using MFA challenge")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return errInvalidMFAChallenge
+	}
+	return nil
+}
+
+func (repo *repo) CreateOrgSelection(ctx context.Context, selection PendingOrgSelection) error {
+	sqlCmd := `
+		INSERT INTO org_selections (token_hash, user_id, expires_at)
+		VALUES ($1, $2, $3)`
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, selection.TokenHash, selection.UserID, selection.ExpiresAt)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreateOrgSelection", "err", err)
+		return errors.New("error saving org selection")
+	}
+	return nil
+}
+
+// Uses up the org selection with the token hash and hands it back, as long as it
+// hasn't been used or expired. Same as UseEmailVerification, finding and using
+// it up in one statement is what makes it good for exactly one go.
+func (repo *repo) UseOrgSelection(ctx context.Context, tokenHash string) (PendingOrgSelection, error) {
+	var selection PendingOrgSelection
+
+	sqlCmd := `
+		UPDATE org_selections SET used_at = now()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now()
+		RETURNING token_hash, user_id, expires_at`
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, tokenHash).Scan(&selection.TokenHash, &selection.UserID, &selection.ExpiresAt)
+
+	if err == sql.ErrNoRows {
+		return PendingOrgSelection{}, errInvalidOrgSelection
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UseOrgSelection", "err", err)
+		return PendingOrgSelection{}, errors.New("error using org selection")
+	}
+
+	return selection, nil
+}
+
+func (repo *repo) CreatePasswordlessLogin(ctx context.Context, login PasswordlessLogin) error {
+	sqlCmd := `
+		INSERT INTO passwordless_logins (token_hash, method, user_id, org_id, code_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, login.TokenHash, login.Method, login.UserID, login.OrgID, login.CodeHash, login.ExpiresAt)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreatePasswordlessLogin", "err", err)
+		return errors.New("error saving login")
+	}
+	return nil
+}
+
+// Only finds logins that can still be used, i.e. not expired, not used and
+// without too many wrong codes against them
+func (repo *repo) GetPasswordlessLogin(ctx context.Context, tokenHash string, method string) (PasswordlessLogin, error) {
+	var login PasswordlessLogin
+
+	sqlCmd := `
+		SELECT token_hash, method, user_id, org_id, code_hash, expires_at, attempts
+		FROM passwordless_logins
+		WHERE token_hash = $1 AND method = $2 AND used_at IS NULL AND expires_at > now() AND attempts < $3`
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, tokenHash, method, smsCodeAttempts).Scan(&login.TokenHash,
+		&login.Method, &login.UserID, &login.OrgID, &login.CodeHash, &login.ExpiresAt, &login.Attempts)
+
+	if err == sql.ErrNoRows {
+		return PasswordlessLogin{}, invalidPasswordlessLogin(method)
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetPasswordlessLogin", "err", err)
+		return PasswordlessLogin{}, errors.New("error getting login")
+	}
+
+	return login, nil
+}
+
+func (repo *repo) RecordFailedPasswordlessLogin(ctx context.Context, tokenHash string) error {
+	sqlCmd := `UPDATE passwordless_logins SET attempts = attempts + 1 WHERE token_hash = $1`
 
+	if _, err := repo.db.ExecContext(ctx, sqlCmd, tokenHash); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "RecordFailedPasswordlessLogin", "err", err)
+		return errors.New("error saving login")
 	}
+	return nil
+}
 
-	update += " "
-	return update
+// Uses up the login as long as it can still be used
+func (repo *repo) UsePasswordlessLogin(ctx context.Context, tokenHash string, method string) error {
+	sqlCmd := `
+		UPDATE passwordless_logins SET used_at = now()
+		WHERE token_hash = $1 AND method = $2 AND used_at IS NULL AND expires_at > now() AND attempts < $3`
+
+	result, err := repo.db.ExecContext(ctx, sqlCmd, tokenHash, method, smsCodeAttempts)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UsePasswordlessLogin", "err", err)
+		return errors.New("error using login")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return invalidPasswordlessLogin(method)
+	}
+	return nil
 }
 
-// TODO: I should have a wrapper function over fields/values that could potentially be NULL
-// from the SQL query that can then convert to the receiver's datatype's zero-value OR
-// omit that from the response entirely.
+func (repo *repo) CreatePasskey(ctx context.Context, passkey PasskeyCredential) error {
+	sqlCmd := `
+		INSERT INTO passkeys (id, user_id, name, public_key, attestation_type, transports, aaguid,
+			sign_count, user_verified, backup_eligible, backup_state)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 
-// This should be handled here so as to not taint any of the other code with DB
-// related workarounds or anything like that.
+	_, err := repo.db.ExecContext(ctx, sqlCmd, passkey.ID, passkey.UserID, passkey.Name, passkey.PublicKey,
+		passkey.AttestationType, pq.Array(passkey.Transports), passkey.AAGUID, int64(passkey.SignCount),
+		passkey.UserVerified, passkey.BackupEligible, passkey.BackupState)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errors.New("that passkey is already registered")
+		}
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreatePasskey", "err", err)
+		return errors.New("error saving passkey")
+	}
+	return nil
+}
+
+// The user's passkeys, oldest first
+func (repo *repo) ListPasskeys(ctx context.Context, userID string) ([]PasskeyCredential, error) {
+	sqlCmd := `
+		SELECT id, user_id, name, public_key, attestation_type, transports, aaguid, sign_count,
+			user_verified, backup_eligible, backup_state, created_at, last_used_at
+		FROM passkeys
+		WHERE user_id = $1
+		ORDER BY created_at, id`
+
+	rows, err := repo.db.QueryContext(ctx, sqlCmd, userID)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "ListPasskeys", "err", err)
+		return nil, errors.New("error listing passkeys")
+	}
+	defer rows.Close()
+
+	passkeys := []PasskeyCredential{}
+	for rows.Next() {
+		var passkey PasskeyCredential
+		var signCount int64
+		err := rows.Scan(&passkey.ID, &passkey.UserID, &passkey.Name, &passkey.PublicKey, &passkey.AttestationType,
+			pq.Array(&passkey.Transports), &passkey.AAGUID, &signCount, &passkey.UserVerified,
+			&passkey.BackupEligible, &passkey.BackupState, &passkey.CreatedAt, nullableTime(&passkey.LastUsedAt))
+		if err != nil {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "ListPasskeys", "err", err)
+			return nil, errors.New("error listing passkeys")
+		}
+		passkey.SignCount = uint32(signCount)
+		passkeys = append(passkeys, passkey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("error listing passkeys")
+	}
+
+	return passkeys, nil
+}
+
+// Records a login with the passkey, along with what its authenticator says now
+func (repo *repo) RecordPasskeyUse(ctx context.Context, id string, signCount uint32, backupState bool) error {
+	sqlCmd := `
+		UPDATE passkeys SET sign_count = $2, backup_state = $3, last_used_at = now()
+		WHERE id = $1`
+
+	if _, err := repo.db.ExecContext(ctx, sqlCmd, id, int64(signCount), backupState); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "RecordPasskeyUse", "err", err)
+		return errors.New("error saving passkey")
+	}
+	return nil
+}
+
+func (repo *repo) DeletePasskey(ctx context.Context, userID string, id string) error {
+	sqlCmd := `DELETE FROM passkeys WHERE user_id = $1 AND id = $2`
+
+	result, err := repo.db.ExecContext(ctx, sqlCmd, userID, id)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "DeletePasskey", "err", err)
+		return errors.New("error deleting passkey")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return fmt.Errorf("%w: no such passkey", ErrNotFound)
+	}
+	return nil
+}
+
+func (repo *repo) CreatePasskeyCeremony(ctx context.Context, ceremony PasskeyCeremonyState) error {
+	sqlCmd := `
+		INSERT INTO passkey_ceremonies (token_hash, kind, user_id, org_id, session, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, ceremony.TokenHash, ceremony.Kind, nullIfEmpty(ceremony.UserID),
+		nullIfEmpty(ceremony.OrgID), ceremony.Session, ceremony.ExpiresAt)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreatePasskeyCeremony", "err", err)
+		return errors.New("error saving passkey ceremony")
+	}
+	return nil
+}
+
+// Uses up the ceremony and hands it back, as long as it's of the kind asked for
+// and can still be finished
+func (repo *repo) UsePasskeyCeremony(ctx context.Context, tokenHash string, kind string) (PasskeyCeremonyState, error) {
+	var ceremony PasskeyCeremonyState
+
+	sqlCmd := `
+		UPDATE passkey_ceremonies SET used_at = now()
+		WHERE token_hash = $1 AND kind = $2 AND used_at IS NULL AND expires_at > now()
+		RETURNING token_hash, kind, user_id, org_id, session, expires_at`
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, tokenHash, kind).Scan(&ceremony.TokenHash, &ceremony.Kind,
+		nullableString(&ceremony.UserID), nullableString(&ceremony.OrgID), &ceremony.Session, &ceremony.ExpiresAt)
+
+	if err == sql.ErrNoRows {
+		return PasskeyCeremonyState{}, errInvalidPasskeyCeremony
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UsePasskeyCeremony", "err", err)
+		return PasskeyCeremonyState{}, errors.New("error using passkey ceremony")
+	}
+
+	return ceremony, nil
+}
+
+func (repo *repo) CreateEmailVerification(ctx context.Context, verification EmailVerification) error {
+	sqlCmd := `
+		INSERT INTO email_verifications (token_hash, user_id, email, expires_at)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, verification.TokenHash, verification.UserID, verification.Email, verification.ExpiresAt)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreateEmailVerification", "err", err)
+		return errors.New("error saving email verification")
+	}
+	return nil
+}
+
+// Uses up the user's verification with the token hash and hands it back, as long
+// as it hasn't been used or expired. Using it up in the same statement that finds
+// it is what makes each one good for exactly one go.
+func (repo *repo) UseEmailVerification(ctx context.Context, userID string, tokenHash string) (EmailVerification, error) {
+	var verification EmailVerification
+
+	sqlCmd := `
+		UPDATE email_verifications SET used_at = now()
+		WHERE token_hash = $1 AND user_id = $2 AND used_at IS NULL AND expires_at > now()
+		RETURNING token_hash, user_id, email, expires_at`
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, tokenHash, userID).Scan(&verification.TokenHash, &verification.UserID, &verification.Email, &verification.ExpiresAt)
+
+	if err == sql.ErrNoRows {
+		return EmailVerification{}, errInvalidVerification
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UseEmailVerification", "err", err)
+		return EmailVerification{}, errors.New("error verifying email")
+	}
+
+	return verification, nil
+}
+
+// Marks the user's email verified, only if it's still the address that was verified
+func (repo *repo) MarkEmailVerified(ctx context.Context, userID string, email string) error {
+	sqlCmd := `
+		UPDATE user_profiles SET email_verified_at = now()
+		WHERE account_id = $1 AND lower(email) = lower($2)`
+
+	result, err := repo.db.ExecContext(ctx, sqlCmd, userID, email)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "MarkEmailVerified", "err", err)
+		return errors.New("error verifying email")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return errors.New("the email address has changed since the verification was sent")
+	}
+	return nil
+}
+
+// Replaces whatever verification the user had outstanding, so only the code
+// texted last works
+func (repo *repo) CreatePhoneVerification(ctx context.Context, verification PhoneVerification) error {
+	sqlCmd := `
+		INSERT INTO phone_verifications (user_id, phone, code_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET phone = EXCLUDED.phone, code_hash = EXCLUDED.code_hash,
+			created_at = now(), expires_at = EXCLUDED.expires_at, attempts = 0, used_at = NULL`
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, verification.UserID, verification.Phone, verification.CodeHash, verification.ExpiresAt)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreatePhoneVerification", "err", err)
+		return errors.New("error saving phone verification")
+	}
+	return nil
+}
+
+// Only finds a verification that can still be used, i.e. not expired, not used
+// and without too many wrong codes against it
+func (repo *repo) GetPhoneVerification(ctx context.Context, userID string) (PhoneVerification, error) {
+	var verification PhoneVerification
+
+	sqlCmd := `
+		SELECT user_id, phone, code_hash, expires_at, attempts
+		FROM phone_verifications
+		WHERE user_id = $1 AND used_at IS NULL AND expires_at > now() AND attempts < $2`
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, userID, smsCodeAttempts).Scan(&verification.UserID,
+		&verification.Phone, &verification.CodeHash, &verification.ExpiresAt, &verification.Attempts)
+
+	if err == sql.ErrNoRows {
+		return PhoneVerification{}, errInvalidPhoneCode
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetPhoneVerification", "err", err)
+		return PhoneVerification{}, errors.New("error getting phone verification")
+	}
+
+	return verification, nil
+}
+
+func (repo *repo) RecordFailedPhoneVerification(ctx context.Context, userID string) error {
+	sqlCmd := `UPDATE phone_verifications SET attempts = attempts + 1 WHERE user_id = $1`
+
+	if _, err := repo.db.ExecContext(ctx, sqlCmd, userID); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "RecordFailedPhoneVerification", "err", err)
+		return errors.New("error saving phone verification")
+	}
+	return nil
+}
+
+// Uses up the user's verification as long as it can still be used
+func (repo *repo) UsePhoneVerification(ctx context.Context, userID string) error {
+	sqlCmd := `
+		UPDATE phone_verifications SET used_at = now()
+		WHERE user_id = $1 AND used_at IS NULL AND expires_at > now() AND attempts < $2`
+
+	result, err := repo.db.ExecContext(ctx, sqlCmd, userID, smsCodeAttempts)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UsePhoneVerification", "err", err)
+		return errors.New("error verifying phone")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return errInvalidPhoneCode
+	}
+	return nil
+}
+
+// Marks the user's phone verified, only if it's still the number that was verified
+func (repo *repo) MarkPhoneVerified(ctx context.Context, userID string, phone string) error {
+	sqlCmd := `
+		UPDATE user_profiles SET phone_verified_at = now()
+		WHERE account_id = $1 AND phone = $2`
+
+	result, err := repo.db.ExecContext(ctx, sqlCmd, userID, phone)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "MarkPhoneVerified", "err", err)
+		return errors.New("error verifying phone")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return errors.New("the phone number has changed since the code was sent")
+	}
+	return nil
+}
+
+func (repo *repo) CreateOrgAccount(ctx context.Context, orgAccount OrgAccount) error {
+	sqlCmd := `
+		INSERT INTO org_accounts (id, name, type, mfa_required, passkey_required, parent_id)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, orgAccount.ID, orgAccount.Name, orgAccount.Type, orgAccount.MFARequired, orgAccount.PasskeyRequired,
+		nullIfEmpty(orgAccount.ParentID))
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreateOrgAccount", "err", err)
+		return errors.New("error saving organization account")
+	}
+	return nil
+}
+
+func (repo *repo) CreateOrgProfile(ctx context.Context, orgProfile OrgProfile) error {
+	sqlCmd := `
+	INSERT INTO org_profiles (account_id, address, phone, timezone, website)
+	VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, orgProfile.AccountID, orgProfile.Address, orgProfile.Phone, orgProfile.Timezone, orgProfile.Website)
+
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreateOrgProfile", "err", err)
+		return errors.New("error saving organization profile")
+	}
+	return nil
+}
+
+const orgAccountSelect = `o.id, o.name, o.type, o.joined_on, o.mfa_required, o.passkey_required, o.parent_id, o.status, o.deleted_at`
+
+func scanOrgAccount(row interface{ Scan(...interface{}) error }) (OrgAccount, error) {
+	var account OrgAccount
+	err := row.Scan(&account.ID, &account.Name, &account.Type, &account.JoinedOn, &account.MFARequired, &account.PasskeyRequired,
+		nullableString(&account.ParentID), &account.Status, nullableTime(&account.DeletedAt))
+	return account, err
+}
+
+// Only active orgs are found, see GetOrgAccountAnyStatus for the rest
+func (repo *repo) GetOrgAccount(ctx context.Context, id string) (OrgAccount, error) {
+	sqlCmd := `SELECT ` + orgAccountSelect + ` FROM org_accounts o WHERE o.id = $1 AND o.status = 'active'`
+
+	account, err := scanOrgAccount(repo.db.QueryRowContext(ctx, sqlCmd, id))
+
+	if err != nil {
+		return account, errors.New("could not find organization account")
+	}
+
+	return account, nil
+}
+
+// The org whatever its status, for when that's what's being looked at (e.g.
+// restoring it)
+func (repo *repo) GetOrgAccountAnyStatus(ctx context.Context, id string) (OrgAccount, error) {
+	sqlCmd := `SELECT ` + orgAccountSelect + ` FROM org_accounts o WHERE o.id = $1`
+
+	account, err := scanOrgAccount(repo.db.QueryRowContext(ctx, sqlCmd, id))
+
+	if err == sql.ErrNoRows {
+		return account, fmt.Errorf("%w: no organization account %s", ErrNotFound, id)
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetOrgAccountAnyStatus", "err", err)
+		return account, errors.New("error getting organization account")
+	}
+
+	return account, nil
+}
+
+// The hierarchy queries below leave out deleted orgs, but not suspended or
+// deactivated ones: they're still in the tree, just with their status showing.
+
+// The orgs directly under the org
+func (repo *repo) GetOrgChildren(ctx context.Context, id string) ([]OrgAccount, error) {
+	sqlCmd := `SELECT ` + orgAccountSelect + ` FROM org_accounts o WHERE o.parent_id = $1 AND o.status <> 'deleted' ORDER BY o.name`
+
+	return repo.queryOrgAccounts(ctx, "GetOrgChildren", sqlCmd, id)
+}
+
+// Every org above the org, from the top of the tree down to its parent
+func (repo *repo) GetOrgAncestors(ctx context.Context, id string) ([]OrgAccount, error) {
+	sqlCmd := `
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_id, 1 AS depth FROM org_accounts WHERE id = $1
+			UNION ALL
+			SELECT o.parent_id, a.depth + 1
+			FROM org_accounts o
+			JOIN ancestors a ON o.id = a.parent_id
+			WHERE a.depth < $2
+		)
+		SELECT ` + orgAccountSelect + `
+		FROM ancestors a
+		JOIN org_accounts o ON o.id = a.parent_id
+		WHERE o.status <> 'deleted'
+		ORDER BY a.depth DESC`
+
+	return repo.queryOrgAccounts(ctx, "GetOrgAncestors", sqlCmd, id, maxOrgDepth)
+}
+
+// Every org below the org, a level at a time so each org's parent comes before it
+func (repo *repo) GetOrgDescendants(ctx context.Context, id string) ([]OrgAccount, error) {
+	sqlCmd := `
+		WITH RECURSIVE descendants AS (
+			SELECT id, 1 AS depth FROM org_accounts WHERE parent_id = $1 AND status <> 'deleted'
+			UNION ALL
+			SELECT o.id, d.depth + 1
+			FROM org_accounts o
+			JOIN descendants d ON o.parent_id = d.id
+			WHERE d.depth < $2 AND o.status <> 'deleted'
+		)
+		SELECT ` + orgAccountSelect + `
+		FROM descendants d
+		JOIN org_accounts o ON o.id = d.id
+		ORDER BY d.depth, o.name`
+
+	return repo.queryOrgAccounts(ctx, "GetOrgDescendants", sqlCmd, id, maxOrgDepth)
+}
+
+func (repo *repo) queryOrgAccounts(ctx context.Context, method string, sqlCmd string, args ...interface{}) ([]OrgAccount, error) {
+	rows, err := repo.db.QueryContext(ctx, sqlCmd, args...)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", method, "err", err)
+		return nil, errors.New("error getting organizations")
+	}
+	defer rows.Close()
+
+	orgs := []OrgAccount{}
+	for rows.Next() {
+		org, err := scanOrgAccount(rows)
+		if err != nil {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", method, "err", err)
+			return nil, errors.New("error getting organizations")
+		}
+		orgs = append(orgs, org)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("error getting organizations")
+	}
+
+	return orgs, nil
+}
+
+func (repo *repo) GetOrgProfile(ctx context.Context, accountID string) (OrgProfile, error) {
+
+	var profile OrgProfile
+
+	sqlCmd := `SELECT account_id, address, phone, timezone, website FROM org_profiles WHERE account_id = $1`
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, accountID).Scan(&profile.AccountID, nullableString(&profile.Address), nullableString(&profile.Phone), nullableString(&profile.Timezone), nullableString(&profile.Website))
+
+	if err != nil {
+		return profile, errors.New("could not find organization profile")
+	}
+
+	return profile, nil
+}
+
+func (repo *repo) UpdateOrgAccount(ctx context.Context, id string, updates map[string]interface{}) error {
+
+	sqlCmd, args, err := buildUpdate("org_accounts", "id", orgAccountColumns, updates)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.db.ExecContext(ctx,
+		sqlCmd,
+		append([]interface{}{id}, args...)...)
+
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UpdateOrgAccount", "err", err)
+		return errors.New("unable to update organization account")
+	}
+	return nil
+}
+
+func (repo *repo) UpdateOrgProfile(ctx context.Context, accountID string, updates map[string]interface{}) error {
+
+	sqlCmd, args, err := buildUpdate("org_profiles", "account_id", orgProfileColumns, updates)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.db.ExecContext(ctx,
+		sqlCmd,
+		append([]interface{}{accountID}, args...)...)
+
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "UpdateOrgProfile", "err", err)
+		return errors.New("unable to update organization profile")
+	}
+	return nil
+}
+
+// Same as DeleteUserAccount, the org is marked deleted and keeps its profile,
+// details and memberships. Its sessions and open invites are revoked, and its
+// network relationships terminated, in the same transaction.
+func (repo *repo) DeleteOrgAccount(ctx context.Context, id string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.New("error deleting organization account")
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE org_accounts SET status = 'deleted', deleted_at = now()
+		WHERE id = $1 AND status <> 'deleted'`, id)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "DeleteOrgAccount", "err", err)
+		return errors.New("error deleting organization account")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return fmt.Errorf("%w: no organization account %s", ErrNotFound, id)
+	}
+
+	for _, sqlCmd := range []string{
+		`UPDATE sessions SET revoked_at = now() WHERE org_id=$1 AND revoked_at IS NULL`,
+		`UPDATE org_invites SET revoked_at = now() WHERE org_id=$1 AND accepted_at IS NULL AND revoked_at IS NULL`,
+		`UPDATE network_relationships SET status = 'terminated', terminated_at = now(),
+			termination_date = GREATEST(effective_date, LEAST(COALESCE(termination_date, CURRENT_DATE), CURRENT_DATE))
+		WHERE (provider_id=$1 OR payor_id=$1) AND status <> 'terminated'`,
+		`DELETE FROM mfa_challenges WHERE org_id=$1`,
+		`DELETE FROM passwordless_logins WHERE org_id=$1`,
+		`DELETE FROM passkey_ceremonies WHERE org_id=$1`,
+	} {
+		if _, err := tx.ExecContext(ctx, sqlCmd, id); err != nil {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "DeleteOrgAccount", "err", err)
+			return errors.New("error deleting organization account")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.New("error deleting organization account")
+	}
+	return nil
+}
+
+// Same as PurgeUserAccount, the memberships and profile go in the same
+// transaction as the account itself. Only for undoing an org we didn't finish
+// creating, deleting one goes through DeleteOrgAccount.
+func (repo *repo) PurgeOrgAccount(ctx context.Context, id string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.New("error deleting organization account")
+	}
+	defer tx.Rollback()
+
+	for _, sqlCmd := range []string{
+		`DELETE FROM org_users WHERE org_id=$1`,
+		`DELETE FROM org_invites WHERE org_id=$1`,
+		`DELETE FROM mfa_challenges WHERE org_id=$1`,
+		`DELETE FROM passwordless_logins WHERE org_id=$1`,
+		`DELETE FROM passkey_ceremonies WHERE org_id=$1`,
+		`DELETE FROM provider_details WHERE account_id=$1`,
+		`DELETE FROM payer_ids WHERE account_id=$1`,
+		`DELETE FROM network_relationships WHERE provider_id=$1 OR payor_id=$1`,
+		`DELETE FROM org_profiles WHERE account_id=$1`,
+		`DELETE FROM org_accounts WHERE id=$1`,
+	} {
+		if _, err := tx.ExecContext(ctx, sqlCmd, id); err != nil {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "PurgeOrgAccount", "err", err)
+			return errors.New("error deleting organization account")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.New("error deleting organization account")
+	}
+	return nil
+}
+
+// Same as SetUserStatus for orgs
+func (repo *repo) SetOrgStatus(ctx context.Context, id string, change statusChange) error {
+	result, err := repo.db.ExecContext(ctx, `
+		UPDATE org_accounts SET status = $2, deleted_at = NULL
+		WHERE id = $1 AND status = ANY($3)`, id, change.to, pq.Array(change.from))
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "SetOrgStatus", "err", err)
+		return errors.New("error updating organization account status")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return fmt.Errorf("organization account is not %s", strings.Join(change.from, " or "))
+	}
+	return nil
+}
+
+func (repo *repo) CreateProviderDetails(ctx context.Context, details ProviderDetails) error {
+	sqlCmd := `
+		INSERT INTO provider_details (account_id, npi, tax_id)
+		VALUES ($1, $2, $3)`
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, details.AccountID, details.NPI, details.TaxID)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreateProviderDetails", "err", err)
+		if isUniqueViolation(err) {
+			return errors.New("NPI is already registered to another organization")
+		}
+		return errors.New("error saving provider details")
+	}
+	return nil
+}
+
+// The provider details of the org, or nil if it doesn't have any
+func (repo *repo) GetProviderDetails(ctx context.Context, accountID string) (*ProviderDetails, error) {
+	var details ProviderDetails
+
+	sqlCmd := `SELECT account_id, npi, tax_id FROM provider_details WHERE account_id = $1`
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, accountID).Scan(&details.AccountID, &details.NPI, &details.TaxID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetProviderDetails", "err", err)
+		return nil, errors.New("error getting provider details")
+	}
+
+	return &details, nil
+}
+
+// Replaces the payor's payer IDs with the given ones, all or nothing
+func (repo *repo) SetPayerIDs(ctx context.Context, accountID string, payerIDs []PayerID) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.New("error saving payer IDs")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM payer_ids WHERE account_id=$1`, accountID); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "SetPayerIDs", "err", err)
+		return errors.New("error saving payer IDs")
+	}
+
+	for _, payerID := range payerIDs {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO payer_ids (payer_id, account_id, label) VALUES ($1, $2, $3)`,
+			payerID.PayerID, accountID, payerID.Label)
+		if err != nil {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "SetPayerIDs", "err", err)
+			if isUniqueViolation(err) {
+				return fmt.Errorf("payer ID %s already belongs to another payor", payerID.PayerID)
+			}
+			return errors.New("error saving payer IDs")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.New("error saving payer IDs")
+	}
+	return nil
+}
+
+// The payor's details, a payor without any payer IDs yet still gets (empty) details
+func (repo *repo) GetPayorDetails(ctx context.Context, accountID string) (*PayorDetails, error) {
+	sqlCmd := `SELECT payer_id, label FROM payer_ids WHERE account_id = $1 ORDER BY payer_id`
+
+	rows, err := repo.db.QueryContext(ctx, sqlCmd, accountID)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetPayorDetails", "err", err)
+		return nil, errors.New("error getting payor details")
+	}
+	defer rows.Close()
+
+	details := PayorDetails{AccountID: accountID, PayerIDs: []PayerID{}}
+	for rows.Next() {
+		var payerID PayerID
+		if err := rows.Scan(&payerID.PayerID, &payerID.Label); err != nil {
+			return nil, errors.New("error getting payor details")
+		}
+		details.PayerIDs = append(details.PayerIDs, payerID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("error getting payor details")
+	}
+
+	return &details, nil
+}
+
+func (repo *repo) GetOrgIDByPayerID(ctx context.Context, payerID string) (string, error) {
+	var orgID string
+
+	sqlCmd := `
+		SELECT p.account_id FROM payer_ids p
+		JOIN org_accounts o ON o.id = p.account_id
+		WHERE p.payer_id = $1 AND o.status = 'active'`
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, payerID).Scan(&orgID)
+
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("%w: no payor has payer ID %s", ErrNotFound, payerID)
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetOrgIDByPayerID", "err", err)
+		return "", errors.New("error looking up payer ID")
+	}
+
+	return orgID, nil
+}
+
+func (repo *repo) AssociateUserToOrg(ctx context.Context, userID string, orgID string, role string) error {
+	sqlCmd := `INSERT INTO org_users (user_id, org_id, role) VALUES ($1, $2, $3)`
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, userID, orgID, role)
+
+	if err != nil {
+		return errors.New("error associating user to organization")
+	}
+	return nil
+}
+
+// Makes the user the org's admin, as long as nobody has ever joined it (deleted
+// users included, they still hold their memberships), ErrOrgHasMembers
+// otherwise. The org's row stays locked from checking to joining, so two users
+// joining a new org at once can't both end up its first member.
+func (repo *repo) AssociateFirstUserToOrg(ctx context.Context, userID string, orgID string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.New("error associating user to organization")
+	}
+	defer tx.Rollback()
+
+	// Locking first and only then looking is what makes it safe, the second
+	// statement sees whatever whoever held the lock before us committed
+	var locked string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM org_accounts WHERE id = $1 FOR UPDATE`, orgID).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: no organization %s", ErrNotFound, orgID)
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "AssociateFirstUserToOrg", "err", err)
+		return errors.New("error associating user to organization")
+	}
+
+	var hasMembers bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM org_users WHERE org_id = $1)`, orgID).Scan(&hasMembers)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "AssociateFirstUserToOrg", "err", err)
+		return errors.New("error associating user to organization")
+	}
+	if hasMembers {
+		return ErrOrgHasMembers
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO org_users (user_id, org_id, role) VALUES ($1, $2, $3)`, userID, orgID, RoleAdmin); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "AssociateFirstUserToOrg", "err", err)
+		return errors.New("error associating user to organization")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.New("error associating user to organization")
+	}
+	return nil
+}
+
+// Lists the active orgs the user is a member of
+func (repo *repo) GetUserOrgs(ctx context.Context, userID string) ([]OrgMembership, error) {
+	sqlCmd := `
+		SELECT o.id, o.name, o.type, ou.role
+		FROM org_users ou
+		JOIN org_accounts o ON o.id = ou.org_id
+		WHERE ou.user_id = $1 AND o.status = 'active'
+		ORDER BY o.name`
+
+	rows, err := repo.db.QueryContext(ctx, sqlCmd, userID)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetUserOrgs", "err", err)
+		return nil, errors.New("error getting user's organizations")
+	}
+	defer rows.Close()
+
+	memberships := []OrgMembership{}
+	for rows.Next() {
+		var membership OrgMembership
+		if err := rows.Scan(&membership.OrgID, &membership.OrgName, &membership.OrgType, &membership.Role); err != nil {
+			return nil, errors.New("error getting user's organizations")
+		}
+		memberships = append(memberships, membership)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("error getting user's organizations")
+	}
+
+	return memberships, nil
+}
+
+func (repo *repo) ConfirmUserToOrgAssociation(ctx context.Context, userID string, orgID string) error {
+	sqlCmd := `SELECT COUNT(id) FROM org_users WHERE user_id = $1 AND org_id = $2`
+
+	var count int
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, userID, orgID).Scan(&count)
+
+	if err != nil {
+		return errors.New("error confirming user association to organization")
+	}
+
+	if count == 0 {
+		return errors.New("user not associated to organization")
+	}
+
+	return nil
+}
+
+// The user's role within the org, or an error if they aren't a member at all
+func (repo *repo) GetOrgMemberRole(ctx context.Context, userID string, orgID string) (string, error) {
+	sqlCmd := `SELECT role FROM org_users WHERE user_id = $1 AND org_id = $2`
+
+	var role string
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, userID, orgID).Scan(&role)
+
+	if err == sql.ErrNoRows {
+		return "", errors.New("user not associated to organization")
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetOrgMemberRole", "err", err)
+		return "", errors.New("error getting user's role in organization")
+	}
+
+	return role, nil
+}
+
+// Whether the user is an admin of the org, or of any org above it
+func (repo *repo) IsOrgAdmin(ctx context.Context, userID string, orgID string) (bool, error) {
+	sqlCmd := `
+		WITH RECURSIVE lineage AS (
+			SELECT id, parent_id, 0 AS depth FROM org_accounts WHERE id = $2
+			UNION ALL
+			SELECT o.id, o.parent_id, l.depth + 1
+			FROM org_accounts o
+			JOIN lineage l ON o.id = l.parent_id
+			WHERE l.depth < $4
+		)
+		SELECT EXISTS (
+			SELECT 1 FROM org_users ou
+			JOIN lineage l ON l.id = ou.org_id
+			WHERE ou.user_id = $1 AND ou.role = $3
+		)`
+
+	var admin bool
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, userID, orgID, RoleAdmin, maxOrgDepth).Scan(&admin)
+
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "IsOrgAdmin", "err", err)
+		return false, errors.New("error getting user's role in organization")
+	}
+
+	return admin, nil
+}
+
+// Deleted users keep their memberships (so restoring them puts them back where
+// they were) but don't count
+func (repo *repo) CountOrgMembers(ctx context.Context, orgID string) (int, error) {
+	sqlCmd := `
+		SELECT COUNT(ou.id) FROM org_users ou
+		JOIN user_accounts a ON a.id = ou.user_id
+		WHERE ou.org_id = $1 AND a.status <> 'deleted'`
+
+	var count int
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, orgID).Scan(&count)
+
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CountOrgMembers", "err", err)
+		return 0, errors.New("error counting organization members")
+	}
+
+	return count, nil
+}
+
+// What an org's users can be sorted by: the SQL expression to order on, and the
+// type its value (as kept in a cursor) has to be cast back to for comparing.
+// last_login is coalesced since users that never logged in would otherwise make
+// the keyset comparison come out NULL.
+var orgUserSorts = map[string]struct{ expr, cast string }{
+	"name":       {`lower(p.last_name || ' ' || p.first_name)`, "text"},
+	"joined_on":  {`a.joined_on`, "timestamptz"},
+	"last_login": {`COALESCE(p.last_login, 'epoch')`, "timestamptz"},
+}
+
+// Pages through an org's users with keyset pagination, i.e. the cursor holds the
+// sort value and ID of the row the last page ended on and the next page picks up
+// right after it. Unlike OFFSET that stays cheap however deep you go and doesn't
+// skip or repeat anyone when users are added or removed in between pages.
+func (repo *repo) ListOrgUsers(ctx context.Context, orgID string, query OrgUserQuery) (OrgUserPage, error) {
+	var page OrgUserPage
+
+	sortBy, ok := orgUserSorts[query.Sort]
+	if !ok {
+		return page, fmt.Errorf("cannot sort by %s", query.Sort)
+	}
+
+	var cursor pageCursor
+	if query.Cursor != "" {
+		var err error
+		cursor, err = decodePageCursor(query.Cursor)
+		if err != nil {
+			return page, err
+		}
+		if cursor.Sort != query.Sort || cursor.Desc != query.Desc {
+			return page, errInvalidCursor
+		}
+	}
+
+	from := `
+		FROM org_users ou
+		JOIN user_accounts a ON a.id = ou.user_id
+		JOIN user_profiles p ON p.account_id = a.id`
+
+	where := []string{"ou.org_id = $1"}
+	args := []interface{}{orgID}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.Role != "" {
+		where = append(where, "ou.role = "+arg(query.Role))
+	}
+	if query.Status != "" {
+		where = append(where, "a.status = "+arg(query.Status))
+	}
+	if query.Text != "" {
+		pattern := arg("%" + escapeLike(query.Text) + "%")
+		where = append(where, "((p.first_name || ' ' || p.last_name) ILIKE "+pattern+" OR p.email ILIKE "+pattern+")")
+	}
+
+	// The total ignores the cursor, it's how many there are across all the pages
+	countCmd := `SELECT COUNT(*)` + from + ` WHERE ` + strings.Join(where, " AND ")
+	if err := repo.db.QueryRowContext(ctx, countCmd, args...).Scan(&page.Total); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "ListOrgUsers", "err", err)
+		return page, errors.New("error listing organization users")
+	}
+
+	// Paging backwards is just paging forwards through the reversed ordering (and
+	// flipping the rows back around afterwards).
+	desc := query.Desc != cursor.Before
+	op, dir := ">", "ASC"
+	if desc {
+		op, dir = "<", "DESC"
+	}
+	if query.Cursor != "" {
+		where = append(where, fmt.Sprintf("(%s, a.id) %s (%s::%s, %s)", sortBy.expr, op, arg(cursor.Value), sortBy.cast, arg(cursor.ID)))
+	}
+
+	// One more than asked for, to tell whether there's another page after this one
+	sqlCmd := `
+		SELECT ` + userAccountSelect + `,
+			p.first_name, p.last_name, p.email, p.phone, p.last_login, p.email_verified_at, p.phone_verified_at,
+			ou.role, (` + sortBy.expr + `)::text` + from + `
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY ` + sortBy.expr + ` ` + dir + `, a.id ` + dir + `
+		LIMIT ` + arg(query.Limit+1)
+
+	rows, err := repo.db.QueryContext(ctx, sqlCmd, args...)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "ListOrgUsers", "err", err)
+		return page, errors.New("error listing organization users")
+	}
+	defer rows.Close()
+
+	page.Users = []OrgMember{}
+	var sortValues []string
+	for rows.Next() {
+		var member OrgMember
+		var sortValue string
+		err := rows.Scan(&member.Account.ID, &member.Account.Username, &member.Account.OrgType, &member.Account.JoinedOn, &member.Account.Status, nullableTime(&member.Account.DeletedAt),
+			&member.Profile.FirstName, &member.Profile.LastName, nullableString(&member.Profile.Email), nullableString(&member.Profile.Phone), nullableTime(&member.Profile.LastLogin), nullableTime(&member.Profile.EmailVerifiedAt), nullableTime(&member.Profile.PhoneVerifiedAt),
+			&member.Role, &sortValue)
+		if err != nil {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "ListOrgUsers", "err", err)
+			return page, errors.New("error listing organization users")
+		}
+		member.Profile.AccountID = member.Account.ID
+		page.Users = append(page.Users, member)
+		sortValues = append(sortValues, sortValue)
+	}
+	if err := rows.Err(); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "ListOrgUsers", "err", err)
+		return page, errors.New("error listing organization users")
+	}
+
+	more := len(page.Users) > query.Limit
+	if more {
+		page.Users = page.Users[:query.Limit]
+		sortValues = sortValues[:query.Limit]
+	}
+	if cursor.Before {
+		for i, j := 0, len(page.Users)-1; i < j; i, j = i+1, j-1 {
+			page.Users[i], page.Users[j] = page.Users[j], page.Users[i]
+			sortValues[i], sortValues[j] = sortValues[j], sortValues[i]
+		}
+	}
+	if len(page.Users) == 0 {
+		return page, nil
+	}
+
+	// Coming from a cursor means there's a page on the side we came from, and
+	// "more" tells us about the side we're heading towards.
+	hasNext, hasPrev := more, query.Cursor != ""
+	if cursor.Before {
+		hasNext, hasPrev = query.Cursor != "", more
+	}
+	if hasNext {
+		last := len(page.Users) - 1
+		page.NextCursor = pageCursor{Sort: query.Sort, Desc: query.Desc, Value: sortValues[last], ID: page.Users[last].Account.ID}.encode()
+	}
+	if hasPrev {
+		page.PrevCursor = pageCursor{Sort: query.Sort, Desc: query.Desc, Value: sortValues[0], ID: page.Users[0].Account.ID, Before: true}.encode()
+	}
+
+	return page, nil
+}
+
+func (repo *repo) RemoveUserFromOrg(ctx context.Context, userID string, orgID string) error {
+	sqlCmd := `DELETE FROM org_users WHERE user_id = $1 AND org_id = $2`
+
+	if _, err := repo.db.ExecContext(ctx, sqlCmd, userID, orgID); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "RemoveUserFromOrg", "err", err)
+		return errors.New("error removing user from organization")
+	}
+	return nil
+}
+
+func (repo *repo) CreateInvite(ctx context.Context, invite Invite) error {
+	sqlCmd := `
+		INSERT INTO org_invites (id, org_id, email, role, invited_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	var invitedBy interface{}
+	if invite.InvitedBy != "" {
+		invitedBy = invite.InvitedBy
+	}
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, invite.ID, invite.OrgID, invite.Email, invite.Role, invitedBy, invite.ExpiresAt)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreateInvite", "err", err)
+		if isUniqueViolation(err) {
+			return errors.New("there is already an open invite for that email address, resend it instead")
+		}
+		return errors.New("error saving invite")
+	}
+	return nil
+}
+
+const inviteColumns = `id, org_id, email, role, invited_by, created_at, expires_at, accepted_at, accepted_by, revoked_at`
+
+func scanInvite(row interface{ Scan(...interface{}) error }) (Invite, error) {
+	var invite Invite
+	err := row.Scan(&invite.ID, &invite.OrgID, &invite.Email, &invite.Role, nullableString(&invite.InvitedBy),
+		&invite.CreatedAt, &invite.ExpiresAt, nullableTime(&invite.AcceptedAt), nullableString(&invite.AcceptedBy), nullableTime(&invite.RevokedAt))
+	return invite, err
+}
+
+func (repo *repo) GetInvite(ctx context.Context, id string) (Invite, error) {
+	sqlCmd := `SELECT ` + inviteColumns + ` FROM org_invites WHERE id = $1`
+
+	invite, err := scanInvite(repo.db.QueryRowContext(ctx, sqlCmd, id))
+
+	if err == sql.ErrNoRows {
+		return Invite{}, fmt.Errorf("%w: no such invite", ErrNotFound)
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetInvite", "err", err)
+		return Invite{}, errors.New("error getting invite")
+	}
+
+	return invite, nil
+}
+
+// Every invite the org has sent, newest first
+func (repo *repo) ListInvites(ctx context.Context, orgID string) ([]Invite, error) {
+	sqlCmd := `SELECT ` + inviteColumns + ` FROM org_invites WHERE org_id = $1 ORDER BY created_at DESC, id`
+
+	rows, err := repo.db.QueryContext(ctx, sqlCmd, orgID)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "ListInvites", "err", err)
+		return nil, errors.New("error listing invites")
+	}
+	defer rows.Close()
+
+	invites := []Invite{}
+	for rows.Next() {
+		invite, err := scanInvite(rows)
+		if err != nil {
+			return nil, errors.New("error listing invites")
+		}
+		invites = append(invites, invite)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("error listing invites")
+	}
+
+	return invites, nil
+}
+
+// The invite updates below only touch invites that are still open (neither
+// accepted nor revoked), so two of them racing can't both win.
+
+func (repo *repo) updateOpenInvite(ctx context.Context, method string, sqlCmd string, args ...interface{}) error {
+	result, err := repo.db.ExecContext(ctx, sqlCmd, args...)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", method, "err", err)
+		return errors.New("error updating invite")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return errors.New("invite has already been accepted or revoked")
+	}
+	return nil
+}
+
+func (repo *repo) RevokeInvite(ctx context.Context, id string) error {
+	return repo.updateOpenInvite(ctx, "RevokeInvite", `
+		UPDATE org_invites SET revoked_at = now()
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL`, id)
+}
+
+// Gives the invite a new expiry, which leaves any token issued for the old one useless
+func (repo *repo) RenewInvite(ctx context.Context, id string, expiresAt time.Time) error {
+	return repo.updateOpenInvite(ctx, "RenewInvite", `
+		UPDATE org_invites SET expires_at = $2
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL`, id, expiresAt)
+}
+
+// Marks the invite accepted by the user, as long as it still has the expiry the
+// token was issued with and that hasn't passed.
+func (repo *repo) AcceptInvite(ctx context.Context, id string, expiresAt time.Time, userID string) error {
+	return repo.updateOpenInvite(ctx, "AcceptInvite", `
+		UPDATE org_invites SET accepted_at = now(), accepted_by = $3
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+			AND expires_at = $2 AND expires_at > now()`, id, expiresAt, userID)
+}
+
+func (repo *repo) CreateNetworkRelationship(ctx context.Context, relationship NetworkRelationship) error {
+	sqlCmd := `
+		INSERT INTO network_relationships (id, provider_id, payor_id, status, effective_date, termination_date, requested_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, relationship.ID, relationship.ProviderID, relationship.PayorID, relationship.Status,
+		relationship.EffectiveDate, nullIfEmpty(relationship.TerminationDate), relationship.RequestedBy)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreateNetworkRelationship", "err", err)
+		if isUniqueViolation(err) {
+			return errors.New("there is already a pending or active relationship between those organizations")
+		}
+		return errors.New("error saving network relationship")
+	}
+	return nil
+}
+
+// Dates come back as YYYY-MM-DD so they don't pick up a time or a timezone on the way
+const networkRelationshipSelect = `
+		SELECT n.id, n.provider_id, p.name, n.payor_id, y.name, n.status,
+			to_char(n.effective_date, 'YYYY-MM-DD'), to_char(n.termination_date, 'YYYY-MM-DD'),
+			n.requested_by, n.requested_at, n.accepted_at, n.terminated_at
+		FROM network_relationships n
+		JOIN org_accounts p ON p.id = n.provider_id
+		JOIN org_accounts y ON y.id = n.payor_id`
+
+func scanNetworkRelationship(row interface{ Scan(...interface{}) error }) (NetworkRelationship, error) {
+	var relationship NetworkRelationship
+	err := row.Scan(&relationship.ID, &relationship.ProviderID, &relationship.ProviderName, &relationship.PayorID, &relationship.PayorName, &relationship.Status,
+		&relationship.EffectiveDate, nullableString(&relationship.TerminationDate),
+		&relationship.RequestedBy, &relationship.RequestedAt, nullableTime(&relationship.AcceptedAt), nullableTime(&relationship.TerminatedAt))
+	return relationship, err
+}
+
+func (repo *repo) GetNetworkRelationship(ctx context.Context, id string) (NetworkRelationship, error) {
+	sqlCmd := networkRelationshipSelect + ` WHERE n.id = $1`
+
+	relationship, err := scanNetworkRelationship(repo.db.QueryRowContext(ctx, sqlCmd, id))
+
+	if err == sql.ErrNoRows {
+		return NetworkRelationship{}, fmt.Errorf("%w: no such network relationship", ErrNotFound)
+	}
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetNetworkRelationship", "err", err)
+		return NetworkRelationship{}, errors.New("error getting network relationship")
+	}
+
+	return relationship, nil
+}
+
+// Same idea as the invites, each update only applies to relationships in the
+// state it expects so two of them racing can't both win.
+
+func (repo *repo) updateNetworkRelationship(ctx context.Context, method string, conflict string, sqlCmd string, args ...interface{}) error {
+	result, err := repo.db.ExecContext(ctx, sqlCmd, args...)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", method, "err", err)
+		return errors.New("error updating network relationship")
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return errors.New(conflict)
+	}
+	return nil
+}
+
+func (repo *repo) AcceptNetworkRelationship(ctx context.Context, id string) error {
+	return repo.updateNetworkRelationship(ctx, "AcceptNetworkRelationship", "network relationship is no longer pending", `
+		UPDATE network_relationships SET status = 'active', accepted_at = now()
+		WHERE id = $1 AND status = 'pending'`, id)
+}
+
+// Ends the relationship today, unless it was already set to end before then. One
+// that hadn't started yet ends on the day it would have started, so it never
+// counts as in effect.
+func (repo *repo) TerminateNetworkRelationship(ctx context.Context, id string) error {
+	return repo.updateNetworkRelationship(ctx, "TerminateNetworkRelationship", "network relationship has already been terminated", `
+		UPDATE network_relationships SET status = 'terminated', terminated_at = now(),
+			termination_date = GREATEST(effective_date, LEAST(COALESCE(termination_date, CURRENT_DATE), CURRENT_DATE))
+		WHERE id = $1 AND status <> 'terminated'`, id)
+}
+
+// The payors the provider has a relationship with
+func (repo *repo) ListNetworkPayors(ctx context.Context, providerID string, query NetworkQuery) ([]NetworkRelationship, error) {
+	return repo.listNetworkRelationships(ctx, "ListNetworkPayors", "n.provider_id", "y.name", providerID, query)
+}
+
+// The providers the payor has a relationship with
+func (repo *repo) ListNetworkProviders(ctx context.Context, payorID string, query NetworkQuery) ([]NetworkRelationship, error) {
+	return repo.listNetworkRelationships(ctx, "ListNetworkProviders", "n.payor_id", "p.name", payorID, query)
+}
+
+// Lists the org's relationships from one side, ordered by the name of the org on
+// the other side. Being effective on a date means having been accepted, starting
+// on or before it and not having ended by then.
+func (repo *repo) listNetworkRelationships(ctx context.Context, method string, side string, partnerName string, orgID string, query NetworkQuery) ([]NetworkRelationship, error) {
+	where := []string{side + " = $1"}
+	args := []interface{}{orgID}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.Status != "" {
+		where = append(where, "n.status = "+arg(query.Status))
+	}
+	if query.EffectiveOn != "" {
+		on := arg(query.EffectiveOn) + "::date"
+		where = append(where, "n.accepted_at IS NOT NULL AND n.effective_date <= "+on+
+			" AND (n.termination_date IS NULL OR n.termination_date > "+on+")")
+	}
+
+	sqlCmd := networkRelationshipSelect + `
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY ` + partnerName + `, n.effective_date DESC, n.id`
+
+	rows, err := repo.db.QueryContext(ctx, sqlCmd, args...)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", method, "err", err)
+		return nil, errors.New("error listing network relationships")
+	}
+	defer rows.Close()
+
+	relationships := []NetworkRelationship{}
+	for rows.Next() {
+		relationship, err := scanNetworkRelationship(rows)
+		if err != nil {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", method, "err", err)
+			return nil, errors.New("error listing network relationships")
+		}
+		relationships = append(relationships, relationship)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("error listing network relationships")
+	}
+
+	return relationships, nil
+}
+
+func (repo *repo) CreateSession(ctx context.Context, session Session) error {
+	sqlCmd := `
+		INSERT INTO sessions (id, token_hash, user_id, org_id, auth_method, mfa, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := repo.db.ExecContext(ctx, sqlCmd, session.ID, session.TokenHash, session.UserID, session.OrgID,
+		session.AuthMethod, session.MFA, session.ExpiresAt)
+	if err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "CreateSession", "err", err)
+		return errors.New("error saving session")
+	}
+	return nil
+}
+
+// Only finds sessions that are still good, i.e. not expired, not revoked and for
+// an active user in an active org
+func (repo *repo) GetSessionByTokenHash(ctx context.Context, tokenHash string) (Session, error) {
+	var session Session
+
+	// Suspending (or deleting) the user or the org revokes their sessions too,
+	// the joins make sure that holds even if revoking them didn't happen
+	sqlCmd := `
+		SELECT s.id, s.token_hash, s.user_id, s.org_id, s.auth_method, s.mfa, s.created_at, s.expires_at
+		FROM sessions s
+		JOIN user_accounts a ON a.id = s.user_id AND a.status = 'active'
+		JOIN org_accounts o ON o.id = s.org_id AND o.status = 'active'
+		WHERE s.token_hash = $1 AND s.revoked_at IS NULL AND s.expires_at > now()`
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, tokenHash).Scan(&session.ID, &session.TokenHash, &session.UserID, &session.OrgID,
+		&session.AuthMethod, &session.MFA, &session.CreatedAt, &session.ExpiresAt)
+
+	if err != nil {
+		if err != sql.ErrNoRows {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetSessionByTokenHash", "err", err)
+		}
+		return Session{}, errors.New("session not found")
+	}
+
+	return session, nil
+}
+
+// Same as GetSessionByTokenHash, only by the session's ID
+func (repo *repo) GetSession(ctx context.Context, id string) (Session, error) {
+	var session Session
+
+	sqlCmd := `
+		SELECT id, token_hash, user_id, org_id, auth_method, mfa, created_at, expires_at
+		FROM sessions
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > now()`
+
+	err := repo.db.QueryRowContext(ctx, sqlCmd, id).Scan(&session.ID, &session.TokenHash, &session.UserID, &session.OrgID,
+		&session.AuthMethod, &session.MFA, &session.CreatedAt, &session.ExpiresAt)
+
+	if err != nil {
+		if err != sql.ErrNoRows {
+			level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "GetSession", "err", err)
+		}
+		return Session{}, errors.New("session not found")
+	}
+
+	return session, nil
+}
+
+func (repo *repo) RevokeSession(ctx context.Context, id string) error {
+	sqlCmd := `UPDATE sessions SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL`
+
+	if _, err := repo.db.ExecContext(ctx, sqlCmd, id); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "RevokeSession", "err", err)
+		return errors.New("error revoking session")
+	}
+	return nil
+}
+
+// Whether the error is Postgres refusing a duplicate in a UNIQUE column
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// Marker value for an updates map that puts a column back to its DEFAULT (e.g.
+// last_login's now()) rather than setting it to a value.
+type columnDefault struct{}
+
+var setToDefault = columnDefault{}
+
+// The columns each update is allowed to touch, anything else in the updates map
+// is refused rather than making its way into the SQL.
+var (
+	userAccountColumns = map[string]bool{"username": true}
+	userProfileColumns = map[string]bool{"first_name": true, "last_name": true, "email": true, "phone": true, "last_login": true, "email_verified_at": true, "phone_verified_at": true}
+	orgAccountColumns  = map[string]bool{"name": true, "mfa_required": true, "passkey_required": true, "parent_id": true}
+	orgProfileColumns  = map[string]bool{"phone": true, "address": true, "timezone": true, "website": true}
+)
+
+// Builds a parameterized `UPDATE <table> SET col = $2, ... WHERE <key> = $1` out of the
+// updates map, returning the SQL and the values for $2 onwards. Column names are
+// checked against allowed and values are always bound as parameters, so nothing a
+// caller sends can end up spliced into the SQL itself. nil values are skipped.
+func buildUpdate(table string, key string, allowed map[string]bool, updates map[string]interface{}) (string, []interface{}, error) {
+	// Sort the columns so the same updates always produce the same SQL
+	columns := make([]string, 0, len(updates))
+	for column, value := range updates {
+		if value == nil {
+			continue
+		}
+		if !allowed[column] {
+			return "", nil, fmt.Errorf("%s cannot be updated", column)
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return "", nil, errors.New("nothing to update")
+	}
+	sort.Strings(columns)
+
+	var args []interface{}
+	sets := make([]string, len(columns))
+	for i, column := range columns {
+		if _, ok := updates[column].(columnDefault); ok {
+			sets[i] = column + " = DEFAULT"
+			continue
+		}
+		args = append(args, updates[column])
+		sets[i] = fmt.Sprintf("%s = $%d", column, len(args)+1)
+	}
+
+	sqlCmd := `UPDATE ` + table + ` SET ` + strings.Join(sets, ", ") + ` WHERE ` + key + ` = $1`
+
+	return sqlCmd, args, nil
+}
+
+// Scan destinations for columns that can be NULL. They go through the sql.Null*
+// types and leave the zero value (or nil for a *time.Time, which then drops out
+// of the JSON) in the field when the column is NULL. Keeping that here means none
+// of the other code has to know about the DB's NULLs.
+
+type nullString struct{ dst *string }
+
+// Scan into dst, with NULL becoming ""
+func nullableString(dst *string) sql.Scanner { return nullString{dst} }
+
+func (n nullString) Scan(src interface{}) error {
+	var ns sql.NullString
+	if err := ns.Scan(src); err != nil {
+		return err
+	}
+	*n.dst = ns.String
+	return nil
+}
+
+type nullTime struct{ dst **time.Time }
+
+// Scan into dst, with NULL becoming nil
+func nullableTime(dst **time.Time) sql.Scanner { return nullTime{dst} }
+
+func (n nullTime) Scan(src interface{}) error {
+	var nt sql.NullTime
+	if err := nt.Scan(src); err != nil {
+		return err
+	}
+	*n.dst = nil
+	if nt.Valid {
+		t := nt.Time
+		*n.dst = &t
+	}
+	return nil
+}
+
+// The other way around, for parameters: "" goes in as NULL
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// Revokes every session the user still has, logging them out everywhere
+func (repo *repo) RevokeUserSessions(ctx context.Context, userID string) error {
+	sqlCmd := `UPDATE sessions SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`
+
+	if _, err := repo.db.ExecContext(ctx, sqlCmd, userID); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "RevokeUserSessions", "err", err)
+		return errors.New("error revoking sessions")
+	}
+	return nil
+}
+
+// Same for everyone logged in to the org
+func (repo *repo) RevokeOrgSessions(ctx context.Context, orgID string) error {
+	sqlCmd := `UPDATE sessions SET revoked_at = now() WHERE org_id = $1 AND revoked_at IS NULL`
+
+	if _, err := repo.db.ExecContext(ctx, sqlCmd, orgID); err != nil {
+		level.Error(loggerWithRequestID(ctx, repo.logger)).Log("method", "RevokeOrgSessions", "err", err)
+		return errors.New("error revoking sessions")
+	}
+	return nil
+}
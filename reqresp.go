@@ -1,5 +1,10 @@
 package accountsrv
 
+import (
+	"encoding/json"
+	"net/http"
+)
+
 type CreateUserRequest struct {
 	OrgID     string `json:"org_id"`
 	Username  string `json:"username"`
@@ -18,8 +23,13 @@ type CreateUserResponse struct {
 
 func (r CreateUserResponse) error() error { return r.Err }
 
+func (r CreateUserRequest) rateLimitKeys() (string, string) { return r.Username, r.OrgID }
+
 type GetUserRequest struct {
 	ID string `json:"id"`
+	// What else to bring back with the account (profile, orgs), when empty only
+	// the account itself is returned.
+	Include []string `json:"include,omitempty"`
 }
 
 type GetUserAccountResponse struct {
@@ -29,18 +39,66 @@ type GetUserAccountResponse struct {
 
 func (r GetUserAccountResponse) error() error { return r.Err }
 
+type GetDetailedUserResponse struct {
+	User DetailedUser `json:"user"`
+	Err  error        `json:"error,omitempty"`
+}
+
+func (r GetDetailedUserResponse) error() error { return r.Err }
+
+type DeleteUserRequest struct {
+	ID string `json:"id"`
+}
+
+type DeleteUserResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r DeleteUserResponse) error() error { return r.Err }
+
+// For suspending, deactivating, reactivating and restoring a user
+type UserStatusRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type UserStatusResponse struct {
+	Account UserAccount `json:"account"`
+	Err     error       `json:"error,omitempty"`
+}
+
+func (r UserStatusResponse) error() error { return r.Err }
+
+type UpdateAccountRequest struct {
+	ID      string
+	Updates AccountUpdates `json:"account_updates"`
+}
+
+type UpdateAccountResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r UpdateAccountResponse) error() error { return r.Err }
+
 type LoginRequest struct {
 	OrgID    string
 	Username string `json:"username"`
 	Password string `json:"password"`
 }
+
+// One or the other: the logged in user, or when they need a second factor the
+// challenge to answer with it (see CompleteMFALogin).
 type LoginResponse struct {
-	LoginDetails LoginUser `json:"login_details"`
-	Err          error     `json:"error,omitempty"`
+	LoginDetails *LoginUser    `json:"login_details,omitempty"`
+	MFA          *MFAChallenge `json:"mfa,omitempty"`
+	Err          error         `json:"error,omitempty"`
 }
 
 func (r LoginResponse) error() error { return r.Err }
 
+func (r LoginRequest) rateLimitKeys() (string, string) { return r.Username, r.OrgID }
+
 type UpdateProfileRequest struct {
 	AccountID string
 	Updates   ProfileUpdates `json:"profile_updates"`
@@ -54,12 +112,15 @@ type UpdateProfileResponse struct {
 func (r UpdateProfileResponse) error() error { return r.Err }
 
 type CreateOrgRequest struct {
-	Name     string `json:"name"`
-	Type     string `json:"type"`
-	Phone    string `json:"phone"`
-	Address  string `json:"address"`
-	Timezone string `json:"timezone"`
-	Website  string `json:"website"`
+	Name            string           `json:"name"`
+	Type            string           `json:"type"`
+	ParentID        string           `json:"parent_id,omitempty"` // The org to create it under, if any
+	Phone           string           `json:"phone"`
+	Address         string           `json:"address"`
+	Timezone        string           `json:"timezone"`
+	Website         string           `json:"website"`
+	ProviderDetails *ProviderDetails `json:"provider_details,omitempty"` // Only for provider orgs
+	PayorDetails    *PayorDetails    `json:"payor_details,omitempty"`    // Only for payor orgs
 }
 
 type CreateOrgResponse struct {
@@ -69,9 +130,574 @@ type CreateOrgResponse struct {
 
 func (r CreateOrgResponse) error() error { return r.Err }
 
+type AccountUpdates struct {
+	Username string `json:"username,omitempty"`
+}
+
+// Nothing to it, the caller is whoever the bearer token says they are
+type GetSessionRequest struct{}
+
+type GetSessionResponse struct {
+	Principal Principal `json:"principal"`
+	Err       error     `json:"error,omitempty"`
+}
+
+func (r GetSessionResponse) error() error { return r.Err }
+
+type GetOrgRequest struct {
+	ID string `json:"id"`
+}
+
+type GetOrgResponse struct {
+	Org DetailedOrg `json:"org"`
+	Err error       `json:"error,omitempty"`
+}
+
+func (r GetOrgResponse) error() error { return r.Err }
+
+type UpdateOrgAccountRequest struct {
+	ID      string
+	Updates OrgAccountUpdates `json:"account_updates"`
+}
+
+type UpdateOrgAccountResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r UpdateOrgAccountResponse) error() error { return r.Err }
+
+type UpdateOrgProfileRequest struct {
+	ID      string
+	Updates OrgProfileUpdates `json:"profile_updates"`
+}
+
+type UpdateOrgProfileResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r UpdateOrgProfileResponse) error() error { return r.Err }
+
+type DeleteOrgRequest struct {
+	ID string `json:"id"`
+	// Delete the org even though it still has members, takes an admin of the org
+	Force bool `json:"force"`
+}
+
+type DeleteOrgResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r DeleteOrgResponse) error() error { return r.Err }
+
+// Same as UserStatusRequest for orgs
+type OrgStatusRequest struct {
+	OrgID string `json:"org_id"`
+}
+
+type OrgStatusResponse struct {
+	Account OrgAccount `json:"account"`
+	Err     error      `json:"error,omitempty"`
+}
+
+func (r OrgStatusResponse) error() error { return r.Err }
+
+type OrgAccountUpdates struct {
+	Name            string  `json:"name,omitempty"`
+	MFARequired     *bool   `json:"mfa_required,omitempty"`     // nil leaves it as it is
+	PasskeyRequired *bool   `json:"passkey_required,omitempty"` // Same
+	ParentID        *string `json:"parent_id,omitempty"`        // Same, "" takes it out from under its parent
+}
+
+type OrgProfileUpdates struct {
+	Phone    string `json:"phone,omitempty"`
+	Address  string `json:"address,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+	Website  string `json:"website,omitempty"`
+}
+
 type ProfileUpdates struct {
 	FirstName string `json:"first_name,omitempty"`
 	LastName  string `json:"last_name,omitempty"`
 	Email     string `json:"email,omitempty"`
 	Phone     string `json:"phone,omitempty"`
 }
+
+type ListOrgUsersRequest struct {
+	OrgID string       `json:"org_id"`
+	Query OrgUserQuery `json:"query"`
+}
+
+type ListOrgUsersResponse struct {
+	OrgUserPage
+	Err error `json:"error,omitempty"`
+}
+
+func (r ListOrgUsersResponse) error() error { return r.Err }
+
+type ListChildOrgsRequest struct {
+	OrgID string `json:"org_id"`
+}
+
+type ListChildOrgsResponse struct {
+	Orgs []OrgAccount `json:"orgs"`
+	Err  error        `json:"error,omitempty"`
+}
+
+func (r ListChildOrgsResponse) error() error { return r.Err }
+
+type GetOrgTreeRequest struct {
+	OrgID string `json:"org_id"`
+}
+
+type GetOrgTreeResponse struct {
+	OrgHierarchy
+	Err error `json:"error,omitempty"`
+}
+
+func (r GetOrgTreeResponse) error() error { return r.Err }
+
+type UpdatePayorDetailsRequest struct {
+	ID      string
+	Details PayorDetails `json:"payor_details"`
+}
+
+type UpdatePayorDetailsResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r UpdatePayorDetailsResponse) error() error { return r.Err }
+
+type FindPayorRequest struct {
+	PayerID string `json:"payer_id"`
+}
+
+type FindPayorResponse struct {
+	Org DetailedOrg `json:"org"`
+	Err error       `json:"error,omitempty"`
+}
+
+func (r FindPayorResponse) error() error { return r.Err }
+
+type CreateInviteRequest struct {
+	OrgID string `json:"-"`
+	Email string `json:"email"`
+	Role  string `json:"role,omitempty"` // member when left out
+}
+
+type CreateInviteResponse struct {
+	IssuedInvite
+	Err error `json:"error,omitempty"`
+}
+
+func (r CreateInviteResponse) error() error { return r.Err }
+
+type ListInvitesRequest struct {
+	OrgID string `json:"org_id"`
+}
+
+type ListInvitesResponse struct {
+	Invites []Invite `json:"invites"`
+	Err     error    `json:"error,omitempty"`
+}
+
+func (r ListInvitesResponse) error() error { return r.Err }
+
+type RevokeInviteRequest struct {
+	OrgID    string `json:"org_id"`
+	InviteID string `json:"invite_id"`
+}
+
+type RevokeInviteResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r RevokeInviteResponse) error() error { return r.Err }
+
+type ResendInviteRequest struct {
+	OrgID    string `json:"org_id"`
+	InviteID string `json:"invite_id"`
+}
+
+type ResendInviteResponse struct {
+	IssuedInvite
+	Err error `json:"error,omitempty"`
+}
+
+func (r ResendInviteResponse) error() error { return r.Err }
+
+// Everything but the token is only needed when the caller isn't logged in, i.e.
+// when accepting the invite creates their account.
+type AcceptInviteRequest struct {
+	Token     string `json:"token"`
+	Username  string `json:"username,omitempty"`
+	Password  string `json:"password,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+}
+
+type AcceptInviteResponse struct {
+	UserID string `json:"user_id,omitempty"`
+	Err    error  `json:"error,omitempty"`
+}
+
+func (r AcceptInviteResponse) error() error { return r.Err }
+
+func (r AcceptInviteRequest) rateLimitKeys() (string, string) { return r.Username, "" }
+
+type RequestNetworkRelationshipRequest struct {
+	OrgID           string `json:"-"`
+	PartnerID       string `json:"partner_id"`
+	EffectiveDate   string `json:"effective_date"`
+	TerminationDate string `json:"termination_date,omitempty"` // Open ended when left out
+}
+
+type NetworkRelationshipResponse struct {
+	Relationship NetworkRelationship `json:"relationship"`
+	Err          error               `json:"error,omitempty"`
+}
+
+func (r NetworkRelationshipResponse) error() error { return r.Err }
+
+// For both accepting and terminating a relationship
+type NetworkRelationshipActionRequest struct {
+	OrgID          string `json:"org_id"`
+	RelationshipID string `json:"relationship_id"`
+}
+
+type ListNetworkRequest struct {
+	OrgID string       `json:"org_id"`
+	Query NetworkQuery `json:"query"`
+}
+
+type ListNetworkResponse struct {
+	Relationships []NetworkRelationship `json:"relationships"`
+	Err           error                 `json:"error,omitempty"`
+}
+
+func (r ListNetworkResponse) error() error { return r.Err }
+
+type QueryAuditLogRequest struct {
+	Query AuditQuery `json:"query"`
+}
+
+type QueryAuditLogResponse struct {
+	Events []AuditEvent `json:"events"`
+	Err    error        `json:"error,omitempty"`
+}
+
+func (r QueryAuditLogResponse) error() error { return r.Err }
+
+type SendEmailVerificationRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type SendEmailVerificationResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r SendEmailVerificationResponse) error() error { return r.Err }
+
+type VerifyEmailRequest struct {
+	UserID string `json:"-"`
+	Token  string `json:"token"`
+}
+
+type VerifyEmailResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r VerifyEmailResponse) error() error { return r.Err }
+
+type SendPhoneVerificationRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type SendPhoneVerificationResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r SendPhoneVerificationResponse) error() error { return r.Err }
+
+type VerifyPhoneRequest struct {
+	UserID string `json:"-"`
+	Code   string `json:"code"`
+}
+
+type VerifyPhoneResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r VerifyPhoneResponse) error() error { return r.Err }
+
+type RequestPasswordResetRequest struct {
+	Username string `json:"username,omitempty"`
+	Email    string `json:"email,omitempty"`
+}
+
+// Always 202, whether there was an account to reset or not
+type RequestPasswordResetResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r RequestPasswordResetResponse) error() error { return r.Err }
+
+func (r RequestPasswordResetResponse) StatusCode() int { return http.StatusAccepted }
+
+// Whichever was given, so hammering on one address is limited too
+func (r RequestPasswordResetRequest) rateLimitKeys() (string, string) {
+	if r.Username != "" {
+		return r.Username, ""
+	}
+	return r.Email, ""
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"-"`
+	Password string `json:"password"`
+}
+
+type ResetPasswordResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r ResetPasswordResponse) error() error { return r.Err }
+
+type CompleteMFALoginRequest struct {
+	Token string `json:"token"`
+	Code  string `json:"code"`
+}
+
+type CompleteMFALoginResponse struct {
+	LoginDetails LoginUser `json:"login_details"`
+	Err          error     `json:"error,omitempty"`
+}
+
+func (r CompleteMFALoginResponse) error() error { return r.Err }
+
+type EnrollTOTPRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type EnrollTOTPResponse struct {
+	Enrollment TOTPEnrollment `json:"enrollment"`
+	Err        error          `json:"error,omitempty"`
+}
+
+func (r EnrollTOTPResponse) error() error { return r.Err }
+
+type ConfirmTOTPRequest struct {
+	UserID string `json:"-"`
+	Code   string `json:"code"`
+}
+
+type ConfirmTOTPResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+	Err           error    `json:"error,omitempty"`
+}
+
+func (r ConfirmTOTPResponse) error() error { return r.Err }
+
+// Code is either from the authenticator app or one of the recovery codes
+type DisableMFARequest struct {
+	UserID string `json:"-"`
+	Code   string `json:"code"`
+}
+
+type DisableMFAResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r DisableMFAResponse) error() error { return r.Err }
+
+type ResetMFARequest struct {
+	UserID string `json:"user_id"`
+}
+
+type ResetMFAResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r ResetMFAResponse) error() error { return r.Err }
+
+type BeginPasskeyRegistrationRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type BeginPasskeyRegistrationResponse struct {
+	Ceremony PasskeyCeremony `json:"ceremony"`
+	Err      error           `json:"error,omitempty"`
+}
+
+func (r BeginPasskeyRegistrationResponse) error() error { return r.Err }
+
+// Credential is what navigator.credentials.create() resolved to, as JSON
+type FinishPasskeyRegistrationRequest struct {
+	UserID     string          `json:"-"`
+	Token      string          `json:"token"`
+	Name       string          `json:"name,omitempty"`
+	Credential json.RawMessage `json:"credential"`
+}
+
+type FinishPasskeyRegistrationResponse struct {
+	Passkey Passkey `json:"passkey"`
+	Err     error   `json:"error,omitempty"`
+}
+
+func (r FinishPasskeyRegistrationResponse) error() error { return r.Err }
+
+type ListPasskeysRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type ListPasskeysResponse struct {
+	Passkeys []Passkey `json:"passkeys"`
+	Err      error     `json:"error,omitempty"`
+}
+
+func (r ListPasskeysResponse) error() error { return r.Err }
+
+type DeletePasskeyRequest struct {
+	UserID    string `json:"user_id"`
+	PasskeyID string `json:"passkey_id"`
+}
+
+type DeletePasskeyResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r DeletePasskeyResponse) error() error { return r.Err }
+
+type BeginPasskeyLoginRequest struct {
+	OrgID string `json:"org_id"`
+}
+
+type BeginPasskeyLoginResponse struct {
+	Ceremony PasskeyCeremony `json:"ceremony"`
+	Err      error           `json:"error,omitempty"`
+}
+
+func (r BeginPasskeyLoginResponse) error() error { return r.Err }
+
+func (r BeginPasskeyLoginRequest) rateLimitKeys() (string, string) { return "", r.OrgID }
+
+// Credential is what navigator.credentials.get() resolved to, as JSON
+type PasskeyLoginRequest struct {
+	OrgID      string          `json:"-"`
+	Token      string          `json:"token"`
+	Credential json.RawMessage `json:"credential"`
+}
+
+type PasskeyLoginResponse struct {
+	LoginDetails LoginUser `json:"login_details"`
+	Err          error     `json:"error,omitempty"`
+}
+
+func (r PasskeyLoginResponse) error() error { return r.Err }
+
+func (r PasskeyLoginRequest) rateLimitKeys() (string, string) { return "", r.OrgID }
+
+type RequestMagicLinkRequest struct {
+	OrgID    string `json:"-"`
+	Username string `json:"username,omitempty"`
+	Email    string `json:"email,omitempty"`
+}
+
+// Always 202, whether there was an account to email or not
+type RequestMagicLinkResponse struct {
+	OK  string `json:"ok"`
+	Err error  `json:"error,omitempty"`
+}
+
+func (r RequestMagicLinkResponse) error() error { return r.Err }
+
+func (r RequestMagicLinkResponse) StatusCode() int { return http.StatusAccepted }
+
+func (r RequestMagicLinkRequest) rateLimitKeys() (string, string) {
+	if r.Username != "" {
+		return r.Username, r.OrgID
+	}
+	return r.Email, r.OrgID
+}
+
+// Answered with a LoginResponse, same as logging in with a password
+type MagicLinkLoginRequest struct {
+	OrgID string `json:"-"`
+	Token string `json:"-"`
+}
+
+func (r MagicLinkLoginRequest) rateLimitKeys() (string, string) { return "", r.OrgID }
+
+type RequestSMSCodeRequest struct {
+	OrgID    string `json:"-"`
+	Username string `json:"username"`
+}
+
+type RequestSMSCodeResponse struct {
+	Challenge SMSCodeChallenge `json:"challenge"`
+	Err       error            `json:"error,omitempty"`
+}
+
+func (r RequestSMSCodeResponse) error() error { return r.Err }
+
+func (r RequestSMSCodeRequest) rateLimitKeys() (string, string) { return r.Username, r.OrgID }
+
+// Answered with a LoginResponse, same as logging in with a password
+type SMSCodeLoginRequest struct {
+	OrgID string `json:"-"`
+	Token string `json:"token"`
+	Code  string `json:"code"`
+}
+
+func (r SMSCodeLoginRequest) rateLimitKeys() (string, string) { return "", r.OrgID }
+
+type LoginWithoutOrgRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type LoginWithoutOrgResponse struct {
+	Selection OrgSelection `json:"selection"`
+	Err       error        `json:"error,omitempty"`
+}
+
+func (r LoginWithoutOrgResponse) error() error { return r.Err }
+
+func (r LoginWithoutOrgRequest) rateLimitKeys() (string, string) { return r.Username, "" }
+
+// Answered with a LoginResponse, same as logging in with a password
+type ChooseLoginOrgRequest struct {
+	Token string `json:"token"`
+	OrgID string `json:"org_id"`
+}
+
+func (r ChooseLoginOrgRequest) rateLimitKeys() (string, string) { return "", r.OrgID }
+
+// Answered with a LoginResponse too, the new session is for the org
+type SwitchOrgRequest struct {
+	OrgID string `json:"org_id"`
+}
+
+// Nothing to it, the orgs are the caller's
+type ListMyOrgsRequest struct{}
+
+type ListMyOrgsResponse struct {
+	Orgs []OrgMembership `json:"orgs"`
+	Err  error           `json:"error,omitempty"`
+}
+
+func (r ListMyOrgsResponse) error() error { return r.Err }
@@ -0,0 +1,74 @@
+package accountsrv
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/log"
+	"github.com/gofrs/uuid"
+)
+
+// The header we read the caller's request ID from and echo it back on
+const RequestIDHeader = "X-Request-ID"
+
+// Anything longer than this coming in from the outside world gets thrown away
+// and replaced with one we generate, so nobody can stuff our logs with junk.
+const maxRequestIDLength = 128
+
+// Unexported type for the context key so no other package can collide with it
+type requestIDKey struct{}
+
+// Returns a copy of the context carrying the given request ID
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// Pulls the request ID out of the context, or an empty string if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Decorates a logger with the request ID found in the context (if any), this is
+// how the service and repo loggers get the request ID on every line they write
+// without each method having to remember to add it.
+func loggerWithRequestID(ctx context.Context, logger log.Logger) log.Logger {
+	if id := RequestIDFromContext(ctx); id != "" {
+		return log.With(logger, "request_id", id)
+	}
+	return logger
+}
+
+// HTTP middleware that accepts the caller's X-Request-ID (or generates one if it
+// is missing or looks bogus), stashes it in the request context for the layers below
+// and echoes it back in the response headers.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		respWriter.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(respWriter, req.WithContext(ContextWithRequestID(req.Context(), id)))
+	})
+}
+
+func newRequestID() string {
+	uuid, _ := uuid.NewV4()
+	return uuid.String()
+}
+
+// Only allow printable ASCII without spaces, which covers UUIDs and most tracing IDs
+// while keeping anything that could break a logfmt line out.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c <= ' ' || c > '~' || c == '"' || c == '=' {
+			return false
+		}
+	}
+	return true
+}
@@ -1,10 +1,19 @@
 package accountsrv
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/gofrs/uuid"
 )
 
@@ -18,27 +27,123 @@ type Service interface {
 	DeleteUserAccount(ctx context.Context, id string) error
 	UpdateUserProfile(ctx context.Context, accountID string, updates map[string]interface{}) error
 	GetUserAccount(ctx context.Context, id string) (UserAccount, error)
-	Login(ctx context.Context, orgID string, username string, password string) (LoginUser, error)
+	GetDetailedUser(ctx context.Context, id string, includeOrgs bool) (DetailedUser, error)
+	UpdateUserAccount(ctx context.Context, id string, updates map[string]interface{}) error
+	SuspendUser(ctx context.Context, userID string) (UserAccount, error)
+	DeactivateUser(ctx context.Context, userID string) (UserAccount, error)
+	ReactivateUser(ctx context.Context, userID string) (UserAccount, error)
+	RestoreUser(ctx context.Context, userID string) (UserAccount, error)
+	Login(ctx context.Context, orgID string, username string, password string) (LoginUser, *MFAChallenge, error)
+	CompleteMFALogin(ctx context.Context, challengeToken string, code string) (LoginUser, error)
+	Authenticate(ctx context.Context, token string) (Principal, error)
+	LoginWithoutOrg(ctx context.Context, username string, password string) (OrgSelection, error)
+	ChooseLoginOrg(ctx context.Context, token string, orgID string) (LoginUser, *MFAChallenge, error)
+	SwitchOrg(ctx context.Context, orgID string) (LoginUser, *MFAChallenge, error)
+	ListMyOrgs(ctx context.Context) ([]OrgMembership, error)
 
-	CreateOrg(ctx context.Context, name string, orgType string, phone string, address string, timezone string, website string) (string, error)
+	CreateOrg(ctx context.Context, name string, orgType string, parentID string, phone string, address string, timezone string, website string, providerDetails *ProviderDetails, payorDetails *PayorDetails) (string, error)
+	GetOrg(ctx context.Context, id string) (DetailedOrg, error)
+	UpdateOrgAccount(ctx context.Context, id string, updates map[string]interface{}) error
+	UpdateOrgProfile(ctx context.Context, id string, updates map[string]interface{}) error
+	DeleteOrg(ctx context.Context, id string, force bool) error
+	SuspendOrg(ctx context.Context, orgID string) (OrgAccount, error)
+	DeactivateOrg(ctx context.Context, orgID string) (OrgAccount, error)
+	ReactivateOrg(ctx context.Context, orgID string) (OrgAccount, error)
+	RestoreOrg(ctx context.Context, orgID string) (OrgAccount, error)
+	ListOrgUsers(ctx context.Context, orgID string, query OrgUserQuery) (OrgUserPage, error)
+	ListChildOrgs(ctx context.Context, orgID string) ([]OrgAccount, error)
+	GetOrgTree(ctx context.Context, orgID string) (OrgHierarchy, error)
+	UpdatePayorDetails(ctx context.Context, orgID string, details PayorDetails) error
+	FindPayor(ctx context.Context, payerID string) (DetailedOrg, error)
+
+	CreateInvite(ctx context.Context, orgID string, email string, role string) (IssuedInvite, error)
+	ListInvites(ctx context.Context, orgID string) ([]Invite, error)
+	RevokeInvite(ctx context.Context, orgID string, inviteID string) error
+	ResendInvite(ctx context.Context, orgID string, inviteID string) (IssuedInvite, error)
+	AcceptInvite(ctx context.Context, token string, username string, password string, firstName string, lastName string, phone string) (string, error)
+
+	RequestNetworkRelationship(ctx context.Context, orgID string, partnerID string, effectiveDate string, terminationDate string) (NetworkRelationship, error)
+	AcceptNetworkRelationship(ctx context.Context, orgID string, relationshipID string) (NetworkRelationship, error)
+	TerminateNetworkRelationship(ctx context.Context, orgID string, relationshipID string) (NetworkRelationship, error)
+	ListPayors(ctx context.Context, providerID string, query NetworkQuery) ([]NetworkRelationship, error)
+	ListProviders(ctx context.Context, payorID string, query NetworkQuery) ([]NetworkRelationship, error)
+
+	QueryAuditLog(ctx context.Context, query AuditQuery) ([]AuditEvent, error)
+
+	SendEmailVerification(ctx context.Context, userID string) error
+	VerifyEmail(ctx context.Context, userID string, token string) error
+	SendPhoneVerification(ctx context.Context, userID string) error
+	VerifyPhone(ctx context.Context, userID string, code string) error
+
+	RequestPasswordReset(ctx context.Context, username string, email string) error
+	ResetPassword(ctx context.Context, token string, password string) error
+
+	EnrollTOTP(ctx context.Context, userID string) (TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userID string, code string) ([]string, error)
+	DisableMFA(ctx context.Context, userID string, code string) error
+	ResetMFA(ctx context.Context, userID string) error
+
+	BeginPasskeyRegistration(ctx context.Context, userID string) (PasskeyCeremony, error)
+	FinishPasskeyRegistration(ctx context.Context, userID string, token string, name string, credential []byte) (Passkey, error)
+	ListPasskeys(ctx context.Context, userID string) ([]Passkey, error)
+	DeletePasskey(ctx context.Context, userID string, passkeyID string) error
+	BeginPasskeyLogin(ctx context.Context, orgID string) (PasskeyCeremony, error)
+	PasskeyLogin(ctx context.Context, orgID string, token string, credential []byte) (LoginUser, error)
+
+	RequestMagicLink(ctx context.Context, orgID string, username string, email string) error
+	MagicLinkLogin(ctx context.Context, orgID string, token string) (LoginUser, *MFAChallenge, error)
+	RequestSMSCode(ctx context.Context, orgID string, username string) (SMSCodeChallenge, error)
+	SMSCodeLogin(ctx context.Context, orgID string, token string, code string) (LoginUser, *MFAChallenge, error)
+}
+
+// What the service needs to know besides its repository and logger
+type ServiceConfig struct {
+	SigningKey []byte             // What the tokens we hand out (e.g. invites) are signed with
+	Mailer     Mailer             // How emails (e.g. verifying an address) get sent
+	SMSSender  SMSSender          // How text messages (login codes) get sent, nil turns SMS login off
+	AppURL     string             // Where the links in those emails point, e.g. https://app.example.com
+	MFAIssuer  string             // What authenticator apps list TOTP codes under, accountsrv when empty
+	WebAuthn   *webauthn.WebAuthn // The relying party passkeys are registered with, nil turns passkeys off
+	AuditSink  AuditSink          // Where QueryAuditLog reads from (AuditMiddleware writes to it), nil when there's no audit trail
 }
 
 // The properties the service will contain
 type service struct {
-	repository Repository // To interface with the DB, this is an interface that will handle all the DB interaction, the service just needs to know this is the "persistance store"
-	logger     log.Logger // To log and see what's going on inside the service
+	repository Repository         // To interface with the DB, this is an interface that will handle all the DB interaction, the service just needs to know this is the "persistance store"
+	logger     log.Logger         // To log and see what's going on inside the service
+	signingKey []byte             // What the tokens we hand out (e.g. invites) are signed with
+	mailer     Mailer             // To send emails
+	smsSender  SMSSender          // To send text messages, nil when there's no way to
+	appURL     string             // Where the links in emails point
+	mfaIssuer  string             // What authenticator apps list our codes under
+	webAuthn   *webauthn.WebAuthn // For passkeys, nil when they're off
+	auditSink  AuditSink          // To read the audit trail, nil when there isn't one
 }
 
 // Implement the Service interface using the service struct and methods defined for it.
 // What's genius is that the repository field is itself an interface, and the methods
 // defined for the service struct actually utilize the methods of the Repository interface
 // to implement the methods of the Service interface... amazing.
-func NewService(rep Repository, logger log.Logger) Service {
+func NewService(rep Repository, logger log.Logger, config ServiceConfig) Service {
+	mfaIssuer := config.MFAIssuer
+	if mfaIssuer == "" {
+		mfaIssuer = "accountsrv"
+	}
+
+	go dummyCredential()
+
 	// Return pointer to a service struct, which will be the concrete type implementing
 	// the Service interface.
 	return &service{
 		repository: rep,
 		logger:     logger,
+		signingKey: config.SigningKey,
+		mailer:     config.Mailer,
+		smsSender:  config.SMSSender,
+		appURL:     strings.TrimSuffix(config.AppURL, "/"),
+		mfaIssuer:  mfaIssuer,
+		webAuthn:   config.WebAuthn,
+		auditSink:  config.AuditSink,
 	}
 }
 
@@ -54,22 +159,28 @@ of the work. Cool idea.
 // we can still use the value receiver type. However, this means this method is operating on
 // a COPY of the service struct rather than the "actual" underlying service struct
 func (s service) CreateUser(ctx context.Context, orgID string, username string, password string, orgType string, firstName string, lastName string, email string, phone string) (string, error) {
-	logger := log.With(s.logger, "method", "CreateUser")
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "CreateUser")
+
+	orgAccount, err := s.repository.GetOrgAccount(ctx, orgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return "", err
+	}
+
+	// The user takes on the type of the org they're created in, saying otherwise is an error
+	if orgType == "" {
+		orgType = orgAccount.Type
+	}
 
 	uuid, _ := uuid.NewV4()
 	id := uuid.String()
 	user := UserAccount{
 		ID:       id,
 		Username: username,
-		Password: password,
 		OrgType:  orgType,
 	}
 
-	// Use the respository interface's implementation of create user to actually do
-	// the portion of the business logic, this implementation just abstracts that away
-	// and provides context and orchastration.. very neat.
-	if err := s.repository.CreateUserAccount(ctx, user); err != nil {
-		level.Error(logger).Log("err", err)
+	if err := checkUserOrgType(user, orgAccount); err != nil {
 		return "", err
 	}
 
@@ -81,25 +192,83 @@ func (s service) CreateUser(ctx context.Context, orgID string, username string,
 		Phone:     phone,
 	}
 
-	if err := s.repository.CreateUserProfile(ctx, profile); err != nil {
-		s.repository.DeleteUserAccount(ctx, id)
+	if err := s.newUser(ctx, user, profile, password); err != nil {
 		level.Error(logger).Log("err", err)
 		return "", err
 	}
 
-	if err := s.repository.AssociateUserToOrg(ctx, id, orgID); err != nil {
-		s.repository.DeleteUserAccount(ctx, id)
+	// Whoever joins an org first becomes its admin. After that only an admin can
+	// add users here (as regular members), anyone else has to be invited. Which
+	// one this is gets decided in the same transaction as joining, so two users
+	// can't both be first. Either way the org's type has to have the role.
+	err = checkOrgRole(orgAccount, RoleAdmin)
+	if err == nil {
+		err = s.repository.AssociateFirstUserToOrg(ctx, id, orgID)
+	}
+	if errors.Is(err, ErrOrgHasMembers) {
+		if s.requireOrgAdmin(ctx, orgID) != nil {
+			err = fmt.Errorf("%w: only an admin can add users to an organization that has members, ask one for an invite", ErrForbidden)
+		} else if err = checkOrgRole(orgAccount, RoleMember); err == nil {
+			err = s.repository.AssociateUserToOrg(ctx, id, orgID, RoleMember)
+		}
+	}
+	if err != nil {
+		s.repository.PurgeUserAccount(ctx, id)
 		level.Error(logger).Log("err", err)
 		return "", err
 	}
 
 	logger.Log("created user", id)
 
+	if email != "" {
+		if err := s.sendEmailVerification(ctx, profile); err != nil {
+			level.Error(logger).Log("err", err)
+		}
+	}
+
 	return id, nil
 }
 
+// Creates the user's account, profile and credential, all or nothing. Putting them
+// in an org is up to the caller.
+func (s service) newUser(ctx context.Context, user UserAccount, profile UserProfile, password string) error {
+	if err := checkPasswordPolicy(password, user.Username, profile.Email); err != nil {
+		return err
+	}
+	credential, err := newCredential(user.ID, password)
+	if err != nil {
+		return err
+	}
+
+	// Use the respository interface's implementation of create user to actually do
+	// the portion of the business logic, this implementation just abstracts that away
+	// and provides context and orchastration.. very neat.
+	if err := s.repository.CreateUserAccount(ctx, user); err != nil {
+		return err
+	}
+
+	if err := s.repository.CreateUserProfile(ctx, profile); err != nil {
+		s.repository.PurgeUserAccount(ctx, user.ID)
+		return err
+	}
+
+	if err := s.repository.CreateCredential(ctx, credential); err != nil {
+		s.repository.PurgeUserAccount(ctx, user.ID)
+		return err
+	}
+
+	return nil
+}
+
+// The account isn't really deleted, it's marked deleted (which logs them out and
+// hides them from lookups) and RestoreUser can bring it back. Takes the user
+// themselves or an admin, see requireSelfOrUserAdmin.
 func (s service) DeleteUserAccount(ctx context.Context, id string) error {
-	logger := log.With(s.logger, "method", "DeleteUserAccount")
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "DeleteUserAccount")
+
+	if err := s.requireSelfOrUserAdmin(ctx, id); err != nil {
+		return err
+	}
 
 	// Use the respository interface's implementation of create user to actually do
 	// the portion of the business logic, this implementation just abstracts that away
@@ -114,9 +283,14 @@ func (s service) DeleteUserAccount(ctx context.Context, id string) error {
 	return nil
 }
 
-// Method for service struct for the Service interface to implement
+// Method for service struct for the Service interface to implement. Same as
+// DeleteUserAccount for who gets to see it.
 func (s service) GetUserAccount(ctx context.Context, id string) (UserAccount, error) {
-	logger := log.With(s.logger, "method", "GetUser")
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "GetUser")
+
+	if err := s.requireSelfOrUserAdmin(ctx, id); err != nil {
+		return UserAccount{}, err
+	}
 
 	// Same thing here, using the repository property's methods to actually do the
 	// fetching while this method just is kind of a control flow method.
@@ -132,116 +306,2858 @@ func (s service) GetUserAccount(ctx context.Context, id string) (UserAccount, er
 	return account, nil
 }
 
-func (s service) Login(ctx context.Context, orgID string, username string, password string) (LoginUser, error) {
-	logger := log.With(s.logger, "method", "Login")
+// Gets the user's account and profile together, plus the orgs they're a member
+// of when asked for. Same as DeleteUserAccount for who gets to see it.
+func (s service) GetDetailedUser(ctx context.Context, id string, includeOrgs bool) (DetailedUser, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "GetDetailedUser")
+
+	if err := s.requireSelfOrUserAdmin(ctx, id); err != nil {
+		return DetailedUser{}, err
+	}
+
+	account, err := s.repository.GetUserAccount(ctx, id)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return DetailedUser{}, err
+	}
+
+	profile, err := s.repository.GetUserProfile(ctx, id)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return DetailedUser{}, err
+	}
+
+	detailedUser := DetailedUser{
+		Account: account,
+		Profile: profile,
+	}
+
+	if includeOrgs {
+		detailedUser.Orgs, err = s.repository.GetUserOrgs(ctx, id)
+		if err != nil {
+			level.Error(logger).Log("err", err)
+			return DetailedUser{}, err
+		}
+	}
+
+	logger.Log("Get detailed user", id)
+
+	return detailedUser, nil
+}
+
+// Updates account-level fields (e.g. the username), profile fields go through
+// UpdateUserProfile. Same as DeleteUserAccount for who gets to do it.
+func (s service) UpdateUserAccount(ctx context.Context, id string, updates map[string]interface{}) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "UpdateUserAccount")
+
+	if err := s.requireSelfOrUserAdmin(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repository.UpdateUserAccount(ctx, id, updates); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("updated user account", id)
+
+	return nil
+}
+
+// Suspending a user logs them out everywhere and keeps them out until they're
+// reactivated, Login tells them why (see ErrAccountSuspended). Like ResetMFA it
+// takes an admin of the org the caller is logged in to, the user has to be a
+// member of it and it can't be done to yourself. The same goes for the other
+// status changes below.
+func (s service) SuspendUser(ctx context.Context, userID string) (UserAccount, error) {
+	return s.changeUserStatus(ctx, "SuspendUser", userID, suspendAccount)
+}
+
+// Same as SuspendUser, except logging in fails as if they weren't there
+func (s service) DeactivateUser(ctx context.Context, userID string) (UserAccount, error) {
+	return s.changeUserStatus(ctx, "DeactivateUser", userID, deactivateAccount)
+}
+
+// Undoes SuspendUser or DeactivateUser. Their old sessions stay revoked, they
+// have to log in again.
+func (s service) ReactivateUser(ctx context.Context, userID string) (UserAccount, error) {
+	return s.changeUserStatus(ctx, "ReactivateUser", userID, reactivateAccount)
+}
+
+// Undoes DeleteUserAccount, they come back with the profile, password and
+// memberships they had
+func (s service) RestoreUser(ctx context.Context, userID string) (UserAccount, error) {
+	return s.changeUserStatus(ctx, "RestoreUser", userID, restoreAccount)
+}
 
-	// TODO: check if org even exists first... ??
+func (s service) changeUserStatus(ctx context.Context, method string, userID string, change statusChange) (UserAccount, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", method)
 
-	// Get the user account by their username and password
-	account, err := s.repository.GetAccountByLoginCredentials(ctx, username, password)
+	if err := s.requireUserAdmin(ctx, userID); err != nil {
+		return UserAccount{}, err
+	}
 
+	account, err := s.repository.GetUserAccountAnyStatus(ctx, userID)
 	if err != nil {
 		level.Error(logger).Log("err", err)
-		return LoginUser{}, err
+		return UserAccount{}, err
+	}
+	if err := change.check("user", account.Status); err != nil {
+		return UserAccount{}, err
 	}
 
-	if err := s.repository.ConfirmUserToOrgAssociation(ctx, account.ID, orgID); err != nil {
+	if err := s.repository.SetUserStatus(ctx, userID, change); err != nil {
 		level.Error(logger).Log("err", err)
-		return LoginUser{}, err
+		return UserAccount{}, err
+	}
+	// Sessions don't work for a user who isn't active anyway, revoking them
+	// stops them coming back when the user does
+	if change.to != UserStatusActive {
+		if err := s.repository.RevokeUserSessions(ctx, userID); err != nil {
+			level.Error(logger).Log("err", err)
+		}
+	}
+
+	logger.Log("user status", userID, "from", account.Status, "to", change.to)
+
+	return s.repository.GetUserAccountAnyStatus(ctx, userID)
+}
+
+// When the account has MFA, or the org requires it, the password only gets the
+// caller as far as an MFAChallenge, which CompleteMFALogin takes the rest of the
+// way. Otherwise they're logged in there and then. Orgs that require passkeys
+// don't take passwords at all, see PasskeyLogin.
+func (s service) Login(ctx context.Context, orgID string, username string, password string) (LoginUser, *MFAChallenge, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "Login")
+
+	// Whatever goes wrong, an unknown username, the wrong password or an org the
+	// user isn't in, the caller hears the same errInvalidLogin. Which it was only
+	// goes in the log. The one exception is the right password for a suspended
+	// user, who's told so (ErrAccountSuspended).
+	account, err := s.checkLogin(ctx, logger, username, password)
+	if err != nil {
+		return LoginUser{}, nil, err
+	}
+
+	loginUser, challenge, err := s.loginToOrg(ctx, account, orgID, sessionAuth{Method: AuthMethodPassword})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return LoginUser{}, nil, err
+	}
+	if challenge != nil {
+		logger.Log("MFA challenge", account.ID)
+		return LoginUser{}, challenge, nil
+	}
+
+	logger.Log("Login user", account.ID)
+
+	return loginUser, nil, nil
+}
+
+// The username and password part of logging in, the account comes back when the
+// password checks out and the account is active. A suspended one is
+// ErrAccountSuspended, anything else is errInvalidLogin, with what it really was
+// only in the log.
+func (s service) checkLogin(ctx context.Context, logger log.Logger, username string, password string) (UserAccount, error) {
+	account, err := s.repository.GetAccountByUsernameAnyStatus(ctx, username)
+	if err != nil {
+		// Still read a credential (there's none for the nil UUID) and check the
+		// password against something, so an unknown username takes as long to
+		// turn down as a wrong password
+		s.repository.GetCredential(ctx, uuid.Nil.String())
+		dummyCredential().verify(password)
+		level.Error(logger).Log("err", err)
+		return UserAccount{}, errInvalidLogin
+	}
+
+	if err := s.checkPassword(ctx, account.ID, password); err != nil {
+		level.Error(logger).Log("err", err)
+		return UserAccount{}, errInvalidLogin
+	}
+
+	// Only now they've shown they're who they say they are, otherwise anyone
+	// could find out who's suspended
+	switch account.Status {
+	case UserStatusActive:
+		return account, nil
+	case UserStatusSuspended:
+		level.Error(logger).Log("user", account.ID, "err", ErrAccountSuspended)
+		return UserAccount{}, ErrAccountSuspended
+	default:
+		level.Error(logger).Log("user", account.ID, "err", "account is "+account.Status)
+		return UserAccount{}, errInvalidLogin
+	}
+}
+
+// Takes a user who proved who they are (with a password, magic link, SMS code or
+// passkey) the rest of the way into the org: they have to be a member of it, and
+// the org can want a passkey instead or a second factor on top. Hands back an
+// MFAChallenge rather than logging them in when it wants the latter.
+func (s service) loginToOrg(ctx context.Context, account UserAccount, orgID string, auth sessionAuth) (LoginUser, *MFAChallenge, error) {
+	detailedOrg, err := s.orgToLogInTo(ctx, account, orgID)
+	if err != nil {
+		return LoginUser{}, nil, err
+	}
+
+	// They checked out, but the org wants passkeys and nothing else
+	if detailedOrg.Account.PasskeyRequired && auth.Method != AuthMethodPasskey {
+		return LoginUser{}, nil, errPasskeyRequired
+	}
+
+	// No point asking for a second factor they've already given
+	if !auth.MFA {
+		factor, err := s.repository.GetTOTPFactor(ctx, account.ID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return LoginUser{}, nil, err
+		}
+		mfaEnabled := err == nil && factor.ConfirmedAt != nil
+
+		if mfaEnabled || detailedOrg.Account.MFARequired {
+			challenge, err := s.createLoginChallenge(ctx, account, orgID, auth.Method, !mfaEnabled)
+			if err != nil {
+				return LoginUser{}, nil, err
+			}
+
+			return LoginUser{}, &challenge, nil
+		}
+	}
+
+	loginUser, err := s.finishLogin(ctx, account, detailedOrg, auth)
+	if err != nil {
+		return LoginUser{}, nil, err
+	}
+
+	return loginUser, nil, nil
+}
+
+// The org the user is logging in to, as long as they can. Not being a member, the
+// org not existing and the org being the wrong type all come back as
+// errInvalidLogin, so logging in can't be used to find out who's in which org.
+func (s service) orgToLogInTo(ctx context.Context, account UserAccount, orgID string) (DetailedOrg, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "orgToLogInTo")
+
+	if err := s.repository.ConfirmUserToOrgAssociation(ctx, account.ID, orgID); err != nil {
+		level.Error(logger).Log("user", account.ID, "org", orgID, "err", err)
+		return DetailedOrg{}, errInvalidLogin
+	}
+
+	detailedOrg, err := s.getDetailedOrg(ctx, orgID)
+	if err != nil {
+		level.Error(logger).Log("org", orgID, "err", err)
+		return DetailedOrg{}, errInvalidLogin
+	}
+
+	if err := checkUserOrgType(account, detailedOrg.Account); err != nil {
+		level.Error(logger).Log("user", account.ID, "org", orgID, "err", err)
+		return DetailedOrg{}, errInvalidLogin
 	}
 
+	return detailedOrg, nil
+}
+
+// Finishes logging in a user whose password (and second factor if they need one)
+// checked out: records the login and starts their session.
+func (s service) finishLogin(ctx context.Context, account UserAccount, detailedOrg DetailedOrg, auth sessionAuth) (LoginUser, error) {
 	if err := s.repository.UpdateUserProfile(ctx, account.ID, map[string]interface{}{
-		"last_login": "DEFAULT",
+		"last_login": setToDefault,
 	}); err != nil {
-		level.Error(logger).Log("err", err)
 		return LoginUser{}, err
 	}
 
 	profile, err := s.repository.GetUserProfile(ctx, account.ID)
+	if err != nil {
+		return LoginUser{}, err
+	}
+
+	sessionToken, err := s.createSession(ctx, account.ID, detailedOrg.Account.ID, auth)
+	if err != nil {
+		return LoginUser{}, err
+	}
+
+	// They're logging in to the org, so they see its time
+	detailedUser := DetailedUser{
+		Account: account,
+		Profile: profile,
+	}.in(detailedOrg.Profile.location())
+
+	return LoginUser{
+		User:    detailedUser,
+		Org:     detailedOrg,
+		Session: sessionToken,
+	}, nil
+}
+
+// The second step of logging in, answering the MFAChallenge Login handed back
+// with a code from the user's authenticator app or one of their recovery codes.
+// If the challenge came with an enrollment this confirms it too, and the
+// recovery codes for it come back with the login.
+func (s service) CompleteMFALogin(ctx context.Context, challengeToken string, code string) (LoginUser, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "CompleteMFALogin")
+
+	tokenHash := hashToken(challengeToken)
+	challenge, err := s.repository.GetLoginChallenge(ctx, tokenHash)
+	if err != nil {
+		return LoginUser{}, err
+	}
+
+	factor, err := s.repository.GetTOTPFactor(ctx, challenge.UserID)
+	if errors.Is(err, ErrNotFound) {
+		// MFA was reset since the challenge was handed out
+		return LoginUser{}, errInvalidMFAChallenge
+	}
 	if err != nil {
 		level.Error(logger).Log("err", err)
 		return LoginUser{}, err
 	}
 
-	orgAccount, err := s.repository.GetOrgAccount(ctx, orgID)
+	var recoveryCodes []string
+	if factor.ConfirmedAt == nil {
+		recoveryCodes, err = s.confirmTOTP(ctx, factor, code)
+	} else {
+		err = s.checkMFACode(ctx, factor, code)
+	}
+	if err != nil {
+		if err := s.repository.RecordFailedChallenge(ctx, tokenHash); err != nil {
+			level.Error(logger).Log("err", err)
+		}
+		return LoginUser{}, err
+	}
+
+	if err := s.repository.UseLoginChallenge(ctx, tokenHash); err != nil {
+		return LoginUser{}, err
+	}
+
+	account, err := s.repository.GetUserAccount(ctx, challenge.UserID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return LoginUser{}, err
+	}
+	detailedOrg, err := s.getDetailedOrg(ctx, challenge.OrgID)
 	if err != nil {
 		level.Error(logger).Log("err", err)
 		return LoginUser{}, err
 	}
 
-	orgProfile, err := s.repository.GetOrgProfile(ctx, orgID)
+	loginUser, err := s.finishLogin(ctx, account, detailedOrg, sessionAuth{Method: challenge.AuthMethod, MFA: true})
 	if err != nil {
 		level.Error(logger).Log("err", err)
 		return LoginUser{}, err
 	}
+	loginUser.RecoveryCodes = recoveryCodes
 
 	logger.Log("Login user", account.ID)
 
-	detailedUser := DetailedUser{
-		Account: account,
-		Profile: profile,
+	return loginUser, nil
+}
+
+// Hands out an MFAChallenge for the user logging in to the org (however they got
+// that far, authMethod). With enroll (the org requires MFA but the user doesn't
+// have it yet) it comes with a new TOTP secret for them to set up.
+func (s service) createLoginChallenge(ctx context.Context, account UserAccount, orgID string, authMethod string, enroll bool) (MFAChallenge, error) {
+	var enrollment *TOTPEnrollment
+	if enroll {
+		e, err := s.newTOTPEnrollment(ctx, account)
+		if err != nil {
+			return MFAChallenge{}, err
+		}
+		enrollment = &e
 	}
 
-	detailedOrg := DetailedOrg{
-		Account: orgAccount,
-		Profile: orgProfile,
+	token, err := newSessionToken()
+	if err != nil {
+		return MFAChallenge{}, err
 	}
 
-	return LoginUser{
-		detailedUser,
-		detailedOrg,
-	}, nil
-}
+	challenge := LoginChallenge{
+		TokenHash:  hashToken(token),
+		UserID:     account.ID,
+		OrgID:      orgID,
+		AuthMethod: authMethod,
+		ExpiresAt:  time.Now().Add(mfaChallengeTTL).UTC(),
+	}
+	if err := s.repository.CreateLoginChallenge(ctx, challenge); err != nil {
+		return MFAChallenge{}, err
+	}
 
-func (s service) UpdateUserProfile(ctx context.Context, accountID string, updates map[string]interface{}) error {
-	logger := log.With(s.logger, "method", "UpdateUserProfile")
+	return MFAChallenge{Token: token, ExpiresAt: challenge.ExpiresAt, Enrollment: enrollment}, nil
+}
 
-	err := s.repository.UpdateUserProfile(ctx, accountID, updates)
+// Checks the password against the user's credential, keeping count of the
+// failed attempts. The counting happens after we've answered, otherwise a wrong
+// password would take longer to turn down than an unknown username. A
+// credential that isn't hashed the way we'd hash it today gets rehashed while we
+// have the password at hand.
+func (s service) checkPassword(ctx context.Context, userID string, password string) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "checkPassword")
 
+	credential, err := s.repository.GetCredential(ctx, userID)
 	if err != nil {
+		// Same as an unknown username, take as long as a wrong password would
+		dummyCredential().verify(password)
 		return err
 	}
 
-	logger.Log("updated user profile", accountID)
+	ok, rehash := credential.verify(password)
+	if !ok {
+		go func(ctx context.Context) {
+			if err := s.repository.RecordFailedLogin(ctx, userID); err != nil {
+				level.Error(logger).Log("err", err)
+			}
+		}(context.WithoutCancel(ctx))
+		return errors.New("incorrect password")
+	}
+
+	if rehash {
+		if updated, err := newCredential(userID, password); err == nil {
+			err = s.repository.UpdateCredential(ctx, updated)
+			if err != nil {
+				level.Error(logger).Log("err", err)
+			}
+		}
+	} else if credential.FailedAttempts > 0 {
+		if err := s.repository.ResetFailedLogins(ctx, userID); err != nil {
+			level.Error(logger).Log("err", err)
+		}
+	}
 
 	return nil
 }
 
-func (s service) CreateOrg(ctx context.Context, name string, orgType string, phone string, address string, timezone string, website string) (string, error) {
-	logger := log.With(s.logger, "method", "CreateOrg")
+// Starts a session for the user within the org and hands back the token for it
+func (s service) createSession(ctx context.Context, userID string, orgID string, auth sessionAuth) (SessionToken, error) {
+	token, err := newSessionToken()
+	if err != nil {
+		return SessionToken{}, err
+	}
 
 	uuid, _ := uuid.NewV4()
-	id := uuid.String()
+	session := Session{
+		ID:         uuid.String(),
+		TokenHash:  hashToken(token),
+		UserID:     userID,
+		OrgID:      orgID,
+		AuthMethod: auth.Method,
+		MFA:        auth.MFA,
+		ExpiresAt:  time.Now().Add(sessionTTL).UTC(),
+	}
 
-	orgAccount := OrgAccount{
-		ID:   id,
-		Name: name,
-		Type: orgType,
+	if err := s.repository.CreateSession(ctx, session); err != nil {
+		return SessionToken{}, err
+	}
+
+	return SessionToken{Token: token, ExpiresAt: session.ExpiresAt}, nil
+}
+
+// Resolves a bearer token into whoever it was handed out to
+func (s service) Authenticate(ctx context.Context, token string) (Principal, error) {
+	session, err := s.repository.GetSessionByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return Principal{}, ErrUnauthenticated
 	}
 
-	err := s.repository.CreateOrgAccount(ctx, orgAccount)
+	return Principal{
+		UserID:    session.UserID,
+		OrgID:     session.OrgID,
+		SessionID: session.ID,
+		auth:      session.auth(),
+	}, nil
+}
+
+// For users who don't know which org to log in to, or belong to more than one.
+// The password is checked the same as Login, but rather than a session what
+// comes back is every org the user belongs to along with a token to pick one of
+// them with (see ChooseLoginOrg).
+func (s service) LoginWithoutOrg(ctx context.Context, username string, password string) (OrgSelection, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "LoginWithoutOrg")
+
+	account, err := s.checkLogin(ctx, logger, username, password)
+	if err != nil {
+		return OrgSelection{}, err
+	}
+
+	orgs, err := s.repository.GetUserOrgs(ctx, account.ID)
 	if err != nil {
 		level.Error(logger).Log("err", err)
-		return "", err
+		return OrgSelection{}, err
 	}
 
-	orgProfile := OrgProfile{
-		AccountID: id,
-		Phone:     phone,
-		Address:   address,
-		Timezone:  timezone,
-		Website:   website,
+	token, err := newSessionToken()
+	if err != nil {
+		return OrgSelection{}, err
+	}
+	selection := PendingOrgSelection{
+		TokenHash: hashToken(token),
+		UserID:    account.ID,
+		ExpiresAt: time.Now().Add(orgSelectionTTL).UTC(),
+	}
+	if err := s.repository.CreateOrgSelection(ctx, selection); err != nil {
+		level.Error(logger).Log("err", err)
+		return OrgSelection{}, err
 	}
 
-	err = s.repository.CreateOrgProfile(ctx, orgProfile)
+	logger.Log("org selection", account.ID, "orgs", len(orgs))
+
+	return OrgSelection{
+		Token:     token,
+		ExpiresAt: selection.ExpiresAt,
+		Orgs:      orgs,
+	}, nil
+}
+
+// The second half of LoginWithoutOrg, logging in to the org the caller picked.
+// The token works once, and not at all after the password changes. From here on
+// it's the same as Login, the org can still want a second factor (an
+// MFAChallenge comes back) or a passkey rather than a password.
+func (s service) ChooseLoginOrg(ctx context.Context, token string, orgID string) (LoginUser, *MFAChallenge, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "ChooseLoginOrg")
+
+	// Used up whichever org it ends up picking and however that goes, so one
+	// password check can't be turned into more than one session
+	selection, err := s.repository.UseOrgSelection(ctx, hashToken(token))
+	if err != nil {
+		return LoginUser{}, nil, err
+	}
 
+	account, err := s.repository.GetUserAccount(ctx, selection.UserID)
 	if err != nil {
-		s.repository.DeleteOrgAccount(ctx, id)
 		level.Error(logger).Log("err", err)
-		return "", err
+		return LoginUser{}, nil, errInvalidOrgSelection
 	}
 
-	logger.Log("created organization", id)
+	loginUser, challenge, err := s.loginToOrg(ctx, account, orgID, sessionAuth{Method: AuthMethodPassword})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return LoginUser{}, nil, err
+	}
+	if challenge != nil {
+		logger.Log("MFA challenge", account.ID)
+		return LoginUser{}, challenge, nil
+	}
+
+	logger.Log("Login user", account.ID, "org", orgID)
+
+	return loginUser, nil, nil
+}
+
+// Moves the caller's session over to another of their orgs. It's held to the
+// new org's rules for however the session was logged in to, so switching can
+// end in an MFAChallenge, or errPasskeyRequired for a session that didn't start
+// with a passkey. The new session replaces the old one, which is revoked once
+// the switch has gone through. A switch that ends in a challenge leaves the old
+// session alone, answering it starts a new one like any other login would.
+// What the caller gets to do as an org admin or member follows the session to
+// the new org, see requireActiveOrg.
+func (s service) SwitchOrg(ctx context.Context, orgID string) (LoginUser, *MFAChallenge, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "SwitchOrg")
+
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return LoginUser{}, nil, ErrUnauthenticated
+	}
+	session, err := s.repository.GetSession(ctx, principal.SessionID)
+	if err != nil {
+		return LoginUser{}, nil, ErrUnauthenticated
+	}
+
+	account, err := s.repository.GetUserAccount(ctx, session.UserID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return LoginUser{}, nil, err
+	}
+
+	loginUser, challenge, err := s.loginToOrg(ctx, account, orgID, session.auth())
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return LoginUser{}, nil, err
+	}
+	if challenge != nil {
+		logger.Log("MFA challenge", account.ID, "org", orgID)
+		return LoginUser{}, challenge, nil
+	}
+
+	// They're in the new org either way, the old session outliving the switch
+	// isn't worth failing it over
+	if err := s.repository.RevokeSession(ctx, session.ID); err != nil {
+		level.Error(logger).Log("err", err)
+	}
+
+	logger.Log("switched org", account.ID, "from", session.OrgID, "to", orgID)
+
+	return loginUser, nil, nil
+}
+
+// Every org the caller belongs to, i.e. what they could switch their session to
+func (s service) ListMyOrgs(ctx context.Context) ([]OrgMembership, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	return s.repository.GetUserOrgs(ctx, principal.UserID)
+}
+
+// Makes sure whoever is calling is logged in and is an admin of the org. Admins
+// of any org above it count too, see OrgTree. Either way it's only through the
+// org the session is logged in to, see requireActiveOrg.
+func (s service) requireOrgAdmin(ctx context.Context, orgID string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if err := s.requireActiveOrg(ctx, principal, orgID); err != nil {
+		return err
+	}
+
+	admin, err := s.repository.IsOrgAdmin(ctx, principal.UserID, orgID)
+	if err != nil || !admin {
+		return ErrForbidden
+	}
+
+	return s.requireOrgLogin(ctx, principal, orgID)
+}
+
+// Same as requireOrgAdmin, except any member of the org will do (admins of the
+// orgs above it still count, their members don't)
+func (s service) requireOrgMember(ctx context.Context, orgID string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if err := s.requireActiveOrg(ctx, principal, orgID); err != nil {
+		return err
+	}
+
+	if _, err := s.repository.GetOrgMemberRole(ctx, principal.UserID, orgID); err != nil {
+		if admin, err := s.repository.IsOrgAdmin(ctx, principal.UserID, orgID); err != nil || !admin {
+			return ErrForbidden
+		}
+	}
+
+	return s.requireOrgLogin(ctx, principal, orgID)
+}
+
+// Makes sure the session is logged in to the org or one above it. Being an admin
+// (or member) somewhere only counts in a session logged in there, same as for
+// requireUserAdmin, so SwitchOrg is how a caller gets to act on their other orgs.
+func (s service) requireActiveOrg(ctx context.Context, principal Principal, orgID string) error {
+	if principal.OrgID == "" {
+		return fmt.Errorf("%w: log in to an organization first", ErrForbidden)
+	}
+	if principal.OrgID == orgID {
+		return nil
+	}
+
+	ancestors, err := s.repository.GetOrgAncestors(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.ID == principal.OrgID {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: switch to the organization (or one above it) first", ErrForbidden)
+}
+
+// Makes sure the session was logged in the way the org asks for. A session
+// logged in to an org above it was only held to that org's rules, and the org's
+// rules can have changed since the session was logged in to it.
+func (s service) requireOrgLogin(ctx context.Context, principal Principal, orgID string) error {
+	org, err := s.repository.GetOrgAccount(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if org.PasskeyRequired && principal.auth.Method != AuthMethodPasskey {
+		return errPasskeyRequired
+	}
+	if org.MFARequired && !principal.auth.MFA {
+		return errMFARequired
+	}
+	return nil
+}
+
+// The profile fields a caller gets to change
+var profileUpdateColumns = map[string]bool{"first_name": true, "last_name": true, "email": true, "phone": true}
+
+// A new email goes back to being unverified, gets a verification email of its own,
+// and the old address is told about the change. A new phone number goes back to
+// being unverified too. Same as UpdateUserAccount for who
+// gets to do it.
+func (s service) UpdateUserProfile(ctx context.Context, accountID string, updates map[string]interface{}) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "UpdateUserProfile")
+
+	if err := s.requireSelfOrUserAdmin(ctx, accountID); err != nil {
+		return err
+	}
+	// The rest of the profile is ours to keep up to date, not the caller's
+	for column := range updates {
+		if !profileUpdateColumns[column] {
+			return errors.New("the profile's " + column + " can't be updated")
+		}
+	}
+
+	var oldProfile UserProfile
+	newEmail, emailChanged := updates["email"].(string)
+	newPhone, phoneChanged := updates["phone"].(string)
+	if emailChanged || phoneChanged {
+		var err error
+		if oldProfile, err = s.repository.GetUserProfile(ctx, accountID); err != nil {
+			level.Error(logger).Log("err", err)
+			return err
+		}
+		oldProfile.AccountID = accountID
+		// Only the case changing is still the same mailbox
+		emailChanged = emailChanged && !strings.EqualFold(oldProfile.Email, newEmail)
+		if emailChanged {
+			updates["email_verified_at"] = setToDefault // i.e. NULL
+		}
+		if phoneChanged && newPhone != oldProfile.Phone {
+			updates["phone_verified_at"] = setToDefault
+		}
+	}
+
+	err := s.repository.UpdateUserProfile(ctx, accountID, updates)
+
+	if err != nil {
+		return err
+	}
+
+	logger.Log("updated user profile", accountID)
+
+	if emailChanged {
+		newProfile := oldProfile
+		newProfile.Email = newEmail
+		if first, ok := updates["first_name"].(string); ok {
+			newProfile.FirstName = first
+		}
+
+		if err := s.sendEmailVerification(ctx, newProfile); err != nil {
+			level.Error(logger).Log("err", err)
+		}
+		if oldProfile.Email != "" {
+			email, err := emailChangedTemplate.render(oldProfile.Email, struct {
+				FirstName, OldEmail, NewEmail string
+			}{newProfile.FirstName, oldProfile.Email, newEmail})
+			if err == nil {
+				err = s.mailer.Send(ctx, email)
+			}
+			if err != nil {
+				level.Error(logger).Log("err", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Emails the user a fresh link to verify their address with, takes the user
+// themselves. Links sent before stay good until they expire.
+func (s service) SendEmailVerification(ctx context.Context, userID string) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "SendEmailVerification")
+
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if principal.UserID != userID {
+		return ErrForbidden
+	}
+
+	profile, err := s.repository.GetUserProfile(ctx, userID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	profile.AccountID = userID
+	if profile.Email == "" {
+		return errors.New("there is no email address to verify")
+	}
+	if profile.EmailVerifiedAt != nil {
+		return errors.New("email address is already verified")
+	}
+
+	if err := s.sendEmailVerification(ctx, profile); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("sent email verification", userID)
+
+	return nil
+}
+
+// Verifies the user's email with the token from the link we sent them. The token
+// is all the proof it takes, whoever has it has read the email.
+func (s service) VerifyEmail(ctx context.Context, userID string, token string) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "VerifyEmail")
+
+	verification, err := s.repository.UseEmailVerification(ctx, userID, hashToken(token))
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.MarkEmailVerified(ctx, userID, verification.Email); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("verified email", userID)
+
+	return nil
+}
+
+// Texts the user a fresh code to verify their phone number with, takes the user
+// themselves. Only the code texted last works.
+func (s service) SendPhoneVerification(ctx context.Context, userID string) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "SendPhoneVerification")
+
+	if s.smsSender == nil {
+		return errSMSDisabled
+	}
+	if err := s.requireSelf(ctx, userID); err != nil {
+		return err
+	}
+
+	profile, err := s.repository.GetUserProfile(ctx, userID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	if profile.Phone == "" {
+		return errors.New("there is no phone number to verify")
+	}
+	if profile.PhoneVerifiedAt != nil {
+		return errors.New("phone number is already verified")
+	}
+
+	code, err := newSMSCode()
+	if err != nil {
+		return err
+	}
+	verification := PhoneVerification{
+		UserID:    userID,
+		Phone:     profile.Phone,
+		CodeHash:  hashToken(code),
+		ExpiresAt: time.Now().Add(phoneVerificationTTL).UTC(),
+	}
+	if err := s.repository.CreatePhoneVerification(ctx, verification); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	if err := s.smsSender.Send(ctx, SMS{
+		To:   profile.Phone,
+		Body: fmt.Sprintf("Your verification code is %s. It expires in %d minutes, don't share it with anyone.", code, int(phoneVerificationTTL.Minutes())),
+	}); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("sent phone verification", userID)
+
+	return nil
+}
+
+// Verifies the user's phone with the code from the text we sent them. Unlike
+// an email link the code is short enough to guess, so it takes the user's own
+// session and only so many wrong codes before the verification is dead.
+func (s service) VerifyPhone(ctx context.Context, userID string, code string) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "VerifyPhone")
+
+	if err := s.requireSelf(ctx, userID); err != nil {
+		return err
+	}
+
+	verification, err := s.repository.GetPhoneVerification(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !verification.codeMatches(strings.TrimSpace(code)) {
+		if err := s.repository.RecordFailedPhoneVerification(ctx, userID); err != nil {
+			level.Error(logger).Log("err", err)
+		}
+		return errInvalidPhoneCode
+	}
+	if err := s.repository.UsePhoneVerification(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.repository.MarkPhoneVerified(ctx, userID, verification.Phone); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("verified phone", userID)
+
+	return nil
+}
+
+// Records a new verification for the profile's email and sends the link for it
+func (s service) sendEmailVerification(ctx context.Context, profile UserProfile) error {
+	token, err := newSessionToken()
+	if err != nil {
+		return err
+	}
+
+	verification := EmailVerification{
+		TokenHash: hashToken(token),
+		UserID:    profile.AccountID,
+		Email:     profile.Email,
+		ExpiresAt: time.Now().Add(emailVerificationTTL).UTC(),
+	}
+	if err := s.repository.CreateEmailVerification(ctx, verification); err != nil {
+		return err
+	}
+
+	email, err := verifyEmailTemplate.render(profile.Email, struct {
+		FirstName string
+		Link      string
+		ExpiresAt time.Time
+	}{
+		profile.FirstName,
+		s.appURL + "/verify-email?" + url.Values{"user_id": {profile.AccountID}, "token": {token}}.Encode(),
+		verification.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, email)
+}
+
+// Starts a password reset for the account with the username, or every account with
+// the email address. The link only ever goes to an address the user verified, or
+// anyone could put theirs on a profile and take the account over. Whether there is one or not the caller hears the same thing,
+// and at the same speed since the looking up and emailing happens after we've
+// answered, so this can't be used to find out who has an account.
+func (s service) RequestPasswordReset(ctx context.Context, username string, email string) error {
+	if username == "" && email == "" {
+		return errors.New("username or email is required")
+	}
+
+	go s.sendPasswordResets(context.WithoutCancel(ctx), username, email)
+
+	return nil
+}
+
+// Does the actual work for RequestPasswordReset, nothing here makes it back to the caller
+func (s service) sendPasswordResets(ctx context.Context, username string, email string) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "RequestPasswordReset")
+
+	var userIDs []string
+	if username != "" {
+		if account, err := s.repository.GetAccountByUsername(ctx, username); err == nil {
+			userIDs = append(userIDs, account.ID)
+		}
+	}
+	if email != "" {
+		ids, err := s.repository.FindAccountIDsByEmail(ctx, email)
+		if err != nil {
+			level.Error(logger).Log("err", err)
+		}
+		userIDs = append(userIDs, ids...)
+	}
+
+	sent := map[string]bool{}
+	for _, userID := range userIDs {
+		if sent[userID] {
+			continue
+		}
+		sent[userID] = true
+
+		if err := s.sendPasswordReset(ctx, userID); err != nil {
+			level.Error(logger).Log("user", userID, "err", err)
+			continue
+		}
+		logger.Log("sent password reset", userID)
+	}
+}
+
+// Records a new password reset for the user and emails them the link for it, as
+// long as they verified their email address
+func (s service) sendPasswordReset(ctx context.Context, userID string) error {
+	account, err := s.repository.GetUserAccount(ctx, userID)
+	if err != nil {
+		return err
+	}
+	profile, err := s.repository.GetUserProfile(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if profile.Email == "" || profile.EmailVerifiedAt == nil {
+		return errors.New("user has no verified email address to send the reset to")
+	}
+
+	token, err := newSessionToken()
+	if err != nil {
+		return err
+	}
+
+	reset := PasswordReset{
+		TokenHash: hashToken(token),
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(passwordResetTTL).UTC(),
+	}
+	if err := s.repository.CreatePasswordReset(ctx, reset); err != nil {
+		return err
+	}
+
+	email, err := passwordResetTemplate.render(profile.Email, struct {
+		FirstName string
+		Username  string
+		Link      string
+		ExpiresAt time.Time
+	}{
+		profile.FirstName,
+		account.Username,
+		s.appURL + "/reset-password?" + url.Values{"token": {token}}.Encode(),
+		reset.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, email)
+}
+
+// Starts a passwordless login to the org by emailing a magic link to the account
+// with the username, or every account with the email address. Like password
+// resets the caller hears the same thing whether there's an account or not, the
+// looking up and emailing happens after we've answered.
+func (s service) RequestMagicLink(ctx context.Context, orgID string, username string, email string) error {
+	if username == "" && email == "" {
+		return errors.New("username or email is required")
+	}
+
+	go s.sendMagicLinks(context.WithoutCancel(ctx), orgID, username, email)
+
+	return nil
+}
+
+// Does the actual work for RequestMagicLink, nothing here makes it back to the caller
+func (s service) sendMagicLinks(ctx context.Context, orgID string, username string, email string) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "RequestMagicLink")
+
+	var userIDs []string
+	if username != "" {
+		if account, err := s.repository.GetAccountByUsername(ctx, username); err == nil {
+			userIDs = append(userIDs, account.ID)
+		}
+	}
+	if email != "" {
+		ids, err := s.repository.FindAccountIDsByEmail(ctx, email)
+		if err != nil {
+			level.Error(logger).Log("err", err)
+		}
+		userIDs = append(userIDs, ids...)
+	}
+
+	sent := map[string]bool{}
+	for _, userID := range userIDs {
+		if sent[userID] {
+			continue
+		}
+		sent[userID] = true
+
+		if err := s.sendMagicLink(ctx, orgID, userID); err != nil {
+			level.Error(logger).Log("user", userID, "err", err)
+			continue
+		}
+		logger.Log("sent magic link", userID, "org", orgID)
+	}
+}
+
+// Records a new magic link login for the user and emails them the link. Only
+// to an address they've verified, the link is as good as their password.
+func (s service) sendMagicLink(ctx context.Context, orgID string, userID string) error {
+	profile, err := s.repository.GetUserProfile(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if profile.Email == "" || profile.EmailVerifiedAt == nil {
+		return errors.New("user has no verified email address to send the link to")
+	}
+	org, err := s.passwordlessOrg(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	token, err := newSessionToken()
+	if err != nil {
+		return err
+	}
+
+	login := PasswordlessLogin{
+		TokenHash: hashToken(token),
+		Method:    PasswordlessMethodEmail,
+		UserID:    userID,
+		OrgID:     orgID,
+		ExpiresAt: time.Now().Add(magicLinkTTL).UTC(),
+	}
+	if err := s.repository.CreatePasswordlessLogin(ctx, login); err != nil {
+		return err
+	}
+
+	email, err := magicLinkTemplate.render(profile.Email, struct {
+		FirstName string
+		OrgName   string
+		Link      string
+		ExpiresAt time.Time
+	}{
+		profile.FirstName,
+		org.Name,
+		s.appURL + "/login/magic?" + url.Values{"org_id": {orgID}, "token": {token}}.Encode(),
+		login.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, email)
+}
+
+// Logs in with the token from a magic link email. Ends up wherever logging in
+// with a password would have, an MFAChallenge when the user or org wants one.
+func (s service) MagicLinkLogin(ctx context.Context, orgID string, token string) (LoginUser, *MFAChallenge, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "MagicLinkLogin")
+
+	tokenHash := hashToken(token)
+	login, err := s.repository.GetPasswordlessLogin(ctx, tokenHash, PasswordlessMethodEmail)
+	if err != nil {
+		return LoginUser{}, nil, err
+	}
+	if login.OrgID != orgID {
+		return LoginUser{}, nil, errInvalidMagicLink
+	}
+
+	if err := s.repository.UsePasswordlessLogin(ctx, tokenHash, PasswordlessMethodEmail); err != nil {
+		return LoginUser{}, nil, err
+	}
+
+	return s.passwordlessLogin(ctx, logger, login)
+}
+
+// Starts a passwordless login to the org by texting a code to the phone number
+// on the account with the username, as long as the user verified it (see
+// SendPhoneVerification). The token for it comes back straight away whether
+// there's such an account or not, the looking up and texting happens after
+// we've answered. For a username that doesn't exist (or has no verified phone)
+// the token just never works.
+func (s service) RequestSMSCode(ctx context.Context, orgID string, username string) (SMSCodeChallenge, error) {
+	if s.smsSender == nil {
+		return SMSCodeChallenge{}, errSMSDisabled
+	}
+	if username == "" {
+		return SMSCodeChallenge{}, errors.New("username is required")
+	}
+
+	token, err := newSessionToken()
+	if err != nil {
+		return SMSCodeChallenge{}, err
+	}
+	challenge := SMSCodeChallenge{Token: token, ExpiresAt: time.Now().Add(smsCodeTTL).UTC()}
+
+	go s.sendSMSCode(context.WithoutCancel(ctx), orgID, username, hashToken(token), challenge.ExpiresAt)
+
+	return challenge, nil
+}
+
+// Does the actual work for RequestSMSCode, nothing here makes it back to the caller
+func (s service) sendSMSCode(ctx context.Context, orgID string, username string, tokenHash string, expiresAt time.Time) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "RequestSMSCode")
+
+	err := func() error {
+		account, err := s.repository.GetAccountByUsername(ctx, username)
+		if err != nil {
+			return err
+		}
+		profile, err := s.repository.GetUserProfile(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+		if profile.Phone == "" || profile.PhoneVerifiedAt == nil {
+			return errors.New("user has no verified phone number to text the code to")
+		}
+		if _, err := s.passwordlessOrg(ctx, orgID, account.ID); err != nil {
+			return err
+		}
+
+		code, err := newSMSCode()
+		if err != nil {
+			return err
+		}
+
+		if err := s.repository.CreatePasswordlessLogin(ctx, PasswordlessLogin{
+			TokenHash: tokenHash,
+			Method:    PasswordlessMethodSMS,
+			UserID:    account.ID,
+			OrgID:     orgID,
+			CodeHash:  hashToken(code),
+			ExpiresAt: expiresAt,
+		}); err != nil {
+			return err
+		}
+
+		return s.smsSender.Send(ctx, SMS{
+			To:   profile.Phone,
+			Body: fmt.Sprintf("Your login code is %s. It expires in %d minutes, don't share it with anyone.", code, int(smsCodeTTL.Minutes())),
+		})
+	}()
+	if err != nil {
+		level.Error(logger).Log("username", username, "err", err)
+		return
+	}
+
+	logger.Log("sent SMS code", username, "org", orgID)
+}
+
+// Logs in with the token RequestSMSCode handed back and the code from the text
+// message. A login only takes so many wrong codes before it's dead. Ends up
+// wherever logging in with a password would have, like MagicLinkLogin.
+func (s service) SMSCodeLogin(ctx context.Context, orgID string, token string, code string) (LoginUser, *MFAChallenge, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "SMSCodeLogin")
+
+	tokenHash := hashToken(token)
+	login, err := s.repository.GetPasswordlessLogin(ctx, tokenHash, PasswordlessMethodSMS)
+	if err != nil {
+		return LoginUser{}, nil, err
+	}
+	if login.OrgID != orgID {
+		return LoginUser{}, nil, errInvalidSMSCode
+	}
+
+	if !login.codeMatches(strings.TrimSpace(code)) {
+		if err := s.repository.RecordFailedPasswordlessLogin(ctx, tokenHash); err != nil {
+			level.Error(logger).Log("err", err)
+		}
+		return LoginUser{}, nil, errInvalidSMSCode
+	}
+
+	if err := s.repository.UsePasswordlessLogin(ctx, tokenHash, PasswordlessMethodSMS); err != nil {
+		return LoginUser{}, nil, err
+	}
+
+	return s.passwordlessLogin(ctx, logger, login)
+}
+
+// The org a passwordless login is for, as long as the user can log in to it that way
+func (s service) passwordlessOrg(ctx context.Context, orgID string, userID string) (OrgAccount, error) {
+	if err := s.repository.ConfirmUserToOrgAssociation(ctx, userID, orgID); err != nil {
+		return OrgAccount{}, err
+	}
+	org, err := s.repository.GetOrgAccount(ctx, orgID)
+	if err != nil {
+		return OrgAccount{}, err
+	}
+	if org.PasskeyRequired {
+		return OrgAccount{}, errPasskeyRequired
+	}
+	return org, nil
+}
+
+// The part of MagicLinkLogin and SMSCodeLogin after the login was used up
+func (s service) passwordlessLogin(ctx context.Context, logger log.Logger, login PasswordlessLogin) (LoginUser, *MFAChallenge, error) {
+	account, err := s.repository.GetUserAccount(ctx, login.UserID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return LoginUser{}, nil, err
+	}
+
+	loginUser, challenge, err := s.loginToOrg(ctx, account, login.OrgID, sessionAuth{Method: login.Method})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return LoginUser{}, nil, err
+	}
+	if challenge != nil {
+		logger.Log("MFA challenge", account.ID)
+		return LoginUser{}, challenge, nil
+	}
+
+	logger.Log("Login user", account.ID, "via", login.Method)
+
+	return loginUser, nil, nil
+}
+
+// Sets a new password with the token from a password reset email. The password has
+// to pass the policy and can't be one the user has had recently. Every session
+// the user has is revoked, so whoever might have had their old password is out.
+func (s service) ResetPassword(ctx context.Context, token string, password string) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "ResetPassword")
+
+	tokenHash := hashToken(token)
+	reset, err := s.repository.GetPasswordReset(ctx, tokenHash)
+	if err != nil {
+		return err
+	}
+	userID := reset.UserID
+
+	account, err := s.repository.GetUserAccount(ctx, userID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	profile, err := s.repository.GetUserProfile(ctx, userID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	if err := checkPasswordPolicy(password, account.Username, profile.Email); err != nil {
+		return err
+	}
+
+	current, err := s.repository.GetCredential(ctx, userID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	history, err := s.repository.GetPasswordHistory(ctx, userID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	if reusedPassword(password, append([]Credential{current}, history...)) {
+		return fmt.Errorf("password can't be one of your last %d passwords", passwordHistoryDepth)
+	}
+
+	credential, err := newCredential(userID, password)
+	if err != nil {
+		return err
+	}
+
+	// Use the token up before changing anything, if someone beat us to it we stop here
+	if err := s.repository.UsePasswordReset(ctx, tokenHash); err != nil {
+		return err
+	}
+	if err := s.repository.ChangePassword(ctx, credential); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	if err := s.repository.RevokeUserSessions(ctx, userID); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("reset password", userID)
+
+	return nil
+}
+
+// Starts setting up TOTP for the user, handing back the secret for their
+// authenticator app. Nothing is enforced until ConfirmTOTP, and starting over
+// (e.g. they lost the QR code) just replaces the secret.
+func (s service) EnrollTOTP(ctx context.Context, userID string) (TOTPEnrollment, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "EnrollTOTP")
+
+	if err := s.requireSelf(ctx, userID); err != nil {
+		return TOTPEnrollment{}, err
+	}
+
+	account, err := s.repository.GetUserAccount(ctx, userID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return TOTPEnrollment{}, err
+	}
+
+	enrollment, err := s.newTOTPEnrollment(ctx, account)
+	if err != nil {
+		return TOTPEnrollment{}, err
+	}
+
+	logger.Log("started TOTP enrollment", userID)
+
+	return enrollment, nil
+}
+
+// Turns MFA on for the user once they send a first code from their authenticator
+// app, handing back their recovery codes. This is the only time they're shown.
+func (s service) ConfirmTOTP(ctx context.Context, userID string, code string) ([]string, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "ConfirmTOTP")
+
+	if err := s.requireSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	factor, err := s.repository.GetTOTPFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if factor.ConfirmedAt != nil {
+		return nil, errMFAEnabled
+	}
+
+	recoveryCodes, err := s.confirmTOTP(ctx, factor, code)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Log("enabled MFA", userID)
+
+	return recoveryCodes, nil
+}
+
+// Turns MFA off for the user, which takes a code (or recovery code) to prove it's
+// really them. If one of their orgs requires MFA they'll be setting it up again
+// the next time they log in to it.
+func (s service) DisableMFA(ctx context.Context, userID string, code string) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "DisableMFA")
+
+	if err := s.requireSelf(ctx, userID); err != nil {
+		return err
+	}
+
+	factor, err := s.repository.GetTOTPFactor(ctx, userID)
+	if errors.Is(err, ErrNotFound) || (err == nil && factor.ConfirmedAt == nil) {
+		return errMFANotEnabled
+	}
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	if err := s.checkMFACode(ctx, factor, code); err != nil {
+		return err
+	}
+
+	if err := s.repository.DeleteMFA(ctx, userID); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("disabled MFA", userID)
+
+	return nil
+}
+
+// For a user who's lost their authenticator and their recovery codes: an admin of
+// the org the caller is logged in to can clear MFA for any other member of it.
+func (s service) ResetMFA(ctx context.Context, userID string) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "ResetMFA")
+
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if principal.UserID == userID {
+		return fmt.Errorf("%w: use your own code to disable MFA", ErrForbidden)
+	}
+	if err := s.requireOrgAdmin(ctx, principal.OrgID); err != nil {
+		return err
+	}
+	if _, err := s.repository.GetOrgMemberRole(ctx, userID, principal.OrgID); err != nil {
+		return ErrForbidden
+	}
+
+	if err := s.repository.DeleteMFA(ctx, userID); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("reset MFA", userID, "by", principal.UserID)
+
+	return nil
+}
+
+// Makes sure whoever is calling is an admin of the org they're logged in to and
+// the user is a member of it, someone other than the caller
+func (s service) requireUserAdmin(ctx context.Context, userID string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if principal.UserID == userID {
+		return fmt.Errorf("%w: another admin has to do that", ErrForbidden)
+	}
+	if err := s.requireOrgAdmin(ctx, principal.OrgID); err != nil {
+		return err
+	}
+	if _, err := s.repository.GetOrgMemberRole(ctx, userID, principal.OrgID); err != nil {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// Makes sure whoever is calling is logged in as the user
+func (s service) requireSelf(ctx context.Context, userID string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if principal.UserID != userID {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// Makes sure whoever is calling is the user, or failing that an admin of the org
+// they're logged in to that the user is a member of (see requireUserAdmin)
+func (s service) requireSelfOrUserAdmin(ctx context.Context, userID string) error {
+	if err := s.requireSelf(ctx, userID); !errors.Is(err, ErrForbidden) {
+		return err
+	}
+	return s.requireUserAdmin(ctx, userID)
+}
+
+// Gives the user a new, unconfirmed, TOTP secret
+func (s service) newTOTPEnrollment(ctx context.Context, account UserAccount) (TOTPEnrollment, error) {
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return TOTPEnrollment{}, err
+	}
+	if err := s.repository.SetTOTPSecret(ctx, account.ID, secret); err != nil {
+		return TOTPEnrollment{}, err
+	}
+
+	return TOTPEnrollment{
+		Secret: secret,
+		URI:    totpURI(s.mfaIssuer, account.Username, secret),
+	}, nil
+}
+
+// Confirms the unconfirmed TOTP with the code, handing back the new recovery codes
+func (s service) confirmTOTP(ctx context.Context, factor TOTPFactor, code string) ([]string, error) {
+	step, ok := totpMatch(factor.Secret, code, time.Now())
+	if !ok {
+		return nil, errInvalidMFACode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.ConfirmTOTP(ctx, factor.UserID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Checks a code from the user's authenticator app, or failing that one of their
+// recovery codes, using it up either way.
+func (s service) checkMFACode(ctx context.Context, factor TOTPFactor, code string) error {
+	code = strings.TrimSpace(code)
+	if isTOTPCode(code) {
+		step, ok := totpMatch(factor.Secret, code, time.Now())
+		if !ok {
+			return errInvalidMFACode
+		}
+		return s.repository.UseTOTPStep(ctx, factor.UserID, step)
+	}
+	if code == "" {
+		return errInvalidMFACode
+	}
+	return s.repository.UseRecoveryCode(ctx, factor.UserID, hashRecoveryCode(code))
+}
+
+// Starts registering a new passkey for the user, handing back the options for
+// navigator.credentials.create(). Passkeys have to be discoverable (so they can
+// log in without a username) and verify the user (so they can stand in for both
+// the password and MFA).
+func (s service) BeginPasskeyRegistration(ctx context.Context, userID string) (PasskeyCeremony, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "BeginPasskeyRegistration")
+
+	if s.webAuthn == nil {
+		return PasskeyCeremony{}, errPasskeysDisabled
+	}
+	if err := s.requireSelf(ctx, userID); err != nil {
+		return PasskeyCeremony{}, err
+	}
+
+	user, err := s.passkeyUser(ctx, userID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return PasskeyCeremony{}, err
+	}
+
+	// Don't let them register the same authenticator twice
+	exclusions := make([]protocol.CredentialDescriptor, len(user.credentials))
+	for i, c := range user.credentials {
+		exclusions[i] = c.webauthn().Descriptor()
+	}
+
+	creation, session, err := s.webAuthn.BeginRegistration(user,
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			RequireResidentKey: protocol.ResidentKeyRequired(),
+			UserVerification:   protocol.VerificationRequired,
+		}),
+	)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return PasskeyCeremony{}, err
+	}
+
+	ceremony, err := s.createPasskeyCeremony(ctx, PasskeyCeremonyState{Kind: passkeyRegistration, UserID: userID}, session, creation)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return PasskeyCeremony{}, err
+	}
+
+	return ceremony, nil
+}
+
+// Finishes registering a passkey with what navigator.credentials.create() resolved to
+func (s service) FinishPasskeyRegistration(ctx context.Context, userID string, token string, name string, credential []byte) (Passkey, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "FinishPasskeyRegistration")
+
+	if s.webAuthn == nil {
+		return Passkey{}, errPasskeysDisabled
+	}
+	if err := s.requireSelf(ctx, userID); err != nil {
+		return Passkey{}, err
+	}
+
+	state, session, err := s.usePasskeyCeremony(ctx, token, passkeyRegistration)
+	if err != nil {
+		return Passkey{}, err
+	}
+	if state.UserID != userID {
+		return Passkey{}, errInvalidPasskeyCeremony
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(credential))
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return Passkey{}, errPasskeyFailed
+	}
+
+	user, err := s.passkeyUser(ctx, userID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return Passkey{}, err
+	}
+
+	created, err := s.webAuthn.CreateCredential(user, session, parsed)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return Passkey{}, errPasskeyFailed
+	}
+
+	if name == "" {
+		name = "Passkey"
+	}
+	passkey := newPasskeyCredential(userID, name, created)
+	if err := s.repository.CreatePasskey(ctx, passkey); err != nil {
+		return Passkey{}, err
+	}
+	passkey.CreatedAt = time.Now()
+
+	logger.Log("registered passkey", passkey.ID, "user", userID)
+
+	principal, _ := PrincipalFromContext(ctx)
+	return passkey.Passkey.in(s.orgLocation(ctx, principal.OrgID)), nil
+}
+
+// The user's passkeys, only they can see them
+func (s service) ListPasskeys(ctx context.Context, userID string) ([]Passkey, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "ListPasskeys")
+
+	if err := s.requireSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	credentials, err := s.repository.ListPasskeys(ctx, userID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+
+	principal, _ := PrincipalFromContext(ctx)
+	loc := s.orgLocation(ctx, principal.OrgID)
+
+	passkeys := make([]Passkey, len(credentials))
+	for i, c := range credentials {
+		passkeys[i] = c.Passkey.in(loc)
+	}
+
+	return passkeys, nil
+}
+
+func (s service) DeletePasskey(ctx context.Context, userID string, passkeyID string) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "DeletePasskey")
+
+	if err := s.requireSelf(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.repository.DeletePasskey(ctx, userID, passkeyID); err != nil {
+		return err
+	}
+
+	logger.Log("deleted passkey", passkeyID, "user", userID)
+
+	return nil
+}
+
+// Starts logging in to the org with a passkey, handing back the options for
+// navigator.credentials.get(). No username, whichever passkey the user picks
+// says who they are.
+func (s service) BeginPasskeyLogin(ctx context.Context, orgID string) (PasskeyCeremony, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "BeginPasskeyLogin")
+
+	if s.webAuthn == nil {
+		return PasskeyCeremony{}, errPasskeysDisabled
+	}
+	if _, err := s.repository.GetOrgAccount(ctx, orgID); err != nil {
+		return PasskeyCeremony{}, err
+	}
+
+	assertion, session, err := s.webAuthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return PasskeyCeremony{}, err
+	}
+
+	ceremony, err := s.createPasskeyCeremony(ctx, PasskeyCeremonyState{Kind: passkeyLogin, OrgID: orgID}, session, assertion)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return PasskeyCeremony{}, err
+	}
+
+	return ceremony, nil
+}
+
+// Finishes logging in with what navigator.credentials.get() resolved to. The
+// passkey verified the user (it won't get this far otherwise) so it counts as
+// MFA too, there's no second step.
+func (s service) PasskeyLogin(ctx context.Context, orgID string, token string, credential []byte) (LoginUser, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "PasskeyLogin")
+
+	if s.webAuthn == nil {
+		return LoginUser{}, errPasskeysDisabled
+	}
+
+	state, session, err := s.usePasskeyCeremony(ctx, token, passkeyLogin)
+	if err != nil {
+		return LoginUser{}, err
+	}
+	if state.OrgID != orgID {
+		return LoginUser{}, errInvalidPasskeyCeremony
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(credential))
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return LoginUser{}, errPasskeyFailed
+	}
+
+	var user passkeyUser
+	findUser := func(rawID, userHandle []byte) (webauthn.User, error) {
+		userID := passkeyUserID(userHandle)
+		if userID == "" {
+			return nil, errors.New("not one of our user handles")
+		}
+		var err error
+		user, err = s.passkeyUser(ctx, userID)
+		return user, err
+	}
+
+	used, err := s.webAuthn.ValidateDiscoverableLogin(findUser, session, parsed)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return LoginUser{}, errPasskeyFailed
+	}
+	// The counter went backwards, so there's more than one copy of the key about
+	if used.Authenticator.CloneWarning {
+		level.Warn(logger).Log("msg", "passkey sign count went backwards, it may be cloned", "passkey", passkeyID(used.ID))
+		return LoginUser{}, errPasskeyFailed
+	}
+
+	if err := s.repository.RecordPasskeyUse(ctx, passkeyID(used.ID), used.Authenticator.SignCount, used.Flags.BackupState); err != nil {
+		level.Error(logger).Log("err", err)
+	}
+
+	account := user.account
+	detailedOrg, err := s.orgToLogInTo(ctx, account, orgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return LoginUser{}, err
+	}
+
+	loginUser, err := s.finishLogin(ctx, account, detailedOrg, sessionAuth{Method: AuthMethodPasskey, MFA: true})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return LoginUser{}, err
+	}
+
+	logger.Log("Login user", account.ID, "passkey", passkeyID(used.ID))
+
+	return loginUser, nil
+}
+
+// Everything go-webauthn needs to know about the user
+func (s service) passkeyUser(ctx context.Context, userID string) (passkeyUser, error) {
+	account, err := s.repository.GetUserAccount(ctx, userID)
+	if err != nil {
+		return passkeyUser{}, err
+	}
+	profile, err := s.repository.GetUserProfile(ctx, userID)
+	if err != nil {
+		return passkeyUser{}, err
+	}
+	credentials, err := s.repository.ListPasskeys(ctx, userID)
+	if err != nil {
+		return passkeyUser{}, err
+	}
+
+	return passkeyUser{
+		account:     account,
+		displayName: strings.TrimSpace(profile.FirstName + " " + profile.LastName),
+		credentials: credentials,
+	}, nil
+}
+
+// Keeps the library's session data for the second half of the ceremony and hands
+// back the token for it along with the options for the browser
+func (s service) createPasskeyCeremony(ctx context.Context, state PasskeyCeremonyState, session *webauthn.SessionData, options interface{}) (PasskeyCeremony, error) {
+	sessionJSON, err := json.Marshal(session)
+	if err != nil {
+		return PasskeyCeremony{}, err
+	}
+	optionsJSON, err := json.Marshal(options)
+	if err != nil {
+		return PasskeyCeremony{}, err
+	}
+
+	token, err := newSessionToken()
+	if err != nil {
+		return PasskeyCeremony{}, err
+	}
+
+	state.TokenHash = hashToken(token)
+	state.Session = sessionJSON
+	state.ExpiresAt = time.Now().Add(passkeyCeremonyTTL).UTC()
+	if err := s.repository.CreatePasskeyCeremony(ctx, state); err != nil {
+		return PasskeyCeremony{}, err
+	}
+
+	return PasskeyCeremony{Token: token, Options: optionsJSON}, nil
+}
+
+// Uses up the ceremony, handing back what was kept for it
+func (s service) usePasskeyCeremony(ctx context.Context, token string, kind string) (PasskeyCeremonyState, webauthn.SessionData, error) {
+	state, err := s.repository.UsePasskeyCeremony(ctx, hashToken(token), kind)
+	if err != nil {
+		return PasskeyCeremonyState{}, webauthn.SessionData{}, err
+	}
+
+	var session webauthn.SessionData
+	if err := json.Unmarshal(state.Session, &session); err != nil {
+		return PasskeyCeremonyState{}, webauthn.SessionData{}, errInvalidPasskeyCeremony
+	}
+
+	return state, session, nil
+}
+
+// providerDetails are for (and required by) provider orgs and payorDetails for
+// payor orgs, see orgTypes. An org created under a parent (parentID) takes an
+// admin of the parent, see checkOrgParent.
+func (s service) CreateOrg(ctx context.Context, name string, orgType string, parentID string, phone string, address string, timezone string, website string, providerDetails *ProviderDetails, payorDetails *PayorDetails) (string, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "CreateOrg")
+
+	// Check everything up front so a bad NPI doesn't leave a half created org behind
+	spec, err := lookupOrgType(orgType)
+	if err != nil {
+		return "", err
+	}
+	if err := validateTimezone(timezone); err != nil {
+		return "", err
+	}
+	details := DetailedOrg{ProviderDetails: providerDetails, PayorDetails: payorDetails}
+	if err := spec.validate(&details); err != nil {
+		return "", err
+	}
+	providerDetails, payorDetails = details.ProviderDetails, details.PayorDetails
+
+	uuid, _ := uuid.NewV4()
+	id := uuid.String()
+
+	orgAccount := OrgAccount{
+		ID:       id,
+		Name:     name,
+		Type:     orgType,
+		ParentID: parentID,
+	}
+	if parentID != "" {
+		if err := s.checkOrgParent(ctx, orgAccount, OrgTree{Org: orgAccount}); err != nil {
+			return "", err
+		}
+	}
+
+	err = s.repository.CreateOrgAccount(ctx, orgAccount)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return "", err
+	}
+
+	orgProfile := OrgProfile{
+		AccountID: id,
+		Phone:     phone,
+		Address:   address,
+		Timezone:  timezone,
+		Website:   website,
+	}
+
+	err = s.repository.CreateOrgProfile(ctx, orgProfile)
+
+	if err != nil {
+		s.repository.PurgeOrgAccount(ctx, id)
+		level.Error(logger).Log("err", err)
+		return "", err
+	}
+
+	if providerDetails != nil {
+		providerDetails.AccountID = id
+		if err := s.repository.CreateProviderDetails(ctx, *providerDetails); err != nil {
+			s.repository.PurgeOrgAccount(ctx, id)
+			level.Error(logger).Log("err", err)
+			return "", err
+		}
+	}
+
+	if payorDetails != nil {
+		if err := s.repository.SetPayerIDs(ctx, id, payorDetails.PayerIDs); err != nil {
+			s.repository.PurgeOrgAccount(ctx, id)
+			level.Error(logger).Log("err", err)
+			return "", err
+		}
+	}
+
+	logger.Log("created organization", id)
 
 	return id, nil
 }
+
+// Members of the org get to see it, and so do admins of the orgs above it
+func (s service) GetOrg(ctx context.Context, id string) (DetailedOrg, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "GetOrg")
+
+	if err := s.requireOrgMember(ctx, id); err != nil {
+		return DetailedOrg{}, err
+	}
+
+	org, err := s.getDetailedOrg(ctx, id)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return DetailedOrg{}, err
+	}
+
+	logger.Log("Get organization", id)
+
+	return org, nil
+}
+
+// Puts together the org's account, profile and whatever details its type comes with
+func (s service) getDetailedOrg(ctx context.Context, id string) (DetailedOrg, error) {
+	orgAccount, err := s.repository.GetOrgAccount(ctx, id)
+	if err != nil {
+		return DetailedOrg{}, err
+	}
+
+	orgProfile, err := s.repository.GetOrgProfile(ctx, id)
+	if err != nil {
+		return DetailedOrg{}, err
+	}
+
+	org := DetailedOrg{
+		Account: orgAccount.in(orgProfile.location()),
+		Profile: orgProfile,
+	}
+
+	switch orgAccount.Type {
+	case OrgTypeProvider:
+		if org.ProviderDetails, err = s.repository.GetProviderDetails(ctx, id); err != nil {
+			return DetailedOrg{}, err
+		}
+	case OrgTypePayor:
+		if org.PayorDetails, err = s.repository.GetPayorDetails(ctx, id); err != nil {
+			return DetailedOrg{}, err
+		}
+	}
+
+	return org, nil
+}
+
+// Takes an admin of the org, like every other change to it. Moving it under
+// another parent (or out from under its parent with "") takes an admin of where
+// it's coming from and where it's going too, see checkOrgParent.
+func (s service) UpdateOrgAccount(ctx context.Context, id string, updates map[string]interface{}) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "UpdateOrgAccount")
+
+	if err := s.requireOrgAdmin(ctx, id); err != nil {
+		return err
+	}
+
+	if parentID, ok := updates["parent_id"].(*string); ok && parentID != nil {
+		if err := s.moveOrg(ctx, id, *parentID); err != nil {
+			level.Error(logger).Log("err", err)
+			return err
+		}
+		// A nil pointer goes in as NULL, where an empty string would be refused
+		// as a UUID
+		if *parentID == "" {
+			updates["parent_id"] = (*string)(nil)
+		}
+	}
+
+	if err := s.repository.UpdateOrgAccount(ctx, id, updates); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("updated organization account", id)
+
+	return nil
+}
+
+// Takes an admin of the org
+func (s service) UpdateOrgProfile(ctx context.Context, id string, updates map[string]interface{}) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "UpdateOrgProfile")
+
+	if err := s.requireOrgAdmin(ctx, id); err != nil {
+		return err
+	}
+
+	if timezone, ok := updates["timezone"].(string); ok {
+		if err := validateTimezone(timezone); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repository.UpdateOrgProfile(ctx, id, updates); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("updated organization profile", id)
+
+	return nil
+}
+
+// Deletes the org, which takes an admin of it and is refused while it still has
+// members unless force is set. Same as users, it's only marked deleted and
+// RestoreOrg can bring it back.
+func (s service) DeleteOrg(ctx context.Context, id string, force bool) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "DeleteOrg")
+
+	if err := s.requireOrgAdmin(ctx, id); err != nil {
+		return err
+	}
+
+	// Not even with force, the children would be left without a parent their
+	// admins didn't pick
+	children, err := s.repository.GetOrgChildren(ctx, id)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	if len(children) > 0 {
+		return ErrOrgHasChildren
+	}
+
+	members, err := s.repository.CountOrgMembers(ctx, id)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	if members > 0 && !force {
+		return ErrOrgHasMembers
+	}
+
+	if err := s.repository.DeleteOrgAccount(ctx, id); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("deleted organization", id, "members", members)
+
+	return nil
+}
+
+// Suspending an org logs everyone out of it and keeps them out until it's
+// reactivated, meanwhile it drops out of lookups and its members' lists of orgs.
+// Takes an admin of the org or of any org above it, same as the other status
+// changes below.
+func (s service) SuspendOrg(ctx context.Context, orgID string) (OrgAccount, error) {
+	return s.changeOrgStatus(ctx, "SuspendOrg", orgID, suspendAccount)
+}
+
+// Same as SuspendOrg, the difference is only in what it's for
+func (s service) DeactivateOrg(ctx context.Context, orgID string) (OrgAccount, error) {
+	return s.changeOrgStatus(ctx, "DeactivateOrg", orgID, deactivateAccount)
+}
+
+// Undoes SuspendOrg or DeactivateOrg
+func (s service) ReactivateOrg(ctx context.Context, orgID string) (OrgAccount, error) {
+	return s.changeOrgStatus(ctx, "ReactivateOrg", orgID, reactivateAccount)
+}
+
+// Undoes DeleteOrg. Its profile, details and members come back with it, but
+// the invites and network relationships deleting it ended stay that way.
+func (s service) RestoreOrg(ctx context.Context, orgID string) (OrgAccount, error) {
+	return s.changeOrgStatus(ctx, "RestoreOrg", orgID, restoreAccount)
+}
+
+func (s service) changeOrgStatus(ctx context.Context, method string, orgID string, change statusChange) (OrgAccount, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", method)
+
+	if err := s.requireOrgAdmin(ctx, orgID); err != nil {
+		return OrgAccount{}, err
+	}
+
+	org, err := s.repository.GetOrgAccountAnyStatus(ctx, orgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return OrgAccount{}, err
+	}
+	if err := change.check("organization", org.Status); err != nil {
+		return OrgAccount{}, err
+	}
+
+	// A parent can't be deleted out from under its children, so one can't come
+	// back under a deleted parent either
+	if org.ParentID != "" && change.to == OrgStatusActive {
+		parent, err := s.repository.GetOrgAccountAnyStatus(ctx, org.ParentID)
+		if err != nil {
+			level.Error(logger).Log("err", err)
+			return OrgAccount{}, err
+		}
+		if parent.Status == OrgStatusDeleted {
+			return OrgAccount{}, errors.New("parent organization is deleted, restore it first")
+		}
+	}
+
+	if err := s.repository.SetOrgStatus(ctx, orgID, change); err != nil {
+		level.Error(logger).Log("err", err)
+		return OrgAccount{}, err
+	}
+	if change.to != OrgStatusActive {
+		if err := s.repository.RevokeOrgSessions(ctx, orgID); err != nil {
+			level.Error(logger).Log("err", err)
+		}
+	}
+
+	logger.Log("organization status", orgID, "from", org.Status, "to", change.to)
+
+	org, err = s.repository.GetOrgAccountAnyStatus(ctx, orgID)
+	if err != nil {
+		return OrgAccount{}, err
+	}
+	return org.in(s.orgLocation(ctx, orgID)), nil
+}
+
+// Checks the org can move to parentID ("" to have no parent at all). Whoever's
+// moving it has to be an admin of it and of the parent it's leaving, so an org
+// can't walk out from under its parent's admins.
+func (s service) moveOrg(ctx context.Context, id string, parentID string) error {
+	org, err := s.repository.GetOrgAccount(ctx, id)
+	if err != nil {
+		return err
+	}
+	if org.ParentID == parentID {
+		return nil
+	}
+
+	if err := s.requireOrgAdmin(ctx, id); err != nil {
+		return err
+	}
+	if org.ParentID != "" {
+		if err := s.requireOrgAdmin(ctx, org.ParentID); err != nil {
+			return err
+		}
+	}
+	if parentID == "" {
+		return nil
+	}
+
+	descendants, err := s.repository.GetOrgDescendants(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	org.ParentID = parentID
+	return s.checkOrgParent(ctx, org, buildOrgTree(org, descendants))
+}
+
+// Checks the org (with the tree under it) can go under its ParentID: the caller
+// has to be an admin of the parent, which has to be the same type of org, and
+// it has to stay a tree no more than maxOrgDepth deep.
+func (s service) checkOrgParent(ctx context.Context, org OrgAccount, tree OrgTree) error {
+	if err := s.requireOrgAdmin(ctx, org.ParentID); err != nil {
+		return err
+	}
+
+	parent, err := s.repository.GetOrgAccount(ctx, org.ParentID)
+	if err != nil {
+		return err
+	}
+	if parent.Type != org.Type {
+		return errOrgParentType
+	}
+
+	ancestors, err := s.repository.GetOrgAncestors(ctx, parent.ID)
+	if err != nil {
+		return err
+	}
+	ancestors = append(ancestors, parent)
+	for _, ancestor := range ancestors {
+		if ancestor.ID == org.ID {
+			return errOrgCycle
+		}
+	}
+
+	if len(ancestors)+1+tree.height() > maxOrgDepth {
+		return errOrgTooDeep
+	}
+
+	return nil
+}
+
+// The orgs directly under the org. Members of the org get to see them, and so
+// do admins of the orgs above it.
+func (s service) ListChildOrgs(ctx context.Context, orgID string) ([]OrgAccount, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "ListChildOrgs")
+
+	if err := s.requireOrgMember(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	children, err := s.repository.GetOrgChildren(ctx, orgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+
+	return children, nil
+}
+
+// Where the org sits in the tree: every org above it and everything below it.
+// Same as ListChildOrgs for who gets to see it.
+func (s service) GetOrgTree(ctx context.Context, orgID string) (OrgHierarchy, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "GetOrgTree")
+
+	if err := s.requireOrgMember(ctx, orgID); err != nil {
+		return OrgHierarchy{}, err
+	}
+
+	org, err := s.repository.GetOrgAccount(ctx, orgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return OrgHierarchy{}, err
+	}
+	ancestors, err := s.repository.GetOrgAncestors(ctx, orgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return OrgHierarchy{}, err
+	}
+	descendants, err := s.repository.GetOrgDescendants(ctx, orgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return OrgHierarchy{}, err
+	}
+
+	return OrgHierarchy{
+		Ancestors: ancestors,
+		Tree:      buildOrgTree(org, descendants),
+	}, nil
+}
+
+// Lists a page of the org's users, only members of the org get to see who else is in it
+func (s service) ListOrgUsers(ctx context.Context, orgID string, query OrgUserQuery) (OrgUserPage, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "ListOrgUsers")
+
+	if err := s.requireOrgMember(ctx, orgID); err != nil {
+		return OrgUserPage{}, err
+	}
+
+	if query.Role != "" {
+		orgAccount, err := s.repository.GetOrgAccount(ctx, orgID)
+		if err != nil {
+			level.Error(logger).Log("err", err)
+			return OrgUserPage{}, err
+		}
+		if err := checkOrgRole(orgAccount, query.Role); err != nil {
+			return OrgUserPage{}, err
+		}
+	}
+	// Like every other lookup, only active users unless asked for others
+	if query.Status == "" {
+		query.Status = UserStatusActive
+	}
+	if !validAccountStatus(query.Status) {
+		return OrgUserPage{}, errors.New("unknown status " + query.Status)
+	}
+	if query.Sort == "" {
+		query.Sort = "name"
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultPageLimit
+	}
+	if query.Limit > maxPageLimit {
+		query.Limit = maxPageLimit
+	}
+
+	page, err := s.repository.ListOrgUsers(ctx, orgID, query)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return OrgUserPage{}, err
+	}
+
+	orgProfile, err := s.repository.GetOrgProfile(ctx, orgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return OrgUserPage{}, err
+	}
+	loc := orgProfile.location()
+	for i, member := range page.Users {
+		page.Users[i].Account = member.Account.in(loc)
+		page.Users[i].Profile = member.Profile.in(loc)
+	}
+
+	return page, nil
+}
+
+// Replaces the payor's payer IDs, takes an admin of the org
+func (s service) UpdatePayorDetails(ctx context.Context, orgID string, details PayorDetails) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "UpdatePayorDetails")
+
+	if err := s.requireOrgAdmin(ctx, orgID); err != nil {
+		return err
+	}
+
+	orgAccount, err := s.repository.GetOrgAccount(ctx, orgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	spec, err := lookupOrgType(orgAccount.Type)
+	if err != nil {
+		return err
+	}
+	org := DetailedOrg{PayorDetails: &details}
+	if err := spec.validate(&org); err != nil {
+		return err
+	}
+	details = *org.PayorDetails
+
+	if err := s.repository.SetPayerIDs(ctx, orgID, details.PayerIDs); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("updated payor details", orgID)
+
+	return nil
+}
+
+// Looks up the payor that goes by the payer ID
+func (s service) FindPayor(ctx context.Context, payerID string) (DetailedOrg, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "FindPayor")
+
+	orgID, err := s.repository.GetOrgIDByPayerID(ctx, strings.ToUpper(strings.TrimSpace(payerID)))
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return DetailedOrg{}, err
+	}
+
+	return s.getDetailedOrg(ctx, orgID)
+}
+
+// Invites the email address to join the org as role (a regular member when it's
+// empty), takes an admin of the org. The invitee is emailed a link with the token
+// in it, the token also comes back for the admin to pass on some other way.
+func (s service) CreateInvite(ctx context.Context, orgID string, email string, role string) (IssuedInvite, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "CreateInvite")
+
+	if err := s.requireOrgAdmin(ctx, orgID); err != nil {
+		return IssuedInvite{}, err
+	}
+
+	email, err := normalizeInviteEmail(email)
+	if err != nil {
+		return IssuedInvite{}, err
+	}
+
+	orgAccount, err := s.repository.GetOrgAccount(ctx, orgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return IssuedInvite{}, err
+	}
+	if role == "" {
+		role = RoleMember
+	}
+	if err := checkOrgRole(orgAccount, role); err != nil {
+		return IssuedInvite{}, err
+	}
+
+	principal, _ := PrincipalFromContext(ctx)
+	uuid, _ := uuid.NewV4()
+	invite := Invite{
+		ID:        uuid.String(),
+		OrgID:     orgID,
+		Email:     email,
+		Role:      role,
+		InvitedBy: principal.UserID,
+		// Whole seconds, the token only carries the expiry to the second
+		ExpiresAt: time.Now().Add(inviteTTL).Truncate(time.Second).UTC(),
+	}
+
+	if err := s.repository.CreateInvite(ctx, invite); err != nil {
+		level.Error(logger).Log("err", err)
+		return IssuedInvite{}, err
+	}
+
+	logger.Log("created invite", invite.ID, "org", orgID)
+
+	issued, err := s.issueInvite(ctx, invite.ID)
+	if err != nil {
+		return IssuedInvite{}, err
+	}
+	if err := s.sendInvite(ctx, issued); err != nil {
+		level.Error(logger).Log("err", err)
+	}
+
+	return issued, nil
+}
+
+// Lists every invite the org has sent, whatever became of them. Takes an admin of the org.
+func (s service) ListInvites(ctx context.Context, orgID string) ([]Invite, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "ListInvites")
+
+	if err := s.requireOrgAdmin(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	invites, err := s.repository.ListInvites(ctx, orgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+
+	now, loc := time.Now(), s.orgLocation(ctx, orgID)
+	for i, invite := range invites {
+		invite.Status = invite.status(now)
+		invites[i] = invite.in(loc)
+	}
+
+	return invites, nil
+}
+
+// Revokes an invite that hasn't been accepted yet, takes an admin of the org
+func (s service) RevokeInvite(ctx context.Context, orgID string, inviteID string) error {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "RevokeInvite")
+
+	if err := s.requireOrgAdmin(ctx, orgID); err != nil {
+		return err
+	}
+	if _, err := s.getOrgInvite(ctx, orgID, inviteID); err != nil {
+		return err
+	}
+
+	if err := s.repository.RevokeInvite(ctx, inviteID); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+
+	logger.Log("revoked invite", inviteID, "org", orgID)
+
+	return nil
+}
+
+// Issues a fresh token for an invite that hasn't been accepted or revoked, with a
+// fresh expiry to go with it, and emails it to the invitee. Whatever token was
+// issued for it before stops working.
+func (s service) ResendInvite(ctx context.Context, orgID string, inviteID string) (IssuedInvite, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "ResendInvite")
+
+	if err := s.requireOrgAdmin(ctx, orgID); err != nil {
+		return IssuedInvite{}, err
+	}
+	if _, err := s.getOrgInvite(ctx, orgID, inviteID); err != nil {
+		return IssuedInvite{}, err
+	}
+
+	expiresAt := time.Now().Add(inviteTTL).Truncate(time.Second).UTC()
+	if err := s.repository.RenewInvite(ctx, inviteID, expiresAt); err != nil {
+		level.Error(logger).Log("err", err)
+		return IssuedInvite{}, err
+	}
+
+	logger.Log("resent invite", inviteID, "org", orgID)
+
+	issued, err := s.issueInvite(ctx, inviteID)
+	if err != nil {
+		return IssuedInvite{}, err
+	}
+	if err := s.sendInvite(ctx, issued); err != nil {
+		level.Error(logger).Log("err", err)
+	}
+
+	return issued, nil
+}
+
+// Accepts the invite the token was issued for. A logged in caller has their own
+// account added to the org (it has to have verified the address the invite went
+// to, otherwise anyone could put the address on their profile and take it), for
+// anyone else a new account is created out of the rest of the arguments, with the
+// invite's address as its email. Either way the user's ID comes back.
+func (s service) AcceptInvite(ctx context.Context, token string, username string, password string, firstName string, lastName string, phone string) (string, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "AcceptInvite")
+
+	inviteID, expiresAt, err := parseInviteToken(s.signingKey, token)
+	if err != nil {
+		return "", err
+	}
+
+	invite, err := s.repository.GetInvite(ctx, inviteID)
+	if errors.Is(err, ErrNotFound) {
+		return "", errInvalidInvite
+	}
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return "", err
+	}
+	if !invite.ExpiresAt.Equal(expiresAt) || invite.status(time.Now()) != InviteStatusPending {
+		return "", errInvalidInvite
+	}
+
+	orgAccount, err := s.repository.GetOrgAccount(ctx, invite.OrgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return "", err
+	}
+	// The role was fine when the invite went out, the org's type has to still have it
+	if err := checkOrgRole(orgAccount, invite.Role); err != nil {
+		return "", err
+	}
+
+	var userID string
+	var newProfile *UserProfile
+	// How to take it back should the invite turn out to be gone by the time we mark it accepted
+	var undo func()
+
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		account, err := s.repository.GetUserAccount(ctx, principal.UserID)
+		if err != nil {
+			level.Error(logger).Log("err", err)
+			return "", err
+		}
+		profile, err := s.repository.GetUserProfile(ctx, principal.UserID)
+		if err != nil {
+			level.Error(logger).Log("err", err)
+			return "", err
+		}
+		if !strings.EqualFold(profile.Email, invite.Email) {
+			return "", fmt.Errorf("%w: the invite was sent to a different email address", ErrForbidden)
+		}
+		if profile.EmailVerifiedAt == nil {
+			return "", fmt.Errorf("%w: verify your email address before accepting the invite", ErrForbidden)
+		}
+		if err := checkUserOrgType(account, orgAccount); err != nil {
+			return "", err
+		}
+		if s.repository.ConfirmUserToOrgAssociation(ctx, account.ID, invite.OrgID) == nil {
+			return "", errors.New("already a member of the organization")
+		}
+
+		if err := s.repository.AssociateUserToOrg(ctx, account.ID, invite.OrgID, invite.Role); err != nil {
+			level.Error(logger).Log("err", err)
+			return "", err
+		}
+		userID = account.ID
+		undo = func() { s.repository.RemoveUserFromOrg(ctx, account.ID, invite.OrgID) }
+	} else {
+		uuid, _ := uuid.NewV4()
+		user := UserAccount{
+			ID:       uuid.String(),
+			Username: username,
+			OrgType:  orgAccount.Type,
+		}
+		profile := UserProfile{
+			AccountID: user.ID,
+			FirstName: firstName,
+			LastName:  lastName,
+			Email:     invite.Email,
+			Phone:     phone,
+		}
+
+		if err := s.newUser(ctx, user, profile, password); err != nil {
+			level.Error(logger).Log("err", err)
+			return "", err
+		}
+		if err := s.repository.AssociateUserToOrg(ctx, user.ID, invite.OrgID, invite.Role); err != nil {
+			s.repository.PurgeUserAccount(ctx, user.ID)
+			level.Error(logger).Log("err", err)
+			return "", err
+		}
+		userID = user.ID
+		undo = func() { s.repository.PurgeUserAccount(ctx, user.ID) }
+		newProfile = &profile
+	}
+
+	if err := s.repository.AcceptInvite(ctx, invite.ID, expiresAt, userID); err != nil {
+		undo()
+		level.Error(logger).Log("err", err)
+		return "", errInvalidInvite
+	}
+
+	logger.Log("accepted invite", invite.ID, "user", userID, "org", invite.OrgID)
+
+	// The invite token says nothing about who can read the address (the admin gets
+	// it too and can pass it on to anyone), so a new account verifies it like any other.
+	if newProfile != nil {
+		if err := s.sendEmailVerification(ctx, *newProfile); err != nil {
+			level.Error(logger).Log("err", err)
+		}
+	}
+
+	return userID, nil
+}
+
+// The invite, as long as it's one of the org's
+func (s service) getOrgInvite(ctx context.Context, orgID string, inviteID string) (Invite, error) {
+	invite, err := s.repository.GetInvite(ctx, inviteID)
+	if err != nil {
+		return Invite{}, err
+	}
+	if invite.OrgID != orgID {
+		return Invite{}, fmt.Errorf("%w: no such invite", ErrNotFound)
+	}
+	return invite, nil
+}
+
+// Reads the invite back as it now stands and signs a token for it
+func (s service) issueInvite(ctx context.Context, inviteID string) (IssuedInvite, error) {
+	invite, err := s.repository.GetInvite(ctx, inviteID)
+	if err != nil {
+		return IssuedInvite{}, err
+	}
+	invite.Status = invite.status(time.Now())
+
+	return IssuedInvite{
+		Invite: invite.in(s.orgLocation(ctx, invite.OrgID)),
+		Token:  newInviteToken(s.signingKey, invite),
+	}, nil
+}
+
+// Emails the invitee the link to accept the invite with
+func (s service) sendInvite(ctx context.Context, issued IssuedInvite) error {
+	org, err := s.repository.GetOrgAccount(ctx, issued.Invite.OrgID)
+	if err != nil {
+		return err
+	}
+
+	email, err := inviteTemplate.render(issued.Invite.Email, struct {
+		OrgName   string
+		Role      string
+		Link      string
+		ExpiresAt time.Time
+	}{
+		org.Name,
+		issued.Invite.Role,
+		s.appURL + "/accept-invite?" + url.Values{"token": {issued.Token}}.Encode(),
+		issued.Invite.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, email)
+}
+
+// Asks for the org to be in-network with the partner, one of them has to be a
+// provider and the other a payor. Takes an admin of the org, and it stays pending
+// until an admin of the partner accepts it.
+func (s service) RequestNetworkRelationship(ctx context.Context, orgID string, partnerID string, effectiveDate string, terminationDate string) (NetworkRelationship, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "RequestNetworkRelationship")
+
+	if err := s.requireOrgAdmin(ctx, orgID); err != nil {
+		return NetworkRelationship{}, err
+	}
+	if err := validateNetworkDates(effectiveDate, terminationDate); err != nil {
+		return NetworkRelationship{}, err
+	}
+
+	org, err := s.repository.GetOrgAccount(ctx, orgID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return NetworkRelationship{}, err
+	}
+	partner, err := s.repository.GetOrgAccount(ctx, partnerID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return NetworkRelationship{}, err
+	}
+	providerID, payorID, err := networkSides(org, partner)
+	if err != nil {
+		return NetworkRelationship{}, err
+	}
+
+	uuid, _ := uuid.NewV4()
+	relationship := NetworkRelationship{
+		ID:              uuid.String(),
+		ProviderID:      providerID,
+		PayorID:         payorID,
+		Status:          NetworkStatusPending,
+		EffectiveDate:   effectiveDate,
+		TerminationDate: terminationDate,
+		RequestedBy:     orgID,
+	}
+	if err := s.repository.CreateNetworkRelationship(ctx, relationship); err != nil {
+		level.Error(logger).Log("err", err)
+		return NetworkRelationship{}, err
+	}
+
+	logger.Log("requested network relationship", relationship.ID, "provider", providerID, "payor", payorID)
+
+	return s.repository.GetNetworkRelationship(ctx, relationship.ID)
+}
+
+// Accepts a pending relationship the other side asked for, takes an admin of the org
+func (s service) AcceptNetworkRelationship(ctx context.Context, orgID string, relationshipID string) (NetworkRelationship, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "AcceptNetworkRelationship")
+
+	if err := s.requireOrgAdmin(ctx, orgID); err != nil {
+		return NetworkRelationship{}, err
+	}
+	relationship, err := s.getOrgNetworkRelationship(ctx, orgID, relationshipID)
+	if err != nil {
+		return NetworkRelationship{}, err
+	}
+	if relationship.RequestedBy == orgID || relationship.Status != NetworkStatusPending {
+		return NetworkRelationship{}, errNetworkAccepted
+	}
+
+	if err := s.repository.AcceptNetworkRelationship(ctx, relationshipID); err != nil {
+		level.Error(logger).Log("err", err)
+		return NetworkRelationship{}, err
+	}
+
+	logger.Log("accepted network relationship", relationshipID, "org", orgID)
+
+	return s.repository.GetNetworkRelationship(ctx, relationshipID)
+}
+
+// Ends the relationship, either side's admins can do it. A pending one is just
+// turned down (or withdrawn).
+func (s service) TerminateNetworkRelationship(ctx context.Context, orgID string, relationshipID string) (NetworkRelationship, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "TerminateNetworkRelationship")
+
+	if err := s.requireOrgAdmin(ctx, orgID); err != nil {
+		return NetworkRelationship{}, err
+	}
+	if _, err := s.getOrgNetworkRelationship(ctx, orgID, relationshipID); err != nil {
+		return NetworkRelationship{}, err
+	}
+
+	if err := s.repository.TerminateNetworkRelationship(ctx, relationshipID); err != nil {
+		level.Error(logger).Log("err", err)
+		return NetworkRelationship{}, err
+	}
+
+	logger.Log("terminated network relationship", relationshipID, "org", orgID)
+
+	return s.repository.GetNetworkRelationship(ctx, relationshipID)
+}
+
+// The provider's payors, members of the provider get to see them
+func (s service) ListPayors(ctx context.Context, providerID string, query NetworkQuery) ([]NetworkRelationship, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "ListPayors")
+
+	if err := s.checkNetworkQuery(ctx, providerID, OrgTypeProvider, query); err != nil {
+		return nil, err
+	}
+
+	payors, err := s.repository.ListNetworkPayors(ctx, providerID, query)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+
+	return payors, nil
+}
+
+// The payor's providers, members of the payor get to see them
+func (s service) ListProviders(ctx context.Context, payorID string, query NetworkQuery) ([]NetworkRelationship, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "ListProviders")
+
+	if err := s.checkNetworkQuery(ctx, payorID, OrgTypePayor, query); err != nil {
+		return nil, err
+	}
+
+	providers, err := s.repository.ListNetworkProviders(ctx, payorID, query)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+
+	return providers, nil
+}
+
+// Checks the caller belongs to the org, which has to be of the type being listed
+// from, and that the query makes sense
+func (s service) checkNetworkQuery(ctx context.Context, orgID string, orgType string, query NetworkQuery) error {
+	if err := s.requireOrgMember(ctx, orgID); err != nil {
+		return err
+	}
+	if err := validateNetworkQuery(query); err != nil {
+		return err
+	}
+
+	org, err := s.repository.GetOrgAccount(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if org.Type != orgType {
+		return fmt.Errorf("organization is a %s, not a %s", org.Type, orgType)
+	}
+	return nil
+}
+
+// Gets the relationship as long as the org is one of the two sides of it, to
+// anyone else it doesn't exist
+func (s service) getOrgNetworkRelationship(ctx context.Context, orgID string, relationshipID string) (NetworkRelationship, error) {
+	relationship, err := s.repository.GetNetworkRelationship(ctx, relationshipID)
+	if err != nil {
+		return NetworkRelationship{}, err
+	}
+	if relationship.ProviderID != orgID && relationship.PayorID != orgID {
+		return NetworkRelationship{}, fmt.Errorf("%w: no such network relationship", ErrNotFound)
+	}
+	return relationship, nil
+}
+
+// What's been done in the org, newest first. Takes an admin of the org.
+func (s service) QueryAuditLog(ctx context.Context, query AuditQuery) ([]AuditEvent, error) {
+	logger := log.With(loggerWithRequestID(ctx, s.logger), "method", "QueryAuditLog")
+
+	query, err := normalizeAuditQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireOrgAdmin(ctx, query.OrgID); err != nil {
+		return nil, err
+	}
+	if s.auditSink == nil {
+		return nil, errors.New("there is no audit log")
+	}
+
+	events, err := s.auditSink.Query(ctx, query)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// The org's timezone, or nil when it hasn't set one (or we can't tell)
+func (s service) orgLocation(ctx context.Context, orgID string) *time.Location {
+	orgProfile, err := s.repository.GetOrgProfile(ctx, orgID)
+	if err != nil {
+		return nil
+	}
+	return orgProfile.location()
+}
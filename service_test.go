@@ -0,0 +1,620 @@
+package accountsrv
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+// Just enough of a Repository for the service to decide who gets to do what.
+// Anything the tests don't set up panics on the nil embedded interface.
+type fakeRepo struct {
+	Repository
+	orgs        map[string]OrgAccount
+	members     map[string]map[string]string // org ID -> user ID -> role
+	profiles    map[string]UserProfile
+	updated     map[string]map[string]interface{}
+	deleted     map[string]bool // users
+	deletedOrgs map[string]bool
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{
+		orgs:        map[string]OrgAccount{},
+		members:     map[string]map[string]string{},
+		profiles:    map[string]UserProfile{},
+		updated:     map[string]map[string]interface{}{},
+		deleted:     map[string]bool{},
+		deletedOrgs: map[string]bool{},
+	}
+}
+
+// Adds the org too (a provider with no rules of its own) if it isn't there yet
+func (r *fakeRepo) addMember(orgID, userID, role string) {
+	if _, ok := r.orgs[orgID]; !ok {
+		r.orgs[orgID] = OrgAccount{ID: orgID, Type: OrgTypeProvider}
+	}
+	if r.members[orgID] == nil {
+		r.members[orgID] = map[string]string{}
+	}
+	r.members[orgID][userID] = role
+}
+
+func (r *fakeRepo) GetOrgMemberRole(_ context.Context, userID, orgID string) (string, error) {
+	role, ok := r.members[orgID][userID]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return role, nil
+}
+
+// Admins of the orgs above count too, same as the real one
+func (r *fakeRepo) IsOrgAdmin(ctx context.Context, userID, orgID string) (bool, error) {
+	if r.members[orgID][userID] == RoleAdmin {
+		return true, nil
+	}
+	ancestors, _ := r.GetOrgAncestors(ctx, orgID)
+	for _, ancestor := range ancestors {
+		if r.members[ancestor.ID][userID] == RoleAdmin {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// From the top of the tree down to the parent, same as the real one
+func (r *fakeRepo) GetOrgAncestors(_ context.Context, id string) ([]OrgAccount, error) {
+	var ancestors []OrgAccount
+	for parent, ok := r.orgs[r.orgs[id].ParentID]; ok; parent, ok = r.orgs[parent.ParentID] {
+		ancestors = append([]OrgAccount{parent}, ancestors...)
+	}
+	return ancestors, nil
+}
+
+func (r *fakeRepo) GetUserOrgs(_ context.Context, userID string) ([]OrgMembership, error) {
+	var orgs []OrgMembership
+	for orgID, members := range r.members {
+		if role, ok := members[userID]; ok {
+			orgs = append(orgs, OrgMembership{OrgID: orgID, Role: role})
+		}
+	}
+	return orgs, nil
+}
+
+func (r *fakeRepo) GetUserAccountAnyStatus(_ context.Context, id string) (UserAccount, error) {
+	if _, ok := r.profiles[id]; !ok {
+		return UserAccount{}, ErrNotFound
+	}
+	return UserAccount{ID: id, Username: id, Status: UserStatusActive}, nil
+}
+
+func (r *fakeRepo) GetAccountByUsername(_ context.Context, username string) (UserAccount, error) {
+	if _, ok := r.profiles[username]; !ok {
+		return UserAccount{}, ErrNotFound
+	}
+	return UserAccount{ID: username, Username: username}, nil
+}
+
+func (r *fakeRepo) GetUserProfile(_ context.Context, accountID string) (UserProfile, error) {
+	profile, ok := r.profiles[accountID]
+	if !ok {
+		return UserProfile{}, ErrNotFound
+	}
+	return profile, nil
+}
+
+func (r *fakeRepo) UpdateUserProfile(_ context.Context, accountID string, updates map[string]interface{}) error {
+	r.updated[accountID] = updates
+	return nil
+}
+
+var nopLogger = log.NewNopLogger()
+
+func (r *fakeRepo) GetOrgAccount(_ context.Context, id string) (OrgAccount, error) {
+	org, ok := r.orgs[id]
+	if !ok || r.deletedOrgs[id] {
+		return OrgAccount{}, ErrNotFound
+	}
+	return org, nil
+}
+
+func (r *fakeRepo) GetOrgChildren(context.Context, string) ([]OrgAccount, error) {
+	return nil, nil
+}
+
+// Deleted users keep their memberships but don't count, same as the real one
+func (r *fakeRepo) CountOrgMembers(_ context.Context, orgID string) (int, error) {
+	n := 0
+	for userID := range r.members[orgID] {
+		if !r.deleted[userID] {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeRepo) UpdateOrgAccount(_ context.Context, id string, updates map[string]interface{}) error {
+	r.updated[id] = updates
+	return nil
+}
+
+func (r *fakeRepo) DeleteOrgAccount(_ context.Context, id string) error {
+	r.deletedOrgs[id] = true
+	return nil
+}
+
+func (r *fakeRepo) CreateUserAccount(context.Context, UserAccount) error { return nil }
+func (r *fakeRepo) CreateCredential(context.Context, Credential) error   { return nil }
+
+func (r *fakeRepo) CreateUserProfile(_ context.Context, profile UserProfile) error {
+	r.profiles[profile.AccountID] = profile
+	return nil
+}
+
+func (r *fakeRepo) PurgeUserAccount(_ context.Context, id string) error {
+	delete(r.profiles, id)
+	return nil
+}
+
+func (r *fakeRepo) DeleteUserAccount(_ context.Context, id string) error {
+	r.deleted[id] = true
+	return nil
+}
+
+// Every membership counts here, deleted users' included
+func (r *fakeRepo) AssociateFirstUserToOrg(_ context.Context, userID, orgID string) error {
+	if len(r.members[orgID]) > 0 {
+		return ErrOrgHasMembers
+	}
+	r.addMember(orgID, userID, RoleAdmin)
+	return nil
+}
+
+func (r *fakeRepo) AssociateUserToOrg(_ context.Context, userID, orgID, role string) error {
+	r.addMember(orgID, userID, role)
+	return nil
+}
+
+func newTestService(repo Repository) Service {
+	return NewService(repo, nopLogger, ServiceConfig{})
+}
+
+// A session logged in with a password and nothing else
+func as(userID, orgID string) context.Context {
+	return loggedIn(userID, orgID, sessionAuth{Method: AuthMethodPassword})
+}
+
+func loggedIn(userID, orgID string, auth sessionAuth) context.Context {
+	return ContextWithPrincipal(context.Background(), Principal{UserID: userID, OrgID: orgID, SessionID: "session", auth: auth})
+}
+
+// Only the user or an admin of their org gets to change their profile
+func TestUpdateUserProfileNeedsSelfOrAdmin(t *testing.T) {
+	repo := newFakeRepo()
+	repo.addMember("org", "admin", RoleAdmin)
+	repo.addMember("org", "user", RoleMember)
+	repo.addMember("org", "other", RoleMember)
+	repo.addMember("elsewhere", "outsider", RoleAdmin)
+	repo.profiles["user"] = UserProfile{Phone: "+15555550199"}
+	svc := newTestService(repo)
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status int
+	}{
+		{"anonymous", context.Background(), http.StatusUnauthorized},
+		{"another member", as("other", "org"), http.StatusForbidden},
+		{"admin of another org", as("outsider", "elsewhere"), http.StatusForbidden},
+		{"the user", as("user", "org"), http.StatusOK},
+		{"admin of the org", as("admin", "org"), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delete(repo.updated, "user")
+			err := svc.UpdateUserProfile(tt.ctx, "user", map[string]interface{}{"phone": "+15555550100"})
+
+			if tt.status == http.StatusOK {
+				if err != nil {
+					t.Fatalf("got %v, want the update to go through", err)
+				}
+				if repo.updated["user"] == nil {
+					t.Fatal("the profile wasn't updated")
+				}
+				return
+			}
+			if got := CodeFrom(err); got != tt.status {
+				t.Fatalf("got %d (%v), want %d", got, err, tt.status)
+			}
+			if repo.updated["user"] != nil {
+				t.Fatal("the profile was updated anyway")
+			}
+		})
+	}
+}
+
+// The columns we keep up to date ourselves aren't the caller's to set, not even
+// on their own profile
+func TestUpdateUserProfileRefusesInternalColumns(t *testing.T) {
+	repo := newFakeRepo()
+	repo.addMember("org", "user", RoleMember)
+	svc := newTestService(repo)
+
+	for _, column := range []string{"email_verified_at", "last_login"} {
+		err := svc.UpdateUserProfile(as("user", "org"), "user", map[string]interface{}{column: "2020-01-01T00:00:00Z"})
+		if err == nil {
+			t.Errorf("setting %s went through", column)
+		}
+		if errors.Is(err, ErrForbidden) || errors.Is(err, ErrUnauthenticated) {
+			t.Errorf("setting %s was refused as %v, it's the column that's wrong", column, err)
+		}
+	}
+	if repo.updated["user"] != nil {
+		t.Fatal("the profile was updated anyway")
+	}
+}
+
+// A new phone number has to be verified all over again
+func TestUpdateUserProfilePhoneResetsVerification(t *testing.T) {
+	verified := time.Now()
+	repo := newFakeRepo()
+	repo.addMember("org", "user", RoleMember)
+	repo.profiles["user"] = UserProfile{Phone: "+15555550199", PhoneVerifiedAt: &verified}
+	svc := newTestService(repo)
+
+	if err := svc.UpdateUserProfile(as("user", "org"), "user", map[string]interface{}{"phone": "+15555550199"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := repo.updated["user"]["phone_verified_at"]; ok {
+		t.Fatal("the same number was put back to unverified")
+	}
+
+	if err := svc.UpdateUserProfile(as("user", "org"), "user", map[string]interface{}{"phone": "+15555550100"}); err != nil {
+		t.Fatal(err)
+	}
+	if repo.updated["user"]["phone_verified_at"] != setToDefault {
+		t.Fatal("a new number wasn't put back to unverified")
+	}
+}
+
+type fakeSMSSender struct {
+	sent []SMS
+}
+
+func (f *fakeSMSSender) Send(_ context.Context, sms SMS) error {
+	f.sent = append(f.sent, sms)
+	return nil
+}
+
+// Login codes only go to a number the user verified, anyone can put any number
+// on a profile
+func TestSMSCodeNeedsVerifiedPhone(t *testing.T) {
+	repo := newFakeRepo()
+	repo.profiles["user"] = UserProfile{Phone: "+15555550199"}
+	sender := &fakeSMSSender{}
+	svc := NewService(repo, nopLogger, ServiceConfig{SMSSender: sender}).(*service)
+
+	svc.sendSMSCode(context.Background(), "org", "user", hashToken("token"), time.Now().Add(smsCodeTTL))
+
+	if len(sender.sent) != 0 {
+		t.Fatalf("texted %s", sender.sent[0].To)
+	}
+}
+
+// Deleting a user takes the user themselves or an admin of their org
+func TestDeleteUserAccountNeedsSelfOrAdmin(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status int
+	}{
+		{"anonymous", context.Background(), http.StatusUnauthorized},
+		{"another member", as("other", "org"), http.StatusForbidden},
+		{"admin of another org", as("outsider", "elsewhere"), http.StatusForbidden},
+		{"the user", as("user", "org"), http.StatusOK},
+		{"admin of the org", as("admin", "org"), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepo()
+			repo.addMember("org", "admin", RoleAdmin)
+			repo.addMember("org", "user", RoleMember)
+			repo.addMember("org", "other", RoleMember)
+			repo.addMember("elsewhere", "outsider", RoleAdmin)
+
+			err := newTestService(repo).DeleteUserAccount(tt.ctx, "user")
+
+			if tt.status == http.StatusOK {
+				if err != nil || !repo.deleted["user"] {
+					t.Fatalf("got %v, want the user deleted", err)
+				}
+				return
+			}
+			if got := CodeFrom(err); got != tt.status {
+				t.Fatalf("got %d (%v), want %d", got, err, tt.status)
+			}
+			if repo.deleted["user"] {
+				t.Fatal("the user was deleted anyway")
+			}
+		})
+	}
+}
+
+// An org whose only member deleted themselves looks empty to DeleteOrg, which
+// mustn't let just anyone delete it, or take it over by signing up as its
+// "first" user
+func TestOrgWithOnlyDeletedMembers(t *testing.T) {
+	setup := func() (*fakeRepo, Service) {
+		repo := newFakeRepo()
+		repo.orgs["org"] = OrgAccount{ID: "org", Type: OrgTypeProvider}
+		repo.addMember("org", "admin", RoleAdmin)
+		repo.addMember("org", "member", RoleMember)
+		repo.addMember("elsewhere", "outsider", RoleAdmin)
+		svc := newTestService(repo)
+
+		if err := svc.DeleteUserAccount(as("member", "org"), "member"); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.DeleteUserAccount(as("admin", "org"), "admin"); err != nil {
+			t.Fatal(err)
+		}
+		return repo, svc
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		for _, tt := range []struct {
+			name   string
+			ctx    context.Context
+			status int
+		}{
+			{"anonymous", context.Background(), http.StatusUnauthorized},
+			{"admin of another org", as("outsider", "elsewhere"), http.StatusForbidden},
+		} {
+			repo, svc := setup()
+			err := svc.DeleteOrg(tt.ctx, "org", false)
+			if got := CodeFrom(err); got != tt.status {
+				t.Errorf("%s: got %d (%v), want %d", tt.name, got, err, tt.status)
+			}
+			if repo.deletedOrgs["org"] {
+				t.Errorf("%s: the org was deleted anyway", tt.name)
+			}
+		}
+	})
+
+	t.Run("take over", func(t *testing.T) {
+		repo, svc := setup()
+		id, err := svc.CreateUser(context.Background(), "org", "newcomer", "a long enough password", "", "New", "Comer", "", "")
+		if got := CodeFrom(err); got != http.StatusForbidden {
+			t.Fatalf("got %d (%v), want %d", got, err, http.StatusForbidden)
+		}
+		if id != "" || len(repo.profiles) != 0 {
+			t.Fatal("the user was created anyway")
+		}
+		if err := svc.DeleteOrg(context.Background(), "org", false); CodeFrom(err) != http.StatusUnauthorized {
+			t.Fatalf("got %v deleting the org anonymously afterwards", err)
+		}
+	})
+}
+
+// Org admins only get to act on an org through a session logged in to it (or to
+// an org above it), being an admin there isn't enough from a session elsewhere
+func TestOrgAdminNeedsSessionInOrg(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status int
+	}{
+		{"logged in to another org", as("admin", "elsewhere"), http.StatusForbidden},
+		{"logged in to no org", as("admin", ""), http.StatusForbidden},
+		{"logged in to a child org", as("admin", "child"), http.StatusForbidden},
+		{"logged in to the org", as("admin", "org"), http.StatusOK},
+		{"admin of the parent logged in to it", as("parentadmin", "parent"), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepo()
+			repo.orgs["parent"] = OrgAccount{ID: "parent", Type: OrgTypeProvider}
+			repo.orgs["org"] = OrgAccount{ID: "org", Type: OrgTypeProvider, ParentID: "parent"}
+			repo.orgs["child"] = OrgAccount{ID: "child", Type: OrgTypeProvider, ParentID: "org"}
+			repo.orgs["elsewhere"] = OrgAccount{ID: "elsewhere", Type: OrgTypeProvider}
+			repo.addMember("parent", "parentadmin", RoleAdmin)
+			repo.addMember("org", "admin", RoleAdmin)
+			repo.addMember("child", "admin", RoleMember)
+			repo.addMember("elsewhere", "admin", RoleMember)
+
+			err := newTestService(repo).UpdateOrgAccount(tt.ctx, "org", map[string]interface{}{"name": "Renamed"})
+
+			if tt.status == http.StatusOK {
+				if err != nil || repo.updated["org"] == nil {
+					t.Fatalf("got %v, want the org updated", err)
+				}
+				return
+			}
+			if got := CodeFrom(err); got != tt.status {
+				t.Fatalf("got %d (%v), want %d", got, err, tt.status)
+			}
+			if repo.updated["org"] != nil {
+				t.Fatal("the org was updated anyway")
+			}
+		})
+	}
+}
+
+// An admin of an org that requires MFA can't turn it off (or do anything else
+// there) from a session that never gave a second factor, whichever org that
+// session was logged in to
+func TestOrgMFARequiredCantBeSkipped(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status int
+	}{
+		{"logged in to another org", as("admin", "elsewhere"), http.StatusForbidden},
+		{"logged in to the org above without MFA", as("admin", "parent"), http.StatusForbidden},
+		{"logged in to the org before it required MFA", as("admin", "secure"), http.StatusForbidden},
+		{"logged in to the org with MFA", loggedIn("admin", "secure", sessionAuth{Method: AuthMethodPassword, MFA: true}), http.StatusOK},
+		{"logged in to the org above with MFA", loggedIn("admin", "parent", sessionAuth{Method: AuthMethodPassword, MFA: true}), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepo()
+			repo.orgs["parent"] = OrgAccount{ID: "parent", Type: OrgTypeProvider}
+			repo.orgs["secure"] = OrgAccount{ID: "secure", Type: OrgTypeProvider, ParentID: "parent", MFARequired: true}
+			repo.addMember("parent", "admin", RoleAdmin)
+			repo.addMember("secure", "admin", RoleAdmin)
+			repo.addMember("elsewhere", "admin", RoleMember)
+
+			err := newTestService(repo).UpdateOrgAccount(tt.ctx, "secure", map[string]interface{}{"mfa_required": false})
+
+			if tt.status == http.StatusOK {
+				if err != nil || repo.updated["secure"] == nil {
+					t.Fatalf("got %v, want the org updated", err)
+				}
+				return
+			}
+			if got := CodeFrom(err); got != tt.status {
+				t.Fatalf("got %d (%v), want %d", got, err, tt.status)
+			}
+			if repo.updated["secure"] != nil {
+				t.Fatal("the org was updated anyway")
+			}
+		})
+	}
+}
+
+// Same for an org that only allows passkeys, a session logged in some other way
+// doesn't get to act on it
+func TestOrgPasskeyRequiredCantBeSkipped(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status int
+	}{
+		{"logged in to the org above with a password", loggedIn("admin", "parent", sessionAuth{Method: AuthMethodPassword, MFA: true}), http.StatusForbidden},
+		{"logged in to the org before it required passkeys", loggedIn("admin", "secure", sessionAuth{Method: AuthMethodPassword, MFA: true}), http.StatusForbidden},
+		{"logged in to the org above with a passkey", loggedIn("admin", "parent", sessionAuth{Method: AuthMethodPasskey, MFA: true}), http.StatusOK},
+		{"logged in to the org with a passkey", loggedIn("admin", "secure", sessionAuth{Method: AuthMethodPasskey, MFA: true}), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepo()
+			repo.orgs["parent"] = OrgAccount{ID: "parent", Type: OrgTypeProvider}
+			repo.orgs["secure"] = OrgAccount{ID: "secure", Type: OrgTypeProvider, ParentID: "parent", PasskeyRequired: true}
+			repo.addMember("parent", "admin", RoleAdmin)
+			repo.addMember("secure", "admin", RoleAdmin)
+
+			err := newTestService(repo).UpdateOrgAccount(tt.ctx, "secure", map[string]interface{}{"passkey_required": false})
+
+			if tt.status == http.StatusOK {
+				if err != nil || repo.updated["secure"] == nil {
+					t.Fatalf("got %v, want the org updated", err)
+				}
+				return
+			}
+			if got := CodeFrom(err); got != tt.status {
+				t.Fatalf("got %d (%v), want %d", got, err, tt.status)
+			}
+			if repo.updated["secure"] != nil {
+				t.Fatal("the org was updated anyway")
+			}
+		})
+	}
+}
+
+// Which roles members can have comes from the org's type, for invites as much
+// as for filtering who's in the org
+func TestOrgRolesComeFromOrgType(t *testing.T) {
+	saved := orgTypes[OrgTypeClearinghouse]
+	defer func() { orgTypes[OrgTypeClearinghouse] = saved }()
+	membersOnly := saved
+	membersOnly.roles = []string{RoleMember}
+	orgTypes[OrgTypeClearinghouse] = membersOnly
+
+	repo := newFakeRepo()
+	repo.orgs["org"] = OrgAccount{ID: "org", Type: OrgTypeClearinghouse}
+	repo.addMember("org", "admin", RoleAdmin)
+	svc := newTestService(repo)
+
+	if _, err := svc.CreateInvite(as("admin", "org"), "org", "someone@example.com", RoleAdmin); CodeFrom(err) != http.StatusBadRequest {
+		t.Errorf("got %v inviting an admin, want it refused", err)
+	}
+	if _, err := svc.ListOrgUsers(as("admin", "org"), "org", OrgUserQuery{Role: "owner"}); CodeFrom(err) != http.StatusBadRequest {
+		t.Errorf("got %v filtering on an unknown role, want it refused", err)
+	}
+}
+
+// Keeps invites on top of a fakeRepo
+type inviteRepo struct {
+	*fakeRepo
+	invites map[string]Invite
+}
+
+func (r *inviteRepo) CreateInvite(_ context.Context, invite Invite) error {
+	r.invites[invite.ID] = invite
+	return nil
+}
+
+func (r *inviteRepo) GetInvite(_ context.Context, id string) (Invite, error) {
+	invite, ok := r.invites[id]
+	if !ok {
+		return Invite{}, ErrNotFound
+	}
+	return invite, nil
+}
+
+func (r *inviteRepo) RenewInvite(_ context.Context, id string, expiresAt time.Time) error {
+	invite := r.invites[id]
+	invite.ExpiresAt = expiresAt
+	r.invites[id] = invite
+	return nil
+}
+
+func (r *inviteRepo) GetOrgProfile(context.Context, string) (OrgProfile, error) {
+	return OrgProfile{}, ErrNotFound
+}
+
+// Holds on to whatever it's asked to send
+type sentMail struct {
+	emails []Email
+}
+
+func (m *sentMail) Send(_ context.Context, email Email) error {
+	m.emails = append(m.emails, email)
+	return nil
+}
+
+// The invitee gets the link to accept with, and a new one when it's resent
+func TestInvitesAreEmailed(t *testing.T) {
+	repo := &inviteRepo{fakeRepo: newFakeRepo(), invites: map[string]Invite{}}
+	repo.orgs["org"] = OrgAccount{ID: "org", Name: "Acme Clinic", Type: OrgTypeProvider}
+	repo.addMember("org", "admin", RoleAdmin)
+	mail := &sentMail{}
+	svc := NewService(repo, nopLogger, ServiceConfig{SigningKey: []byte("key"), Mailer: mail, AppURL: "https://app.example.com"})
+	ctx := as("admin", "org")
+
+	created, err := svc.CreateInvite(ctx, "org", "invitee@example.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resent, err := svc.ResendInvite(ctx, "org", created.Invite.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mail.emails) != 2 {
+		t.Fatalf("got %d emails, want 2", len(mail.emails))
+	}
+	for i, issued := range []IssuedInvite{created, resent} {
+		email := mail.emails[i]
+		link := "https://app.example.com/accept-invite?" + url.Values{"token": {issued.Token}}.Encode()
+		if email.To != "invitee@example.com" || !strings.Contains(email.Subject, "Acme Clinic") || !strings.Contains(email.Body, link) {
+			t.Errorf("email %d: got %+v, want the link %s sent to the invitee", i, email, link)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package accountsrv
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+// One text message
+type SMS struct {
+	To   string
+	Body string
+}
+
+// Whatever actually gets text messages out the door, the SMS version of Mailer.
+// There's no real provider wired up yet, main hands the service one of the fakes
+// below until there is.
+type SMSSender interface {
+	Send(ctx context.Context, sms SMS) error
+}
+
+// Writes every text message to its own .txt file in dir instead of sending it
+func NewFileSMSSender(dir string) SMSSender {
+	return fileSMSSender{dir: dir}
+}
+
+type fileSMSSender struct {
+	dir string
+}
+
+func (s fileSMSSender) Send(ctx context.Context, sms SMS) error {
+	to, err := normalizePhone(sms.To)
+	if err != nil {
+		return err
+	}
+
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	name := time.Now().UTC().Format("20060102T150405.000000000") + "-" + hex.EncodeToString(suffix) + ".txt"
+
+	if err := os.WriteFile(filepath.Join(s.dir, name), []byte("To: "+to+"\n\n"+sms.Body+"\n"), 0600); err != nil {
+		return fmt.Errorf("error writing text message: %v", err)
+	}
+	return nil
+}
+
+// Logs every text message instead of sending it. Like the log mailer the
+// messages carry codes, so this is for local use only.
+func NewLogSMSSender(logger log.Logger) SMSSender {
+	return logSMSSender{logger: log.With(logger, "sms", "log")}
+}
+
+type logSMSSender struct {
+	logger log.Logger
+}
+
+func (s logSMSSender) Send(ctx context.Context, sms SMS) error {
+	to, err := normalizePhone(sms.To)
+	if err != nil {
+		return err
+	}
+	level.Info(loggerWithRequestID(ctx, s.logger)).Log("to", to, "body", sms.Body)
+	return nil
+}
+
+// Boils a phone number as people type them ("+1 (555) 010-0199") down to the
+// digits and the leading +, which is what SMS providers want
+func normalizePhone(phone string) (string, error) {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(phone) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", errors.New("invalid phone number")
+		}
+	}
+
+	digits := strings.TrimPrefix(b.String(), "+")
+	if len(digits) < 7 || len(digits) > 15 {
+		return "", errors.New("invalid phone number")
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,112 @@
+package accountsrv
+
+import (
+	"fmt"
+	"time"
+)
+
+/*
+Timestamps are time.Time all the way through and go out as RFC 3339. Anything
+returned in the context of one org (the org itself, logging in to it, listing its
+users) is rendered in the org's timezone when it has set one, everything else is
+left the way the DB handed it back.
+*/
+
+// Checks tz is an IANA timezone name we know about, an empty one means "none set"
+func validateTimezone(tz string) error {
+	if tz == "" {
+		return nil
+	}
+	if tz == "Local" {
+		return fmt.Errorf("invalid timezone %q", tz)
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return fmt.Errorf("invalid timezone %q", tz)
+	}
+	return nil
+}
+
+// The org's timezone, or nil if it hasn't set one (or set one we no longer know)
+func (p OrgProfile) location() *time.Location {
+	if p.Timezone == "" || p.Timezone == "Local" {
+		return nil
+	}
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
+
+// The helpers below hand back a copy with the timestamps rendered in loc, a nil
+// loc leaves them as they are.
+
+func (a OrgAccount) in(loc *time.Location) OrgAccount {
+	if loc == nil {
+		return a
+	}
+	a.JoinedOn = a.JoinedOn.In(loc)
+	if a.DeletedAt != nil {
+		deletedAt := a.DeletedAt.In(loc)
+		a.DeletedAt = &deletedAt
+	}
+	return a
+}
+
+func (a UserAccount) in(loc *time.Location) UserAccount {
+	if loc == nil {
+		return a
+	}
+	a.JoinedOn = a.JoinedOn.In(loc)
+	if a.DeletedAt != nil {
+		deletedAt := a.DeletedAt.In(loc)
+		a.DeletedAt = &deletedAt
+	}
+	return a
+}
+
+func (p UserProfile) in(loc *time.Location) UserProfile {
+	if loc == nil {
+		return p
+	}
+	for _, t := range []**time.Time{&p.LastLogin, &p.EmailVerifiedAt, &p.PhoneVerifiedAt} {
+		if *t != nil {
+			local := (*t).In(loc)
+			*t = &local
+		}
+	}
+	return p
+}
+
+func (u DetailedUser) in(loc *time.Location) DetailedUser {
+	u.Account = u.Account.in(loc)
+	u.Profile = u.Profile.in(loc)
+	return u
+}
+
+func (i Invite) in(loc *time.Location) Invite {
+	if loc == nil {
+		return i
+	}
+	i.CreatedAt = i.CreatedAt.In(loc)
+	i.ExpiresAt = i.ExpiresAt.In(loc)
+	for _, t := range []**time.Time{&i.AcceptedAt, &i.RevokedAt} {
+		if *t != nil {
+			local := (*t).In(loc)
+			*t = &local
+		}
+	}
+	return i
+}
+
+func (p Passkey) in(loc *time.Location) Passkey {
+	if loc == nil {
+		return p
+	}
+	p.CreatedAt = p.CreatedAt.In(loc)
+	if p.LastUsedAt != nil {
+		local := p.LastUsedAt.In(loc)
+		p.LastUsedAt = &local
+	}
+	return p
+}
@@ -1,28 +1,50 @@
 package accountsrv
 
+import "time"
+
+// Where a user's account stands. Only active accounts can log in or turn up in
+// lookups, the rest are kept around rather than deleted (see the
+// 017_account_status migration).
+const (
+	UserStatusActive      = "active"
+	UserStatusSuspended   = "suspended"   // Blocked by an admin, logging in gets ErrAccountSuspended
+	UserStatusDeactivated = "deactivated" // Turned off by an admin, logging in fails as if they weren't there
+	UserStatusDeleted     = "deleted"     // Only restoring brings them back
+)
+
 type UserAccount struct {
-	ID       string `db:"id" json:"id"`
-	Username string `db:"username" json:"username"`
-	Password string `db:"password" json:"password,omitempty"`
-	OrgType  string `db:"org_type" json:"org_type"`
-	JoinedOn string `db:"joined_on" json:"joined_on"`
+	ID       string    `db:"id" json:"id"`
+	Username string    `db:"username" json:"username"`
+	OrgType  string    `db:"org_type" json:"org_type"`
+	JoinedOn time.Time `db:"joined_on" json:"joined_on"`
+	Status   string    `db:"status" json:"status"`
+
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"` // nil unless they're deleted
 }
 
 type UserProfile struct {
-	AccountID string `db:"account_id" json:"account_id,omitempty"`
-	FirstName string `db:"first_name" json:"first_name"`
-	LastName  string `db:"last_name" json:"last_name"`
-	Email     string `db:"email" json:"email,omitempty"`
-	Phone     string `db:"phone" json:"phone,omitempty"`
-	LastLogin string `db:"last_login" json:"last_login,omitempty"`
+	AccountID string     `db:"account_id" json:"account_id,omitempty"`
+	FirstName string     `db:"first_name" json:"first_name"`
+	LastName  string     `db:"last_name" json:"last_name"`
+	Email     string     `db:"email" json:"email,omitempty"`
+	Phone     string     `db:"phone" json:"phone,omitempty"`
+	LastLogin *time.Time `db:"last_login" json:"last_login,omitempty"` // nil until they first log in
+
+	EmailVerifiedAt *time.Time `db:"email_verified_at" json:"email_verified_at,omitempty"` // nil until they follow the link we email them
+	PhoneVerifiedAt *time.Time `db:"phone_verified_at" json:"phone_verified_at,omitempty"` // nil until they type back the code we text them
 }
 
 type DetailedUser struct {
-	Account UserAccount `json:"account"`
-	Profile UserProfile `json:"profile"`
+	Account UserAccount     `json:"account"`
+	Profile UserProfile     `json:"profile"`
+	Orgs    []OrgMembership `json:"orgs,omitempty"`
 }
 
 type LoginUser struct {
-	User DetailedUser `json:"user"`
-	Org  DetailedOrg  `json:"org"`
+	User    DetailedUser `json:"user"`
+	Org     DetailedOrg  `json:"org"`
+	Session SessionToken `json:"session"`
+
+	// Only when logging in just turned on MFA, the only time they're ever shown
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
 }